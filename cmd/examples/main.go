@@ -0,0 +1,241 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command examples lists the examples of the repository and runs any of them by name, from anywhere in the
+// repository:
+//
+//	go run ./cmd/examples list
+//	go run ./cmd/examples list rag
+//	go run ./cmd/examples run quickstart/todoagent
+//	go run ./cmd/examples run react_full -- -h
+//
+// Everything after the name of the example is passed to it as is. Once installed with go install ./cmd/examples, it
+// is just examples run <name>.
+//
+// Before running an example, the launcher checks that the keys it needs are set, in the environment or the .env file
+// at the root of the repository, and that the servers it connects to are up, and tells how to fix what is missing;
+// examples check <name> only checks, run --skip-checks runs anyway.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/registry"
+)
+
+// module is the module of the repository, to find its root from a subdirectory.
+const module = "github.com/cloudwego/eino-examples"
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		var exit *exec.ExitError
+		if errors.As(err, &exit) {
+			// the example printed its own error, only its exit code is passed on
+			os.Exit(exit.ExitCode())
+		}
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:          "examples",
+		Short:        "List and run the Eino examples",
+		SilenceUsage: true,
+		// printed by main, but for the failures of the examples which printed their own
+		SilenceErrors: true,
+	}
+	root.AddCommand(newListCmd(), newRunCmd(), newCheckCmd())
+	return root
+}
+
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list [filter]",
+		Short: "List the examples, or those whose name contains filter",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := ""
+			if len(args) > 0 {
+				filter = args[0]
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			group := ""
+			for _, e := range registry.All() {
+				if !strings.Contains(e.Name, filter) {
+					continue
+				}
+				if e.Group() != group {
+					if group != "" {
+						_, _ = fmt.Fprintln(w)
+					}
+					group = e.Group()
+				}
+				_, _ = fmt.Fprintf(w, "%s\t%s\n", e.Name, e.Description)
+			}
+			return w.Flush()
+		},
+	}
+}
+
+func newRunCmd() *cobra.Command {
+	var (
+		dryRun     bool
+		skipChecks bool
+	)
+	cmd := &cobra.Command{
+		Use:   "run <example> [args...]",
+		Short: "Run an example, by its directory or the last part of it if unique",
+		Example: "  examples run quickstart/todoagent\n" +
+			"  examples run rag/kb ingest -dir docs\n" +
+			"  examples run react_full -- -h",
+		Args: cobra.MinimumNArgs(1),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				// the arguments of the example itself, completed as files
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			var names []string
+			for _, e := range registry.All() {
+				names = append(names, e.Name+"\t"+e.Description)
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			e, err := registry.Find(args[0])
+			if err != nil {
+				return err
+			}
+			root, err := findRoot()
+			if err != nil {
+				return err
+			}
+			if !skipChecks {
+				if err = preflight(cmd, root, e); err != nil {
+					return err
+				}
+			}
+
+			goArgs := append([]string{"run", "./" + e.Name}, args[1:]...)
+			if dryRun {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "cd %s && go %s\n", root, strings.Join(goArgs, " "))
+				return nil
+			}
+			return run(root, goArgs)
+		},
+	}
+	// the flags after the name of the example are its own
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "print the command instead of running it")
+	cmd.Flags().BoolVar(&skipChecks, "skip-checks", false, "run without checking the keys and servers the example needs")
+	return cmd
+}
+
+func newCheckCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "check <example>",
+		Short: "Check the keys and servers an example needs, without running it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			e, err := registry.Find(args[0])
+			if err != nil {
+				return err
+			}
+			root, err := findRoot()
+			if err != nil {
+				return err
+			}
+			if err = preflight(cmd, root, e); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s is ready to run\n", e.Name)
+			return nil
+		},
+	}
+}
+
+// preflight loads the .env file of the repository, as the examples do, and checks the requirements of e, printing
+// the problems found with how to fix them.
+func preflight(cmd *cobra.Command, root string, e *registry.Example) error {
+	// the flags of the launcher are cobra's, config reads none
+	flags := flag.NewFlagSet("examples", flag.ContinueOnError)
+	_ = flags.Parse(nil)
+	if err := config.Init(&config.Options{EnvFiles: []string{filepath.Join(root, ".env")}, FlagSet: flags}); err != nil {
+		return err
+	}
+	problems := e.Preflight(cmd.Context())
+	if len(problems) == 0 {
+		return nil
+	}
+
+	w := cmd.ErrOrStderr()
+	_, _ = fmt.Fprintf(w, "%s is not ready to run:\n", e.Name)
+	for _, p := range problems {
+		_, _ = fmt.Fprintf(w, "  ✗ %s\n", p.What)
+		if p.Hint != "" {
+			_, _ = fmt.Fprintf(w, "    %s\n", p.Hint)
+		}
+	}
+	return fmt.Errorf("%d requirement(s) of %s not met, fix them or run with --skip-checks", len(problems), e.Name)
+}
+
+// run runs go with args in dir, attached to the terminal.
+func run(dir string, args []string) error {
+	c := exec.Command("go", args...)
+	c.Dir = dir
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	// Ctrl+C reaches the example too, which stops on its own; the launcher waits for it rather than leaving it behind
+	signal.Ignore(os.Interrupt)
+	defer signal.Reset(os.Interrupt)
+	err := c.Run()
+	if err != nil && !errors.As(err, new(*exec.ExitError)) {
+		return fmt.Errorf("run go failed: %w", err)
+	}
+	return err
+}
+
+// findRoot returns the root of the repository, the directory of the go.mod of module above the working directory.
+func findRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err == nil && strings.Contains(string(data), "module "+module+"\n") {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("not in the %s repository, run the launcher from within it", module)
+		}
+		dir = parent
+	}
+}
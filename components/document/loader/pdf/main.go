@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/file"
+	"github.com/cloudwego/eino-ext/components/document/parser/pdf"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const (
+	metaKeyPage = "page"
+	// metaKeySource is set by the file loader to the uri of the file
+	metaKeySource = "_source"
+)
+
+func main() {
+	path := flag.String("file", "components/document/loader/pdf/testdata/handbook.pdf", "path of the pdf file")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	// with ToPages, every page is parsed into its own Document, in page order
+	pdfParser, err := pdf.NewPDFParser(ctx, &pdf.Config{
+		ToPages: true,
+	})
+	if err != nil {
+		logs.Fatalf("pdf.NewPDFParser failed, err=%v", err)
+	}
+
+	loader, err := file.NewFileLoader(ctx, &file.FileLoaderConfig{
+		UseNameAsID: true,
+		Parser:      pdfParser,
+	})
+	if err != nil {
+		logs.Fatalf("file.NewFileLoader failed, err=%v", err)
+	}
+
+	splitter, err := recursive.NewSplitter(ctx, &recursive.Config{
+		ChunkSize:   200,
+		OverlapSize: 20,
+	})
+	if err != nil {
+		logs.Fatalf("recursive.NewSplitter failed, err=%v", err)
+	}
+
+	pages, err := loader.Load(ctx, document.Source{URI: *path})
+	if err != nil {
+		logs.Fatalf("load pdf failed, err=%v", err)
+	}
+	logs.Infof("loaded %d pages from %s", len(pages), *path)
+
+	chunks, err := splitPages(ctx, splitter, pages)
+	if err != nil {
+		logs.Fatalf("split pages failed, err=%v", err)
+	}
+
+	for _, chunk := range chunks {
+		logs.Infof("chunk id=%s, page=%v, source=%v, content=%q",
+			chunk.ID, chunk.MetaData[metaKeyPage], chunk.MetaData[metaKeySource], chunk.Content)
+	}
+}
+
+// splitPages splits every page on its own, so that a chunk never spans two pages
+// and always carries the page number it comes from, which can be cited in the answer later.
+func splitPages(ctx context.Context, splitter document.Transformer, pages []*schema.Document) ([]*schema.Document, error) {
+	var chunks []*schema.Document
+	for i, page := range pages {
+		if page.MetaData == nil {
+			page.MetaData = map[string]any{}
+		}
+		page.MetaData[metaKeyPage] = i + 1
+
+		pageChunks, err := splitter.Transform(ctx, []*schema.Document{page})
+		if err != nil {
+			return nil, err
+		}
+
+		for j, chunk := range pageChunks {
+			// make sure the page metadata survives the splitter, whatever it does with the metadata
+			if chunk.MetaData == nil {
+				chunk.MetaData = map[string]any{}
+			}
+			for k, v := range page.MetaData {
+				if _, ok := chunk.MetaData[k]; !ok {
+					chunk.MetaData[k] = v
+				}
+			}
+			chunk.ID = fmt.Sprintf("%s_p%d_%d", page.ID, i+1, j)
+		}
+		chunks = append(chunks, pageChunks...)
+	}
+	return chunks, nil
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/schema"
+	"github.com/go-shiori/go-readability"
+)
+
+const (
+	MetaKeySource    = "source"
+	MetaKeyTitle     = "title"
+	MetaKeyByline    = "byline"
+	MetaKeySiteName  = "site_name"
+	MetaKeyExcerpt   = "excerpt"
+	MetaKeyLanguage  = "language"
+	MetaKeyPublished = "published_time"
+	MetaKeyFetchedAt = "fetched_at"
+
+	defaultUserAgent = "Mozilla/5.0 (compatible; eino-examples/1.0)"
+	maxBodySize      = 10 << 20
+)
+
+type readabilityLoaderConfig struct {
+	// Client is used to fetch the page, default is a client with 30s timeout.
+	Client *http.Client
+	// UserAgent is sent with the request, some sites reject requests without one.
+	UserAgent string
+}
+
+// readabilityLoader implements document.Loader, it fetches a web page and extracts
+// the main content with the readability algorithm, the same one used by the Firefox reader view.
+type readabilityLoader struct {
+	client    *http.Client
+	userAgent string
+}
+
+func newReadabilityLoader(config *readabilityLoaderConfig) *readabilityLoader {
+	l := &readabilityLoader{
+		client:    config.Client,
+		userAgent: config.UserAgent,
+	}
+	if l.client == nil {
+		l.client = &http.Client{Timeout: 30 * time.Second}
+	}
+	if l.userAgent == "" {
+		l.userAgent = defaultUserAgent
+	}
+	return l
+}
+
+func (l *readabilityLoader) Load(ctx context.Context, src document.Source, opts ...document.LoaderOption) ([]*schema.Document, error) {
+	pageURL, err := url.Parse(src.URI)
+	if err != nil || (pageURL.Scheme != "http" && pageURL.Scheme != "https") {
+		return nil, fmt.Errorf("invalid url %q, only http and https are supported", src.URI)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", l.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s failed: %w", pageURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s failed: status %s", pageURL, resp.Status)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && !strings.Contains(ct, "html") {
+		return nil, fmt.Errorf("unsupported content type %q of %s", ct, pageURL)
+	}
+
+	// the final url after redirects is used to resolve relative links and as the source
+	finalURL := resp.Request.URL
+	article, err := readability.FromReader(io.LimitReader(resp.Body, maxBodySize), finalURL)
+	if err != nil {
+		return nil, fmt.Errorf("extract content of %s failed: %w", finalURL, err)
+	}
+
+	content := normalizeWhitespace(article.TextContent)
+	if article.Title != "" {
+		content = article.Title + "\n\n" + content
+	}
+
+	metadata := map[string]any{
+		MetaKeySource:    finalURL.String(),
+		MetaKeyTitle:     article.Title,
+		MetaKeyFetchedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	if article.Byline != "" {
+		metadata[MetaKeyByline] = article.Byline
+	}
+	if article.SiteName != "" {
+		metadata[MetaKeySiteName] = article.SiteName
+	}
+	if article.Excerpt != "" {
+		metadata[MetaKeyExcerpt] = article.Excerpt
+	}
+	if article.Language != "" {
+		metadata[MetaKeyLanguage] = article.Language
+	}
+	if article.PublishedTime != nil {
+		metadata[MetaKeyPublished] = article.PublishedTime.UTC().Format(time.RFC3339)
+	}
+
+	return []*schema.Document{{
+		ID:       finalURL.String(),
+		Content:  content,
+		MetaData: metadata,
+	}}, nil
+}
+
+var (
+	spaces     = regexp.MustCompile(`[ \t\f\r\v]+`)
+	emptyLines = regexp.MustCompile(`\n\s*\n+`)
+)
+
+// normalizeWhitespace collapses the indentation and blank lines left over from the html layout.
+func normalizeWhitespace(s string) string {
+	s = spaces.ReplaceAllString(s, " ")
+	s = emptyLines.ReplaceAllString(s, "\n\n")
+
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
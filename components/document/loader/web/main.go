@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/cloudwego/eino/components/document"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// This example is the front half of a web RAG pipeline: fetch a web page,
+// strip the navigation, ads and other boilerplate, and turn the main content into a schema.Document
+// with its source metadata, ready to be split and indexed.
+func main() {
+	url := flag.String("url", "https://go.dev/blog/go1.23", "url of the web page to load")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	loader := newReadabilityLoader(&readabilityLoaderConfig{})
+
+	docs, err := loader.Load(ctx, document.Source{URI: *url})
+	if err != nil {
+		logs.Fatalf("load web page failed, err=%v", err)
+	}
+
+	for _, doc := range docs {
+		logs.Infof("id: %s", doc.ID)
+		for k, v := range doc.MetaData {
+			logs.Infof("metadata %s: %v", k, v)
+		}
+
+		preview := []rune(doc.Content)
+		if len(preview) > 500 {
+			preview = append(preview[:500], []rune("...")...)
+		}
+		logs.Infof("content (%d chars):\n%s", len([]rune(doc.Content)), string(preview))
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/markdown"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const (
+	sourcePath = "components/document/transformer/markdown/testdata/guide.md"
+
+	metaKeyHeadingPath = "heading_path"
+)
+
+// headers maps the markdown header prefix to the metadata key the splitter writes the header text to.
+var headers = map[string]string{
+	"#":   "h1",
+	"##":  "h2",
+	"###": "h3",
+}
+
+func main() {
+	ctx := context.Background()
+
+	content, err := os.ReadFile(sourcePath)
+	if err != nil {
+		logs.Fatalf("read markdown failed, err=%v", err)
+	}
+
+	splitter, err := markdown.NewHeaderSplitter(ctx, &markdown.HeaderConfig{
+		Headers:     headers,
+		TrimHeaders: true, // the heading is kept in the metadata, no need to repeat it in the content
+	})
+	if err != nil {
+		logs.Fatalf("markdown.NewHeaderSplitter failed, err=%v", err)
+	}
+
+	chunks, err := splitter.Transform(ctx, []*schema.Document{{
+		ID:       "guide.md",
+		Content:  string(content),
+		MetaData: map[string]any{"source": sourcePath},
+	}})
+	if err != nil {
+		logs.Fatalf("split markdown failed, err=%v", err)
+	}
+
+	for _, chunk := range chunks {
+		chunk.MetaData[metaKeyHeadingPath] = headingPath(chunk)
+		logs.Infof("chunk %s: %q", citation(chunk), chunk.Content)
+	}
+
+	// The chunk under "### Copying" says nothing about streaming by itself,
+	// only its heading path does. Compare the ranking with and without the heading path in the indexed text.
+	query := "how are streams copied"
+	logs.Infof("query: %s", query)
+
+	logs.Infof("--- indexed by content only ---")
+	ranked := rank(query, chunks, false)
+	for _, r := range ranked[:min(3, len(ranked))] {
+		logs.Infof("score=%d %s", r.score, citation(r.doc))
+	}
+
+	logs.Infof("--- indexed by heading path + content ---")
+	ranked = rank(query, chunks, true)
+	for _, r := range ranked[:min(3, len(ranked))] {
+		logs.Infof("score=%d %s", r.score, citation(r.doc))
+	}
+}
+
+// headingPath joins the headers of a chunk from the outermost to the innermost, e.g. "Eino Guide > Streaming > Copying".
+func headingPath(doc *schema.Document) string {
+	var parts []string
+	for _, key := range []string{"h1", "h2", "h3"} {
+		if v, ok := doc.MetaData[key].(string); ok && v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, " > ")
+}
+
+// citation is what the answer would cite, a precise section is much more useful to the reader than just a file name.
+func citation(doc *schema.Document) string {
+	return "[" + doc.MetaData["source"].(string) + " § " + doc.MetaData[metaKeyHeadingPath].(string) + "]"
+}
+
+type ranked struct {
+	doc   *schema.Document
+	score int
+}
+
+// rank scores chunks by the number of query terms they contain.
+// It stands in for an embedding based retriever, the effect of the heading path is the same:
+// prepending it to the text being embedded ("contextual chunk headers") lets a chunk match on its section.
+func rank(query string, docs []*schema.Document, withHeadingPath bool) []ranked {
+	terms := tokenize(query)
+
+	res := make([]ranked, 0, len(docs))
+	for _, doc := range docs {
+		text := doc.Content
+		if withHeadingPath {
+			text = doc.MetaData[metaKeyHeadingPath].(string) + "\n" + text
+		}
+
+		words := map[string]bool{}
+		for _, w := range tokenize(text) {
+			words[w] = true
+		}
+
+		score := 0
+		for _, t := range terms {
+			if words[t] {
+				score++
+			}
+		}
+		res = append(res, ranked{doc: doc, score: score})
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].score > res[j].score
+	})
+	return res
+}
+
+func tokenize(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	})
+
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		// a crude stemmer, good enough to match "streams" with "streaming" and "copied" with "copying"
+		for _, r := range []struct{ suffix, replace string }{{"ing", ""}, {"ied", "y"}, {"ies", "y"}, {"ed", ""}, {"s", ""}} {
+			if len(f) > len(r.suffix)+2 && strings.HasSuffix(f, r.suffix) {
+				f = strings.TrimSuffix(f, r.suffix) + r.replace
+				break
+			}
+		}
+		words = append(words, f)
+	}
+	return words
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const text = `Eino is a framework for building LLM applications in Go. It provides component abstractions, an orchestration runtime and a set of examples.
+
+A Graph connects nodes with edges. Branches pick the next node at runtime, and a graph must be compiled before it can be invoked. The compiled Runnable supports Invoke, Stream, Collect and Transform.
+
+Streams are handled automatically between nodes. When a node only accepts a non-stream input, the upstream stream is concatenated. When a stream fans out to several nodes, each successor receives its own copy.
+
+Callbacks are triggered when a component starts, ends or returns an error. They are used for logging, tracing and metrics.`
+
+func main() {
+	ctx := context.Background()
+
+	// ChunkSize is the max length of a chunk, OverlapSize is how much of the end of the previous chunk
+	// is repeated at the beginning of the next one, so that a sentence cut at the boundary keeps some context.
+	// The splitter tries the separators in order: it splits by paragraph first, then by line, sentence and word,
+	// and only goes to a finer separator when a piece is still longer than ChunkSize.
+	settings := []*recursive.Config{
+		{ChunkSize: 400, OverlapSize: 0},
+		{ChunkSize: 150, OverlapSize: 0},
+		{ChunkSize: 150, OverlapSize: 40},
+		{ChunkSize: 60, OverlapSize: 20},
+	}
+
+	for _, config := range settings {
+		config.Separators = []string{"\n\n", "\n", ". ", " "}
+
+		splitter, err := recursive.NewSplitter(ctx, config)
+		if err != nil {
+			logs.Fatalf("recursive.NewSplitter failed, err=%v", err)
+		}
+
+		chunks, err := splitter.Transform(ctx, []*schema.Document{{ID: "doc", Content: text}})
+		if err != nil {
+			logs.Fatalf("split failed, err=%v", err)
+		}
+
+		logs.Infof("===== chunk size=%d, overlap=%d => %d chunks =====", config.ChunkSize, config.OverlapSize, len(chunks))
+		for i, chunk := range chunks {
+			logs.Infof("[%d] len=%d %q", i, len(chunk.Content), chunk.Content)
+			if i > 0 {
+				if o := overlap(chunks[i-1].Content, chunk.Content); o != "" {
+					logs.Tokenf("    overlap with previous chunk: %q", o)
+				}
+			}
+		}
+	}
+
+	// Rules of thumb:
+	//   - small chunks give precise retrieval but lose context, large chunks keep context but dilute the embedding
+	//   - 10%~20% of the chunk size is a common overlap, more overlap means more duplicated tokens to embed and store
+	//   - the size is measured in characters by default, set LenFunc to count tokens instead
+}
+
+// overlap returns the longest suffix of prev which is also a prefix of next.
+func overlap(prev, next string) string {
+	for n := min(len(prev), len(next)); n > 0; n-- {
+		if strings.HasPrefix(next, prev[len(prev)-n:]) {
+			return next[:n]
+		}
+	}
+	return ""
+}
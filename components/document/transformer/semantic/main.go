@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/semantic"
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// The paragraphs switch topic in the middle of the text without blank lines between them,
+// which is where a semantic splitter shines and a size based splitter cuts at arbitrary places.
+const text = `Eino is a framework for building LLM applications in Go. It provides component abstractions such as ChatModel, Tool and Retriever. Each component has several implementations in eino-ext. ` +
+	`Pandas are bears native to south central China. They eat bamboo for most of the day. A giant panda can weigh more than 100 kilograms. ` +
+	`A Graph in Eino connects nodes with edges. Branches pick the next node at runtime. The compiled graph supports invoke and stream. ` +
+	`The Great Wall stretches across northern China. It was built over many centuries. Parts of it are more than two thousand years old.`
+
+func main() {
+	validate.Must(validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	counter := &countingEmbedder{Embedder: emb}
+
+	// The semantic splitter cuts the text into sentences by Separators, embeds every sentence together with
+	// BufferSize sentences around it, and splits where the cosine distance between neighbours is above the Percentile.
+	semanticSplitter, err := semantic.NewSplitter(ctx, &semantic.Config{
+		Embedding:    counter,
+		BufferSize:   1,
+		MinChunkSize: 50,
+		Separators:   []string{". "},
+		Percentile:   0.8,
+	})
+	if err != nil {
+		logs.Fatalf("semantic.NewSplitter failed, err=%v", err)
+	}
+
+	recursiveSplitter, err := recursive.NewSplitter(ctx, &recursive.Config{
+		ChunkSize:   200,
+		OverlapSize: 0,
+		Separators:  []string{". ", " "},
+	})
+	if err != nil {
+		logs.Fatalf("recursive.NewSplitter failed, err=%v", err)
+	}
+
+	split(ctx, "recursive", recursiveSplitter)
+	split(ctx, "semantic", semanticSplitter)
+
+	// Cost notes:
+	//   - the recursive splitter is free, it never calls a model
+	//   - the semantic splitter embeds every sentence once when splitting, and the chunks are embedded again
+	//     when indexing, so the embedding cost of ingestion is roughly doubled
+	//   - with BufferSize > 0 every sentence is embedded together with its neighbours,
+	//     so the number of embedded characters grows by about (2*BufferSize+1) times
+	logs.Tokenf("semantic splitter cost: %d embedding calls, %d texts, %d characters embedded",
+		counter.calls, counter.texts, counter.chars)
+}
+
+func split(ctx context.Context, name string, splitter document.Transformer) {
+	chunks, err := splitter.Transform(ctx, []*schema.Document{{ID: "doc", Content: text}})
+	if err != nil {
+		logs.Fatalf("%s split failed, err=%v", name, err)
+	}
+
+	logs.Infof("===== %s splitter: %d chunks =====", name, len(chunks))
+	for i, chunk := range chunks {
+		logs.Infof("[%d] %s", i, chunk.Content)
+	}
+}
+
+// countingEmbedder records how much text is sent to the embedding model.
+type countingEmbedder struct {
+	embedding.Embedder
+
+	mu    sync.Mutex
+	calls int
+	texts int
+	chars int
+}
+
+func (c *countingEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	c.mu.Lock()
+	c.calls++
+	c.texts += len(texts)
+	for _, t := range texts {
+		c.chars += len([]rune(t))
+	}
+	c.mu.Unlock()
+
+	return c.Embedder.EmbedStrings(ctx, texts, opts...)
+}
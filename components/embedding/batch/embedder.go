@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cloudwego/eino/components/embedding"
+)
+
+type batchEmbedderConfig struct {
+	// BatchSize is the max number of texts per embedding request, check the limit of the provider, default 16.
+	BatchSize int
+	// Workers is the number of requests in flight, keep it under the rate limit of the provider, default 4.
+	Workers int
+	// OnProgress is called after every batch with the number of texts embedded, from the worker goroutines but never
+	// concurrently.
+	OnProgress func(done, total int)
+}
+
+// batchEmbedder wraps an Embedder, splitting a large EmbedStrings call into batches sent concurrently
+// by a pool of workers. It is an embedding.Embedder itself, so it can be handed to any indexer as is.
+type batchEmbedder struct {
+	emb        embedding.Embedder
+	batchSize  int
+	workers    int
+	onProgress func(done, total int)
+}
+
+func newBatchEmbedder(emb embedding.Embedder, config *batchEmbedderConfig) *batchEmbedder {
+	b := &batchEmbedder{
+		emb:        emb,
+		batchSize:  config.BatchSize,
+		workers:    config.Workers,
+		onProgress: config.OnProgress,
+	}
+	if b.batchSize <= 0 {
+		b.batchSize = 16
+	}
+	if b.workers <= 0 {
+		b.workers = 4
+	}
+	return b
+}
+
+type batch struct {
+	start int
+	texts []string
+}
+
+// EmbedStrings returns the vectors in the same order as texts. The first failed batch cancels the others.
+func (b *batchEmbedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	batches := make(chan batch)
+	go func() {
+		defer close(batches)
+		for start := 0; start < len(texts); start += b.batchSize {
+			end := min(start+b.batchSize, len(texts))
+			select {
+			case batches <- batch{start: start, texts: texts[start:end]}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		vectors  = make([][]float64, len(texts))
+		done     atomic.Int64
+		wg       sync.WaitGroup
+		mu       sync.Mutex // serializes OnProgress and firstErr
+		firstErr error
+	)
+	for w := 0; w < b.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for bt := range batches {
+				vs, err := b.emb.EmbedStrings(ctx, bt.texts, opts...)
+				if err == nil && len(vs) != len(bt.texts) {
+					err = fmt.Errorf("embedding returned %d vectors for %d texts", len(vs), len(bt.texts))
+				}
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("embed texts [%d, %d) failed: %w", bt.start, bt.start+len(bt.texts), err)
+					}
+					mu.Unlock()
+					cancel()
+					return
+				}
+
+				// every batch writes its own range, no lock is needed
+				copy(vectors[bt.start:], vs)
+
+				n := int(done.Add(int64(len(bt.texts))))
+				if b.onProgress != nil {
+					mu.Lock()
+					b.onProgress(n, len(texts))
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return vectors, nil
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/progress"
+	"github.com/cloudwego/eino-examples/internal/ratelimit"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+// Embedding texts one request at a time spends most of the time waiting on round trips.
+// This example sends several texts per request and several requests at once, and reports the progress.
+//
+//	ARK_API_KEY=xxx ARK_EMBEDDING_MODEL=xxx go run ./components/embedding/batch -n 500 -naive 20
+func main() {
+	input := flag.String("input", "", "file with one text per line, a synthetic corpus is used if empty")
+	n := flag.Int("n", 400, "size of the synthetic corpus")
+	batchSize := flag.Int("batch", 16, "texts per embedding request")
+	workers := flag.Int("workers", 4, "concurrent embedding requests")
+	naive := flag.Int("naive", 0, "also embed the first N texts one by one, to compare the throughput")
+	flag.Parse()
+	validate.Must(validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	arkEmb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	// the concurrent batches share the limiter of ark embedding, set EINO_RATELIMIT_ARK_EMBEDDING_RPM and _TPM to the
+	// quota of the account
+	emb := ratelimit.WrapEmbedder(arkEmb, ratelimit.Shared("ark/embedding"))
+
+	texts, err := loadTexts(*input, *n)
+	if err != nil {
+		logs.Fatalf("load texts failed, err=%v", err)
+	}
+	logs.Infof("%d texts to embed, batch size %d, %d workers", len(texts), *batchSize, *workers)
+
+	if *naive > 0 {
+		k := min(*naive, len(texts))
+		start := time.Now()
+		for _, t := range texts[:k] {
+			if _, err = emb.EmbedStrings(ctx, []string{t}); err != nil {
+				logs.Fatalf("embed failed, err=%v", err)
+			}
+		}
+		elapsed := time.Since(start)
+		logs.Infof("one by one: %d texts in %v, %.1f texts/s, the whole corpus would take about %v",
+			k, elapsed.Round(time.Millisecond), float64(k)/elapsed.Seconds(),
+			(elapsed / time.Duration(k) * time.Duration(len(texts))).Round(time.Second))
+	}
+
+	// a bar on a terminal, a log line every 10s otherwise, one line per batch is too noisy for a large corpus
+	bar := progress.New("embedding", len(texts), nil)
+	batched := newBatchEmbedder(emb, &batchEmbedderConfig{
+		BatchSize: *batchSize,
+		Workers:   *workers,
+		OnProgress: func(done, _ int) {
+			bar.Set(done)
+		},
+	})
+
+	// the batch embedder is an embedding.Embedder, so it plugs into an indexer like any other embedder
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: batched})
+	if err != nil {
+		logs.Fatalf("create memory store failed, err=%v", err)
+	}
+
+	docs := make([]*schema.Document, len(texts))
+	for i, t := range texts {
+		docs[i] = &schema.Document{ID: fmt.Sprintf("doc-%d", i), Content: t}
+	}
+
+	start := time.Now()
+	_, err = store.Store(ctx, docs)
+	bar.Done()
+	if err != nil {
+		logs.Fatalf("index failed, err=%v", err)
+	}
+	elapsed := time.Since(start)
+	logs.Infof("batched: %d texts in %v, %.1f texts/s", len(texts), elapsed.Round(time.Millisecond),
+		float64(len(texts))/elapsed.Seconds())
+
+	res, err := store.Retrieve(ctx, "how to retry a failed request")
+	if err != nil {
+		logs.Fatalf("retrieve failed, err=%v", err)
+	}
+	for _, doc := range res {
+		logs.Infof("  score=%.3f %s", doc.Score(), doc.Content)
+	}
+}
+
+func loadTexts(path string, n int) ([]string, error) {
+	if path == "" {
+		return syntheticCorpus(n), nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var texts []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if line := strings.TrimSpace(sc.Text()); line != "" {
+			texts = append(texts, line)
+		}
+	}
+	return texts, sc.Err()
+}
+
+func syntheticCorpus(n int) []string {
+	subjects := []string{"The indexer", "A retriever", "The chat model", "Every tool", "The graph", "A callback handler", "The splitter", "An agent"}
+	verbs := []string{"retries a failed request", "streams its output", "reads the shared state", "logs the token usage",
+		"times out after thirty seconds", "runs in parallel with its siblings", "validates its input", "caches the last result"}
+	contexts := []string{"when the upstream is slow", "before the answer is sent", "after every step", "during ingestion",
+		"under heavy load", "in the development environment"}
+
+	texts := make([]string, n)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("%s %s %s (note %d).",
+			subjects[i%len(subjects)], verbs[(i/len(subjects))%len(verbs)], contexts[(i/7)%len(contexts)], i)
+	}
+	return texts
+}
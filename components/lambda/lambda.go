@@ -19,9 +19,10 @@ package lambda
 import (
 	"context"
 
-	"github.com/cloudwego/eino-ext/components/model/openai"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/models"
 )
 
 // INFO: 参考文档 https://www.cloudwego.io/zh/docs/eino/core_modules/components/lambda_guide/
@@ -108,10 +109,7 @@ func ExampleOfLambdaInGraph() {
 }
 
 func ExampleOfToListLambda() {
-	chatModel, _ := openai.NewChatModel(context.Background(), &openai.ChatModelConfig{
-		Model:  "gpt-4o",
-		APIKey: "",
-	})
+	chatModel := models.MustChatModel(context.Background())
 
 	// 创建一个 ToList Lambda
 	lambda := compose.ToList[*schema.Message]()
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/chatmodel"
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/errors"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/progress"
+	"github.com/cloudwego/eino-examples/internal/ratelimit"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+var (
+	input   = flag.String("input", "components/model/batch/prompts.txt", "file with one prompt per line")
+	output  = flag.String("output", "", "write results as JSONL to this file, empty means only log them")
+	workers = flag.Int("workers", 4, "max number of concurrent model calls")
+	timeout = flag.Duration("timeout", 60*time.Second, "timeout of a single prompt")
+)
+
+type result struct {
+	Index  int    `json:"index"`
+	Prompt string `json:"prompt"`
+	Answer string `json:"answer,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// ErrorKind classifies the error, e.g. rate_limit or context_length, see internal/errors.
+	ErrorKind errors.Kind `json:"error_kind,omitempty"`
+	Duration  string      `json:"duration"`
+}
+
+func main() {
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	prompts, err := readPrompts(*input)
+	if err != nil {
+		logs.Fatalf("read prompts failed, err=%v", err)
+	}
+
+	// a single ChatModel instance is safe for concurrent use, there is no need to create one per worker
+	var cm model.ChatModel
+	cm = models.MustChatModel(ctx)
+	// the workers share the limiter of the provider, which keeps the batch within its quota instead of retrying on 429s
+	cm = ratelimit.WrapChatModel(cm, ratelimit.Shared(config.String("MODEL_PROVIDER", models.ProviderOpenAI)))
+	cm = chatmodel.NewRetryChatModel(cm, nil)
+
+	start := time.Now()
+	results := runBatch(ctx, cm, prompts, *workers)
+	logs.Infof("processed %d prompts with %d workers in %v", len(prompts), *workers, time.Since(start))
+
+	failed := map[errors.Kind]int{}
+	for _, r := range results {
+		if r.Error != "" {
+			failed[r.ErrorKind]++
+			logs.Errorf("[%d] %s => %s error: %s", r.Index, r.Prompt, r.ErrorKind, r.Error)
+			continue
+		}
+		logs.Infof("[%d] %s => %s", r.Index, r.Prompt, r.Answer)
+	}
+	total := 0
+	for _, kind := range slices.Sorted(maps.Keys(failed)) {
+		total += failed[kind]
+		logs.Infof("failed with %s: %d", kind, failed[kind])
+	}
+	logs.Infof("succeeded: %d, failed: %d", len(results)-total, total)
+
+	if *output != "" {
+		if err = writeResults(*output, results); err != nil {
+			logs.Fatalf("write results failed, err=%v", err)
+		}
+		logs.Infof("results written to %s", *output)
+	}
+}
+
+// runBatch runs prompts through cm with at most n concurrent calls.
+// Results are returned in the same order as the prompts, a failed prompt does not stop the others.
+func runBatch(ctx context.Context, cm model.ChatModel, prompts []string, n int) []*result {
+	if n <= 0 {
+		n = 1
+	}
+
+	results := make([]*result, len(prompts))
+	jobs := make(chan int)
+	bar := progress.New("prompts", len(prompts), nil)
+	defer bar.Done()
+
+	var wg sync.WaitGroup
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// each worker writes to its own slot, so no lock is needed for results
+				results[i] = runOne(ctx, cm, i, prompts[i])
+				bar.Add(1)
+			}
+		}()
+	}
+
+	for i := range prompts {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func runOne(ctx context.Context, cm model.ChatModel, index int, prompt string) (r *result) {
+	start := time.Now()
+	r = &result{Index: index, Prompt: prompt}
+	defer func() {
+		// a panic in one call should be reported as the error of this prompt instead of crashing the batch
+		if p := recover(); p != nil {
+			r.Error, r.ErrorKind = fmt.Sprintf("panic: %v", p), errors.Panic
+		}
+		r.Duration = time.Since(start).Round(time.Millisecond).String()
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	out, err := cm.Generate(ctx, []*schema.Message{
+		schema.SystemMessage("You are a helpful assistant. Answer briefly."),
+		schema.UserMessage(prompt),
+	})
+	if err != nil {
+		r.Error, r.ErrorKind = err.Error(), errors.KindOf(err)
+		return r
+	}
+	r.Answer = out.Content
+	return r
+}
+
+func readPrompts(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var prompts []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		prompts = append(prompts, line)
+	}
+	return prompts, scanner.Err()
+}
+
+func writeResults(path string, results []*result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, r := range results {
+		if err = enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
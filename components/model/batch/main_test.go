@@ -0,0 +1,56 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/models"
+)
+
+// TestBatchReplay runs the prompts through the OpenAI client, its exchanges replayed from
+// testdata/cassettes/batch.json, see internal/httpreplay. Record them again with EINO_HTTP_REPLAY=record and the
+// same model, the system prompt or the prompts having changed.
+func TestBatchReplay(t *testing.T) {
+	t.Setenv("EINO_HTTP_REPLAY", "replay")
+	t.Setenv("EINO_HTTP_CASSETTE", "testdata/cassettes/batch.json")
+	t.Setenv("MODEL_PROVIDER", models.ProviderOpenAI)
+	t.Setenv("OPENAI_API_KEY", "sk-test")
+	t.Setenv("OPENAI_MODEL_NAME", "gpt-4o-mini")
+	t.Setenv("OPENAI_BASE_URL", "https://api.openai.com/v1")
+	ctx := context.Background()
+
+	prompts, err := readPrompts("prompts.txt")
+	assert.NoError(t, err)
+	assert.Len(t, prompts, 8)
+
+	results := runBatch(ctx, models.MustChatModel(ctx), prompts, 4)
+	if !assert.Len(t, results, len(prompts)) {
+		return
+	}
+	for i, r := range results {
+		assert.Equal(t, i, r.Index)
+		assert.Equal(t, prompts[i], r.Prompt)
+		assert.Empty(t, r.Error)
+		assert.NotEmpty(t, r.Answer)
+	}
+	assert.Equal(t, "The capital of France is Paris.", results[0].Answer)
+	assert.Equal(t, "17 * 23 = 391.", results[4].Answer)
+}
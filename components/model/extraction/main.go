@@ -0,0 +1,238 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/document/parser/pdf"
+	"github.com/cloudwego/eino/components/document/parser"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// kind is a type of document and the record extracted from it.
+type kind struct {
+	tool        *schema.ToolInfo
+	instruction string
+	decode      func(arguments string) (record, error)
+}
+
+// newKind infers the JSON schema of T for the tool the model is forced to call: the arguments of the call are the
+// record, so their shape is constrained by the schema instead of by the wording of the prompt.
+func newKind[T any, PT interface {
+	*T
+	record
+}](toolName, toolDesc, instruction string) (*kind, error) {
+	info, err := utils.GoStruct2ToolInfo[T](toolName, toolDesc)
+	if err != nil {
+		return nil, err
+	}
+	return &kind{
+		tool:        info,
+		instruction: instruction,
+		decode: func(arguments string) (record, error) {
+			r := PT(new(T))
+			if err := json.Unmarshal([]byte(arguments), r); err != nil {
+				return nil, fmt.Errorf("the arguments do not match the schema: %w", err)
+			}
+			return r, nil
+		},
+	}, nil
+}
+
+func newKinds() (map[string]*kind, error) {
+	invoice, err := newKind[Invoice]("record_invoice", "record the fields of an invoice",
+		"Extract the invoice below by calling record_invoice. Copy numbers and names exactly as printed, do not compute missing values.")
+	if err != nil {
+		return nil, err
+	}
+	resume, err := newKind[Resume]("record_resume", "record the fields of a resume",
+		"Extract the resume below by calling record_resume. Leave out what the resume does not say.")
+	if err != nil {
+		return nil, err
+	}
+	return map[string]*kind{"invoice": invoice, "resume": resume}, nil
+}
+
+// This example extracts typed records from unstructured documents and writes them to a CSV file:
+//
+//	file (.txt or .pdf) -> parser -> prompt -> model forced to call record_xxx -> decode -> validate -> CSV row
+//
+// The schema of the record is inferred from a Go struct and given to the model as the only tool it can call, so the
+// reply is always JSON of that shape. The schema cannot say that the lines of an invoice add up to its total, or that
+// a date is a date, so every record is validated in Go as well; the documents failing validation are reported instead
+// of written, since a wrong row in a CSV is usually worse than a missing one.
+//
+// testdata/invoices has a second invoice whose amounts do not add up, to show a rejection.
+func main() {
+	kindName := flag.String("kind", "invoice", "type of the documents: invoice or resume")
+	dir := flag.String("dir", "", "directory of the documents, .txt and .pdf, the samples of the kind if empty")
+	out := flag.String("out", "", "CSV file to write, <kind>s.csv if empty")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	kinds, err := newKinds()
+	if err != nil {
+		logs.Fatalf("newKinds failed, err=%v", err)
+	}
+	k, ok := kinds[*kindName]
+	if !ok {
+		logs.Fatalf("unknown kind %q, use invoice or resume", *kindName)
+	}
+	if *dir == "" {
+		*dir = filepath.Join("components/model/extraction/testdata", *kindName+"s")
+	}
+	if *out == "" {
+		*out = *kindName + "s.csv"
+	}
+
+	pdfParser, err := pdf.NewPDFParser(ctx, &pdf.Config{})
+	if err != nil {
+		logs.Fatalf("pdf.NewPDFParser failed, err=%v", err)
+	}
+	docParser, err := parser.NewExtParser(ctx, &parser.ExtParserConfig{
+		Parsers:        map[string]parser.Parser{".pdf": pdfParser},
+		FallbackParser: parser.TextParser{},
+	})
+	if err != nil {
+		logs.Fatalf("NewExtParser failed, err=%v", err)
+	}
+
+	// forced tool choice is a method of the OpenAI client, so the provider is openai, azure or claude
+	cm := models.MustOpenAIChatModel(ctx, models.WithTemperature(0))
+	// forced: the model has to call the tool, it cannot answer in text
+	if err = cm.BindForcedTools([]*schema.ToolInfo{k.tool}); err != nil {
+		logs.Fatalf("BindForcedTools failed, err=%v", err)
+	}
+
+	chain := compose.NewChain[string, record]()
+	chain.
+		AppendLambda(compose.InvokableLambda(func(ctx context.Context, text string) ([]*schema.Message, error) {
+			return []*schema.Message{
+				schema.SystemMessage(k.instruction),
+				schema.UserMessage(text),
+			}, nil
+		})).
+		AppendChatModel(cm).
+		AppendLambda(compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (record, error) {
+			if len(msg.ToolCalls) == 0 {
+				return nil, fmt.Errorf("the model did not call %s", k.tool.Name)
+			}
+			r, err := k.decode(msg.ToolCalls[0].Function.Arguments)
+			if err != nil {
+				return nil, err
+			}
+			if err = r.validate(); err != nil {
+				return nil, fmt.Errorf("invalid record: %w", err)
+			}
+			return r, nil
+		}))
+	extract, err := chain.Compile(ctx)
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	files, err := listDocuments(*dir)
+	if err != nil {
+		logs.Fatalf("listDocuments failed, err=%v", err)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		logs.Fatalf("create %s failed, err=%v", *out, err)
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+
+	var written, rejected int
+	for _, path := range files {
+		text, err := readDocument(ctx, docParser, path)
+		if err != nil {
+			logs.Errorf("read %s failed, err=%v", path, err)
+			rejected++
+			continue
+		}
+		r, err := extract.Invoke(ctx, text)
+		if err != nil {
+			logs.Errorf("%s rejected:\n%v", path, err)
+			rejected++
+			continue
+		}
+		if written == 0 {
+			_ = w.Write(append([]string{"file"}, r.csvHeader()...))
+		}
+		_ = w.Write(append([]string{filepath.Base(path)}, r.csvRow()...))
+		written++
+		logs.Infof("%s extracted", path)
+	}
+	w.Flush()
+	if err = w.Error(); err != nil {
+		logs.Fatalf("write %s failed, err=%v", *out, err)
+	}
+	logs.Infof("%d records written to %s, %d documents rejected", written, *out, rejected)
+}
+
+func listDocuments(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		ext := strings.ToLower(filepath.Ext(e.Name()))
+		if !e.IsDir() && (ext == ".txt" || ext == ".pdf") {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readDocument parses the file into text, the pages of a PDF are joined.
+func readDocument(ctx context.Context, p parser.Parser, path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	docs, err := p.Parse(ctx, file, parser.WithURI(path))
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, 0, len(docs))
+	for _, d := range docs {
+		parts = append(parts, d.Content)
+	}
+	return strings.Join(parts, "\n"), nil
+}
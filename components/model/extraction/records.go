@@ -0,0 +1,195 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// record is a typed row extracted from one document.
+type record interface {
+	// validate checks what the JSON schema cannot express, such as formats and totals that must add up.
+	validate() error
+	csvHeader() []string
+	csvRow() []string
+}
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Invoice is extracted from invoices. Fields without omitempty are required in the schema given to the model.
+type Invoice struct {
+	Number    string        `json:"number" jsonschema:"description=the invoice number as printed"`
+	Vendor    string        `json:"vendor" jsonschema:"description=the company that issued the invoice"`
+	Customer  string        `json:"customer" jsonschema:"description=the company or person billed"`
+	IssueDate string        `json:"issue_date" jsonschema:"description=date of issue as YYYY-MM-DD"`
+	DueDate   string        `json:"due_date,omitempty" jsonschema:"description=payment due date as YYYY-MM-DD"`
+	Currency  string        `json:"currency" jsonschema:"description=ISO 4217 code such as USD or EUR"`
+	Lines     []InvoiceLine `json:"lines"`
+	Subtotal  float64       `json:"subtotal" jsonschema:"description=total before tax"`
+	Tax       float64       `json:"tax" jsonschema:"description=total tax amount and 0 if there is none"`
+	Total     float64       `json:"total" jsonschema:"description=amount due including tax"`
+}
+
+// InvoiceLine is a line of an invoice.
+type InvoiceLine struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+}
+
+func (i *Invoice) validate() error {
+	var errs []error
+	if i.Number == "" || i.Vendor == "" {
+		errs = append(errs, errors.New("number and vendor are required"))
+	}
+	if err := checkDate("issue_date", i.IssueDate, time.DateOnly); err != nil {
+		errs = append(errs, err)
+	}
+	if i.DueDate != "" {
+		if err := checkDate("due_date", i.DueDate, time.DateOnly); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if !currencyCode.MatchString(i.Currency) {
+		errs = append(errs, fmt.Errorf("currency %q is not an ISO 4217 code", i.Currency))
+	}
+
+	// the amounts must add up: a wrong number read by the model is caught here, not by the accounting team
+	var sum float64
+	for n, l := range i.Lines {
+		if !closeTo(l.Quantity*l.UnitPrice, l.Amount) {
+			errs = append(errs, fmt.Errorf("line %d: %v x %v is not %v", n+1, l.Quantity, l.UnitPrice, l.Amount))
+		}
+		sum += l.Amount
+	}
+	if len(i.Lines) > 0 && !closeTo(sum, i.Subtotal) {
+		errs = append(errs, fmt.Errorf("the lines add up to %.2f, not to the subtotal %.2f", sum, i.Subtotal))
+	}
+	if !closeTo(i.Subtotal+i.Tax, i.Total) {
+		errs = append(errs, fmt.Errorf("subtotal %.2f + tax %.2f is not the total %.2f", i.Subtotal, i.Tax, i.Total))
+	}
+	return errors.Join(errs...)
+}
+
+func (i *Invoice) csvHeader() []string {
+	return []string{"number", "vendor", "customer", "issue_date", "due_date", "currency", "lines", "subtotal", "tax", "total"}
+}
+
+func (i *Invoice) csvRow() []string {
+	return []string{i.Number, i.Vendor, i.Customer, i.IssueDate, i.DueDate, i.Currency, strconv.Itoa(len(i.Lines)),
+		formatAmount(i.Subtotal), formatAmount(i.Tax), formatAmount(i.Total)}
+}
+
+// Resume is extracted from resumes.
+type Resume struct {
+	Name       string   `json:"name"`
+	Email      string   `json:"email"`
+	Phone      string   `json:"phone,omitempty"`
+	Location   string   `json:"location,omitempty" jsonschema:"description=city and country"`
+	Skills     []string `json:"skills" jsonschema:"description=technical skills such as languages and tools"`
+	Experience []Job    `json:"experience" jsonschema:"description=jobs from the most recent to the oldest"`
+}
+
+// Job is a position held by the candidate.
+type Job struct {
+	Company string `json:"company"`
+	Title   string `json:"title"`
+	Start   string `json:"start" jsonschema:"description=start month as YYYY-MM"`
+	End     string `json:"end,omitempty" jsonschema:"description=end month as YYYY-MM and empty for the current job"`
+}
+
+func (r *Resume) validate() error {
+	var errs []error
+	if r.Name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+	if _, err := mail.ParseAddress(r.Email); err != nil {
+		errs = append(errs, fmt.Errorf("email %q is invalid", r.Email))
+	}
+	for n, j := range r.Experience {
+		if err := checkDate(fmt.Sprintf("experience %d start", n+1), j.Start, monthLayout); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if j.End == "" {
+			continue
+		}
+		if err := checkDate(fmt.Sprintf("experience %d end", n+1), j.End, monthLayout); err != nil {
+			errs = append(errs, err)
+		} else if j.End < j.Start {
+			errs = append(errs, fmt.Errorf("experience %d ends in %s before it starts in %s", n+1, j.End, j.Start))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Resume) csvHeader() []string {
+	return []string{"name", "email", "phone", "location", "years_of_experience", "skills", "current_company", "current_title"}
+}
+
+func (r *Resume) csvRow() []string {
+	var company, title string
+	if len(r.Experience) > 0 {
+		company, title = r.Experience[0].Company, r.Experience[0].Title
+	}
+	return []string{r.Name, r.Email, r.Phone, r.Location, strconv.FormatFloat(r.yearsOfExperience(), 'f', 1, 64),
+		strings.Join(r.Skills, "; "), company, title}
+}
+
+// yearsOfExperience is computed rather than extracted, models are not reliable at date arithmetic.
+func (r *Resume) yearsOfExperience() float64 {
+	var months int
+	for _, j := range r.Experience {
+		start, err := time.Parse(monthLayout, j.Start)
+		if err != nil {
+			continue
+		}
+		end := time.Now()
+		if j.End != "" {
+			if end, err = time.Parse(monthLayout, j.End); err != nil {
+				continue
+			}
+		}
+		months += (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	}
+	return math.Round(float64(months)/12*10) / 10
+}
+
+const monthLayout = "2006-01"
+
+func checkDate(field, value, layout string) error {
+	if _, err := time.Parse(layout, value); err != nil {
+		return fmt.Errorf("%s %q is not in the %s format", field, value, layout)
+	}
+	return nil
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) < 0.01
+}
+
+func formatAmount(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/chatmodel"
+	"github.com/cloudwego/eino-examples/internal/errors"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/streamprint"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// primary: OpenAI
+	primary := models.MustChatModel(ctx, models.WithProvider(models.ProviderOpenAI))
+	// secondary: Ark
+	secondary := models.MustChatModel(ctx, models.WithProvider(models.ProviderArk))
+	// last resort: a local Ollama model, which keeps working even when the network is down
+	local := models.MustChatModel(ctx, models.WithProvider(models.ProviderOllama))
+
+	cm, err := chatmodel.NewFallbackChatModel(&chatmodel.FallbackConfig{
+		Models:  []model.ChatModel{primary, secondary, local},
+		Timeout: 30 * time.Second, // give up a model if it does not respond within 30s
+		ShouldFallback: func(err error) bool {
+			switch errors.KindOf(err) {
+			case errors.Canceled:
+				return false
+			case errors.ContentFilter:
+				// the next providers would most likely refuse the same prompt
+				logs.Errorf("prompt rejected by the moderation of the provider, err=%v", err)
+				return false
+			case errors.ContextLength:
+				logs.Warnf("prompt too long for the model, trying the next one, which may have a larger window")
+			}
+			// rate limits, outages, timeouts and auth failures of one provider are not shared by the others
+			return true
+		},
+	})
+	if err != nil {
+		logs.Fatalf("create fallback chat model failed, err=%v", err)
+	}
+
+	messages := []*schema.Message{
+		schema.SystemMessage("You are a helpful assistant."),
+		schema.UserMessage("Explain in one sentence why a fallback model improves resilience."),
+	}
+
+	out, err := cm.Generate(ctx, messages)
+	if err != nil {
+		logs.Fatalf("all models failed, err=%v", err)
+	}
+	logs.Infof("generate result: %s", out.Content)
+
+	sr, err := cm.Stream(ctx, messages)
+	if err != nil {
+		logs.Fatalf("all models failed, err=%v", err)
+	}
+	if _, err = streamprint.Print(sr); err != nil {
+		logs.Fatalf("recv failed, err=%v", err)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example calls a reasoning model (OpenAI o-series, or any OpenAI compatible thinking model)
+// with different reasoning efforts, showing how an implementation specific option flows through eino:
+//
+//	WithReasoningEffort("high")          -> model.Option wrapping a func(*reasoningOptions)
+//	reasoningChatModel.Generate          -> model.GetImplSpecificOptions picks it up and puts it into ctx
+//	openai.ChatModel.Generate            -> ignores the options it does not know, passes ctx to the http request
+//	reasoningTransport.RoundTrip         -> reads ctx and adds reasoning_effort / thinking to the request body
+//
+// The fields are added to the requests of the OpenAI client, MODEL_PROVIDER is one of openai, azure and claude.
+// OPENAI_REASONING_MODEL_NAME, e.g. o3-mini, overrides the model of the provider.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	// the client of internal/models brings the proxy, retries and timeouts of the other examples, the reasoning
+	// fields are added before them
+	opts := []models.Option{models.WithTransport(func(next http.RoundTripper) http.RoundTripper {
+		return &reasoningTransport{RoundTripper: next}
+	})}
+	if name := config.String("OPENAI_REASONING_MODEL_NAME", ""); name != "" {
+		opts = append(opts, models.WithModel(name))
+	}
+	cm := newReasoningChatModel(models.MustOpenAIChatModel(ctx, opts...), EffortMedium)
+
+	messages := []*schema.Message{
+		schema.UserMessage("A bat and a ball cost $1.10 in total. The bat costs $1.00 more than the ball. " +
+			"How much does the ball cost? Answer with the number only."),
+	}
+
+	for _, effort := range []string{EffortLow, EffortMedium, EffortHigh} {
+		start := time.Now()
+		out, err := cm.Generate(ctx, messages, WithReasoningEffort(effort))
+		if err != nil {
+			logs.Errorf("generate with effort=%s failed, err=%v", effort, err)
+			continue
+		}
+
+		logs.Infof("effort=%s, latency=%v, answer=%s", effort, time.Since(start).Round(time.Millisecond), out.Content)
+		if out.ResponseMeta != nil && out.ResponseMeta.Usage != nil {
+			// for reasoning models, completion tokens include the hidden reasoning tokens,
+			// so they grow with the effort even though the answer stays the same
+			logs.Tokenf("effort=%s, prompt tokens=%d, completion tokens=%d",
+				effort, out.ResponseMeta.Usage.PromptTokens, out.ResponseMeta.Usage.CompletionTokens)
+		}
+	}
+
+	// some thinking models (e.g. Claude through an OpenAI compatible gateway) take a thinking budget instead
+	if budget := config.Int("THINKING_BUDGET_TOKENS", 0); budget > 0 {
+		out, err := cm.Generate(ctx, messages, WithThinkingBudget(budget))
+		if err != nil {
+			logs.Fatalf("generate with thinking budget failed, err=%v", err)
+		}
+		logs.Infof("thinking budget=%d, answer=%s", budget, out.Content)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	EffortLow    = "low"
+	EffortMedium = "medium"
+	EffortHigh   = "high"
+)
+
+// reasoningOptions is the implementation specific option of reasoningChatModel.
+type reasoningOptions struct {
+	// Effort is sent as reasoning_effort, used by OpenAI o-series models.
+	Effort string
+	// ThinkingBudget is sent as thinking.budget_tokens, used by extended thinking models. 0 means disabled.
+	ThinkingBudget int
+}
+
+// WithReasoningEffort sets how much the model should think before answering, one of low, medium and high.
+func WithReasoningEffort(effort string) model.Option {
+	return model.WrapImplSpecificOptFn(func(o *reasoningOptions) {
+		o.Effort = effort
+	})
+}
+
+// WithThinkingBudget enables extended thinking with at most budget tokens.
+func WithThinkingBudget(budget int) model.Option {
+	return model.WrapImplSpecificOptFn(func(o *reasoningOptions) {
+		o.ThinkingBudget = budget
+	})
+}
+
+type reasoningOptionsKey struct{}
+
+// reasoningChatModel decorates a ChatModel, extracting reasoningOptions from the call options
+// and passing them to reasoningTransport through ctx.
+type reasoningChatModel struct {
+	model.ChatModel
+
+	defaultEffort string
+}
+
+func newReasoningChatModel(m model.ChatModel, defaultEffort string) *reasoningChatModel {
+	return &reasoningChatModel{ChatModel: m, defaultEffort: defaultEffort}
+}
+
+func (r *reasoningChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	return r.ChatModel.Generate(r.withOptions(ctx, opts...), input, opts...)
+}
+
+func (r *reasoningChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	return r.ChatModel.Stream(r.withOptions(ctx, opts...), input, opts...)
+}
+
+func (r *reasoningChatModel) IsCallbacksEnabled() bool {
+	checker, ok := r.ChatModel.(components.Checker)
+	if ok {
+		return checker.IsCallbacksEnabled()
+	}
+
+	return false
+}
+
+func (r *reasoningChatModel) withOptions(ctx context.Context, opts ...model.Option) context.Context {
+	o := model.GetImplSpecificOptions(&reasoningOptions{Effort: r.defaultEffort}, opts...)
+	return context.WithValue(ctx, reasoningOptionsKey{}, o)
+}
+
+// reasoningTransport adds the reasoning fields to the chat completion request body,
+// so that they can be used even if the ChatModel implementation does not support them yet.
+type reasoningTransport struct {
+	http.RoundTripper
+}
+
+func (t *reasoningTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	o, ok := req.Context().Value(reasoningOptionsKey{}).(*reasoningOptions)
+	if !ok || req.Body == nil || req.Method != http.MethodPost {
+		return t.RoundTripper.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var payload map[string]json.RawMessage
+	if err = json.Unmarshal(body, &payload); err != nil {
+		// not a json request, send it as is
+		return t.send(req, body)
+	}
+
+	// the two styles are exclusive, a thinking budget takes precedence over the effort
+	if o.ThinkingBudget > 0 {
+		payload["thinking"], _ = json.Marshal(map[string]any{
+			"type":          "enabled",
+			"budget_tokens": o.ThinkingBudget,
+		})
+	} else if o.Effort != "" {
+		payload["reasoning_effort"], _ = json.Marshal(o.Effort)
+	}
+
+	body, err = json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return t.send(req, body)
+}
+
+func (t *reasoningTransport) send(req *http.Request, body []byte) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	req.ContentLength = int64(len(body))
+	req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	return t.RoundTripper.RoundTrip(req)
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/fewshot"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+var labels = []string{"billing", "bug", "feature_request", "account", "other"}
+
+const systemPrompt = `You classify support tickets of a project management app. Reply with exactly one label among:
+{labels}
+Reply with the label only.`
+
+// Requirements:
+//   - ARK_API_KEY / ARK_EMBEDDING_MODEL for embedding
+//   - MODEL_PROVIDER and the keys of the provider for the chat model, see internal/models
+//
+// This example classifies support tickets with a few-shot prompt whose examples are chosen per ticket: the labeled
+// tickets of testdata/tickets.json are embedded once, and for every ticket the most similar ones are injected into
+// the ChatTemplate as user/assistant pairs by fewshot.Template:
+//
+//	{ticket} -> fewshot.Template (select examples -> ChatTemplate) -> ChatModel -> normalize label
+//
+// Examples close to the ticket show the model where the boundaries between labels are for this product, e.g. that
+// a non-profit discount is a billing question and a missing reset email an account one. -k 0 runs zero-shot to
+// compare.
+func main() {
+	examplesPath := flag.String("examples", "components/prompt/fewshot/testdata/tickets.json", "labeled examples")
+	k := flag.Int("k", 4, "number of examples per ticket, 0 for zero-shot")
+	ticket := flag.String("ticket", "", "ticket to classify, default a few sample tickets")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	cm := models.MustChatModel(ctx, models.WithTemperature(0))
+
+	var tpl prompt.ChatTemplate = prompt.FromMessages(schema.FString,
+		schema.SystemMessage(systemPrompt),
+		schema.MessagesPlaceholder("examples", true),
+		schema.UserMessage("{query}"),
+	)
+	if *k > 0 {
+		selector, err := newSelector(ctx, emb, *examplesPath, *k)
+		if err != nil {
+			logs.Fatalf("newSelector failed, err=%v", err)
+		}
+		tpl, err = fewshot.NewTemplate(&fewshot.TemplateConfig{Selector: selector, Template: tpl})
+		if err != nil {
+			logs.Fatalf("fewshot.NewTemplate failed, err=%v", err)
+		}
+	}
+
+	chain := compose.NewChain[map[string]any, string]()
+	chain.
+		AppendChatTemplate(tpl).
+		AppendChatModel(cm).
+		AppendLambda(compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (string, error) {
+			return normalizeLabel(msg.Content), nil
+		}))
+	runner, err := chain.Compile(ctx)
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	tickets := []string{
+		"The invoice PDF shows the wrong company address, can you fix it?",
+		"Clicking 'archive' on a card archives the card next to it instead.",
+		"Could guests comment on cards without creating an account?",
+		"I no longer have access to the email I signed up with.",
+		"Do you offer a student discount?",
+	}
+	if *ticket != "" {
+		tickets = []string{*ticket}
+	}
+	for _, t := range tickets {
+		label, err := runner.Invoke(ctx, map[string]any{"query": t, "labels": strings.Join(labels, ", ")})
+		if err != nil {
+			logs.Fatalf("Invoke failed, err=%v", err)
+		}
+		logs.Infof("%-16s %s", label, t)
+	}
+}
+
+func newSelector(ctx context.Context, emb *ark.Embedder, path string, k int) (*fewshot.Selector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var examples []*fewshot.Example
+	if err = json.Unmarshal(data, &examples); err != nil {
+		return nil, err
+	}
+	selector, err := fewshot.NewSelector(&fewshot.Config{Embedding: emb, K: k, MaxPerLabel: 2})
+	if err != nil {
+		return nil, err
+	}
+	if err = selector.Add(ctx, examples...); err != nil {
+		return nil, err
+	}
+	logs.Infof("%d examples indexed", selector.Len())
+	return selector, nil
+}
+
+// normalizeLabel maps the reply to a known label, "other" if the model replied anything else.
+func normalizeLabel(reply string) string {
+	reply = strings.Trim(strings.ToLower(strings.TrimSpace(reply)), ".\"'`")
+	for _, l := range labels {
+		if reply == l {
+			return l
+		}
+	}
+	for _, l := range labels {
+		if strings.Contains(reply, l) {
+			return l
+		}
+	}
+	return "other"
+}
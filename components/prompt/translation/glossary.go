@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// entry is a term of the glossary and how it must be translated.
+type entry struct {
+	Term        string
+	Translation string
+	Note        string
+	pattern     *regexp.Regexp
+}
+
+type glossary []*entry
+
+// loadGlossary reads a CSV with the columns term, translation and an optional note.
+func loadGlossary(path string) (glossary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var g glossary
+	for i, rec := range records {
+		if i == 0 || len(rec) < 2 || rec[0] == "" {
+			continue // header or empty line
+		}
+		e := &entry{Term: rec[0], Translation: rec[1]}
+		if len(rec) > 2 {
+			e.Note = rec[2]
+		}
+		// whole words only and case-insensitive, so that "member" matches "Members" but not "remember"
+		e.pattern, err = regexp.Compile(`(?i)\b` + regexp.QuoteMeta(e.Term) + `(s|es)?\b`)
+		if err != nil {
+			return nil, fmt.Errorf("term %q: %w", e.Term, err)
+		}
+		g = append(g, e)
+	}
+
+	// the longest terms first, see match
+	sort.SliceStable(g, func(i, j int) bool { return len(g[i].Term) > len(g[j].Term) })
+	return g, nil
+}
+
+// match returns the entries used in text. A term inside a longer matching term is left out, so that "workspace owner"
+// does not also bring "workspace" when it only appears as part of it.
+func (g glossary) match(text string) []*entry {
+	var (
+		found   []*entry
+		covered = make([]bool, len(text))
+	)
+	for _, e := range g {
+		hit := false
+		for _, loc := range e.pattern.FindAllStringIndex(text, -1) {
+			if covered[loc[0]] {
+				continue
+			}
+			for i := loc[0]; i < loc[1]; i++ {
+				covered[i] = true
+			}
+			hit = true
+		}
+		if hit {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// formatEntries renders the entries for the prompt.
+func formatEntries(entries []*entry) string {
+	if len(entries) == 0 {
+		return "(no glossary term in this segment)"
+	}
+	sb := strings.Builder{}
+	for _, e := range entries {
+		sb.WriteString(fmt.Sprintf("- %s => %s", e.Term, e.Translation))
+		if e.Note != "" {
+			sb.WriteString(" (" + e.Note + ")")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// missing returns the entries whose translation is not in the translated text, the model did not follow the glossary.
+func missing(entries []*entry, translated string) []*entry {
+	var res []*entry
+	for _, e := range entries {
+		if !strings.Contains(strings.ToLower(translated), strings.ToLower(e.Translation)) {
+			res = append(res, e)
+		}
+	}
+	return res
+}
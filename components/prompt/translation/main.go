@@ -0,0 +1,164 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+var translateTemplate = prompt.FromMessages(schema.FString,
+	schema.SystemMessage(`You are a professional translator. Translate the user's text from {source} to {target}.
+Rules:
+- Keep the Markdown exactly: headings, list markers, emphasis, links, line breaks. Translate the link text, not the URL.
+- Do not translate inline code between backticks.
+- Use these glossary translations for the terms they list, they take precedence over any other translation:
+{glossary}
+Reply with the translation only.`),
+	schema.UserMessage("{segment}"),
+)
+
+// segment is a block of the document. Code blocks are kept as they are.
+type segment struct {
+	Text      string
+	Translate bool
+}
+
+// This example translates a Markdown document segment by segment with a domain glossary:
+//
+//	document -> segments -> for each segment: glossary terms found in it -> chat template -> model -> check
+//
+// Only the glossary terms found in the segment are injected in its prompt, so the prompt stays short with a glossary
+// of thousands of terms, and the model is not distracted by terms that do not apply. After translation, the segment is
+// checked for the expected translations and the misses are reported for review.
+//
+// Segments are the blocks between blank lines: a translation of a whole paragraph reads better than one of single
+// sentences, and splitting on blank lines keeps the structure of the document, whose code blocks are not sent to
+// the model at all.
+func main() {
+	input := flag.String("input", "components/prompt/translation/testdata/admin_guide.md", "Markdown document to translate")
+	glossaryPath := flag.String("glossary", "components/prompt/translation/testdata/glossary.csv", "CSV glossary: term,translation,note")
+	source := flag.String("source", "English", "language of the document")
+	target := flag.String("target", "Simplified Chinese", "language to translate to")
+	out := flag.String("out", "admin_guide.translated.md", "file to write the translation to")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	g, err := loadGlossary(*glossaryPath)
+	if err != nil {
+		logs.Fatalf("loadGlossary failed, err=%v", err)
+	}
+	content, err := os.ReadFile(*input)
+	if err != nil {
+		logs.Fatalf("read %s failed, err=%v", *input, err)
+	}
+
+	cm := models.MustChatModel(ctx, models.WithTemperature(0))
+
+	chain := compose.NewChain[map[string]any, string]()
+	chain.
+		AppendChatTemplate(translateTemplate).
+		AppendChatModel(cm).
+		AppendLambda(compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (string, error) {
+			return strings.TrimSpace(msg.Content), nil
+		}))
+	translate, err := chain.Compile(ctx)
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	segments := splitSegments(string(content))
+	translated := make([]string, 0, len(segments))
+	for i, s := range segments {
+		if !s.Translate {
+			translated = append(translated, s.Text)
+			continue
+		}
+
+		terms := g.match(s.Text)
+		result, err := translate.Invoke(ctx, map[string]any{
+			"source":   *source,
+			"target":   *target,
+			"glossary": formatEntries(terms),
+			"segment":  s.Text,
+		})
+		if err != nil {
+			logs.Fatalf("translate segment %d failed, err=%v", i+1, err)
+		}
+		translated = append(translated, result)
+
+		logs.Infof("segment %d/%d: %d glossary terms", i+1, len(segments), len(terms))
+		if misses := missing(terms, result); len(misses) > 0 {
+			logs.Errorf("segment %d does not follow the glossary, to review:\n%s%s", i+1, formatEntries(misses), result)
+		}
+	}
+
+	if err = os.WriteFile(*out, []byte(strings.Join(translated, "\n\n")+"\n"), 0o644); err != nil {
+		logs.Fatalf("write %s failed, err=%v", *out, err)
+	}
+	logs.Infof("translation written to %s", *out)
+}
+
+// splitSegments splits on blank lines, except inside fenced code blocks which make one segment each.
+func splitSegments(doc string) []*segment {
+	var (
+		segments []*segment
+		block    []string
+		inCode   bool
+	)
+	flush := func(translate bool) {
+		if len(block) > 0 {
+			segments = append(segments, &segment{Text: strings.Join(block, "\n"), Translate: translate})
+			block = nil
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n") {
+		isFence := strings.HasPrefix(strings.TrimSpace(line), "```")
+		switch {
+		case isFence && !inCode:
+			flush(true)
+			inCode = true
+			block = append(block, line)
+		case isFence && inCode:
+			block = append(block, line)
+			flush(false)
+			inCode = false
+		case inCode:
+			block = append(block, line)
+		case strings.TrimSpace(line) == "":
+			flush(true)
+		default:
+			block = append(block, line)
+		}
+	}
+	// an unterminated code block is kept as it is too
+	flush(!inCode)
+	return segments
+}
@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	es8idx "github.com/cloudwego/eino-ext/components/indexer/es8"
+	es8ret "github.com/cloudwego/eino-ext/components/retriever/es8"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const (
+	fieldContent  = "content"
+	fieldMetadata = "metadata"
+	fieldVector   = "content_vector"
+)
+
+// ensureIndex creates the index with a text field for BM25 and a dense_vector field for kNN, unless it already exists.
+func ensureIndex(ctx context.Context, client *elasticsearch.Client, index string, dim int) error {
+	resp, err := client.Indices.Exists([]string{index}, client.Indices.Exists.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("check index failed: %w", err)
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		logs.Infof("index %s already exists, reuse it", index)
+		return nil
+	case http.StatusNotFound:
+	default:
+		return fmt.Errorf("check index failed: %s", resp.Status())
+	}
+
+	mapping := fmt.Sprintf(`{
+  "mappings": {
+    "properties": {
+      %q: {"type": "text"},
+      %q: {"type": "object", "enabled": false},
+      %q: {"type": "dense_vector", "dims": %d, "index": true, "similarity": "cosine"}
+    }
+  }
+}`, fieldContent, fieldMetadata, fieldVector, dim)
+
+	resp, err = client.Indices.Create(index,
+		client.Indices.Create.WithContext(ctx),
+		client.Indices.Create.WithBody(strings.NewReader(mapping)))
+	if err != nil {
+		return fmt.Errorf("create index failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("create index failed: %s %s", resp.Status(), body)
+	}
+
+	logs.Infof("index %s created, dim=%d", index, dim)
+	return nil
+}
+
+// refresh makes the documents just indexed visible to search, ES only does it once per second by default.
+func refresh(ctx context.Context, client *elasticsearch.Client, index string) error {
+	resp, err := client.Indices.Refresh(client.Indices.Refresh.WithContext(ctx), client.Indices.Refresh.WithIndex(index))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.IsError() {
+		return fmt.Errorf("refresh failed: %s", resp.Status())
+	}
+	return nil
+}
+
+func newIndexer(ctx context.Context, client *elasticsearch.Client, index string, emb embedding.Embedder) (indexer.Indexer, error) {
+	return es8idx.NewIndexer(ctx, &es8idx.IndexerConfig{
+		Client:    client,
+		Index:     index,
+		BatchSize: 10,
+		DocumentToFields: func(ctx context.Context, doc *schema.Document) (map[string]es8idx.FieldValue, error) {
+			return map[string]es8idx.FieldValue{
+				// EmbedKey tells the indexer to embed this field and store the vector under fieldVector
+				fieldContent:  {Value: doc.Content, EmbedKey: fieldVector},
+				fieldMetadata: {Value: doc.MetaData},
+			}, nil
+		},
+		Embedding: emb,
+	})
+}
+
+func newRetriever(ctx context.Context, client *elasticsearch.Client, index string, mode es8ret.SearchMode,
+	emb embedding.Embedder) (retriever.Retriever, error) {
+
+	return es8ret.NewRetriever(ctx, &es8ret.RetrieverConfig{
+		Client:       client,
+		Index:        index,
+		TopK:         3,
+		SearchMode:   mode,
+		ResultParser: parseHit,
+		Embedding:    emb,
+	})
+}
+
+func parseHit(ctx context.Context, hit types.Hit) (*schema.Document, error) {
+	doc := &schema.Document{MetaData: map[string]any{}}
+	if hit.Id_ != nil {
+		doc.ID = *hit.Id_
+	}
+	if hit.Score_ != nil {
+		doc.WithScore(float64(*hit.Score_))
+	}
+
+	var src map[string]any
+	if err := json.Unmarshal(hit.Source_, &src); err != nil {
+		return nil, fmt.Errorf("unmarshal source of %s failed: %w", doc.ID, err)
+	}
+	if content, ok := src[fieldContent].(string); ok {
+		doc.Content = content
+	}
+	if metadata, ok := src[fieldMetadata].(map[string]any); ok {
+		doc.MetaData = metadata
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	es8ret "github.com/cloudwego/eino-ext/components/retriever/es8"
+	"github.com/cloudwego/eino-ext/components/retriever/es8/search_mode"
+	"github.com/cloudwego/eino/schema"
+	"github.com/elastic/go-elasticsearch/v8"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const indexName = "eino_examples"
+
+// Start elasticsearch first with the docker-compose.yml in this directory, then:
+//
+//	ARK_API_KEY=xxx ARK_EMBEDDING_MODEL=xxx go run ./components/retriever/es8
+func main() {
+	validate.Must(validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	addr := config.String("ES_ADDR", "http://localhost:9200")
+	password, err := secrets.Get(ctx, "ES_PASSWORD")
+	if err != nil {
+		logs.Fatalf("read ES_PASSWORD failed, err=%v", err)
+	}
+	client, err := elasticsearch.NewClient(elasticsearch.Config{
+		Addresses: []string{addr},
+		Username:  config.String("ES_USERNAME", ""),
+		Password:  password,
+	})
+	if err != nil {
+		logs.Fatalf("create es client failed, err=%v", err)
+	}
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+
+	// probe the dimension of the embedding model instead of hard coding it
+	probe, err := emb.EmbedStrings(ctx, []string{"dimension probe"})
+	if err != nil {
+		logs.Fatalf("embed probe failed, err=%v", err)
+	}
+
+	if err = ensureIndex(ctx, client, indexName, len(probe[0])); err != nil {
+		logs.Fatalf("ensure index failed, err=%v", err)
+	}
+
+	idx, err := newIndexer(ctx, client, indexName, emb)
+	if err != nil {
+		logs.Fatalf("create indexer failed, err=%v", err)
+	}
+	// documents are stored with their ID as the ES _id, so running the example again overwrites them
+	ids, err := idx.Store(ctx, []*schema.Document{
+		{ID: "quota", Content: "Error ERR_QUOTA_4012 is returned when the monthly token quota of the account is used up.", MetaData: map[string]any{"topic": "errors"}},
+		{ID: "ratelimit", Content: "Requests beyond the per minute limit are rejected with HTTP 429, slow down and retry with backoff.", MetaData: map[string]any{"topic": "errors"}},
+		{ID: "auth", Content: "Error ERR_AUTH_1001 means the API key is missing or invalid.", MetaData: map[string]any{"topic": "errors"}},
+		{ID: "streaming", Content: "Set stream to true to receive the answer incrementally as server sent events.", MetaData: map[string]any{"topic": "api"}},
+	})
+	if err != nil {
+		logs.Fatalf("store failed, err=%v", err)
+	}
+	if err = refresh(ctx, client, indexName); err != nil {
+		logs.Fatalf("refresh failed, err=%v", err)
+	}
+	logs.Infof("stored %d documents: %v", len(ids), ids)
+
+	modes := []struct {
+		name string
+		mode es8ret.SearchMode
+	}{
+		// BM25 over the text field, good at exact tokens such as error codes, blind to paraphrases
+		{"bm25", search_mode.SearchModeExactMatch(fieldContent)},
+		// kNN over the dense vectors, good at meaning, weak at rare tokens the embedding model has never seen
+		{"dense", search_mode.SearchModeApproximate(&search_mode.ApproximateConfig{
+			VectorFieldName: fieldVector,
+		})},
+		// both in one request, ES adds the BM25 score and the kNN score of every hit
+		{"hybrid", search_mode.SearchModeApproximate(&search_mode.ApproximateConfig{
+			QueryFieldName:  fieldContent,
+			VectorFieldName: fieldVector,
+			Hybrid:          true,
+		})},
+	}
+
+	queries := []string{
+		"ERR_QUOTA_4012",
+		"my calls get refused because I send them too fast",
+	}
+
+	for _, query := range queries {
+		logs.Infof("query=%q", query)
+		for _, m := range modes {
+			ret, err := newRetriever(ctx, client, indexName, m.mode, emb)
+			if err != nil {
+				logs.Fatalf("create %s retriever failed, err=%v", m.name, err)
+			}
+
+			docs, err := ret.Retrieve(ctx, query)
+			if err != nil {
+				logs.Fatalf("%s retrieve failed, err=%v", m.name, err)
+			}
+			logs.Infof("  [%s]", m.name)
+			for _, doc := range docs {
+				logs.Infof("    score=%.3f id=%s content=%s", doc.Score(), doc.ID, doc.Content)
+			}
+		}
+	}
+}
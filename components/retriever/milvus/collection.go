@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const (
+	fieldID       = "id"
+	fieldContent  = "content"
+	fieldMetadata = "metadata"
+	fieldVector   = "vector"
+
+	maxIDLength      = 64
+	maxContentLength = 8192
+)
+
+// ensureCollection creates the collection and its vector index if they do not exist, then loads it.
+// It is safe to be called on every start: an existing collection is reused as long as its dimension matches.
+func ensureCollection(ctx context.Context, cli client.Client, name string, dim int) error {
+	exists, err := cli.HasCollection(ctx, name)
+	if err != nil {
+		return fmt.Errorf("check collection failed: %w", err)
+	}
+
+	if exists {
+		coll, err := cli.DescribeCollection(ctx, name)
+		if err != nil {
+			return fmt.Errorf("describe collection failed: %w", err)
+		}
+		if err = checkDimension(coll.Schema, dim); err != nil {
+			return err
+		}
+		logs.Infof("collection %s already exists, reuse it", name)
+	} else {
+		schema := entity.NewSchema().
+			WithName(name).
+			WithDescription("eino examples documents").
+			WithField(entity.NewField().WithName(fieldID).WithDataType(entity.FieldTypeVarChar).
+				WithIsPrimaryKey(true).WithMaxLength(maxIDLength)).
+			WithField(entity.NewField().WithName(fieldContent).WithDataType(entity.FieldTypeVarChar).
+				WithMaxLength(maxContentLength)).
+			WithField(entity.NewField().WithName(fieldMetadata).WithDataType(entity.FieldTypeJSON)).
+			WithField(entity.NewField().WithName(fieldVector).WithDataType(entity.FieldTypeFloatVector).
+				WithDim(int64(dim)))
+
+		if err = cli.CreateCollection(ctx, schema, entity.DefaultShardNumber); err != nil {
+			return fmt.Errorf("create collection failed: %w", err)
+		}
+		logs.Infof("collection %s created", name)
+	}
+
+	// a collection must have an index on the vector field before it can be loaded
+	indexes, err := cli.DescribeIndex(ctx, name, fieldVector)
+	if err != nil || len(indexes) == 0 {
+		idx, err := entity.NewIndexHNSW(entity.COSINE, 16, 200)
+		if err != nil {
+			return err
+		}
+		if err = cli.CreateIndex(ctx, name, fieldVector, idx, false); err != nil {
+			return fmt.Errorf("create index failed: %w", err)
+		}
+		logs.Infof("index on %s.%s created", name, fieldVector)
+	}
+
+	if err = cli.LoadCollection(ctx, name, false); err != nil {
+		return fmt.Errorf("load collection failed: %w", err)
+	}
+	return nil
+}
+
+func checkDimension(schema *entity.Schema, dim int) error {
+	for _, f := range schema.Fields {
+		if f.Name != fieldVector {
+			continue
+		}
+		if d, _ := strconv.Atoi(f.TypeParams[entity.TypeParamDim]); d != dim {
+			return fmt.Errorf("collection %s has dimension %d but the embedding has %d, drop it or use another collection",
+				schema.CollectionName, d, dim)
+		}
+		return nil
+	}
+	return fmt.Errorf("collection %s has no %s field", schema.CollectionName, fieldVector)
+}
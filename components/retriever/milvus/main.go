@@ -0,0 +1,110 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const collectionName = "eino_examples"
+
+// Start milvus first with the docker-compose.yml in this directory, then:
+//
+//	ARK_API_KEY=xxx ARK_EMBEDDING_MODEL=xxx go run ./components/retriever/milvus
+func main() {
+	validate.Must(validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	addr := config.String("MILVUS_ADDR", "localhost:19530")
+
+	cli, err := client.NewClient(ctx, client.Config{Address: addr})
+	if err != nil {
+		logs.Fatalf("connect to milvus failed, err=%v", err)
+	}
+	defer cli.Close()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+
+	// probe the dimension of the embedding model instead of hard coding it
+	probe, err := emb.EmbedStrings(ctx, []string{"dimension probe"})
+	if err != nil {
+		logs.Fatalf("embed probe failed, err=%v", err)
+	}
+	dim := len(probe[0])
+
+	if err = ensureCollection(ctx, cli, collectionName, dim); err != nil {
+		logs.Fatalf("ensure collection failed, err=%v", err)
+	}
+
+	idx := &milvusIndexer{cli: cli, collection: collectionName, dim: dim, emb: emb}
+	ids, err := idx.Store(ctx, []*schema.Document{
+		{Content: "Eino is a framework for building LLM applications in Go.", MetaData: map[string]any{"topic": "eino"}},
+		{Content: "A Graph connects nodes with edges and supports branches.", MetaData: map[string]any{"topic": "eino"}},
+		{Content: "Milvus is an open source vector database built for similarity search.", MetaData: map[string]any{"topic": "milvus"}},
+		{Content: "Pandas eat bamboo for most of the day.", MetaData: map[string]any{"topic": "animal"}},
+	})
+	if err != nil {
+		logs.Fatalf("store failed, err=%v", err)
+	}
+	logs.Infof("stored %d documents: %v", len(ids), ids)
+
+	ret := &milvusRetriever{
+		cli:            cli,
+		collection:     collectionName,
+		emb:            emb,
+		topK:           3,
+		scoreThreshold: 0.3,
+	}
+
+	query := "What is Eino?"
+	docs, err := ret.Retrieve(ctx, query)
+	if err != nil {
+		logs.Fatalf("retrieve failed, err=%v", err)
+	}
+	logs.Infof("query=%q, top %d above %.2f:", query, 3, 0.3)
+	for _, doc := range docs {
+		logs.Infof("  score=%.3f id=%s metadata=%v content=%s", doc.Score(), doc.ID, doc.MetaData, doc.Content)
+	}
+
+	// the defaults can be overridden per call with the common retriever options
+	docs, err = ret.Retrieve(ctx, query, retriever.WithTopK(1), retriever.WithScoreThreshold(0.5))
+	if err != nil {
+		logs.Fatalf("retrieve failed, err=%v", err)
+	}
+	logs.Infof("query=%q, top 1 above 0.50: %d documents", query, len(docs))
+	for _, doc := range docs {
+		logs.Infof("  score=%.3f id=%s content=%s", doc.Score(), doc.ID, doc.Content)
+	}
+}
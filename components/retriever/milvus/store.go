@@ -0,0 +1,172 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+
+	"github.com/cloudwego/eino-examples/internal/gptr"
+)
+
+// milvusIndexer implements indexer.Indexer on top of a collection created by ensureCollection.
+type milvusIndexer struct {
+	cli        client.Client
+	collection string
+	dim        int
+	emb        embedding.Embedder
+}
+
+func (m *milvusIndexer) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	options := indexer.GetCommonOptions(&indexer.Options{Embedding: m.emb}, opts...)
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.Content
+	}
+	vectors, err := options.Embedding.EmbedStrings(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("embed documents failed: %w", err)
+	}
+	if len(vectors) != len(docs) {
+		return nil, fmt.Errorf("embedding returned %d vectors for %d documents", len(vectors), len(docs))
+	}
+
+	var (
+		ids       = make([]string, len(docs))
+		contents  = make([]string, len(docs))
+		metadatas = make([][]byte, len(docs))
+		floats    = make([][]float32, len(docs))
+	)
+	for i, doc := range docs {
+		// without an explicit id, the content hash is used, so that storing the same document again is an upsert
+		if doc.ID == "" {
+			sum := sha256.Sum256([]byte(doc.Content))
+			doc.ID = hex.EncodeToString(sum[:16])
+		}
+		ids[i] = doc.ID
+		contents[i] = doc.Content
+
+		if metadatas[i], err = json.Marshal(doc.MetaData); err != nil {
+			return nil, fmt.Errorf("marshal metadata of %s failed: %w", doc.ID, err)
+		}
+
+		floats[i] = make([]float32, len(vectors[i]))
+		for j, v := range vectors[i] {
+			floats[i][j] = float32(v)
+		}
+	}
+
+	_, err = m.cli.Upsert(ctx, m.collection, "",
+		entity.NewColumnVarChar(fieldID, ids),
+		entity.NewColumnVarChar(fieldContent, contents),
+		entity.NewColumnJSONBytes(fieldMetadata, metadatas),
+		entity.NewColumnFloatVector(fieldVector, m.dim, floats),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("upsert failed: %w", err)
+	}
+
+	return ids, nil
+}
+
+// milvusRetriever implements retriever.Retriever, returning the top-k documents above the score threshold.
+type milvusRetriever struct {
+	cli            client.Client
+	collection     string
+	emb            embedding.Embedder
+	topK           int
+	scoreThreshold float64
+}
+
+func (m *milvusRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	options := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           gptr.Of(m.topK),
+		ScoreThreshold: gptr.Of(m.scoreThreshold),
+		Embedding:      m.emb,
+	}, opts...)
+
+	vectors, err := options.Embedding.EmbedStrings(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("embed query failed: %w", err)
+	}
+	vector := make(entity.FloatVector, len(vectors[0]))
+	for i, v := range vectors[0] {
+		vector[i] = float32(v)
+	}
+
+	sp, err := entity.NewIndexHNSWSearchParam(64)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := m.cli.Search(ctx, m.collection, nil, "",
+		[]string{fieldContent, fieldMetadata},
+		[]entity.Vector{vector}, fieldVector, entity.COSINE, *options.TopK, sp,
+		// read what was just upserted, the default consistency level may not see it yet
+		client.WithSearchQueryConsistencyLevel(entity.ClStrong),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	res := results[0]
+	if res.Err != nil {
+		return nil, res.Err
+	}
+
+	docs := make([]*schema.Document, 0, res.ResultCount)
+	for i := 0; i < res.ResultCount; i++ {
+		// with COSINE, the score is the similarity, the larger the better
+		score := float64(res.Scores[i])
+		if options.ScoreThreshold != nil && score < *options.ScoreThreshold {
+			continue
+		}
+
+		id, err := res.IDs.GetAsString(i)
+		if err != nil {
+			return nil, err
+		}
+		content, err := res.Fields.GetColumn(fieldContent).GetAsString(i)
+		if err != nil {
+			return nil, err
+		}
+
+		doc := &schema.Document{ID: id, Content: content, MetaData: map[string]any{}}
+		if col, ok := res.Fields.GetColumn(fieldMetadata).(*entity.ColumnJSONBytes); ok {
+			if raw, err := col.ValueByIdx(i); err == nil {
+				_ = json.Unmarshal(raw, &doc.MetaData)
+			}
+		}
+		docs = append(docs, doc.WithScore(score))
+	}
+
+	return docs, nil
+}
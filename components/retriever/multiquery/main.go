@@ -18,45 +18,41 @@ package main
 
 import (
 	"context"
-	"os"
 	"strings"
 
-	"github.com/cloudwego/eino-ext/components/model/openai"
 	"github.com/cloudwego/eino-ext/components/retriever/volc_vikingdb"
-	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/components/retriever"
 	"github.com/cloudwego/eino/flow/retriever/multiquery"
 
+	"github.com/cloudwego/eino-examples/internal/config"
 	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
 )
 
 func main() {
+	validate.Must(
+		validate.Model(),
+		validate.Env("VIKING_DB_HOST", "VIKING_DB_REGION", "VIKING_DB_AK", "VIKING_DB_SK"),
+	)
 
-	openAIAPIKey := os.Getenv("OPENAI_API_KEY")
-	openAIBaseURL := os.Getenv("OPENAI_BASE_URL")
-	openAIModelName := os.Getenv("OPENAI_MODEL_NAME")
+	ctx := context.Background()
 
-	vikingDBHost := os.Getenv("VIKING_DB_HOST")
-	vikingDBRegion := os.Getenv("VIKING_DB_REGION")
-	vikingDBAK := os.Getenv("VIKING_DB_AK")
-	vikingDBSK := os.Getenv("VIKING_DB_SK")
+	vikingDBHost := config.String("VIKING_DB_HOST", "")
+	vikingDBRegion := config.String("VIKING_DB_REGION", "")
+	vikingDBAK := secrets.MustGet(ctx, "VIKING_DB_AK")
+	vikingDBSK := secrets.MustGet(ctx, "VIKING_DB_SK")
 
-	ctx := context.Background()
 	vk, err := newVikingDBRetriever(ctx, vikingDBHost, vikingDBRegion, vikingDBAK, vikingDBSK)
 	if err != nil {
 		logs.Errorf("newVikingDBRetriever failed, err=%v", err)
 		return
 	}
 
-	llm, err := newChatModel(ctx, openAIBaseURL, openAIAPIKey, openAIModelName)
-	if err != nil {
-		logs.Errorf("newChatModel failed, err=%v", err)
-		return
-	}
-
 	// rewrite query by llm
 	mqr, err := multiquery.NewRetriever(ctx, &multiquery.Config{
-		RewriteLLM:      llm,
+		RewriteLLM:      models.MustChatModel(ctx),
 		RewriteTemplate: nil, // use default
 		QueryVar:        "",  // use default
 		LLMOutputParser: nil, // use default
@@ -100,17 +96,6 @@ func main() {
 	logs.Infof("Multi-Query Retrieve success, docs=%v", resp)
 }
 
-func newChatModel(ctx context.Context, baseURL, apiKey, modelName string) (model.ChatModel, error) {
-
-	return openai.NewChatModel(ctx, &openai.ChatModelConfig{
-		BaseURL:    baseURL,
-		APIKey:     apiKey,
-		Model:      modelName,
-		ByAzure:    true,
-		APIVersion: "2024-06-01",
-	})
-}
-
 func newVikingDBRetriever(ctx context.Context, host, region, ak, sk string) (retriever.Retriever, error) {
 
 	baseTopK := 5
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// Start Redis Stack first, which bundles the RediSearch module:
+//
+//	docker run -d -p 6379:6379 redis/redis-stack-server:latest
+//
+// then:
+//
+//	ARK_API_KEY=xxx ARK_EMBEDDING_MODEL=xxx go run ./components/retriever/redis
+func main() {
+	validate.Must(validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	addr := config.String("REDIS_ADDR", "localhost:6379")
+	// RediSearch replies are parsed by the eino redis components in RESP2 format
+	client := redis.NewClient(&redis.Options{Addr: addr, Protocol: 2})
+	defer client.Close()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+
+	probe, err := emb.EmbedStrings(ctx, []string{"dimension probe"})
+	if err != nil {
+		logs.Fatalf("embed probe failed, err=%v", err)
+	}
+
+	// 1. index creation, idempotent
+	if err = ensureIndex(ctx, client, len(probe[0])); err != nil {
+		logs.Fatalf("ensure index failed, err=%v", err)
+	}
+
+	idx, err := newIndexer(ctx, client, emb)
+	if err != nil {
+		logs.Fatalf("create indexer failed, err=%v", err)
+	}
+	ret, err := newRetriever(ctx, client, emb, 2)
+	if err != nil {
+		logs.Fatalf("create retriever failed, err=%v", err)
+	}
+
+	// 2. upsert: every document is a hash keyed by prefix + id, storing a document with an existing id overwrites it
+	docs := []*schema.Document{
+		{ID: "eino", Content: "Eino is a framework for building LLM applications in Go.", MetaData: map[string]any{"category": "framework"}},
+		{ID: "redis", Content: "Redis is an in-memory data store.", MetaData: map[string]any{"category": "database"}},
+		{ID: "panda", Content: "Pandas eat bamboo for most of the day.", MetaData: map[string]any{"category": "animal"}},
+	}
+	if _, err = idx.Store(ctx, docs); err != nil {
+		logs.Fatalf("store failed, err=%v", err)
+	}
+	logs.Infof("stored %d documents, index has %d documents", len(docs), numDocs(ctx, client))
+
+	updated := &schema.Document{
+		ID:       "redis",
+		Content:  "Redis Stack adds vector similarity search to Redis through the RediSearch module.",
+		MetaData: map[string]any{"category": "database"},
+	}
+	if _, err = idx.Store(ctx, []*schema.Document{updated}); err != nil {
+		logs.Fatalf("upsert failed, err=%v", err)
+	}
+	logs.Infof("upserted document %q, index still has %d documents", updated.ID, numDocs(ctx, client))
+
+	// 3. KNN retrieval
+	for _, query := range []string{"vector search in redis", "what do pandas eat"} {
+		result, err := ret.Retrieve(ctx, query)
+		if err != nil {
+			logs.Fatalf("retrieve failed, err=%v", err)
+		}
+		logs.Infof("query=%q", query)
+		for _, doc := range result {
+			logs.Infof("  score=%.3f id=%s metadata=%v content=%s", doc.Score(), doc.ID, doc.MetaData, doc.Content)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	redisidx "github.com/cloudwego/eino-ext/components/indexer/redis"
+	redisret "github.com/cloudwego/eino-ext/components/retriever/redis"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const (
+	keyPrefix = "eino:example:doc:"
+	indexName = "eino:example:vector_index"
+
+	contentField  = "content"
+	metadataField = "metadata"
+	vectorField   = "content_vector"
+	// distanceField is not stored, the retriever returns the vector distance of each hit under this name
+	distanceField = "distance"
+)
+
+// ensureIndex creates an HNSW vector index over all hashes with keyPrefix, unless it already exists.
+func ensureIndex(ctx context.Context, client *redis.Client, dim int) error {
+	if err := client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("connect to redis failed: %w", err)
+	}
+
+	if _, err := client.Do(ctx, "FT.INFO", indexName).Result(); err == nil {
+		logs.Infof("index %s already exists, reuse it", indexName)
+		return nil
+	} else if !strings.Contains(strings.ToLower(err.Error()), "unknown index name") {
+		return fmt.Errorf("check index failed: %w", err)
+	}
+
+	err := client.Do(ctx,
+		"FT.CREATE", indexName,
+		"ON", "HASH",
+		"PREFIX", "1", keyPrefix,
+		"SCHEMA",
+		contentField, "TEXT",
+		metadataField, "TEXT",
+		vectorField, "VECTOR", "HNSW", "6",
+		"TYPE", "FLOAT32",
+		"DIM", dim,
+		"DISTANCE_METRIC", "COSINE",
+	).Err()
+	if err != nil {
+		return fmt.Errorf("create index failed: %w", err)
+	}
+
+	logs.Infof("index %s created, dim=%d", indexName, dim)
+	return nil
+}
+
+func newIndexer(ctx context.Context, client *redis.Client, emb embedding.Embedder) (indexer.Indexer, error) {
+	return redisidx.NewIndexer(ctx, &redisidx.IndexerConfig{
+		Client:    client,
+		KeyPrefix: keyPrefix,
+		BatchSize: 10,
+		DocumentToHashes: func(ctx context.Context, doc *schema.Document) (*redisidx.Hashes, error) {
+			metadata, err := json.Marshal(doc.MetaData)
+			if err != nil {
+				return nil, err
+			}
+
+			return &redisidx.Hashes{
+				Key: doc.ID,
+				Field2Value: map[string]redisidx.FieldValue{
+					// EmbedKey tells the indexer to embed this field and store the vector under vectorField
+					contentField:  {Value: doc.Content, EmbedKey: vectorField},
+					metadataField: {Value: metadata},
+				},
+			}, nil
+		},
+		Embedding: emb,
+	})
+}
+
+func newRetriever(ctx context.Context, client *redis.Client, emb embedding.Embedder, topK int) (retriever.Retriever, error) {
+	return redisret.NewRetriever(ctx, &redisret.RetrieverConfig{
+		Client:       client,
+		Index:        indexName,
+		VectorField:  vectorField,
+		Dialect:      2,
+		ReturnFields: []string{contentField, metadataField, distanceField},
+		TopK:         topK,
+		DocumentConverter: func(ctx context.Context, doc redis.Document) (*schema.Document, error) {
+			resp := &schema.Document{
+				ID:       strings.TrimPrefix(doc.ID, keyPrefix),
+				MetaData: map[string]any{},
+			}
+			for field, val := range doc.Fields {
+				switch field {
+				case contentField:
+					resp.Content = val
+				case metadataField:
+					_ = json.Unmarshal([]byte(val), &resp.MetaData)
+				case distanceField:
+					// cosine distance is in [0, 2], turn it into a similarity so that larger is better
+					if distance, err := strconv.ParseFloat(val, 64); err == nil {
+						resp.WithScore(1 - distance)
+					}
+				}
+			}
+			return resp, nil
+		},
+		Embedding: emb,
+	})
+}
+
+func numDocs(ctx context.Context, client *redis.Client) int64 {
+	res, err := client.Do(ctx, "FT.INFO", indexName).Slice()
+	if err != nil {
+		return -1
+	}
+	// FT.INFO replies with a flat list of key value pairs in RESP2
+	for i := 0; i+1 < len(res); i += 2 {
+		if k, ok := res[i].(string); ok && k == "num_docs" {
+			switch v := res[i+1].(type) {
+			case int64:
+				return v
+			case string:
+				n, _ := strconv.ParseInt(v, 10, 64)
+				return n
+			}
+		}
+	}
+	return -1
+}
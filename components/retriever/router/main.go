@@ -18,24 +18,28 @@ package main
 
 import (
 	"context"
-	"os"
 	"strings"
 
 	"github.com/cloudwego/eino-ext/components/retriever/volc_vikingdb"
 	"github.com/cloudwego/eino/components/retriever"
 	"github.com/cloudwego/eino/flow/retriever/router"
 
+	"github.com/cloudwego/eino-examples/internal/config"
 	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
 )
 
 func main() {
-
-	vikingDBHost := os.Getenv("VIKING_DB_HOST")
-	vikingDBRegion := os.Getenv("VIKING_DB_REGION")
-	vikingDBAK := os.Getenv("VIKING_DB_AK")
-	vikingDBSK := os.Getenv("VIKING_DB_SK")
+	validate.Must(validate.Env("VIKING_DB_HOST", "VIKING_DB_REGION", "VIKING_DB_AK", "VIKING_DB_SK"))
 
 	ctx := context.Background()
+
+	vikingDBHost := config.String("VIKING_DB_HOST", "")
+	vikingDBRegion := config.String("VIKING_DB_REGION", "")
+	vikingDBAK := secrets.MustGet(ctx, "VIKING_DB_AK")
+	vikingDBSK := secrets.MustGet(ctx, "VIKING_DB_SK")
+
 	vk, err := newVikingDBRetriever(ctx, vikingDBHost, vikingDBRegion, vikingDBAK, vikingDBSK)
 	if err != nil {
 		logs.Errorf("newVikingDBRetriever failed, err=%v", err)
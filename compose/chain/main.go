@@ -21,21 +21,18 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
-	"os"
 
-	"github.com/cloudwego/eino-ext/components/model/openai"
 	"github.com/cloudwego/eino/components/prompt"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
-	"github.com/cloudwego/eino-examples/internal/gptr"
 	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
 )
 
 func main() {
-	openAPIBaseURL := os.Getenv("OPENAI_BASE_URL")
-	openAPIAK := os.Getenv("OPENAI_API_KEY")
-	modelName := os.Getenv("MODEL_NAME")
+	validate.Must(validate.Model())
 
 	ctx := context.Background()
 	// build branch func
@@ -84,21 +81,8 @@ func main() {
 			return "你的叫声是怎样的？", nil
 		}))
 
-	modelConf := &openai.ChatModelConfig{
-		BaseURL:     openAPIBaseURL,
-		APIKey:      openAPIAK,
-		Model:       modelName,
-		Temperature: gptr.Of(float32(0.7)),
-		ByAzure:     false,
-		APIVersion:  "2024-06-01",
-	}
-
 	// create chat model node
-	cm, err := openai.NewChatModel(context.Background(), modelConf)
-	if err != nil {
-		log.Panic(err)
-		return
-	}
+	cm := models.MustChatModel(ctx, models.WithTemperature(0.7))
 
 	rolePlayerChain := compose.NewChain[map[string]any, *schema.Message]()
 	rolePlayerChain.
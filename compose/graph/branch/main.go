@@ -0,0 +1,145 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const (
+	nodeClassifyTemplate = "classify_template"
+	nodeClassifier       = "classifier"
+	nodeRoute            = "route"
+	nodeBilling          = "billing_template"
+	nodeTechnical        = "technical_template"
+	nodeGeneral          = "general_template"
+	nodeAnswer           = "answer_model"
+)
+
+const (
+	intentBilling   = "billing"
+	intentTechnical = "technical"
+	intentGeneral   = "general"
+)
+
+// This example routes a customer message to a specialized prompt:
+//
+//	                                                       -> billing_template   -
+//	classify_template -> classifier -> route -> (branch) --> technical_template --> answer_model
+//	                                                       -> general_template   -
+//
+// The classifier node asks the model for the intent, the route lambda turns its reply into the variables
+// of the next prompt, and the branch picks which prompt node runs next based on the intent.
+// Only the chosen branch runs, the other templates are skipped.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	// classification should be deterministic
+	classifier := models.MustChatModel(ctx, models.WithTemperature(0))
+	answerer := models.MustChatModel(ctx, models.WithTemperature(0.7))
+
+	type state struct {
+		query string
+	}
+
+	g := compose.NewGraph[map[string]any, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *state {
+		return &state{}
+	}))
+
+	// keep the query in the state, the classifier output replaces it on the way to route
+	_ = g.AddChatTemplateNode(nodeClassifyTemplate, prompt.MustGet("graph_branch_classify"), compose.WithStatePreHandler[map[string]any, *state](func(ctx context.Context, in map[string]any, s *state) (map[string]any, error) {
+		s.query, _ = in["query"].(string)
+		return in, nil
+	}))
+	_ = g.AddChatModelNode(nodeClassifier, classifier)
+	_ = g.AddLambdaNode(nodeRoute, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (map[string]any, error) {
+		intent := normalizeIntent(msg.Content)
+		logs.Infof("classifier replied %q, intent=%s", msg.Content, intent)
+
+		var query string
+		err := compose.ProcessState[*state](ctx, func(_ context.Context, s *state) error {
+			query = s.query
+			return nil
+		})
+		return map[string]any{"query": query, "intent": intent}, err
+	}))
+
+	_ = g.AddChatTemplateNode(nodeBilling, prompt.MustGet("graph_branch_billing"))
+	_ = g.AddChatTemplateNode(nodeTechnical, prompt.MustGet("graph_branch_technical"))
+	_ = g.AddChatTemplateNode(nodeGeneral, prompt.MustGet("graph_branch_general"))
+	_ = g.AddChatModelNode(nodeAnswer, answerer)
+
+	_ = g.AddEdge(compose.START, nodeClassifyTemplate)
+	_ = g.AddEdge(nodeClassifyTemplate, nodeClassifier)
+	_ = g.AddEdge(nodeClassifier, nodeRoute)
+	// the second argument lists every node the condition may return, it is checked when compiling
+	_ = g.AddBranch(nodeRoute, compose.NewGraphBranch(func(ctx context.Context, in map[string]any) (string, error) {
+		switch in["intent"] {
+		case intentBilling:
+			return nodeBilling, nil
+		case intentTechnical:
+			return nodeTechnical, nil
+		default:
+			return nodeGeneral, nil
+		}
+	}, map[string]bool{nodeBilling: true, nodeTechnical: true, nodeGeneral: true}))
+	_ = g.AddEdge(nodeBilling, nodeAnswer)
+	_ = g.AddEdge(nodeTechnical, nodeAnswer)
+	_ = g.AddEdge(nodeGeneral, nodeAnswer)
+	_ = g.AddEdge(nodeAnswer, compose.END)
+
+	r, err := g.Compile(ctx, compose.WithGraphName("intent_router"))
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	for _, query := range []string{
+		"I was charged twice for my March invoice, can I get one of them refunded?",
+		"The SDK returns 'connection reset by peer' when I upload files larger than 10MB.",
+		"Do you have an office in Singapore?",
+	} {
+		out, err := r.Invoke(ctx, map[string]any{"query": query})
+		if err != nil {
+			logs.Fatalf("Invoke failed, err=%v", err)
+		}
+		logs.Infof("query: %s", query)
+		logs.Tokenf("%s\n", out.Content)
+	}
+}
+
+// normalizeIntent maps the reply of the classifier to a known intent. Models sometimes add
+// punctuation, capital letters or a whole sentence, and an unknown reply must not break routing.
+func normalizeIntent(reply string) string {
+	reply = strings.ToLower(reply)
+	for _, intent := range []string{intentBilling, intentTechnical, intentGeneral} {
+		if strings.Contains(reply, intent) {
+			return intent
+		}
+	}
+	return intentGeneral
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodePrepare = "prepare"
+	nodePrompt  = "prompt"
+	nodeModel   = "model"
+	nodeUpdate  = "update"
+)
+
+// densityPass is what the model returns for every pass, as the arguments of a forced tool call.
+type densityPass struct {
+	MissingEntities []string `json:"missing_entities" jsonschema:"description=1 to 3 informative entities of the article not in the previous summary"`
+	Summary         string   `json:"summary" jsonschema:"description=the summary of this pass"`
+}
+
+// pass is one summary of the chain, with the entities it added and the tracked entities it lost.
+type pass struct {
+	Number      int
+	Summary     string
+	NewEntities []string
+	// Rejected are the entities returned by the model which were already covered or are not in the article.
+	Rejected []string
+	// Lost are the entities covered by the previous passes no longer found in the summary.
+	Lost []string
+}
+
+// densityResult is the output of the graph: every pass, the densest last.
+type densityResult struct {
+	Passes   []*pass
+	Entities []string
+}
+
+type densityState struct {
+	article  string
+	passes   []*pass
+	entities []string
+	// done is set when a pass found no new entity, the summary cannot get denser
+	done bool
+}
+
+// buildDensityGraph builds the chain-of-density loop, one model call per pass:
+//
+//	START -> prepare -> prompt -> model -> update --(passes left, new entities found)--> prompt
+//	                                              --(done)--> END
+//
+// The state tracks the entities covered so far: they are given to every pass so the model knows what to keep and
+// what is new, the entities the model claims are new are checked against the state and the article, and every
+// summary is checked for the entities it lost.
+func buildDensityGraph(ctx context.Context, cm model.ChatModel, passes, words int) (compose.Runnable[string, *densityResult], error) {
+	g := compose.NewGraph[string, *densityResult](compose.WithGenLocalState(func(ctx context.Context) *densityState {
+		return &densityState{}
+	}))
+
+	_ = g.AddLambdaNode(nodePrepare, compose.InvokableLambda(func(ctx context.Context, article string) (*densityResult, error) {
+		if strings.TrimSpace(article) == "" {
+			return nil, errors.New("nothing to summarize")
+		}
+		err := compose.ProcessState[*densityState](ctx, func(_ context.Context, s *densityState) error {
+			s.article = article
+			return nil
+		})
+		return &densityResult{}, err
+	}))
+	_ = g.AddLambdaNode(nodePrompt, compose.InvokableLambda(func(ctx context.Context, _ *densityResult) ([]*schema.Message, error) {
+		name, vars := "graph_chain_of_density_first", map[string]any{"words": words}
+		err := compose.ProcessState[*densityState](ctx, func(_ context.Context, s *densityState) error {
+			vars["article"] = s.article
+			if n := len(s.passes); n > 0 {
+				name = "graph_chain_of_density_densify"
+				vars["pass"], vars["entities"], vars["previous"] = n+1, strings.Join(s.entities, "; "), s.passes[n-1].Summary
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return prompt.MustGet(name).Format(ctx, vars)
+	}))
+	_ = g.AddChatModelNode(nodeModel, cm)
+	_ = g.AddLambdaNode(nodeUpdate, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*densityResult, error) {
+		if len(msg.ToolCalls) == 0 {
+			return nil, errors.New("the model did not call record_pass")
+		}
+		out := &densityPass{}
+		if err := json.Unmarshal([]byte(msg.ToolCalls[0].Function.Arguments), out); err != nil {
+			return nil, fmt.Errorf("decode pass failed: %w", err)
+		}
+
+		var res *densityResult
+		err := compose.ProcessState[*densityState](ctx, func(_ context.Context, s *densityState) error {
+			p := &pass{Number: len(s.passes) + 1, Summary: out.Summary}
+			for _, e := range out.MissingEntities {
+				if e = strings.TrimSpace(e); e == "" {
+					continue
+				}
+				if containsFold(s.entities, e) || !strings.Contains(strings.ToLower(s.article), strings.ToLower(e)) {
+					p.Rejected = append(p.Rejected, e)
+					continue
+				}
+				p.NewEntities = append(p.NewEntities, e)
+			}
+			for _, e := range s.entities {
+				if !strings.Contains(strings.ToLower(out.Summary), strings.ToLower(e)) {
+					p.Lost = append(p.Lost, e)
+				}
+			}
+			logs.Infof("pass %d: %d words, new entities %q, rejected %q, lost %q",
+				p.Number, len(strings.Fields(p.Summary)), p.NewEntities, p.Rejected, p.Lost)
+
+			s.entities = append(s.entities, p.NewEntities...)
+			s.passes = append(s.passes, p)
+			s.done = len(p.NewEntities) == 0
+			res = &densityResult{Passes: s.passes, Entities: s.entities}
+			return nil
+		})
+		return res, err
+	}))
+
+	_ = g.AddEdge(compose.START, nodePrepare)
+	_ = g.AddEdge(nodePrepare, nodePrompt)
+	_ = g.AddEdge(nodePrompt, nodeModel)
+	_ = g.AddEdge(nodeModel, nodeUpdate)
+	_ = g.AddBranch(nodeUpdate, compose.NewGraphBranch(func(ctx context.Context, res *densityResult) (string, error) {
+		next := compose.END
+		err := compose.ProcessState[*densityState](ctx, func(_ context.Context, s *densityState) error {
+			if !s.done && len(s.passes) < passes {
+				next = nodePrompt
+			}
+			return nil
+		})
+		return next, err
+	}, map[string]bool{nodePrompt: true, compose.END: true}))
+
+	return g.Compile(ctx, compose.WithGraphName("chain_of_density"), compose.WithMaxRunSteps(4*passes+10))
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
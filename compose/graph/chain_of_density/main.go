@@ -0,0 +1,81 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example summarizes an article with chain of density: a first sparse summary, then passes that each add the
+// 1 to 3 most informative entities still missing without making the summary longer, so every pass is denser than
+// the previous one. The graph is a loop whose state accumulates the passes and the entities covered so far.
+//
+// The densest summary is not always the best one: past 3 or 4 passes it tends to become hard to read, which is why
+// every pass is printed, to pick the one at the right density for the reader.
+func main() {
+	input := flag.String("input", "compose/graph/mapreduce/testdata/postmortem.md", "article to summarize")
+	passes := flag.Int("passes", 5, "max number of passes, fewer if a pass finds no new entity")
+	words := flag.Int("words", 80, "target length of every summary, in words")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	article, err := os.ReadFile(*input)
+	if err != nil {
+		logs.Fatalf("read %s failed, err=%v", *input, err)
+	}
+
+	info, err := utils.GoStruct2ToolInfo[densityPass]("record_pass", "record the entities added and the summary of a pass")
+	if err != nil {
+		logs.Fatalf("GoStruct2ToolInfo failed, err=%v", err)
+	}
+	// forced tool choice is a method of the OpenAI client, so the provider is openai, azure or claude
+	cm := models.MustOpenAIChatModel(ctx)
+	if err = cm.BindForcedTools([]*schema.ToolInfo{info}); err != nil {
+		logs.Fatalf("BindForcedTools failed, err=%v", err)
+	}
+
+	runner, err := buildDensityGraph(ctx, cm, *passes, *words)
+	if err != nil {
+		logs.Fatalf("buildDensityGraph failed, err=%v", err)
+	}
+	res, err := runner.Invoke(ctx, string(article))
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	covered := 0
+	for _, p := range res.Passes {
+		covered += len(p.NewEntities)
+		wordCount := len(strings.Fields(p.Summary))
+		logs.Infof("pass %d, %d words, %d entities, %.1f entities per 100 words:",
+			p.Number, wordCount, covered, 100*float64(covered)/float64(max(wordCount, 1)))
+		logs.Tokenf("%s\n\n", p.Summary)
+	}
+	logs.Infof("entities covered: %s", strings.Join(res.Entities, "; "))
+}
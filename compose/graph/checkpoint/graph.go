@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-examples/internal/checkpoint"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodeOutline = "outline"
+	nodeDraft   = "draft"
+	nodePolish  = "polish"
+)
+
+// articleState is checkpointed as JSON, its fields are exported for that.
+type articleState struct {
+	Topic   string `json:"topic"`
+	Outline string `json:"outline"`
+	Draft   string `json:"draft"`
+	Final   string `json:"final"`
+}
+
+func buildGraph(ctx context.Context, cm model.ChatModel, store checkpoint.Store) (compose.Runnable[string, string], error) {
+	g := compose.NewGraph[string, string](compose.WithGenLocalState(func(ctx context.Context) *articleState {
+		return &articleState{}
+	}))
+
+	// generate formats the prompt name with text and returns the reply of the model
+	generate := func(ctx context.Context, name, text string) (string, error) {
+		in, err := prompt.MustGet(name).Format(ctx, map[string]any{"text": text})
+		if err != nil {
+			return "", err
+		}
+		msg, err := cm.Generate(ctx, in)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(msg.Content), nil
+	}
+
+	_ = g.AddLambdaNode(nodeOutline, compose.InvokableLambda(func(ctx context.Context, topic string) (string, error) {
+		return generate(ctx, "graph_checkpoint_outline", topic)
+	}),
+		compose.WithStatePreHandler(func(ctx context.Context, topic string, s *articleState) (string, error) {
+			s.Topic = topic
+			return topic, nil
+		}),
+		compose.WithStatePostHandler(checkpoint.PostHandler(store, nodeOutline, func(ctx context.Context, outline string, s *articleState) (string, error) {
+			s.Outline = outline
+			return outline, nil
+		})),
+	)
+	_ = g.AddLambdaNode(nodeDraft, compose.InvokableLambda(func(ctx context.Context, outline string) (string, error) {
+		return generate(ctx, "graph_checkpoint_draft", outline)
+	}),
+		compose.WithStatePostHandler(checkpoint.PostHandler(store, nodeDraft, func(ctx context.Context, draft string, s *articleState) (string, error) {
+			s.Draft = draft
+			return draft, nil
+		})),
+	)
+	_ = g.AddLambdaNode(nodePolish, compose.InvokableLambda(func(ctx context.Context, draft string) (string, error) {
+		return generate(ctx, "graph_checkpoint_polish", draft)
+	}),
+		compose.WithStatePostHandler(checkpoint.PostHandler(store, nodePolish, func(ctx context.Context, final string, s *articleState) (string, error) {
+			s.Final = final
+			return final, nil
+		})),
+	)
+
+	_ = g.AddEdge(compose.START, nodeOutline)
+	_ = g.AddEdge(nodeOutline, nodeDraft)
+	_ = g.AddEdge(nodeDraft, nodePolish)
+	_ = g.AddEdge(nodePolish, compose.END)
+
+	r, err := g.Compile(ctx, compose.WithGraphName("checkpointed_article"))
+	if err != nil {
+		return nil, fmt.Errorf("compile failed: %w", err)
+	}
+	return r, nil
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/checkpoint"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/store"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example checkpoints a three step writing pipeline, outline -> draft -> polish, after every node:
+//
+//	START -> outline -> draft -> polish -> END
+//
+// The local state of the graph, the outputs of the nodes so far, is saved to a file, to SQLite or to Redis under a
+// run ID by a StatePostHandler of internal/checkpoint. Run it, then look at the checkpoint of the run in
+// .cache/checkpoints, or in Redis with GET eino:checkpoint:<run id>:
+//
+//	go run ./compose/graph/checkpoint -store file
+//	go run ./compose/graph/checkpoint -store sqlite://.cache/checkpoints.db
+//	go run ./compose/graph/checkpoint -store "redis://127.0.0.1:6379/0?protocol=2"
+func main() {
+	storeURL := flag.String("store", "file", "where the checkpoints are saved: file, or the url of a store of internal/store")
+	dir := flag.String("dir", ".cache/checkpoints", "directory of the file store")
+	runID := flag.String("run", "", "id of the run, default a new one")
+	topic := flag.String("topic", "Why small teams should write postmortems", "topic of the article")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	var cpStore checkpoint.Store
+	var err error
+	if *storeURL == "file" {
+		cpStore, err = checkpoint.NewFileStore(*dir)
+	} else {
+		var kv store.Store
+		if kv, err = store.Open(*storeURL); err == nil {
+			defer kv.Close()
+			cpStore, err = checkpoint.NewKVStore(&checkpoint.KVConfig{Store: kv, TTL: 24 * time.Hour})
+		}
+	}
+	if err != nil {
+		logs.Fatalf("create %s store failed, err=%v", *storeURL, err)
+	}
+
+	cm := models.MustChatModel(ctx)
+
+	runner, err := buildGraph(ctx, cm, &loggingStore{Store: cpStore})
+	if err != nil {
+		logs.Fatalf("buildGraph failed, err=%v", err)
+	}
+
+	if *runID == "" {
+		*runID = fmt.Sprintf("run-%d", time.Now().UnixNano())
+	}
+	logs.Infof("run %s", *runID)
+
+	article, err := runner.Invoke(checkpoint.WithRunID(ctx, *runID), *topic)
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+	logs.Infof("article:\n%s", article)
+
+	st := &articleState{}
+	cp, err := checkpoint.LoadState(ctx, cpStore, *runID, st)
+	if err != nil {
+		logs.Fatalf("LoadState failed, err=%v", err)
+	}
+	logs.Infof("last checkpoint of run %s: step %d after %s, saved at %s, outline of %d characters, draft of %d characters",
+		cp.RunID, cp.Step, cp.Node, cp.SavedAt.Format(time.RFC3339), len(st.Outline), len(st.Draft))
+}
+
+// loggingStore logs every checkpoint saved.
+type loggingStore struct {
+	checkpoint.Store
+}
+
+func (l *loggingStore) Save(ctx context.Context, cp *checkpoint.Checkpoint) error {
+	if err := l.Store.Save(ctx, cp); err != nil {
+		return err
+	}
+	logs.Infof("checkpoint %d saved after %s, %d bytes of state", cp.Step, cp.Node, len(cp.State))
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+// verdict is the result of the moderation of one user message.
+type verdict struct {
+	Flagged    bool     `json:"flagged"`
+	Categories []string `json:"categories"`
+}
+
+// moderator checks a user message against the usage policy.
+type moderator interface {
+	Moderate(ctx context.Context, text string) (*verdict, error)
+}
+
+// apiModerator calls a moderation endpoint compatible with POST /v1/moderations of OpenAI. The endpoint is dedicated
+// to moderation: it is fast, free on OpenAI, and its categories are maintained by the provider.
+type apiModerator struct {
+	baseURL string
+	apiKey  string
+	client  *http.Client
+}
+
+type moderationResponse struct {
+	Results []struct {
+		Flagged    bool            `json:"flagged"`
+		Categories map[string]bool `json:"categories"`
+	} `json:"results"`
+}
+
+func (m *apiModerator) Moderate(ctx context.Context, text string) (*verdict, error) {
+	body, err := json.Marshal(map[string]string{"input": text})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(m.baseURL, "/")+"/moderations", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.apiKey)
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("moderation API returned %s: %s", resp.Status, b)
+	}
+
+	var res moderationResponse
+	if err = json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	if len(res.Results) == 0 {
+		return nil, fmt.Errorf("moderation API returned no result")
+	}
+
+	v := &verdict{Flagged: res.Results[0].Flagged}
+	for c, hit := range res.Results[0].Categories {
+		if hit {
+			v.Categories = append(v.Categories, c)
+		}
+	}
+	sort.Strings(v.Categories)
+	return v, nil
+}
+
+// modelModerator asks a chat model to apply a policy written in the prompt. It is slower and costs tokens, but works
+// with any model provider and the policy, prompts/graph_guardrail_policy.prompt, can be adapted to the application,
+// such as forbidding competitors' names.
+type modelModerator struct {
+	cm model.ChatModel
+}
+
+func (m *modelModerator) Moderate(ctx context.Context, text string) (*verdict, error) {
+	in, err := prompt.MustGet("graph_guardrail_policy").Format(ctx, map[string]any{"text": text})
+	if err != nil {
+		return nil, err
+	}
+	msg, err := m.cm.Generate(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	content := strings.TrimSpace(msg.Content)
+	content = strings.TrimPrefix(strings.TrimSuffix(content, "```"), "```json")
+
+	v := &verdict{}
+	if err = json.Unmarshal([]byte(strings.TrimSpace(content)), v); err != nil {
+		return nil, fmt.Errorf("the moderator did not reply with JSON: %w", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-examples/internal/checkpoint"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodePrepare = "prepare"
+	nodeReview  = "review"
+	nodeGate    = "approval_gate"
+	nodeRefund  = "refund"
+	nodeReject  = "reject"
+	nodeNotify  = "notify"
+
+	decisionApprove = "approve"
+	decisionReject  = "reject"
+)
+
+// refundState is the local state of the graph, checkpointed as JSON after the review, and restored on resume.
+type refundState struct {
+	Request  string `json:"request"`
+	Proposal string `json:"proposal"`
+	// Decision is set by the human on resume, the run pauses before the refund while it is empty.
+	Decision string `json:"decision"`
+	Reviewer string `json:"reviewer"`
+	// RefundID is set once the refund is issued, so that it is never issued twice.
+	RefundID string `json:"refund_id"`
+}
+
+// errPaused is returned by the gate when the run waits for a human decision. The checkpoint is already saved.
+var errPaused = errors.New("waiting for approval")
+
+type resumeKey struct{}
+
+// withResumedState makes the graph start from s instead of an empty state.
+func withResumedState(ctx context.Context, s *refundState) context.Context {
+	return context.WithValue(ctx, resumeKey{}, s)
+}
+
+// buildGraph builds a refund workflow paused before the refund for a human decision:
+//
+//	START -> prepare --(new run)--> review -> approval_gate --(approve)--> refund -> notify -> END
+//	                 --(resumed)------------^              --(reject)--> reject ----^
+//	                                                       --(no decision yet)--> errPaused
+//
+// This version of eino cannot interrupt a graph, the run ends with errPaused instead, and the state saved by the
+// checkpoint of the review is loaded into the next run, which goes straight to the gate.
+func buildGraph(ctx context.Context, cm model.ChatModel, store checkpoint.Store) (compose.Runnable[string, string], error) {
+	g := compose.NewGraph[string, string](compose.WithGenLocalState(func(ctx context.Context) *refundState {
+		if s, ok := ctx.Value(resumeKey{}).(*refundState); ok {
+			return s
+		}
+		return &refundState{}
+	}))
+
+	_ = g.AddLambdaNode(nodePrepare, compose.InvokableLambda(func(ctx context.Context, request string) (*refundState, error) {
+		var snapshot refundState
+		err := compose.ProcessState[*refundState](ctx, func(_ context.Context, s *refundState) error {
+			if s.Request == "" {
+				s.Request = request
+			}
+			snapshot = *s
+			return nil
+		})
+		return &snapshot, err
+	}))
+	_ = g.AddLambdaNode(nodeReview, compose.InvokableLambda(func(ctx context.Context, s *refundState) (string, error) {
+		in, err := prompt.MustGet("graph_interrupt_review").Format(ctx, map[string]any{"request": s.Request})
+		if err != nil {
+			return "", err
+		}
+		msg, err := cm.Generate(ctx, in)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(msg.Content), nil
+	}),
+		compose.WithStatePostHandler(checkpoint.PostHandler(store, nodeReview, func(ctx context.Context, proposal string, s *refundState) (string, error) {
+			s.Proposal = proposal
+			return proposal, nil
+		})),
+	)
+	_ = g.AddLambdaNode(nodeGate, compose.InvokableLambda(func(ctx context.Context, _ any) (*refundState, error) {
+		var snapshot refundState
+		err := compose.ProcessState[*refundState](ctx, func(_ context.Context, s *refundState) error {
+			snapshot = *s
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		switch snapshot.Decision {
+		case "":
+			return nil, errPaused
+		case decisionApprove, decisionReject:
+			return &snapshot, nil
+		default:
+			return nil, fmt.Errorf("unknown decision %q", snapshot.Decision)
+		}
+	}))
+	_ = g.AddLambdaNode(nodeRefund, compose.InvokableLambda(func(ctx context.Context, s *refundState) (string, error) {
+		if s.RefundID != "" {
+			return s.RefundID, nil
+		}
+		return issueRefund(ctx, s)
+	}),
+		compose.WithStatePostHandler(checkpoint.PostHandler(store, nodeRefund, func(ctx context.Context, id string, s *refundState) (string, error) {
+			s.RefundID = id
+			return fmt.Sprintf("The refund was approved by %s and issued as %s. Proposal:\n%s", s.Reviewer, id, s.Proposal), nil
+		})),
+	)
+	_ = g.AddLambdaNode(nodeReject, compose.InvokableLambda(func(ctx context.Context, s *refundState) (string, error) {
+		return fmt.Sprintf("The refund was rejected by %s. Proposal of the review:\n%s", s.Reviewer, s.Proposal), nil
+	}))
+	_ = g.AddLambdaNode(nodeNotify, compose.InvokableLambda(func(ctx context.Context, outcome string) (string, error) {
+		in, err := prompt.MustGet("graph_interrupt_notify").Format(ctx, map[string]any{"outcome": outcome})
+		if err != nil {
+			return "", err
+		}
+		msg, err := cm.Generate(ctx, in)
+		if err != nil {
+			return "", err
+		}
+		return msg.Content, nil
+	}))
+
+	_ = g.AddEdge(compose.START, nodePrepare)
+	_ = g.AddBranch(nodePrepare, compose.NewGraphBranch(func(ctx context.Context, s *refundState) (string, error) {
+		if s.Proposal != "" {
+			logs.Infof("resuming after the review, decision %q", s.Decision)
+			return nodeGate, nil
+		}
+		return nodeReview, nil
+	}, map[string]bool{nodeReview: true, nodeGate: true}))
+	_ = g.AddEdge(nodeReview, nodeGate)
+	_ = g.AddBranch(nodeGate, compose.NewGraphBranch(func(ctx context.Context, s *refundState) (string, error) {
+		if s.Decision == decisionApprove {
+			return nodeRefund, nil
+		}
+		return nodeReject, nil
+	}, map[string]bool{nodeRefund: true, nodeReject: true}))
+	_ = g.AddEdge(nodeRefund, nodeNotify)
+	_ = g.AddEdge(nodeReject, nodeNotify)
+	_ = g.AddEdge(nodeNotify, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("refund_approval"))
+}
+
+// issueRefund stands for the payment API, the call that must not happen without a human approval.
+func issueRefund(ctx context.Context, s *refundState) (string, error) {
+	id := fmt.Sprintf("rf_%d", time.Now().Unix())
+	logs.Infof("refund %s issued", id)
+	return id, nil
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/checkpoint"
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const defaultRequest = `Order #4471, delivered 12 days ago: noise cancelling headphones, 249 USD.
+I opened them, but the left side crackles at high volume. I would like my money back.`
+
+// This example pauses a graph for a human decision and resumes it in another process, the human in the loop of
+// an approval that can take hours: a model reviews a refund request, the run stops before the refund is issued,
+// and a second invocation resumes it with the decision.
+//
+//	go run ./compose/graph/interrupt
+//	  ... paused, run run-1718000000 waits for approval
+//	go run ./compose/graph/interrupt -run run-1718000000 -decision approve -reviewer alice
+//
+// Between the two, the state of the run lives in the checkpoint store of internal/checkpoint, a directory here.
+func main() {
+	request := flag.String("request", defaultRequest, "the refund request of the customer, for a new run")
+	runID := flag.String("run", "", "id of the paused run to resume, empty starts a new run")
+	decision := flag.String("decision", "", "decision on the paused run: approve or reject")
+	reviewer := flag.String("reviewer", config.String("USER", ""), "name of the human deciding")
+	dir := flag.String("dir", ".cache/runs", "directory of the checkpoints")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	store, err := checkpoint.NewFileStore(*dir)
+	if err != nil {
+		logs.Fatalf("NewFileStore failed, err=%v", err)
+	}
+	cm := models.MustChatModel(ctx)
+	runner, err := buildGraph(ctx, cm, store)
+	if err != nil {
+		logs.Fatalf("buildGraph failed, err=%v", err)
+	}
+
+	if *runID == "" {
+		*runID = fmt.Sprintf("run-%d", time.Now().Unix())
+		logs.Infof("new run %s", *runID)
+	} else {
+		if *decision != decisionApprove && *decision != decisionReject {
+			logs.Fatalf("resuming needs -decision %s or %s", decisionApprove, decisionReject)
+		}
+		st := &refundState{}
+		if _, err = checkpoint.LoadState(ctx, store, *runID, st); err != nil {
+			logs.Fatalf("load run %s failed, err=%v", *runID, err)
+		}
+		st.Decision, st.Reviewer = *decision, *reviewer
+		ctx = withResumedState(ctx, st)
+	}
+
+	out, err := runner.Invoke(checkpoint.WithRunID(ctx, *runID), *request)
+	if errors.Is(err, errPaused) {
+		st := &refundState{}
+		if _, err = checkpoint.LoadState(ctx, store, *runID, st); err != nil {
+			logs.Fatalf("load run %s failed, err=%v", *runID, err)
+		}
+		logs.Infof("proposal of the review:\n%s", st.Proposal)
+		logs.Infof("paused, run %s waits for approval, resume it with:\n  go run ./compose/graph/interrupt -run %s -decision approve|reject",
+			*runID, *runID)
+		return
+	}
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	logs.Infof("email to the customer:\n%s", out)
+	if err = store.Delete(ctx, *runID); err != nil {
+		logs.Errorf("delete checkpoint failed, err=%v", err)
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/cost"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example summarizes a document too long for one prompt with map-reduce:
+//
+//   - map: the document is split into chunks, and every chunk is summarized in parallel
+//   - reduce: the summaries are grouped by -fan-in, every group is merged into one summary in parallel,
+//     and this is repeated until a single summary remains
+//
+// Each round is one compose.Graph with one branch per input, so the parallelism comes from the graph runtime.
+func main() {
+	input := flag.String("input", "compose/graph/mapreduce/testdata/postmortem.md", "document to summarize")
+	chunkSize := flag.Int("chunk-size", 800, "max characters per chunk")
+	fanIn := flag.Int("fan-in", 3, "number of summaries merged by one reduce call")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	cm := models.MustChatModel(ctx)
+
+	content, err := os.ReadFile(*input)
+	if err != nil {
+		logs.Fatalf("read %s failed, err=%v", *input, err)
+	}
+
+	splitter, err := recursive.NewSplitter(ctx, &recursive.Config{
+		ChunkSize:   *chunkSize,
+		OverlapSize: *chunkSize / 10,
+		Separators:  []string{"\n## ", "\n\n", "\n", ". "},
+	})
+	if err != nil {
+		logs.Fatalf("recursive.NewSplitter failed, err=%v", err)
+	}
+	docs, err := splitter.Transform(ctx, []*schema.Document{{Content: string(content)}})
+	if err != nil {
+		logs.Fatalf("split failed, err=%v", err)
+	}
+
+	chunks := make([]string, 0, len(docs))
+	for _, d := range docs {
+		if strings.TrimSpace(d.Content) != "" {
+			chunks = append(chunks, d.Content)
+		}
+	}
+	if len(chunks) == 0 {
+		logs.Fatalf("%s has no text to summarize", *input)
+	}
+	logs.Infof("%d characters split into %d chunks", len(content), len(chunks))
+
+	tracker := cost.NewTracker()
+	mapper := &mapper{cm: cm, runners: map[string]mapRunner{}, opts: []compose.Option{compose.WithCallbacks(tracker.Handler())}}
+
+	summaries, err := mapper.Map(ctx, "graph_mapreduce_map", chunks)
+	if err != nil {
+		logs.Fatalf("map failed, err=%v", err)
+	}
+	logs.Infof("map: %d chunks -> %d summaries", len(chunks), len(summaries))
+
+	for round := 1; len(summaries) > 1; round++ {
+		groups := group(summaries, max(*fanIn, 2))
+		summaries, err = mapper.Map(ctx, "graph_mapreduce_reduce", groups)
+		if err != nil {
+			logs.Fatalf("reduce round %d failed, err=%v", round, err)
+		}
+		logs.Infof("reduce round %d: %d groups -> %d summaries", round, len(groups), len(summaries))
+	}
+
+	if len(summaries) == 0 {
+		logs.Fatalf("map and reduce returned no summary")
+	}
+	logs.Infof("final summary:")
+	logs.Tokenf("%s\n", summaries[0])
+	tracker.Report("usage")
+}
+
+// group joins every n consecutive texts into one input of the next reduce round.
+func group(texts []string, n int) []string {
+	var groups []string
+	for start := 0; start < len(texts); start += n {
+		end := min(start+n, len(texts))
+		groups = append(groups, strings.Join(texts[start:end], "\n\n---\n\n"))
+	}
+	return groups
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/model"
+	eprompt "github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+type mapRunner = compose.Runnable[[]string, []string]
+
+// mapper applies one prompt to many texts at once. The graph has one branch per text,
+// so a graph is compiled, and cached, for every prompt and number of texts seen.
+type mapper struct {
+	cm      model.ChatModel
+	runners map[string]mapRunner
+	// opts are passed to every run, e.g. callbacks
+	opts []compose.Option
+}
+
+// Map formats the prompt name with every text as its variable text, and returns the answers in the order of texts.
+func (m *mapper) Map(ctx context.Context, name string, texts []string) ([]string, error) {
+	r, err := m.runner(ctx, name, len(texts))
+	if err != nil {
+		return nil, err
+	}
+	return r.Invoke(ctx, texts, m.opts...)
+}
+
+func (m *mapper) runner(ctx context.Context, name string, n int) (mapRunner, error) {
+	key := fmt.Sprintf("%s_%d", name, n)
+	if r, ok := m.runners[key]; ok {
+		return r, nil
+	}
+	tpl, err := prompt.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	r, err := buildMapGraph(ctx, m.cm, tpl, n)
+	if err != nil {
+		return nil, err
+	}
+	m.runners[key] = r
+	return r, nil
+}
+
+// buildMapGraph builds a graph that runs the chat model on n texts in parallel:
+//
+//	         -> pick_0 -> template_0 -> model_0 -
+//	START --> ...                               --> collect -> END
+//	         -> pick_n -> template_n -> model_n -
+//
+// pick_i selects the i-th text from the shared input, model_i writes its answer under its own output key,
+// and collect puts the answers back in the order of the input once every branch has finished.
+func buildMapGraph(ctx context.Context, cm model.ChatModel, tpl eprompt.ChatTemplate, n int) (mapRunner, error) {
+	g := compose.NewGraph[[]string, []string]()
+
+	const nodeCollect = "collect"
+
+	_ = g.AddLambdaNode(nodeCollect, compose.InvokableLambda(func(ctx context.Context, in map[string]any) ([]string, error) {
+		out := make([]string, n)
+		for i := range out {
+			msg, ok := in[fmt.Sprintf("model_%d", i)].(*schema.Message)
+			if !ok {
+				return nil, fmt.Errorf("missing output of branch %d", i)
+			}
+			out[i] = msg.Content
+		}
+		return out, nil
+	}))
+
+	for i := 0; i < n; i++ {
+		i := i
+		pick, tplKey, modelKey := fmt.Sprintf("pick_%d", i), fmt.Sprintf("template_%d", i), fmt.Sprintf("model_%d", i)
+
+		_ = g.AddLambdaNode(pick, compose.InvokableLambda(func(ctx context.Context, texts []string) (map[string]any, error) {
+			return map[string]any{"text": texts[i]}, nil
+		}))
+		_ = g.AddChatTemplateNode(tplKey, tpl)
+		_ = g.AddChatModelNode(modelKey, cm, compose.WithOutputKey(modelKey))
+
+		_ = g.AddEdge(compose.START, pick)
+		_ = g.AddEdge(pick, tplKey)
+		_ = g.AddEdge(tplKey, modelKey)
+		_ = g.AddEdge(modelKey, nodeCollect)
+	}
+
+	_ = g.AddEdge(nodeCollect, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName(fmt.Sprintf("map_%d", n)), compose.WithNodeTriggerMode(compose.AllPredecessor))
+}
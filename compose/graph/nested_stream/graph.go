@@ -0,0 +1,104 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodePrompt    = "prompt"
+	nodeAnswer    = "answer"
+	nodeNumber    = "number"
+	nodeModel     = "model"
+	nodeDropEmpty = "drop_empty"
+	nodeToToken   = "to_token"
+)
+
+// token is a piece of text generated by the model.
+type token struct {
+	Content string
+}
+
+// event is what the client receives for every token, Seq counts from 0 so that the client can check it missed none.
+type event struct {
+	Seq     int    `json:"seq"`
+	Content string `json:"content"`
+}
+
+// buildGraph nests the model two levels deep, behind three lambdas:
+//
+//	prompt -> answer [ model -> drop_empty -> to_token ] -> number
+//
+// Every lambda after the model is a TransformableLambda, stream in and stream out, so a token crosses the whole
+// graph as soon as the model sends it. An InvokableLambda there would still work in Stream mode, but eino would
+// concatenate its input stream to call it, and the client would get the whole answer at once at the end.
+func buildGraph(ctx context.Context, cm model.ChatModel) (compose.Runnable[string, *event], error) {
+	answer := compose.NewGraph[[]*schema.Message, *token]()
+	_ = answer.AddChatModelNode(nodeModel, cm)
+	_ = answer.AddLambdaNode(nodeDropEmpty, compose.TransformableLambda(dropEmpty))
+	_ = answer.AddLambdaNode(nodeToToken, compose.TransformableLambda(toToken))
+	_ = answer.AddEdge(compose.START, nodeModel)
+	_ = answer.AddEdge(nodeModel, nodeDropEmpty)
+	_ = answer.AddEdge(nodeDropEmpty, nodeToToken)
+	_ = answer.AddEdge(nodeToToken, compose.END)
+
+	g := compose.NewGraph[string, *event]()
+	_ = g.AddLambdaNode(nodePrompt, compose.InvokableLambda(func(ctx context.Context, question string) ([]*schema.Message, error) {
+		return prompt.MustGet("graph_nested_stream_answer").Format(ctx, map[string]any{"question": question})
+	}))
+	_ = g.AddGraphNode(nodeAnswer, answer)
+	_ = g.AddLambdaNode(nodeNumber, compose.TransformableLambda(number))
+	_ = g.AddEdge(compose.START, nodePrompt)
+	_ = g.AddEdge(nodePrompt, nodeAnswer)
+	_ = g.AddEdge(nodeAnswer, nodeNumber)
+	_ = g.AddEdge(nodeNumber, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("NestedStream"))
+}
+
+// dropEmpty drops the chunks without text, such as the last chunk of some models which only carries the usage.
+func dropEmpty(ctx context.Context, sr *schema.StreamReader[*schema.Message]) (*schema.StreamReader[*schema.Message], error) {
+	return schema.StreamReaderWithConvert(sr, func(msg *schema.Message) (*schema.Message, error) {
+		if msg.Content == "" {
+			return nil, schema.ErrNoValue
+		}
+		return msg, nil
+	}), nil
+}
+
+func toToken(ctx context.Context, sr *schema.StreamReader[*schema.Message]) (*schema.StreamReader[*token], error) {
+	return schema.StreamReaderWithConvert(sr, func(msg *schema.Message) (*token, error) {
+		return &token{Content: msg.Content}, nil
+	}), nil
+}
+
+// number numbers the tokens of one run, the counter is local to the call so concurrent runs do not share it.
+func number(ctx context.Context, sr *schema.StreamReader[*token]) (*schema.StreamReader[*event], error) {
+	seq := 0
+	return schema.StreamReaderWithConvert(sr, func(t *token) (*event, error) {
+		e := &event{Seq: seq, Content: t.Content}
+		seq++
+		return e, nil
+	}), nil
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptedModel streams what its script sends, from a goroutine, so that a test can hold the stream midway.
+type scriptedModel struct {
+	script func(sw *schema.StreamWriter[*schema.Message])
+}
+
+func (m *scriptedModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	return nil, errors.New("only Stream is supported")
+}
+
+func (m *scriptedModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	sr, sw := schema.Pipe[*schema.Message](0)
+	go func() {
+		defer sw.Close()
+		m.script(sw)
+	}()
+	return sr, nil
+}
+
+func (m *scriptedModel) BindTools(tools []*schema.ToolInfo) error {
+	return nil
+}
+
+func sendTokens(sw *schema.StreamWriter[*schema.Message], tokens ...string) {
+	for _, t := range tokens {
+		sw.Send(schema.AssistantMessage(t, nil), nil)
+	}
+}
+
+type sseEvent struct {
+	name string
+	data string
+}
+
+// startServer serves the graph of cm and returns the events of one request, as they arrive.
+func startServer(t *testing.T, cm model.ChatModel) <-chan sseEvent {
+	t.Helper()
+	runner, err := buildGraph(context.Background(), cm)
+	if err != nil {
+		t.Fatalf("buildGraph failed: %v", err)
+	}
+	srv := httptest.NewServer(newHandler(runner))
+	t.Cleanup(srv.Close)
+
+	// the request is sent in the background: a buffering graph would not even send the headers before the end
+	events := make(chan sseEvent, 1000)
+	go func() {
+		defer close(events)
+		resp, err := http.Get(srv.URL + "/chat?q=hi")
+		if err != nil {
+			events <- sseEvent{name: "request failed", data: err.Error()}
+			return
+		}
+		defer resp.Body.Close()
+		if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+			events <- sseEvent{name: "unexpected content type", data: ct}
+			return
+		}
+		_ = readEvents(resp.Body, func(name, data string) error {
+			events <- sseEvent{name: name, data: data}
+			return nil
+		})
+	}()
+	return events
+}
+
+func next(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("the stream ended early")
+		}
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event within 5s")
+	}
+	return sseEvent{}
+}
+
+func decode(t *testing.T, e sseEvent) *event {
+	t.Helper()
+	assert.Equal(t, "message", e.name)
+	out := &event{}
+	assert.NoError(t, json.Unmarshal([]byte(e.data), out))
+	return out
+}
+
+func TestTokensAreNotBuffered(t *testing.T) {
+	release := make(chan struct{})
+	events := startServer(t, &scriptedModel{script: func(sw *schema.StreamWriter[*schema.Message]) {
+		sendTokens(sw, "The ")
+		// the model holds the rest of the answer until the client got the first token
+		<-release
+		sendTokens(sw, "sky ", "is ", "blue.")
+	}})
+	// registered after the server, so that it runs before the server is closed: a buffering graph waits for the
+	// whole answer, which would otherwise block the handler and the cleanup forever
+	var once sync.Once
+	t.Cleanup(func() { once.Do(func() { close(release) }) })
+
+	first := decode(t, next(t, events))
+	assert.Equal(t, &event{Seq: 0, Content: "The "}, first)
+
+	once.Do(func() { close(release) })
+	var rest []string
+	for e := next(t, events); e.name != "done"; e = next(t, events) {
+		rest = append(rest, decode(t, e).Content)
+	}
+	assert.Equal(t, []string{"sky ", "is ", "blue."}, rest)
+}
+
+func TestChunkOrdering(t *testing.T) {
+	const n = 500
+	tokens := make([]string, n)
+	for i := range tokens {
+		tokens[i] = fmt.Sprintf("t%d ", i)
+	}
+	events := startServer(t, &scriptedModel{script: func(sw *schema.StreamWriter[*schema.Message]) {
+		sendTokens(sw, tokens...)
+	}})
+
+	var got strings.Builder
+	seq := 0
+	for e := next(t, events); e.name != "done"; e = next(t, events) {
+		ev := decode(t, e)
+		if !assert.Equal(t, seq, ev.Seq, "tokens out of order or missing") {
+			return
+		}
+		got.WriteString(ev.Content)
+		seq++
+	}
+	assert.Equal(t, n, seq)
+	assert.Equal(t, strings.Join(tokens, ""), got.String())
+}
+
+func TestEmptyChunksAreDropped(t *testing.T) {
+	events := startServer(t, &scriptedModel{script: func(sw *schema.StreamWriter[*schema.Message]) {
+		sendTokens(sw, "", "a", "", "", "b", "")
+	}})
+
+	assert.Equal(t, &event{Seq: 0, Content: "a"}, decode(t, next(t, events)))
+	assert.Equal(t, &event{Seq: 1, Content: "b"}, decode(t, next(t, events)))
+	assert.Equal(t, "done", next(t, events).name)
+}
+
+func TestErrorMidStream(t *testing.T) {
+	events := startServer(t, &scriptedModel{script: func(sw *schema.StreamWriter[*schema.Message]) {
+		sendTokens(sw, "partial")
+		sw.Send(nil, errors.New("connection reset"))
+	}})
+
+	assert.Equal(t, &event{Seq: 0, Content: "partial"}, decode(t, next(t, events)))
+	e := next(t, events)
+	assert.Equal(t, "error", e.name)
+	assert.Contains(t, e.data, "connection reset")
+}
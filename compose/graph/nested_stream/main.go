@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example streams the tokens of a model nested in a subgraph, behind several lambdas, to an HTTP client as
+// Server-Sent Events, each token leaving the server as soon as the model generates it (see buildGraph).
+//
+// Without -addr, it serves on a random local port and reads its own stream, printing when each token arrives: the
+// times grow token by token, where a buffering graph would print them all at the same time at the end.
+//
+//	go run ./compose/graph/nested_stream -addr :8080
+//	curl -N 'localhost:8080/chat?q=why+is+the+sky+blue'
+func main() {
+	addr := flag.String("addr", "", "address to serve on, empty to run a local client against a local server")
+	question := flag.String("q", "Why is the sky blue?", "question asked by the local client")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	cm := models.MustChatModel(ctx)
+	runner, err := buildGraph(ctx, cm)
+	if err != nil {
+		logs.Fatalf("buildGraph failed, err=%v", err)
+	}
+
+	if *addr != "" {
+		logs.Infof("listening on http://%s/chat?q=...", *addr)
+		if err = http.ListenAndServe(*addr, newHandler(runner)); err != nil {
+			logs.Fatalf("ListenAndServe failed, err=%v", err)
+		}
+		return
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		logs.Fatalf("listen failed, err=%v", err)
+	}
+	go func() { _ = http.Serve(ln, newHandler(runner)) }()
+
+	start := time.Now()
+	resp, err := http.Get(fmt.Sprintf("http://%s/chat?q=%s", ln.Addr(), url.QueryEscape(*question)))
+	if err != nil {
+		logs.Fatalf("request failed, err=%v", err)
+	}
+	defer resp.Body.Close()
+
+	err = readEvents(resp.Body, func(name, data string) error {
+		switch name {
+		case "message":
+			e := &event{}
+			if err := json.Unmarshal([]byte(data), e); err != nil {
+				return err
+			}
+			logs.Infof("%6dms #%d %q", time.Since(start).Milliseconds(), e.Seq, e.Content)
+		case "error":
+			return errors.New(data)
+		}
+		return nil
+	})
+	if err != nil {
+		logs.Fatalf("read events failed, err=%v", err)
+	}
+	logs.Infof("%6dms done", time.Since(start).Milliseconds())
+}
+
+// readEvents reads Server-Sent Events from r and calls fn for each of them until the done event or the end of r.
+// Only the event and data fields are supported, with a single data line per event.
+func readEvents(r io.Reader, fn func(name, data string) error) error {
+	sc := bufio.NewScanner(r)
+	name, data := "message", ""
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		case line == "":
+			if err := fn(name, data); err != nil {
+				return err
+			}
+			if name == "done" {
+				return nil
+			}
+			name, data = "message", ""
+		}
+	}
+	return sc.Err()
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// newHandler serves GET /chat?q=... as Server-Sent Events: one message event per token, then a done event, or an
+// error event if the graph fails after the stream started.
+func newHandler(runner compose.Runnable[string, *event]) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/chat", func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		// the request context is canceled when the client goes away, which cancels the model call too
+		ctx := r.Context()
+		sr, err := runner.Stream(ctx, r.URL.Query().Get("q"))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("stream failed: %v", err), http.StatusBadGateway)
+			return
+		}
+		defer sr.Close()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for {
+			e, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				_ = writeEvent(w, "done", "[DONE]")
+				flusher.Flush()
+				return
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					_ = writeEvent(w, "error", err.Error())
+					flusher.Flush()
+				}
+				return
+			}
+
+			data, _ := json.Marshal(e)
+			if err = writeEvent(w, "message", string(data)); err != nil {
+				logs.Errorf("write event failed, the client may be gone, err=%v", err)
+				return
+			}
+			// without a flush per event, net/http buffers the body and the tokens arrive in batches
+			flusher.Flush()
+		}
+	})
+	return mux
+}
+
+func writeEvent(w io.Writer, name, data string) error {
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data)
+	return err
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/outputcheck"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// Meeting is extracted from an email. Fields without omitempty are required.
+type Meeting struct {
+	Title     string   `json:"title"`
+	Date      string   `json:"date" jsonschema:"description=YYYY-MM-DD"`
+	Start     string   `json:"start" jsonschema:"description=HH:MM in 24h format"`
+	Attendees []string `json:"attendees" jsonschema:"description=first names"`
+	Priority  string   `json:"priority" jsonschema:"description=low or normal or high"`
+	Location  string   `json:"location,omitempty"`
+}
+
+const email = `Hi all, let's do the Q3 roadmap review next Thursday the 12th of September 2024, 2pm, in the Lisbon room.
+Ana, Bruno and Chen must be there, it's important since the budget is decided there. Thanks, Dana`
+
+var sku = regexp.MustCompile(`^[A-Z]{3}-\d{4}$`)
+
+// This example uses internal/outputcheck, a graph that validates the reply of a model and loops back with the
+// validation error until the reply is valid, or fails with an *outputcheck.ValidationError after MaxRetries:
+//
+//   - a JSON reply validated against the JSON schema of the Meeting struct
+//   - a one-line reply validated with a regular expression
+//
+// Models break output formats in small ways, a missing field or an extra sentence; sending the precise error back
+// fixes most of them in one retry, which is cheaper than a bigger model or a longer prompt for every call.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	cm := models.MustChatModel(ctx)
+
+	meetingSchema, err := outputcheck.JSONSchemaOf[Meeting]()
+	if err != nil {
+		logs.Fatalf("JSONSchemaOf failed, err=%v", err)
+	}
+	// the enum of the jsonschema tag keeps only its last value in this version of eino, the priority is checked apart
+	validateMeeting := outputcheck.All(meetingSchema, checkPriority)
+	extract, err := outputcheck.Compile(ctx, &outputcheck.Config{Model: cm, Validate: validateMeeting, MaxRetries: 2})
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	in, err := prompt.MustGet("graph_output_retry_meeting").Format(ctx, map[string]any{"email": email})
+	if err != nil {
+		logs.Fatalf("Format failed, err=%v", err)
+	}
+	out, err := extract.Invoke(ctx, in)
+	if err != nil {
+		logFailure(err)
+	} else {
+		m := &Meeting{}
+		// valid against the schema, so it decodes
+		_ = json.Unmarshal([]byte(outputcheck.StripFences(out.Content)), m)
+		logs.Infof("meeting: %+v", m)
+	}
+
+	code, err := outputcheck.Compile(ctx, &outputcheck.Config{Model: cm, Validate: outputcheck.Regexp(sku), MaxRetries: 3})
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+	in, err = prompt.MustGet("graph_output_retry_product_code").Format(ctx, map[string]any{
+		"product": "A waterproof hiking backpack of 30 liters",
+	})
+	if err != nil {
+		logs.Fatalf("Format failed, err=%v", err)
+	}
+	out, err = code.Invoke(ctx, in)
+	if err != nil {
+		logFailure(err)
+		return
+	}
+	logs.Infof("product code: %s", out.Content)
+}
+
+func checkPriority(content string) error {
+	m := &Meeting{}
+	if json.Unmarshal([]byte(outputcheck.StripFences(content)), m) != nil {
+		return nil // reported by the schema
+	}
+	switch m.Priority {
+	case "low", "normal", "high":
+		return nil
+	}
+	return fmt.Errorf("priority %q is not one of low, normal, high", m.Priority)
+}
+
+func logFailure(err error) {
+	var verr *outputcheck.ValidationError
+	if errors.As(err, &verr) {
+		logs.Errorf("no valid output after %d attempts, last reply:\n%s\nerr=%v", verr.Attempts, verr.Content, verr.Err)
+		return
+	}
+	logs.Errorf("Invoke failed, err=%v", err)
+}
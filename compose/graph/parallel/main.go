@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// perspectives are the branches of the fan-out: each has its own prompt, prompts/graph_parallel_<key>.prompt, its own
+// model node and its own output key.
+var perspectives = []string{"optimist", "skeptic", "engineer"}
+
+// This example fans one input out to three model nodes running in parallel, and fans their outputs back in:
+//
+//	         -> optimist_template -> optimist -
+//	START --> skeptic_template  -> skeptic  --> join -> END
+//	         -> engineer_template -> engineer -
+//
+// Every model node writes its message under its own output key. The graph is compiled with AllPredecessor,
+// so the join node only runs once all three predecessors have finished, and receives one map merging their outputs.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	cm := models.MustChatModel(ctx)
+
+	g := compose.NewGraph[map[string]any, string]()
+
+	const nodeJoin = "join"
+	// the join node is added first, the edges of the branches end on it
+	_ = g.AddLambdaNode(nodeJoin, compose.InvokableLambda(func(ctx context.Context, in map[string]any) (string, error) {
+		sb := strings.Builder{}
+		for _, p := range perspectives {
+			msg, ok := in[p].(*schema.Message)
+			if !ok {
+				return "", fmt.Errorf("missing output of %s", p)
+			}
+			sb.WriteString(fmt.Sprintf("## %s\n%s\n\n", strings.ToUpper(p[:1])+p[1:], strings.TrimSpace(msg.Content)))
+		}
+		return sb.String(), nil
+	}))
+
+	for _, p := range perspectives {
+		tplKey := p + "_template"
+		_ = g.AddChatTemplateNode(tplKey, prompt.MustGet("graph_parallel_"+p))
+		_ = g.AddChatModelNode(p, cm, compose.WithOutputKey(p), compose.WithNodeName(p))
+
+		_ = g.AddEdge(compose.START, tplKey)
+		_ = g.AddEdge(tplKey, p)
+		_ = g.AddEdge(p, nodeJoin)
+	}
+
+	_ = g.AddEdge(nodeJoin, compose.END)
+
+	r, err := g.Compile(ctx, compose.WithGraphName("fan_out_fan_in"), compose.WithNodeTriggerMode(compose.AllPredecessor))
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	// log when each model node starts and ends, relative to the start of the run, to see them overlap
+	begin := time.Now()
+	timing := callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+			if info.Component == components.ComponentOfChatModel {
+				logs.Infof("[%s] started at +%v", info.Name, time.Since(begin).Round(time.Millisecond))
+			}
+			return ctx
+		}).
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			if info.Component == components.ComponentOfChatModel {
+				logs.Infof("[%s] finished at +%v", info.Name, time.Since(begin).Round(time.Millisecond))
+			}
+			return ctx
+		}).
+		Build()
+
+	out, err := r.Invoke(ctx, map[string]any{
+		"proposal": "Move the team from a monorepo to one repository per service.",
+	}, compose.WithCallbacks(timing))
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	logs.Infof("total %v, close to the slowest branch rather than the sum of the three",
+		time.Since(begin).Round(time.Millisecond))
+	logs.Tokenf("%s", out)
+}
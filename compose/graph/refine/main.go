@@ -0,0 +1,143 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example summarizes a long document with the refine strategy and compares it with map-reduce on the same
+// chunks (see compose/graph/mapreduce for the parallel version of map-reduce):
+//
+//   - refine: one call per chunk, in order, each call rewrites the running summary with the next chunk
+//   - map-reduce: one call per chunk to summarize it on its own, then one call to merge the summaries
+//
+// Refine is sequential and its prompts grow with the summary, so it is slower and usually uses more prompt tokens;
+// in exchange, later parts of the document are read with the context of the earlier ones, which keeps the summary
+// consistent when a later part corrects or completes an earlier one, as the timeline of a postmortem often does.
+// The token counts come from the usage reported by the model.
+func main() {
+	input := flag.String("input", "compose/graph/mapreduce/testdata/postmortem.md", "document to summarize")
+	chunkSize := flag.Int("chunk-size", 800, "max characters per chunk")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	cm := models.MustChatModel(ctx)
+
+	content, err := os.ReadFile(*input)
+	if err != nil {
+		logs.Fatalf("read %s failed, err=%v", *input, err)
+	}
+	splitter, err := recursive.NewSplitter(ctx, &recursive.Config{
+		ChunkSize:   *chunkSize,
+		OverlapSize: *chunkSize / 10,
+		Separators:  []string{"\n## ", "\n\n", "\n", ". "},
+	})
+	if err != nil {
+		logs.Fatalf("recursive.NewSplitter failed, err=%v", err)
+	}
+	docs, err := splitter.Transform(ctx, []*schema.Document{{Content: string(content)}})
+	if err != nil {
+		logs.Fatalf("split failed, err=%v", err)
+	}
+	chunks := make([]string, len(docs))
+	for i, d := range docs {
+		chunks[i] = d.Content
+	}
+	logs.Infof("%d characters split into %d chunks", len(content), len(chunks))
+
+	refine, err := buildRefineGraph(ctx, cm)
+	if err != nil {
+		logs.Fatalf("buildRefineGraph failed, err=%v", err)
+	}
+
+	start := time.Now()
+	refined, err := refine.Invoke(ctx, chunks)
+	if err != nil {
+		logs.Fatalf("refine failed, err=%v", err)
+	}
+	refineTime := time.Since(start)
+
+	start = time.Now()
+	reduced, mrUsage, err := mapReduce(ctx, cm, chunks)
+	if err != nil {
+		logs.Fatalf("mapReduce failed, err=%v", err)
+	}
+	mrTime := time.Since(start)
+
+	logs.Infof("refine summary:")
+	logs.Tokenf("%s\n\n", refined.Summary)
+	logs.Infof("map-reduce summary:")
+	logs.Tokenf("%s\n\n", reduced)
+
+	report("refine", refined.Usage, refineTime, refined.Summary)
+	report("map-reduce", mrUsage, mrTime, reduced)
+}
+
+// mapReduce is the baseline, with a single reduce and sequential calls so that both strategies are timed alike.
+func mapReduce(ctx context.Context, cm model.ChatModel, chunks []string) (string, usage, error) {
+	var u usage
+	summaries := make([]string, 0, len(chunks))
+	for _, c := range chunks {
+		in, err := prompt.MustGet("graph_mapreduce_map").Format(ctx, map[string]any{"text": c})
+		if err != nil {
+			return "", u, err
+		}
+		msg, err := cm.Generate(ctx, in)
+		if err != nil {
+			return "", u, err
+		}
+		u.add(msg)
+		summaries = append(summaries, msg.Content)
+	}
+
+	in, err := prompt.MustGet("graph_mapreduce_reduce").Format(ctx, map[string]any{"text": strings.Join(summaries, "\n\n---\n\n")})
+	if err != nil {
+		return "", u, err
+	}
+	msg, err := cm.Generate(ctx, in)
+	if err != nil {
+		return "", u, err
+	}
+	u.add(msg)
+	return msg.Content, u, nil
+}
+
+func report(strategy string, u usage, d time.Duration, summary string) {
+	if !u.Reported {
+		logs.Infof("%-10s calls=%d time=%s summary=%d chars, tokens not reported by the model",
+			strategy, u.Calls, d.Round(time.Millisecond), len(summary))
+		return
+	}
+	logs.Infof("%-10s calls=%d time=%s summary=%d chars prompt_tokens=%d completion_tokens=%d total_tokens=%d",
+		strategy, u.Calls, d.Round(time.Millisecond), len(summary), u.PromptTokens, u.CompletionTokens, u.PromptTokens+u.CompletionTokens)
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodePrepare      = "prepare"
+	nodeRefinePrompt = "refine_prompt"
+	nodeModel        = "model"
+	nodeUpdate       = "update"
+)
+
+// usage sums the cost of the model calls of one strategy.
+type usage struct {
+	Calls            int
+	PromptTokens     int
+	CompletionTokens int
+	// Reported is false when the model returned no token usage, the token counts are then zero.
+	Reported bool
+}
+
+func (u *usage) add(msg *schema.Message) {
+	u.Calls++
+	if msg.ResponseMeta == nil || msg.ResponseMeta.Usage == nil {
+		return
+	}
+	u.Reported = true
+	u.PromptTokens += msg.ResponseMeta.Usage.PromptTokens
+	u.CompletionTokens += msg.ResponseMeta.Usage.CompletionTokens
+}
+
+type refineState struct {
+	chunks  []string
+	next    int
+	summary string
+	usage   usage
+}
+
+// refineResult is the output of the refine graph.
+type refineResult struct {
+	Summary string
+	Usage   usage
+}
+
+// buildRefineGraph builds a loop over the chunks, one model call per chunk, each one rewriting the running summary:
+//
+//	START -> prepare -> refine_prompt -> model -> update --(chunks left)--> refine_prompt
+//	                                                     --(done)--> END
+//
+// Unlike map-reduce, the calls cannot run in parallel, but every call sees what was summarized before, so a chunk
+// that corrects or continues an earlier one is merged in its context.
+func buildRefineGraph(ctx context.Context, cm model.ChatModel) (compose.Runnable[[]string, *refineResult], error) {
+	g := compose.NewGraph[[]string, *refineResult](compose.WithGenLocalState(func(ctx context.Context) *refineState {
+		return &refineState{}
+	}))
+
+	_ = g.AddLambdaNode(nodePrepare, compose.InvokableLambda(func(ctx context.Context, chunks []string) (*refineResult, error) {
+		if len(chunks) == 0 {
+			return nil, errors.New("nothing to summarize")
+		}
+		err := compose.ProcessState[*refineState](ctx, func(_ context.Context, s *refineState) error {
+			s.chunks = chunks
+			return nil
+		})
+		return &refineResult{}, err
+	}))
+	// the input is the result so far, the prompt is built from the state which also has the chunks
+	_ = g.AddLambdaNode(nodeRefinePrompt, compose.InvokableLambda(func(ctx context.Context, _ *refineResult) ([]*schema.Message, error) {
+		name, vars := "graph_refine_first", map[string]any{}
+		err := compose.ProcessState[*refineState](ctx, func(_ context.Context, s *refineState) error {
+			vars["text"] = s.chunks[s.next]
+			if s.next > 0 {
+				name = "graph_refine_refine"
+				vars["summary"], vars["part"], vars["parts"] = s.summary, s.next+1, len(s.chunks)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return prompt.MustGet(name).Format(ctx, vars)
+	}))
+	_ = g.AddChatModelNode(nodeModel, cm)
+	_ = g.AddLambdaNode(nodeUpdate, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*refineResult, error) {
+		var res *refineResult
+		err := compose.ProcessState[*refineState](ctx, func(_ context.Context, s *refineState) error {
+			s.summary = msg.Content
+			s.usage.add(msg)
+			s.next++
+			res = &refineResult{Summary: s.summary, Usage: s.usage}
+			return nil
+		})
+		return res, err
+	}))
+
+	_ = g.AddEdge(compose.START, nodePrepare)
+	_ = g.AddEdge(nodePrepare, nodeRefinePrompt)
+	_ = g.AddEdge(nodeRefinePrompt, nodeModel)
+	_ = g.AddEdge(nodeModel, nodeUpdate)
+	_ = g.AddBranch(nodeUpdate, compose.NewGraphBranch(func(ctx context.Context, res *refineResult) (string, error) {
+		next := compose.END
+		err := compose.ProcessState[*refineState](ctx, func(_ context.Context, s *refineState) error {
+			if s.next < len(s.chunks) {
+				next = nodeRefinePrompt
+			}
+			return nil
+		})
+		return next, err
+	}, map[string]bool{nodeRefinePrompt: true, compose.END: true}))
+
+	return g.Compile(ctx, compose.WithGraphName("refine"), compose.WithMaxRunSteps(1000))
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodeOutlineTemplate = "outline_template"
+	nodeOutlineModel    = "outline_model"
+	nodeToDraftVars     = "to_draft_vars"
+	nodeDraftTemplate   = "draft_template"
+	nodeDraftModel      = "draft_model"
+)
+
+// nodes are the nodes of the graph, in order.
+var nodes = []string{nodeOutlineTemplate, nodeOutlineModel, nodeToDraftVars, nodeDraftTemplate, nodeDraftModel}
+
+// buildGraph builds the graph starting at node from, the nodes before it are left out:
+//
+//	outline_template -> outline_model -> to_draft_vars -> draft_template -> draft_model
+//
+// Eino runs a graph from its START node only, so replaying from an intermediate node compiles the graph without
+// the nodes before it, and gives the recorded input of the node to the graph. The input of the graph is any
+// because it is the input of from, checked at run time.
+func buildGraph(ctx context.Context, cm model.ChatModel, from string) (compose.Runnable[any, *schema.Message], error) {
+	start := -1
+	for i, n := range nodes {
+		if n == from {
+			start = i
+		}
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("unknown node %q", from)
+	}
+
+	g := compose.NewGraph[any, *schema.Message]()
+	adders := map[string]func() error{
+		nodeOutlineTemplate: func() error {
+			return g.AddChatTemplateNode(nodeOutlineTemplate, prompt.MustGet("graph_replay_outline"))
+		},
+		nodeOutlineModel: func() error {
+			return g.AddChatModelNode(nodeOutlineModel, cm)
+		},
+		nodeToDraftVars: func() error {
+			return g.AddLambdaNode(nodeToDraftVars, compose.InvokableLambda(func(ctx context.Context, outline *schema.Message) (map[string]any, error) {
+				return map[string]any{"outline": outline.Content}, nil
+			}))
+		},
+		nodeDraftTemplate: func() error {
+			return g.AddChatTemplateNode(nodeDraftTemplate, prompt.MustGet("graph_replay_draft"))
+		},
+		nodeDraftModel: func() error {
+			return g.AddChatModelNode(nodeDraftModel, cm)
+		},
+	}
+
+	prev := compose.START
+	for _, n := range nodes[start:] {
+		if err := adders[n](); err != nil {
+			return nil, err
+		}
+		if err := g.AddEdge(prev, n); err != nil {
+			return nil, err
+		}
+		prev = n
+	}
+	if err := g.AddEdge(prev, compose.END); err != nil {
+		return nil, err
+	}
+
+	return g.Compile(ctx, compose.WithGraphName("BlogPost"))
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/callbacks"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example records every node of a graph run to a file, then runs the graph again from any node with the
+// recorded input of that node, to iterate on a prompt without paying for, or waiting on, the nodes before it:
+//
+//	go run ./compose/graph/replay -topic "why we moved our CI to ARM runners"
+//	# edit prompts/graph_replay_draft.prompt, then replay from the draft template: the outline is not generated again
+//	EINO_PROMPTS_DIR=prompts go run ./compose/graph/replay -from draft_template
+//	# print the input and output of every node as it runs
+//	go run ./compose/graph/replay -debug
+//
+// Replaying from draft_model reuses the recorded prompt messages as they were, to check how the model varies on the
+// very same input. The recording is plain JSON, the input of a node can also be edited in it by hand.
+func main() {
+	topic := flag.String("topic", "how to write a useful postmortem", "topic of the blog post")
+	recordPath := flag.String("recording", ".cache/replay/blog_post.json", "file the run is recorded to and replayed from")
+	from := flag.String("from", "", "replay the recording from this node instead of running the whole graph")
+	debug := flag.Bool("debug", false, "print the input and output of every node")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	cm := models.MustChatModel(ctx)
+
+	var opts []compose.Option
+	if *debug {
+		opts = append(opts, callbacks.WithDebug(nil))
+	}
+
+	if *from == "" {
+		record(ctx, cm, *topic, *recordPath, opts...)
+		return
+	}
+	replay(ctx, cm, *from, *recordPath, opts...)
+}
+
+func record(ctx context.Context, cm model.ChatModel, topic, path string, opts ...compose.Option) {
+	runner, err := buildGraph(ctx, cm, nodes[0])
+	if err != nil {
+		logs.Fatalf("buildGraph failed, err=%v", err)
+	}
+
+	recorder := callbacks.NewRecorder()
+	post, err := runner.Invoke(ctx, map[string]any{"topic": topic}, append(recorder.Options(nodes...), opts...)...)
+	rec := recorder.Recording()
+	// a failed run is saved too, to replay it once the failing node is fixed
+	if serr := rec.Save(path); serr != nil {
+		logs.Errorf("save recording failed, err=%v", serr)
+	} else {
+		logs.Infof("%d node runs recorded to %s", len(rec.Nodes), path)
+	}
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+	for _, n := range rec.Nodes {
+		logs.Infof("  %-17s %-13s %6dms", n.Node, n.Component, n.LatencyMS)
+	}
+	logs.Infof("post:")
+	logs.Tokenf("%s\n", post.Content)
+}
+
+func replay(ctx context.Context, cm model.ChatModel, from, path string, opts ...compose.Option) {
+	rec, err := callbacks.LoadRecording(path)
+	if err != nil {
+		logs.Fatalf("LoadRecording failed, err=%v", err)
+	}
+	input, err := decodeInput(rec, from)
+	if err != nil {
+		logs.Fatalf("decodeInput failed, err=%v", err)
+	}
+	runner, err := buildGraph(ctx, cm, from)
+	if err != nil {
+		logs.Fatalf("buildGraph failed, err=%v", err)
+	}
+
+	logs.Infof("replaying from %s with its recorded input", from)
+	post, err := runner.Invoke(ctx, input, opts...)
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	if recorded, err := callbacks.DecodeOutput[*schema.Message](rec, nodeDraftModel); err == nil {
+		logs.Infof("recorded post:")
+		logs.Tokenf("%s\n\n", recorded.Content)
+	}
+	logs.Infof("replayed post:")
+	logs.Tokenf("%s\n", post.Content)
+}
+
+// decodeInput decodes the recorded input of a node into its input type.
+func decodeInput(rec *callbacks.Recording, node string) (any, error) {
+	switch node {
+	case nodeOutlineTemplate, nodeDraftTemplate:
+		return callbacks.DecodeInput[map[string]any](rec, node)
+	case nodeOutlineModel, nodeDraftModel:
+		return callbacks.DecodeInput[[]*schema.Message](rec, node)
+	case nodeToDraftVars:
+		return callbacks.DecodeInput[*schema.Message](rec, node)
+	}
+	return nil, fmt.Errorf("unknown node %q", node)
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/mock"
+	"github.com/cloudwego/eino-examples/internal/testutil"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blog_post.json")
+	inDir := testutil.Replace(regexp.QuoteMeta(dir), "<DIR>")
+	// the latencies are right-aligned, their padding varies with them
+	latency := testutil.Replace(` +\d+ms\n`, " <DURATION>\n")
+
+	cm := mock.NewChatModel(
+		mock.Reply("Postmortems\nWhat happened\nWhy\nWhat we change"),
+		mock.Reply("Things break. Here is what happened, why, and what we change."),
+		mock.Reply("Outages happen. This is what went wrong and what we do next."),
+	)
+
+	out := testutil.CaptureOutput(t, func() {
+		record(ctx, cm, "how to write a useful postmortem", path)
+	})
+	testutil.AssertGolden(t, "record", out, inDir, latency)
+
+	recording, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	testutil.AssertGolden(t, "recording", string(recording), testutil.JSONFields("start", "latency_ms"))
+
+	out = testutil.CaptureOutput(t, func() {
+		replay(ctx, cm, nodeDraftModel, path)
+	})
+	testutil.AssertGolden(t, "replay", out)
+
+	calls := cm.Calls()
+	if assert.Len(t, calls, 3) {
+		// the replayed model gets the very messages it got when recording
+		assert.Equal(t, calls[1].Input, calls[2].Input)
+	}
+}
@@ -0,0 +1,252 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+const (
+	nodeInit      = "init"
+	nodeRetrieve  = "retrieve"
+	nodePlan      = "plan"
+	nodeCollect   = "collect"
+	nodeTemplate  = "template"
+	nodeChatModel = "chat_model"
+
+	maxIterations = 3
+)
+
+// researchState is the graph-local state: one instance per run, created by WithGenLocalState.
+//
+// Concurrency: the graph hands the state to StatePreHandler, StatePostHandler and ProcessState callbacks
+// while holding a lock, so the state must only be touched from there. To make that hard to get wrong,
+// the fields are unexported and only changed through the methods below, and every method returning data
+// returns a copy, so no slice of the state leaks out to code running without the lock, e.g. a goroutine started by a node.
+type researchState struct {
+	question   string
+	iterations int
+	// pending is the query being searched, set before retrieve runs and consumed after
+	pending string
+	queries []string
+	docs    []*schema.Document
+	seen    map[string]bool
+}
+
+func newResearchState(_ context.Context) *researchState {
+	return &researchState{seen: map[string]bool{}}
+}
+
+// addDocs records the pending query, keeps the documents not seen yet and returns how many were new.
+func (s *researchState) addDocs(docs []*schema.Document) int {
+	s.iterations++
+	s.queries = append(s.queries, s.pending)
+	s.pending = ""
+
+	added := 0
+	for _, doc := range docs {
+		if s.seen[doc.ID] {
+			continue
+		}
+		s.seen[doc.ID] = true
+		s.docs = append(s.docs, doc)
+		added++
+	}
+	return added
+}
+
+func (s *researchState) snapshot() (question string, queries []string, docs []*schema.Document) {
+	return s.question, append([]string(nil), s.queries...), append([]*schema.Document(nil), s.docs...)
+}
+
+var corpus = []*schema.Document{
+	{ID: "alert", Content: "Alert 2291 fired at 02:14: disk usage above 95% on host db-7, which runs the ledger-db service."},
+	{ID: "catalog-ledger", Content: "Service catalog: ledger-db is owned by the Payments Platform team."},
+	{ID: "catalog-search", Content: "Service catalog: search-api is owned by the Discovery team."},
+	{ID: "oncall", Content: "On-call this week: Payments Platform is covered by Priya, Discovery by Tomás."},
+	{ID: "runbook", Content: "Runbook for full disks on database hosts: rotate the WAL archive, then extend the volume."},
+}
+
+// This example answers a multi-hop question by retrieving in a loop, with the progress kept in the graph state:
+//
+//	START -> init -> retrieve -> plan --(DONE or max iterations)--> collect -> template -> chat_model -> END
+//	                    ^          |
+//	                    +- query --+
+//
+// init has a StatePreHandler saving the question, since later nodes only receive search queries.
+// retrieve has a StatePostHandler appending the new documents and counting iterations.
+// plan and collect are custom nodes reading the state with ProcessState,
+// and the branch after plan reads the iteration count to stop the loop.
+func main() {
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	cm := models.MustChatModel(ctx, models.WithTemperature(0))
+
+	// a small topK makes one search not enough, so that the loop has something to do
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 1})
+	if err != nil {
+		logs.Fatalf("create memory store failed, err=%v", err)
+	}
+	if _, err = store.Store(ctx, corpus); err != nil {
+		logs.Fatalf("index failed, err=%v", err)
+	}
+
+	g := compose.NewGraph[string, *schema.Message](compose.WithGenLocalState(newResearchState))
+
+	// the first search query is the question itself
+	_ = g.AddLambdaNode(nodeInit, compose.InvokableLambda(func(ctx context.Context, question string) (string, error) {
+		return question, nil
+	}), compose.WithStatePreHandler[string, *researchState](func(ctx context.Context, question string, s *researchState) (string, error) {
+		s.question = question
+		return question, nil
+	}))
+
+	// the pre handler sees the query and the post handler the documents, the state links them together.
+	// Do not use a variable captured by the closures for that: a compiled graph is shared by concurrent runs, the state is not.
+	_ = g.AddRetrieverNode(nodeRetrieve, store,
+		compose.WithStatePreHandler[string, *researchState](func(ctx context.Context, query string, s *researchState) (string, error) {
+			s.pending = query
+			return query, nil
+		}),
+		compose.WithStatePostHandler[[]*schema.Document, *researchState](func(ctx context.Context, docs []*schema.Document, s *researchState) ([]*schema.Document, error) {
+			query := s.pending
+			added := s.addDocs(docs)
+			logs.Infof("iteration %d: query=%q, %d new documents", s.iterations, query, added)
+			return docs, nil
+		}))
+
+	_ = g.AddLambdaNode(nodePlan, compose.InvokableLambda(func(ctx context.Context, _ []*schema.Document) (string, error) {
+		var (
+			question string
+			queries  []string
+			docs     []*schema.Document
+		)
+		// take a copy under the lock and release it before calling the model, which takes seconds
+		err := compose.ProcessState[*researchState](ctx, func(_ context.Context, s *researchState) error {
+			question, queries, docs = s.snapshot()
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+
+		in, err := prompt.MustGet("graph_stateful_plan").Format(ctx, map[string]any{
+			"question": question,
+			"queries":  strings.Join(queries, "; "),
+			"facts":    formatFacts(docs),
+		})
+		if err != nil {
+			return "", err
+		}
+		msg, err := cm.Generate(ctx, in)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(msg.Content), nil
+	}))
+
+	_ = g.AddLambdaNode(nodeCollect, compose.InvokableLambda(func(ctx context.Context, _ string) (map[string]any, error) {
+		var docs []*schema.Document
+		err := compose.ProcessState[*researchState](ctx, func(_ context.Context, s *researchState) error {
+			_, _, docs = s.snapshot()
+			return nil
+		})
+		return map[string]any{"facts": formatFacts(docs)}, err
+	}))
+
+	// the question is in the state, not in the input of the template
+	_ = g.AddChatTemplateNode(nodeTemplate, prompt.MustGet("graph_stateful_answer"), compose.WithStatePreHandler[map[string]any, *researchState](func(ctx context.Context, in map[string]any, s *researchState) (map[string]any, error) {
+		in["question"] = s.question
+		return in, nil
+	}))
+	_ = g.AddChatModelNode(nodeChatModel, cm)
+
+	_ = g.AddEdge(compose.START, nodeInit)
+	_ = g.AddEdge(nodeInit, nodeRetrieve)
+	_ = g.AddEdge(nodeRetrieve, nodePlan)
+	_ = g.AddBranch(nodePlan, compose.NewGraphBranch(func(ctx context.Context, next string) (string, error) {
+		if strings.EqualFold(strings.Trim(next, ". "), "DONE") {
+			return nodeCollect, nil
+		}
+
+		var iterations int
+		err := compose.ProcessState[*researchState](ctx, func(_ context.Context, s *researchState) error {
+			iterations = s.iterations
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if iterations >= maxIterations {
+			logs.Infof("stop after %d iterations, answering with what was found", iterations)
+			return nodeCollect, nil
+		}
+		return nodeRetrieve, nil
+	}, map[string]bool{nodeRetrieve: true, nodeCollect: true}))
+	_ = g.AddEdge(nodeCollect, nodeTemplate)
+	_ = g.AddEdge(nodeTemplate, nodeChatModel)
+	_ = g.AddEdge(nodeChatModel, compose.END)
+
+	// every iteration runs retrieve and plan once, leave room for the loop on top of the other nodes
+	r, err := g.Compile(ctx, compose.WithGraphName("iterative_retrieval"), compose.WithMaxRunSteps(2*maxIterations+10))
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	question := "Who should be paged for the disk alert of last night?"
+	out, err := r.Invoke(ctx, question)
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	logs.Infof("question: %s", question)
+	logs.Tokenf("%s\n", out.Content)
+}
+
+func formatFacts(docs []*schema.Document) string {
+	if len(docs) == 0 {
+		return "(none)\n"
+	}
+	sb := strings.Builder{}
+	for _, doc := range docs {
+		sb.WriteString("- " + doc.Content + "\n")
+	}
+	return sb.String()
+}
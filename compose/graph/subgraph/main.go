@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+const (
+	nodePrepare   = "prepare"
+	nodeDocs      = "docs_retrieval"
+	nodeFAQ       = "faq_retrieval"
+	nodeTemplate  = "template"
+	nodeChatModel = "chat_model"
+)
+
+var productDocs = []*schema.Document{
+	{Content: "The Pro plan includes 10 seats, 100 GB of storage and priority support."},
+	{Content: "Seats can be added to any plan for 8 USD per seat per month."},
+	{Content: "Storage is shared by all seats of a workspace."},
+	{Content: "Exports are available in CSV and JSON from the settings page."},
+}
+
+var faq = []*schema.Document{
+	{Content: "Q: Can I change plans in the middle of a month? A: Yes, the price difference is prorated to the day."},
+	{Content: "Q: What happens when storage is full? A: Uploads are blocked until files are deleted or storage is added."},
+	{Content: "Q: Do unused seats roll over? A: No, seats are billed monthly whether they are used or not."},
+}
+
+// This example reuses one retrieval subgraph twice inside a larger graph:
+//
+//	             -> docs_retrieval (subgraph) -
+//	prepare ---<                               >-- template -> chat_model
+//	             -> faq_retrieval  (subgraph) -
+//
+// Both retrieval nodes are built by newRetrievalGraph, each over its own knowledge base.
+// The subgraphs write their result under the output keys docs and faq, which are exactly the variables of the template.
+func main() {
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	cm := models.MustChatModel(ctx)
+
+	docsStore := mustStore(ctx, emb, productDocs)
+	faqStore := mustStore(ctx, emb, faq)
+
+	type state struct {
+		question string
+	}
+
+	g := compose.NewGraph[string, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *state {
+		return &state{}
+	}))
+
+	_ = g.AddLambdaNode(nodePrepare, compose.InvokableLambda(func(ctx context.Context, question string) (string, error) {
+		err := compose.ProcessState[*state](ctx, func(_ context.Context, s *state) error {
+			s.question = question
+			return nil
+		})
+		return question, err
+	}))
+	_ = g.AddGraphNode(nodeDocs, newRetrievalGraph("docs", docsStore, 0.3), compose.WithOutputKey("docs"))
+	_ = g.AddGraphNode(nodeFAQ, newRetrievalGraph("faq", faqStore, 0.3), compose.WithOutputKey("faq"))
+	// the question is added to the merged outputs of the two subgraphs before the template is formatted
+	_ = g.AddChatTemplateNode(nodeTemplate, prompt.MustGet("graph_subgraph_answer"), compose.WithStatePreHandler[map[string]any, *state](func(ctx context.Context, in map[string]any, s *state) (map[string]any, error) {
+		in["question"] = s.question
+		return in, nil
+	}))
+	_ = g.AddChatModelNode(nodeChatModel, cm)
+
+	_ = g.AddEdge(compose.START, nodePrepare)
+	_ = g.AddEdge(nodePrepare, nodeDocs)
+	_ = g.AddEdge(nodePrepare, nodeFAQ)
+	_ = g.AddEdge(nodeDocs, nodeTemplate)
+	_ = g.AddEdge(nodeFAQ, nodeTemplate)
+	_ = g.AddEdge(nodeTemplate, nodeChatModel)
+	_ = g.AddEdge(nodeChatModel, compose.END)
+
+	r, err := g.Compile(ctx, compose.WithGraphName("support_agent"), compose.WithNodeTriggerMode(compose.AllPredecessor))
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	question := "We are on the Pro plan with 12 people, what will we pay and can we switch mid-month?"
+	out, err := r.Invoke(ctx, question)
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	logs.Infof("question: %s", question)
+	logs.Tokenf("%s\n", out.Content)
+}
+
+func mustStore(ctx context.Context, emb embedding.Embedder, docs []*schema.Document) *vectorstore.MemoryStore {
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 2})
+	if err != nil {
+		logs.Fatalf("create memory store failed, err=%v", err)
+	}
+	if _, err = store.Store(ctx, docs); err != nil {
+		logs.Fatalf("index failed, err=%v", err)
+	}
+	return store
+}
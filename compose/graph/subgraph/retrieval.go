@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// newRetrievalGraph packages retrieval as a reusable fragment: a question goes in, a block of context
+// ready to be put into a prompt comes out.
+//
+//	normalize -> retrieve -> format
+//
+// It is returned uncompiled, so that a parent graph can embed it with AddGraphNode as many times as needed,
+// each time with its own retriever. The parent only sees a node taking a string and returning a string.
+func newRetrievalGraph(name string, ret retriever.Retriever, minScore float64) *compose.Graph[string, string] {
+	g := compose.NewGraph[string, string]()
+
+	_ = g.AddLambdaNode("normalize", compose.InvokableLambda(func(ctx context.Context, question string) (string, error) {
+		return strings.TrimSpace(question), nil
+	}))
+	_ = g.AddRetrieverNode("retrieve", ret)
+	_ = g.AddLambdaNode("format", compose.InvokableLambda(func(ctx context.Context, docs []*schema.Document) (string, error) {
+		sb := strings.Builder{}
+		for _, doc := range docs {
+			if doc.Score() < minScore {
+				continue
+			}
+			logs.Infof("[%s] score=%.3f %s", name, doc.Score(), doc.Content)
+			sb.WriteString(fmt.Sprintf("- %s\n", doc.Content))
+		}
+		if sb.Len() == 0 {
+			return "(nothing relevant found)", nil
+		}
+		return sb.String(), nil
+	}))
+
+	_ = g.AddEdge(compose.START, "normalize")
+	_ = g.AddEdge("normalize", "retrieve")
+	_ = g.AddEdge("retrieve", "format")
+	_ = g.AddEdge("format", compose.END)
+
+	return g
+}
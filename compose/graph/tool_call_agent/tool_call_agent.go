@@ -18,53 +18,32 @@ package main
 
 import (
 	"context"
-	"os"
 
-	"github.com/cloudwego/eino-ext/components/model/openai"
 	"github.com/cloudwego/eino/callbacks"
-	"github.com/cloudwego/eino/components/prompt"
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/components/tool/utils"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
-	"github.com/cloudwego/eino-examples/internal/gptr"
 	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/validate"
 )
 
 func main() {
-
-	//openAIBaseURL := os.Getenv("OPENAI_BASE_URL")
-	openAIAPIKey := os.Getenv("OPENAI_API_KEY")
-	modelName := os.Getenv("OPENAI_MODEL_NAME")
+	validate.Must(validate.Model())
 
 	ctx := context.Background()
 
 	callbacks.InitCallbackHandlers([]callbacks.Handler{&loggerCallbacks{}})
 
 	// 1. create an instance of ChatTemplate as 1st Graph Node
-	systemTpl := `你是一名房产经纪人，结合用户的薪酬和工作，使用 user_info API，为其提供相关的房产信息。邮箱是必须的`
-	chatTpl := prompt.FromMessages(schema.FString,
-		schema.SystemMessage(systemTpl),
-		schema.MessagesPlaceholder("message_histories", true),
-		schema.UserMessage("{user_query}"),
-	)
-
-	modelConf := &openai.ChatModelConfig{
-		//BaseURL:     openAIBaseURL,
-		APIKey: openAIAPIKey,
-		//ByAzure:     true,
-		Model:       modelName,
-		Temperature: gptr.Of(float32(0.7)),
-		APIVersion:  "2024-06-01",
-	}
+	chatTpl := prompt.MustGet("graph_tool_call_agent_agent")
 
-	// 2. create an instance of ChatModel as 2nd Graph Node
-	chatModel, err := openai.NewChatModel(ctx, modelConf)
-	if err != nil {
-		logs.Errorf("NewChatModel failed, err=%v", err)
-		return
-	}
+	// 2. create an instance of ChatModel as 2nd Graph Node.
+	// BindForcedTools is a method of the OpenAI client, so the provider is openai, azure or claude
+	chatModel := models.MustOpenAIChatModel(ctx, models.WithTemperature(0.7))
 
 	// 3. create an instance of tool.InvokableTool for Intent recognition and execution
 	userInfoTool := utils.NewTool(
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/streamprint"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const (
+	nodeModel = "model"
+	nodeTools = "tools"
+)
+
+type weatherRequest struct {
+	City string `json:"city" jsonschema:"description=name of the city"`
+}
+
+type state struct {
+	messages []*schema.Message
+}
+
+// This example is about one thing: the branch after a chat model, going to the tools when the model called some,
+// and to END when it answered.
+//
+//	START -> model --(ToolCalls not empty)--> tools -> model
+//	               --(no ToolCalls)--> END
+//
+// The condition reads schema.Message.ToolCalls. Two versions of it are below: routeMessage for a branch built with
+// NewGraphBranch, which sees the whole message, and routeStream for NewStreamGraphBranch, which decides as soon as
+// the stream shows a tool call, without waiting for the end of an answer. Run with -stream to use the second one.
+func main() {
+	stream := flag.Bool("stream", false, "build the branch with NewStreamGraphBranch and stream the answer")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	cm := models.MustChatModel(ctx)
+
+	weather, err := utils.InferTool("get_weather", "get the current weather of a city",
+		func(ctx context.Context, in *weatherRequest) (string, error) {
+			return in.City + ": 18°C, light rain", nil
+		})
+	if err != nil {
+		logs.Fatalf("InferTool failed, err=%v", err)
+	}
+	info, err := weather.Info(ctx)
+	if err != nil {
+		logs.Fatalf("Info failed, err=%v", err)
+	}
+	if err = cm.BindTools([]*schema.ToolInfo{info}); err != nil {
+		logs.Fatalf("BindTools failed, err=%v", err)
+	}
+	tools, err := compose.NewToolNode(ctx, &compose.ToolsNodeConfig{Tools: []tool.BaseTool{weather}})
+	if err != nil {
+		logs.Fatalf("NewToolNode failed, err=%v", err)
+	}
+
+	g := compose.NewGraph[[]*schema.Message, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *state {
+		return &state{}
+	}))
+	// the model sees the whole conversation: the input first, then its tool calls and their results
+	_ = g.AddChatModelNode(nodeModel, cm,
+		compose.WithStatePreHandler(func(ctx context.Context, in []*schema.Message, s *state) ([]*schema.Message, error) {
+			s.messages = append(s.messages, in...)
+			return s.messages, nil
+		}),
+		compose.WithStatePostHandler(func(ctx context.Context, out *schema.Message, s *state) (*schema.Message, error) {
+			s.messages = append(s.messages, out)
+			return out, nil
+		}),
+	)
+	_ = g.AddToolsNode(nodeTools, tools)
+
+	ends := map[string]bool{nodeTools: true, compose.END: true}
+	branch := compose.NewGraphBranch(routeMessage, ends)
+	if *stream {
+		branch = compose.NewStreamGraphBranch(routeStream, ends)
+	}
+
+	_ = g.AddEdge(compose.START, nodeModel)
+	_ = g.AddBranch(nodeModel, branch)
+	_ = g.AddEdge(nodeTools, nodeModel)
+
+	r, err := g.Compile(ctx, compose.WithMaxRunSteps(10))
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	for _, q := range []string{"What's the weather in Lisbon?", "Say hello in Portuguese."} {
+		logs.Infof("user: %s", q)
+		input := []*schema.Message{schema.UserMessage(q)}
+		if !*stream {
+			out, err := r.Invoke(ctx, input)
+			if err != nil {
+				logs.Fatalf("Invoke failed, err=%v", err)
+			}
+			logs.Infof("assistant: %s", out.Content)
+			continue
+		}
+
+		sr, err := r.Stream(ctx, input)
+		if err != nil {
+			logs.Fatalf("Stream failed, err=%v", err)
+		}
+		if _, err = streamprint.Print(sr); err != nil {
+			logs.Fatalf("Recv failed, err=%v", err)
+		}
+	}
+}
+
+// routeMessage goes to the tools when the model called at least one. The content is not looked at: some models
+// write a sentence before their tool calls, the message is still a tool call.
+func routeMessage(ctx context.Context, msg *schema.Message) (string, error) {
+	if len(msg.ToolCalls) > 0 {
+		logs.Infof("route: %d tool call(s), to %s", len(msg.ToolCalls), nodeTools)
+		return nodeTools, nil
+	}
+	logs.Infof("route: final answer, to END")
+	return compose.END, nil
+}
+
+// routeStream reads the chunks until one has a tool call, or until the end of the stream. The tool calls are split
+// over many chunks, the first one is enough to decide; the graph gives the branch a copy of the stream, the tools
+// node still receives every chunk.
+func routeStream(ctx context.Context, sr *schema.StreamReader[*schema.Message]) (string, error) {
+	defer sr.Close()
+
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			logs.Infof("route: final answer, to END")
+			return compose.END, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if len(chunk.ToolCalls) > 0 {
+			logs.Infof("route: tool call in the stream, to %s", nodeTools)
+			return nodeTools, nil
+		}
+	}
+}
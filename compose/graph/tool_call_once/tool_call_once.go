@@ -19,46 +19,26 @@ package main
 import (
 	"context"
 	"errors"
-	"os"
 
-	"github.com/cloudwego/eino-ext/components/model/openai"
-	"github.com/cloudwego/eino/components/prompt"
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/components/tool/utils"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
-	"github.com/cloudwego/eino-examples/internal/gptr"
 	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/validate"
 )
 
 func main() {
-	//openAIBaseURL := os.Getenv("OPENAI_BASE_URL")
-	openAIAPIKey := os.Getenv("OPENAI_API_KEY")
-	modelName := os.Getenv("OPENAI_MODEL_NAME")
+	validate.Must(validate.Model())
 
 	ctx := context.Background()
 
-	systemTpl := `你是一名房产经纪人，结合用户的薪酬和工作，使用 user_info API，为其提供相关的房产信息。邮箱是必须的`
-	chatTpl := prompt.FromMessages(schema.FString,
-		schema.SystemMessage(systemTpl),
-		schema.MessagesPlaceholder("message_histories", true),
-		schema.UserMessage("{query}"),
-	)
-
-	modelConf := &openai.ChatModelConfig{
-		//BaseURL:     openAIBaseURL,
-		APIKey: openAIAPIKey,
-		//ByAzure:     true,
-		Model:       modelName,
-		Temperature: gptr.Of(float32(0.7)),
-		APIVersion:  "2024-06-01",
-	}
+	chatTpl := prompt.MustGet("graph_tool_call_once_agent")
 
-	chatModel, err := openai.NewChatModel(ctx, modelConf)
-	if err != nil {
-		logs.Fatalf("NewChatModel failed, err=%v", err)
-	}
+	chatModel := models.MustChatModel(ctx, models.WithTemperature(0.7))
 
 	userInfoTool := utils.NewTool(
 		&schema.ToolInfo{
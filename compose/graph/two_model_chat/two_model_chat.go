@@ -21,56 +21,42 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 
-	"github.com/cloudwego/eino-ext/components/model/openai"
 	callbacks2 "github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 	"github.com/cloudwego/eino/utils/callbacks"
 
-	"github.com/cloudwego/eino-examples/internal/gptr"
 	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/streamprint"
+	"github.com/cloudwego/eino-examples/internal/validate"
 )
 
 func main() {
-	//openAIBaseURL := os.Getenv("OPENAI_BASE_URL")
-	openAIAPIKey := os.Getenv("OPENAI_API_KEY")
-	modelName := os.Getenv("OPENAI_MODEL_NAME")
+	validate.Must(validate.Model())
 
 	ctx := context.Background()
 
-	modelConf := &openai.ChatModelConfig{
-		//BaseURL:     openAIBaseURL,
-		APIKey: openAIAPIKey,
-		//ByAzure:     true,
-		Model:       modelName,
-		Temperature: gptr.Of(float32(0.7)),
-		APIVersion:  "2024-06-01",
-	}
-
 	type state struct {
 		currentRound int
 		msgs         []*schema.Message
 	}
 
-	llm, err := openai.NewChatModel(ctx, modelConf)
-	if err != nil {
-		logs.Fatalf("new chat model failed: %v", err)
-	}
+	llm := models.MustChatModel(ctx, models.WithTemperature(0.7))
+	writer, critic := prompt.MustGet("graph_two_model_chat_writer"), prompt.MustGet("graph_two_model_chat_critic")
 
 	g := compose.NewGraph[[]*schema.Message, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *state { return &state{} }))
 	_ = g.AddChatModelNode("writer", llm, compose.WithStatePreHandler[[]*schema.Message, *state](func(ctx context.Context, input []*schema.Message, state *state) ([]*schema.Message, error) {
 		state.currentRound++
 		state.msgs = append(state.msgs, input...)
-		input = append([]*schema.Message{schema.SystemMessage("you are a writer who writes jokes and revise it according to the critic's feedback. Prepend your joke with your name which is \"writer: \"")}, state.msgs...)
-		return input, nil
+		return writer.Format(ctx, map[string]any{"history": state.msgs})
 	}), compose.WithNodeName("writer"))
 	_ = g.AddChatModelNode("critic", llm, compose.WithStatePreHandler[[]*schema.Message, *state](func(ctx context.Context, input []*schema.Message, state *state) ([]*schema.Message, error) {
 		state.msgs = append(state.msgs, input...)
-		input = append([]*schema.Message{schema.SystemMessage("you are a critic who ONLY gives feedback about jokes, emphasizing on funniness. Prepend your feedback with your name which is \"critic: \"")}, state.msgs...)
-		return input, nil
+		return critic.Format(ctx, map[string]any{"history": state.msgs})
 	}), compose.WithNodeName("critic"))
 	_ = g.AddLambdaNode("toList1", compose.ToList[*schema.Message]())
 	_ = g.AddLambdaNode("toList2", compose.ToList[*schema.Message]())
@@ -99,16 +85,8 @@ func main() {
 		logs.Fatalf("compile error: %v", err)
 	}
 
-	sResponse := &streamResponse{
-		ch: make(chan string),
-	}
-	go func() {
-		for m := range sResponse.ch {
-			fmt.Print(m)
-		}
-	}()
 	handler := callbacks.NewHandlerHelper().ChatModel(&callbacks.ModelCallbackHandler{
-		OnEndWithStreamOutput: sResponse.OnStreamStart,
+		OnEndWithStreamOutput: printStream,
 	}).Handler()
 
 	outStream, err := runner.Stream(ctx, []*schema.Message{schema.UserMessage("write a funny line about robot, in 20 words.")},
@@ -116,32 +94,30 @@ func main() {
 	if err != nil {
 		logs.Fatalf("stream error: %v", err)
 	}
+	// the turns are printed by the callback as they are streamed, the output is the last one
+	defer outStream.Close()
 	for {
 		_, err := outStream.Recv()
-		if err == io.EOF {
-			close(sResponse.ch)
-			break
-		}
-	}
-}
-
-type streamResponse struct {
-	ch chan string
-}
-
-func (s *streamResponse) OnStreamStart(ctx context.Context, runInfo *callbacks2.RunInfo, input *schema.StreamReader[*model.CallbackOutput]) context.Context {
-	defer input.Close()
-	s.ch <- "\n=======\n"
-	for {
-		frame, err := input.Recv()
 		if errors.Is(err, io.EOF) {
 			break
 		}
 		if err != nil {
-			logs.Fatalf("internal error: %s\n", err)
+			logs.Fatalf("recv error: %v", err)
 		}
+	}
+}
 
-		s.ch <- frame.Message.Content
+// printStream prints the turn of the writer or the critic streamed by the chat model.
+func printStream(ctx context.Context, _ *callbacks2.RunInfo, input *schema.StreamReader[*model.CallbackOutput]) context.Context {
+	fmt.Println("\n=======")
+	sr := schema.StreamReaderWithConvert(input, func(frame *model.CallbackOutput) (*schema.Message, error) {
+		if frame.Message == nil {
+			return nil, schema.ErrNoValue
+		}
+		return frame.Message, nil
+	})
+	if _, err := streamprint.Print(sr); err != nil {
+		logs.Fatalf("internal error: %v", err)
 	}
 	return ctx
 }
@@ -0,0 +1,184 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example shows the three stream operations most pipelines end up needing:
+//
+//   - copy: one model stream consumed by several readers at once, e.g. printed to the user while being saved
+//   - merge: several streams read as one, in arrival order, e.g. two models answering in parallel
+//   - concat: chunks joined back into the full message once the stream ends
+//
+// The helpers are in stream.go, the edge cases (early close, empty stream, error in the middle) in stream_test.go.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	cm := models.MustChatModel(ctx)
+
+	copyStream(ctx, cm)
+	mergeStreams(ctx, cm)
+	parallelNodes(ctx, cm)
+}
+
+// copyStream reads one model stream three times: printed live, saved in full, and previewed.
+// The preview stops after a few chunks, which does not cut the stream short for the other two.
+func copyStream(ctx context.Context, cm model.ChatModel) {
+	logs.Infof("=== copy ===")
+
+	sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage("Explain in 3 sentences what a goroutine leak is.")})
+	if err != nil {
+		logs.Fatalf("Stream failed, err=%v", err)
+	}
+
+	var full *schema.Message
+	var preview strings.Builder
+	err = fanOut(sr,
+		func(sr *schema.StreamReader[*schema.Message]) error {
+			for {
+				chunk, err := sr.Recv()
+				if errors.Is(err, io.EOF) {
+					logs.Tokenf("\n")
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				logs.Tokenf("%s", chunk.Content)
+			}
+		},
+		func(sr *schema.StreamReader[*schema.Message]) error {
+			msg, err := concatStream(sr)
+			full = msg
+			return err
+		},
+		func(sr *schema.StreamReader[*schema.Message]) error {
+			// returning early is fine, fanOut closes this copy and the other copies are not affected
+			for i := 0; i < 3; i++ {
+				chunk, err := sr.Recv()
+				if err != nil {
+					break
+				}
+				preview.WriteString(chunk.Content)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		logs.Fatalf("consume stream failed, err=%v", err)
+	}
+
+	logs.Infof("preview of the first 3 chunks: %q", preview.String())
+	logs.Infof("saved full message: %d characters, role=%s", len(full.Content), full.Role)
+}
+
+// mergeStreams starts two model streams and reads them as one, tagging every chunk with its source.
+func mergeStreams(ctx context.Context, cm model.ChatModel) {
+	logs.Infof("=== merge ===")
+
+	streams := map[string]*schema.StreamReader[*schema.Message]{}
+	for name, question := range map[string]string{
+		"haiku":    "Write a haiku about channels in Go.",
+		"limerick": "Write a limerick about mutexes in Go.",
+	} {
+		sr, err := cm.Stream(ctx, []*schema.Message{schema.UserMessage(question)})
+		if err != nil {
+			logs.Fatalf("Stream failed, err=%v", err)
+		}
+		streams[name] = sr
+	}
+
+	merged := mergeTagged(streams)
+	defer merged.Close()
+
+	// chunks of the two streams interleave, so collect them per source to concatenate afterwards
+	chunks := map[string][]*schema.Message{}
+	for {
+		c, err := merged.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			logs.Fatalf("Recv failed, err=%v", err)
+		}
+		logs.Infof("[%s] %q", c.Source, c.Chunk.Content)
+		chunks[c.Source] = append(chunks[c.Source], c.Chunk)
+	}
+
+	for name, cs := range chunks {
+		msg, err := schema.ConcatMessages(cs)
+		if err != nil {
+			logs.Fatalf("ConcatMessages failed, err=%v", err)
+		}
+		logs.Infof("%s, %d chunks:\n%s", name, len(cs), msg.Content)
+	}
+}
+
+// parallelNodes lets the graph do the merge: when parallel nodes write to output keys and the graph is streamed,
+// their streams are merged into one stream of maps, one key per chunk.
+func parallelNodes(ctx context.Context, cm model.ChatModel) {
+	logs.Infof("=== parallel nodes ===")
+
+	g := compose.NewGraph[map[string]any, map[string]any]()
+	for key, system := range map[string]string{
+		"pros": "List 2 advantages of the proposal, one line each.",
+		"cons": "List 2 drawbacks of the proposal, one line each.",
+	} {
+		_ = g.AddChatTemplateNode(key+"_template", prompt.FromMessages(schema.FString,
+			schema.SystemMessage(system),
+			schema.UserMessage("Proposal: {proposal}"),
+		))
+		_ = g.AddChatModelNode(key, cm, compose.WithOutputKey(key))
+		_ = g.AddEdge(compose.START, key+"_template")
+		_ = g.AddEdge(key+"_template", key)
+		_ = g.AddEdge(key, compose.END)
+	}
+
+	r, err := g.Compile(ctx, compose.WithNodeTriggerMode(compose.AllPredecessor))
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	sr, err := r.Stream(ctx, map[string]any{"proposal": "Replace the nightly batch job with an event stream."})
+	if err != nil {
+		logs.Fatalf("Stream failed, err=%v", err)
+	}
+
+	out, err := concatByKey(sr)
+	if err != nil {
+		logs.Fatalf("concatByKey failed, err=%v", err)
+	}
+	for _, key := range []string{"pros", "cons"} {
+		logs.Infof("%s:\n%s", key, out[key].Content)
+	}
+}
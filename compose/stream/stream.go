@@ -0,0 +1,138 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+var errEmptyStream = errors.New("stream ended without any chunk")
+
+// fanOut copies sr once per consumer and runs the consumers concurrently, each one on its own copy.
+// A consumer may stop reading early, the others still get every chunk: the copies are independent,
+// and the original stream is only closed once every copy is. fanOut closes every copy after its consumer returns,
+// so consumers do not have to, and returns the errors of all consumers joined.
+//
+// sr must not be used after fanOut, Copy makes it unusable.
+func fanOut(sr *schema.StreamReader[*schema.Message], consumers ...func(*schema.StreamReader[*schema.Message]) error) error {
+	if len(consumers) == 0 {
+		sr.Close()
+		return nil
+	}
+
+	copies := sr.Copy(len(consumers))
+	errs := make([]error, len(consumers))
+
+	var wg sync.WaitGroup
+	for i, consume := range consumers {
+		wg.Add(1)
+		go func(i int, consume func(*schema.StreamReader[*schema.Message]) error) {
+			defer wg.Done()
+			defer copies[i].Close()
+			errs[i] = consume(copies[i])
+		}(i, consume)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// concatStream reads sr to the end and concatenates the chunks back into one message, then closes sr.
+// An error received from the stream is returned as is, and a stream without any chunk is an error,
+// since a chat model always sends at least one.
+func concatStream(sr *schema.StreamReader[*schema.Message]) (*schema.Message, error) {
+	defer sr.Close()
+
+	var chunks []*schema.Message
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+
+	if len(chunks) == 0 {
+		return nil, errEmptyStream
+	}
+	return schema.ConcatMessages(chunks)
+}
+
+// taggedChunk is a chunk of a merged stream, with the name of the stream it came from.
+type taggedChunk struct {
+	Source string
+	Chunk  *schema.Message
+}
+
+// mergeTagged merges the streams into one, in the order the chunks arrive.
+// schema.MergeStreamReaders alone loses where a chunk comes from, so every stream is converted to tag its chunks first.
+// After the merged stream is closed, each stream is closed as soon as it delivers its next chunk,
+// so a sender keeps going for at most one chunk before its Send reports closed.
+func mergeTagged(streams map[string]*schema.StreamReader[*schema.Message]) *schema.StreamReader[*taggedChunk] {
+	srs := make([]*schema.StreamReader[*taggedChunk], 0, len(streams))
+	for name, sr := range streams {
+		name := name
+		srs = append(srs, schema.StreamReaderWithConvert(sr, func(msg *schema.Message) (*taggedChunk, error) {
+			return &taggedChunk{Source: name, Chunk: msg}, nil
+		}))
+	}
+	return schema.MergeStreamReaders(srs)
+}
+
+// concatByKey reads the stream of a graph whose parallel nodes write to output keys: eino merges the streams of
+// those nodes into one stream of maps, each chunk holding the chunk of one node under its key.
+// It concatenates the chunks of every key into a full message, then closes sr.
+func concatByKey(sr *schema.StreamReader[map[string]any]) (map[string]*schema.Message, error) {
+	defer sr.Close()
+
+	chunks := map[string][]*schema.Message{}
+	for {
+		m, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for key, v := range m {
+			msg, ok := v.(*schema.Message)
+			if !ok {
+				return nil, fmt.Errorf("unexpected chunk type %T under key %s", v, key)
+			}
+			chunks[key] = append(chunks[key], msg)
+		}
+	}
+
+	out := make(map[string]*schema.Message, len(chunks))
+	for key, msgs := range chunks {
+		msg, err := schema.ConcatMessages(msgs)
+		if err != nil {
+			return nil, fmt.Errorf("concat chunks of %s failed: %w", key, err)
+		}
+		out[key] = msg
+	}
+	return out, nil
+}
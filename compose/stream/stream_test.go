@@ -0,0 +1,195 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func chunks(contents ...string) []*schema.Message {
+	msgs := make([]*schema.Message, len(contents))
+	for i, c := range contents {
+		msgs[i] = &schema.Message{Role: schema.Assistant, Content: c}
+	}
+	return msgs
+}
+
+// endlessWriter sends chunks until the reader side is closed, and reports how many it sent once it stops.
+func endlessWriter(sw *schema.StreamWriter[*schema.Message]) <-chan int {
+	done := make(chan int, 1)
+	go func() {
+		defer sw.Close()
+		for i := 0; i < 100000; i++ {
+			if closed := sw.Send(&schema.Message{Role: schema.Assistant, Content: "x"}, nil); closed {
+				done <- i
+				return
+			}
+		}
+		done <- -1
+	}()
+	return done
+}
+
+func waitStopped(t *testing.T, done <-chan int) {
+	t.Helper()
+	select {
+	case n := <-done:
+		assert.GreaterOrEqual(t, n, 0, "the writer was never told the stream is closed")
+	case <-time.After(5 * time.Second):
+		t.Fatal("the writer is still blocked, the stream was not closed")
+	}
+}
+
+func TestFanOutEarlyClose(t *testing.T) {
+	var (
+		first string
+		full  *schema.Message
+	)
+	err := fanOut(schema.StreamReaderFromArray(chunks("a", "b", "c", "d", "e")),
+		func(sr *schema.StreamReader[*schema.Message]) error {
+			chunk, err := sr.Recv()
+			first = chunk.Content
+			return err
+		},
+		func(sr *schema.StreamReader[*schema.Message]) error {
+			msg, err := concatStream(sr)
+			full = msg
+			return err
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a", first)
+	// the first consumer stopped after one chunk, the second one still got all of them
+	assert.Equal(t, "abcde", full.Content)
+}
+
+func TestFanOutAllClosedEarly(t *testing.T) {
+	sr, sw := schema.Pipe[*schema.Message](0)
+	done := endlessWriter(sw)
+
+	readOne := func(sr *schema.StreamReader[*schema.Message]) error {
+		_, err := sr.Recv()
+		return err
+	}
+	assert.NoError(t, fanOut(sr, readOne, readOne))
+
+	// once every copy is closed, the original stream is closed and the sender stops
+	waitStopped(t, done)
+}
+
+func TestFanOutErrors(t *testing.T) {
+	errA, errB := errors.New("a failed"), errors.New("b failed")
+
+	err := fanOut(schema.StreamReaderFromArray(chunks("a")),
+		func(*schema.StreamReader[*schema.Message]) error { return errA },
+		func(*schema.StreamReader[*schema.Message]) error { return nil },
+		func(*schema.StreamReader[*schema.Message]) error { return errB },
+	)
+
+	assert.ErrorIs(t, err, errA)
+	assert.ErrorIs(t, err, errB)
+}
+
+func TestConcatStream(t *testing.T) {
+	t.Run("full", func(t *testing.T) {
+		msg, err := concatStream(schema.StreamReaderFromArray(chunks("Hello", ", ", "world")))
+		assert.NoError(t, err)
+		assert.Equal(t, "Hello, world", msg.Content)
+		assert.Equal(t, schema.Assistant, msg.Role)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		sr, sw := schema.Pipe[*schema.Message](1)
+		sw.Close()
+
+		_, err := concatStream(sr)
+		assert.ErrorIs(t, err, errEmptyStream)
+	})
+
+	t.Run("error in the middle", func(t *testing.T) {
+		errBroken := errors.New("connection reset")
+		sr, sw := schema.Pipe[*schema.Message](3)
+		sw.Send(&schema.Message{Role: schema.Assistant, Content: "partial"}, nil)
+		sw.Send(nil, errBroken)
+		sw.Close()
+
+		msg, err := concatStream(sr)
+		assert.ErrorIs(t, err, errBroken)
+		assert.Nil(t, msg)
+	})
+}
+
+func TestMergeTagged(t *testing.T) {
+	merged := mergeTagged(map[string]*schema.StreamReader[*schema.Message]{
+		"left":  schema.StreamReaderFromArray(chunks("1", "2", "3")),
+		"right": schema.StreamReaderFromArray(chunks("a", "b")),
+	})
+	defer merged.Close()
+
+	got := map[string]string{}
+	for {
+		c, err := merged.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+		got[c.Source] += c.Chunk.Content
+	}
+
+	// chunks of different sources interleave, but each source keeps its own order
+	assert.Equal(t, map[string]string{"left": "123", "right": "ab"}, got)
+}
+
+func TestMergeTaggedEarlyClose(t *testing.T) {
+	left, leftW := schema.Pipe[*schema.Message](0)
+	right, rightW := schema.Pipe[*schema.Message](0)
+	leftDone, rightDone := endlessWriter(leftW), endlessWriter(rightW)
+
+	merged := mergeTagged(map[string]*schema.StreamReader[*schema.Message]{"left": left, "right": right})
+	_, err := merged.Recv()
+	assert.NoError(t, err)
+	merged.Close()
+
+	// both senders must notice, otherwise their goroutines leak
+	waitStopped(t, leftDone)
+	waitStopped(t, rightDone)
+}
+
+func TestConcatByKey(t *testing.T) {
+	t.Run("interleaved", func(t *testing.T) {
+		out, err := concatByKey(schema.StreamReaderFromArray([]map[string]any{
+			{"pros": &schema.Message{Role: schema.Assistant, Content: "fast"}},
+			{"cons": &schema.Message{Role: schema.Assistant, Content: "complex"}},
+			{"pros": &schema.Message{Content: "er"}},
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, "faster", out["pros"].Content)
+		assert.Equal(t, "complex", out["cons"].Content)
+	})
+
+	t.Run("unexpected type", func(t *testing.T) {
+		_, err := concatByKey(schema.StreamReaderFromArray([]map[string]any{{"pros": "not a message"}}))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const (
+	nodeClassify  = "classify"
+	nodeLookup    = "lookup"
+	nodeDraftVars = "draft_vars"
+	nodeTemplate  = "draft_template"
+	nodeDraft     = "draft_model"
+)
+
+// Ticket is the input of the workflow.
+type Ticket struct {
+	CustomerEmail string
+	Subject       string
+	Body          string
+}
+
+// Triage is the output of the workflow, every field is filled by a different node.
+type Triage struct {
+	Category string
+	Urgency  string
+	Plan     string
+	SLAHours int
+	Reply    string
+}
+
+// ClassifyInput only needs the body of the ticket, not the whole ticket.
+type ClassifyInput struct {
+	Text string
+}
+
+type Classification struct {
+	Category string `json:"category"`
+	Urgency  string `json:"urgency"`
+}
+
+// LookupInput only needs the email of the customer.
+type LookupInput struct {
+	Email string
+}
+
+type Account struct {
+	Plan     string
+	SLAHours int
+}
+
+// DraftInput collects fields from three different predecessors: the ticket, the classification and the account.
+type DraftInput struct {
+	Subject  string
+	Body     string
+	Category string
+	Plan     string
+	SLAHours int
+}
+
+// accounts stands in for a CRM lookup.
+var accounts = map[string]*Account{
+	"ops@acme.example": {Plan: "Enterprise", SLAHours: 4},
+	"kim@example.com":  {Plan: "Free", SLAHours: 72},
+}
+
+const classifyPrompt = `Classify the support ticket below.
+Reply with JSON only: {"category": "billing" | "technical" | "account", "urgency": "low" | "normal" | "high"}
+
+Ticket:
+%s`
+
+const draftPrompt = `You are a support agent. Write a short first reply to the customer.
+The ticket is about {category}, the customer is on the {plan} plan and we answer within {sla_hours} hours.
+Do not promise anything beyond that.`
+
+// This example builds a ticket triage with the Workflow API:
+//
+//	START.Body          -> classify.Text
+//	START.CustomerEmail -> lookup.Email
+//	START.Subject, START.Body, classify.Category, lookup.Plan, lookup.SLAHours -> draft_vars
+//	draft_vars -> draft_template -> draft_model
+//	classify.Category, classify.Urgency, lookup.Plan, lookup.SLAHours, draft_model.Content -> END
+//
+// In a Chain or a Graph, an edge passes the whole output of a node to the next one, so every node has to accept
+// exactly what its predecessor returns, and data needed further down has to be carried along or parked in the state.
+// In a Workflow, an edge carries fields: AddInput(from, MapFields("A", "B")) sets field B of the input from field A
+// of the output of from, and one node can take fields from several predecessors, START included.
+// Nodes run as soon as all their predecessors are done, so classify and lookup run in parallel here.
+//
+// Rule of thumb: use a Chain for a straight pipeline, a Graph when you need branches or loops,
+// and a Workflow when nodes exchange structs and each node needs a different part of them.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	cm := models.MustChatModel(ctx, models.WithTemperature(0))
+
+	wf := compose.NewWorkflow[*Ticket, *Triage]()
+
+	// MapFields picks one field of the predecessor output and sets one field of this node's input
+	wf.AddLambdaNode(nodeClassify, compose.InvokableLambda(func(ctx context.Context, in ClassifyInput) (*Classification, error) {
+		return classify(ctx, cm, in.Text)
+	})).AddInput(compose.START, compose.MapFields("Body", "Text"))
+
+	wf.AddLambdaNode(nodeLookup, compose.InvokableLambda(func(ctx context.Context, in LookupInput) (*Account, error) {
+		if acc, ok := accounts[strings.ToLower(in.Email)]; ok {
+			return acc, nil
+		}
+		return &Account{Plan: "Free", SLAHours: 72}, nil
+	})).AddInput(compose.START, compose.MapFields("CustomerEmail", "Email"))
+
+	// fields from three predecessors are merged into one DraftInput, the fields not mapped keep their zero value
+	wf.AddLambdaNode(nodeDraftVars, compose.InvokableLambda(func(ctx context.Context, in DraftInput) (map[string]any, error) {
+		return map[string]any{
+			"category":  in.Category,
+			"plan":      in.Plan,
+			"sla_hours": in.SLAHours,
+			"subject":   in.Subject,
+			"body":      in.Body,
+		}, nil
+	})).
+		AddInput(compose.START, compose.MapFields("Subject", "Subject"), compose.MapFields("Body", "Body")).
+		AddInput(nodeClassify, compose.MapFields("Category", "Category")).
+		AddInput(nodeLookup, compose.MapFields("Plan", "Plan"), compose.MapFields("SLAHours", "SLAHours"))
+
+	// without mappings, AddInput passes the whole output, just like an edge in a Graph
+	wf.AddChatTemplateNode(nodeTemplate, prompt.FromMessages(schema.FString,
+		schema.SystemMessage(draftPrompt),
+		schema.UserMessage("Subject: {subject}\n\n{body}"),
+	)).AddInput(nodeDraftVars)
+	wf.AddChatModelNode(nodeDraft, cm).AddInput(nodeTemplate)
+
+	// the output struct is assembled from several nodes as well, *schema.Message is a struct so its Content can be mapped
+	wf.AddEnd(nodeClassify, compose.MapFields("Category", "Category"), compose.MapFields("Urgency", "Urgency")).
+		AddEnd(nodeLookup, compose.MapFields("Plan", "Plan"), compose.MapFields("SLAHours", "SLAHours")).
+		AddEnd(nodeDraft, compose.MapFields("Content", "Reply"))
+
+	r, err := wf.Compile(ctx, compose.WithGraphName("ticket_triage"))
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	tickets := []*Ticket{
+		{
+			CustomerEmail: "ops@acme.example",
+			Subject:       "Production API returns 500 since this morning",
+			Body:          "All calls to /v1/orders fail with HTTP 500 since 08:00 UTC, our checkout is down.",
+		},
+		{
+			CustomerEmail: "kim@example.com",
+			Subject:       "Charged twice",
+			Body:          "I see two charges of 9 USD on my card for March, can you refund one?",
+		},
+	}
+
+	for _, t := range tickets {
+		out, err := r.Invoke(ctx, t)
+		if err != nil {
+			logs.Fatalf("Invoke failed, err=%v", err)
+		}
+
+		logs.Infof("ticket %q from %s", t.Subject, t.CustomerEmail)
+		logs.Infof("category=%s, urgency=%s, plan=%s, sla=%dh", out.Category, out.Urgency, out.Plan, out.SLAHours)
+		logs.Tokenf("%s\n\n", out.Reply)
+	}
+}
+
+func classify(ctx context.Context, cm model.ChatModel, text string) (*Classification, error) {
+	msg, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage(fmt.Sprintf(classifyPrompt, text))})
+	if err != nil {
+		return nil, err
+	}
+
+	// some models wrap JSON in a markdown code block even when asked not to
+	content := strings.TrimSpace(msg.Content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+
+	c := &Classification{}
+	if err = json.Unmarshal([]byte(strings.TrimSpace(content)), c); err != nil {
+		return nil, fmt.Errorf("unexpected classification %q: %w", msg.Content, err)
+	}
+	return c, nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// Report is the result of a suite.
+type Report struct {
+	Suite     string        `json:"suite"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Tasks     []*TaskResult `json:"tasks"`
+}
+
+// Passed returns the number of tasks passed.
+func (r *Report) Passed() int {
+	n := 0
+	for _, t := range r.Tasks {
+		if t.Passed {
+			n++
+		}
+	}
+	return n
+}
+
+// OK reports whether every task passed.
+func (r *Report) OK() bool {
+	return r.Passed() == len(r.Tasks)
+}
+
+// WriteText writes a line per task, with the failed checks of the failed ones, and the totals.
+func (r *Report) WriteText(w io.Writer) error {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("suite %s\n", r.Suite))
+	for _, t := range r.Tasks {
+		sb.WriteString(FormatTask(t))
+	}
+	sb.WriteString(fmt.Sprintf("%d/%d tasks passed in %s\n", r.Passed(), len(r.Tasks), r.Duration.Round(time.Millisecond)))
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// FormatTask formats the result of a task as in WriteText.
+func FormatTask(t *TaskResult) string {
+	sb := strings.Builder{}
+	status := "PASS"
+	if !t.Passed {
+		status = "FAIL"
+	}
+	passedRuns := 0
+	for _, rr := range t.Runs {
+		if rr.Passed() {
+			passedRuns++
+		}
+	}
+	sb.WriteString(fmt.Sprintf("  %s  %s", status, t.Task))
+	if len(t.Runs) > 1 {
+		sb.WriteString(fmt.Sprintf(" (%d/%d runs)", passedRuns, len(t.Runs)))
+	}
+	sb.WriteString("\n")
+
+	for i, rr := range t.Runs {
+		if rr.Passed() {
+			continue
+		}
+		prefix := "        "
+		if len(t.Runs) > 1 {
+			prefix = fmt.Sprintf("        run %d: ", i+1)
+		}
+		if rr.Error != "" {
+			sb.WriteString(fmt.Sprintf("%serror: %s\n", prefix, rr.Error))
+			continue
+		}
+		for _, f := range rr.Failures {
+			sb.WriteString(fmt.Sprintf("%s%s\n", prefix, f))
+		}
+	}
+	return sb.String()
+}
+
+// WriteJSON writes the whole report, answers and tool calls included, to path.
+func (r *Report) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
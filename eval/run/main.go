@@ -0,0 +1,173 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent"
+	"github.com/cloudwego/eino/flow/agent/react"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/eval"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/progress"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const persona = `You are a travel assistant. Use the tools for weather, prices and flights, never guess them.
+You cannot book anything: give the options and tell the user how to book.`
+
+// This example evaluates a ReAct travel assistant against eval/suites/travel.yaml and exits with status 1 if a task
+// fails, so that it can run in CI after a change of prompt, model or tools:
+//
+//	go run ./eval/run -suite eval/suites/travel.yaml -json .cache/eval.json
+//
+// Another agent is evaluated the same way, by wrapping it in an eval.Target passing the callback handler to its run.
+func main() {
+	suitePath := flag.String("suite", "eval/suites/travel.yaml", "path of the suite")
+	jsonOut := flag.String("json", "", "write the full report, answers and tool calls included, to this file")
+	timeout := flag.Duration("timeout", 2*time.Minute, "timeout of every run of a task")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	suite, err := eval.LoadSuite(*suitePath)
+	if err != nil {
+		logs.Fatalf("LoadSuite failed, err=%v", err)
+	}
+
+	ra, err := newAgent(ctx)
+	if err != nil {
+		logs.Fatalf("newAgent failed, err=%v", err)
+	}
+
+	target := func(ctx context.Context, input []*schema.Message, handler callbacks.Handler) (*schema.Message, error) {
+		return ra.Generate(ctx, input, agent.WithComposeOptions(compose.WithCallbacks(handler)))
+	}
+
+	bar := progress.New("tasks", len(suite.Tasks), nil)
+	report, err := eval.Run(ctx, suite, &eval.Config{
+		Target:  target,
+		Timeout: *timeout,
+		OnResult: func(r *eval.TaskResult) {
+			bar.Above(func() { logs.Tokenf("%s", eval.FormatTask(r)) })
+			bar.Add(1)
+		},
+	})
+	bar.Done()
+	if err != nil {
+		logs.Fatalf("Run failed, err=%v", err)
+	}
+
+	logs.Infof("%d/%d tasks passed in %s", report.Passed(), len(report.Tasks), report.Duration.Round(time.Millisecond))
+	if *jsonOut != "" {
+		if err = report.WriteJSON(*jsonOut); err != nil {
+			logs.Errorf("WriteJSON failed, err=%v", err)
+		}
+	}
+	if !report.OK() {
+		os.Exit(1)
+	}
+}
+
+func newAgent(ctx context.Context) (*react.Agent, error) {
+	// evaluations compare runs, the less sampling noise the better
+	cm, err := models.NewChatModel(ctx, models.WithTemperature(0))
+	if err != nil {
+		return nil, err
+	}
+	tools, err := newTools()
+	if err != nil {
+		return nil, err
+	}
+
+	return react.NewAgent(ctx, &react.AgentConfig{
+		Model:       cm,
+		ToolsConfig: compose.ToolsNodeConfig{Tools: tools},
+		MessageModifier: func(ctx context.Context, input []*schema.Message) []*schema.Message {
+			return append([]*schema.Message{schema.SystemMessage(persona)}, input...)
+		},
+		MaxStep: 12,
+	})
+}
+
+type weatherInput struct {
+	City string `json:"city"`
+}
+
+type convertInput struct {
+	Amount float64 `json:"amount"`
+	From   string  `json:"from" jsonschema:"description=ISO currency code such as EUR"`
+	To     string  `json:"to" jsonschema:"description=ISO currency code such as USD"`
+}
+
+type flightInput struct {
+	From string `json:"from" jsonschema:"description=IATA code of the departure airport"`
+	To   string `json:"to" jsonschema:"description=IATA code of the arrival airport"`
+	Date string `json:"date" jsonschema:"description=YYYY-MM-DD"`
+}
+
+type flight struct {
+	ID       string  `json:"id"`
+	Departs  string  `json:"departs"`
+	Arrives  string  `json:"arrives"`
+	PriceEUR float64 `json:"price_eur"`
+}
+
+// newTools returns tools with fixed answers, so that the suite can check the numbers in the answers.
+func newTools() ([]tool.BaseTool, error) {
+	weather, err := utils.InferTool("get_weather", "get the current weather of a city",
+		func(ctx context.Context, in *weatherInput) (string, error) {
+			return in.City + ": 21°C, sunny, wind 12 km/h", nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	rates := map[string]float64{"EUR": 1, "USD": 1.1, "GBP": 0.85, "JPY": 160}
+	convert, err := utils.InferTool("convert_currency", "convert an amount of money at today's rate",
+		func(ctx context.Context, in *convertInput) (float64, error) {
+			from, to := rates[in.From], rates[in.To]
+			if from == 0 || to == 0 {
+				return 0, nil
+			}
+			return in.Amount / from * to, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	flights, err := utils.InferTool("search_flights", "search flights between two airports on a date",
+		func(ctx context.Context, in *flightInput) ([]*flight, error) {
+			return []*flight{
+				{ID: "AF276", Departs: in.Date + " 13:20", Arrives: "+1 08:45", PriceEUR: 812},
+				{ID: "NH216", Departs: in.Date + " 19:55", Arrives: "+1 15:30", PriceEUR: 879},
+			}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return []tool.BaseTool{weather, convert, flights}, nil
+}
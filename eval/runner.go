@@ -0,0 +1,207 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	template "github.com/cloudwego/eino/utils/callbacks"
+)
+
+// Target runs the agent under evaluation on the input. It must pass handler to the run, with compose.WithCallbacks
+// for a graph, or agent.WithComposeOptions(compose.WithCallbacks(handler)) for the ReAct agent: the tool calls are
+// recorded from its callbacks.
+type Target func(ctx context.Context, input []*schema.Message, handler callbacks.Handler) (*schema.Message, error)
+
+// Config configures Run.
+type Config struct {
+	Target Target
+	// Timeout bounds every run of a task, default 2 minutes.
+	Timeout time.Duration
+	// OnResult is called after every task, to print the progress.
+	OnResult func(r *TaskResult)
+}
+
+// ToolCall is a call of a tool made by the agent.
+type ToolCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// RunResult is one run of a task.
+type RunResult struct {
+	Answer     string        `json:"answer"`
+	ToolCalls  []ToolCall    `json:"tool_calls"`
+	ModelCalls int           `json:"model_calls"`
+	Duration   time.Duration `json:"duration"`
+	// Error is the error of the agent, the run fails without checks.
+	Error string `json:"error,omitempty"`
+	// Failures are the checks which failed.
+	Failures []string `json:"failures,omitempty"`
+}
+
+// Passed reports whether the run had no error and no failed check.
+func (r *RunResult) Passed() bool {
+	return r.Error == "" && len(r.Failures) == 0
+}
+
+// TaskResult is the result of every run of a task.
+type TaskResult struct {
+	Task   string       `json:"task"`
+	Passed bool         `json:"passed"`
+	Runs   []*RunResult `json:"runs"`
+}
+
+// Run runs every task of the suite one after the other, and checks the expectations. An error of the agent
+// fails the task, not the suite; Run returns an error only if ctx is done.
+func Run(ctx context.Context, suite *Suite, config *Config) (*Report, error) {
+	if config == nil || config.Target == nil {
+		return nil, fmt.Errorf("eval needs a target")
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+
+	report := &Report{Suite: suite.Name, StartedAt: time.Now()}
+	for _, t := range suite.Tasks {
+		tr := &TaskResult{Task: t.Name, Passed: true}
+		for i := 0; i < t.Runs; i++ {
+			if err := ctx.Err(); err != nil {
+				return report, err
+			}
+			rr := runOnce(ctx, config.Target, t, timeout)
+			tr.Runs = append(tr.Runs, rr)
+			tr.Passed = tr.Passed && rr.Passed()
+		}
+		report.Tasks = append(report.Tasks, tr)
+		if config.OnResult != nil {
+			config.OnResult(tr)
+		}
+	}
+	report.Duration = time.Since(report.StartedAt)
+	return report, nil
+}
+
+func runOnce(ctx context.Context, target Target, t *Task, timeout time.Duration) *RunResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rec := &recorder{}
+	var input []*schema.Message
+	if t.System != "" {
+		input = append(input, schema.SystemMessage(t.System))
+	}
+	input = append(input, schema.UserMessage(t.Input))
+
+	start := time.Now()
+	out, err := target(ctx, input, rec.handler())
+
+	rr := &RunResult{Duration: time.Since(start)}
+	rr.ToolCalls, rr.ModelCalls = rec.result()
+	if err != nil {
+		rr.Error = err.Error()
+		return rr
+	}
+	rr.Answer = out.Content
+	rr.Failures = check(&t.Expect, rr)
+	return rr
+}
+
+// recorder collects the calls of one run. The callbacks of the tools of a ToolsNode run concurrently.
+type recorder struct {
+	mu         sync.Mutex
+	toolCalls  []ToolCall
+	modelCalls int
+}
+
+func (r *recorder) handler() callbacks.Handler {
+	return template.NewHandlerHelper().
+		ChatModel(&template.ModelCallbackHandler{
+			OnStart: func(ctx context.Context, info *callbacks.RunInfo, input *model.CallbackInput) context.Context {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				r.modelCalls++
+				return ctx
+			},
+		}).
+		Tool(&template.ToolCallbackHandler{
+			OnStart: func(ctx context.Context, info *callbacks.RunInfo, input *tool.CallbackInput) context.Context {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				r.toolCalls = append(r.toolCalls, ToolCall{Name: info.Name, Arguments: input.ArgumentsInJSON})
+				return ctx
+			},
+		}).
+		Handler()
+}
+
+func (r *recorder) result() ([]ToolCall, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ToolCall(nil), r.toolCalls...), r.modelCalls
+}
+
+// check returns a description of every failed expectation.
+func check(e *Expect, rr *RunResult) []string {
+	var failures []string
+
+	called := make(map[string]int, len(rr.ToolCalls))
+	for _, c := range rr.ToolCalls {
+		called[c.Name]++
+	}
+	for _, name := range e.ToolsCalled {
+		if called[name] == 0 {
+			failures = append(failures, fmt.Sprintf("tool %s was not called", name))
+		}
+	}
+	for _, name := range e.ToolsNotCalled {
+		if n := called[name]; n > 0 {
+			failures = append(failures, fmt.Sprintf("tool %s was called %d time(s)", name, n))
+		}
+	}
+	if e.NoToolCalls && len(rr.ToolCalls) > 0 {
+		failures = append(failures, fmt.Sprintf("expected no tool call, got %d", len(rr.ToolCalls)))
+	}
+	if e.MaxToolCalls > 0 && len(rr.ToolCalls) > e.MaxToolCalls {
+		failures = append(failures, fmt.Sprintf("%d tool calls, at most %d expected", len(rr.ToolCalls), e.MaxToolCalls))
+	}
+
+	answer := strings.ToLower(rr.Answer)
+	for _, s := range e.AnswerContains {
+		if !strings.Contains(answer, strings.ToLower(s)) {
+			failures = append(failures, fmt.Sprintf("answer does not contain %q", s))
+		}
+	}
+	for _, s := range e.AnswerNotContains {
+		if strings.Contains(answer, strings.ToLower(s)) {
+			failures = append(failures, fmt.Sprintf("answer contains %q", s))
+		}
+	}
+	if e.answerRegexp != nil && !e.answerRegexp.MatchString(rr.Answer) {
+		failures = append(failures, fmt.Sprintf("answer does not match %s", e.AnswerMatches))
+	}
+	return failures
+}
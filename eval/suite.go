@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package eval runs an agent against a suite of tasks written in YAML and checks its behavior: which tools it
+// called, and what the answer says. The report tells which tasks pass, so that a change of prompt, model or tool
+// can be checked against the behaviors the examples are expected to have.
+//
+// A suite looks like:
+//
+//	name: shop assistant
+//	tasks:
+//	  - name: checks the stock before recommending
+//	    input: I need a silent keyboard, what do you have in stock?
+//	    expect:
+//	      tools_called: [search_products, check_stock]
+//	      answer_contains: [KB-310]
+//	      max_tool_calls: 6
+//
+// Answers of models vary, the checks should be about what must be true of every good answer, case is ignored.
+package eval
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Suite is a named list of tasks.
+type Suite struct {
+	Name  string  `yaml:"name"`
+	Tasks []*Task `yaml:"tasks"`
+}
+
+// Task is one input to the agent and the behavior expected from it.
+type Task struct {
+	Name string `yaml:"name"`
+	// System is the system prompt of the task, the agent may have its own.
+	System string `yaml:"system,omitempty"`
+	Input  string `yaml:"input"`
+	Expect Expect `yaml:"expect"`
+	// Runs is the number of times the task is run, default 1. A task passes if every run passes: a behavior seen
+	// in 2 runs out of 3 is a flaky one.
+	Runs int `yaml:"runs,omitempty"`
+}
+
+// Expect lists the checks of a task, the empty ones are skipped.
+type Expect struct {
+	// ToolsCalled must all be called at least once, in any order.
+	ToolsCalled []string `yaml:"tools_called,omitempty"`
+	// ToolsNotCalled must not be called.
+	ToolsNotCalled []string `yaml:"tools_not_called,omitempty"`
+	// NoToolCalls requires an answer without any tool call.
+	NoToolCalls bool `yaml:"no_tool_calls,omitempty"`
+	// MaxToolCalls bounds the number of tool calls, 0 means no bound.
+	MaxToolCalls int `yaml:"max_tool_calls,omitempty"`
+	// AnswerContains must all be in the answer, case insensitive.
+	AnswerContains []string `yaml:"answer_contains,omitempty"`
+	// AnswerNotContains must not be in the answer, case insensitive.
+	AnswerNotContains []string `yaml:"answer_not_contains,omitempty"`
+	// AnswerMatches is a regular expression the answer must match.
+	AnswerMatches string `yaml:"answer_matches,omitempty"`
+
+	answerRegexp *regexp.Regexp
+}
+
+// LoadSuite reads and checks a suite.
+func LoadSuite(path string) (*Suite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Suite{}
+	if err = yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parse suite %s failed: %w", path, err)
+	}
+	if s.Name == "" {
+		s.Name = path
+	}
+	if len(s.Tasks) == 0 {
+		return nil, fmt.Errorf("suite %s has no tasks", path)
+	}
+
+	names := make(map[string]bool, len(s.Tasks))
+	for i, t := range s.Tasks {
+		if t.Name == "" {
+			t.Name = fmt.Sprintf("task %d", i+1)
+		}
+		if names[t.Name] {
+			return nil, fmt.Errorf("suite %s: duplicate task name %q", path, t.Name)
+		}
+		names[t.Name] = true
+		if t.Input == "" {
+			return nil, fmt.Errorf("suite %s: task %q has no input", path, t.Name)
+		}
+		if t.Runs <= 0 {
+			t.Runs = 1
+		}
+		if t.Expect.AnswerMatches != "" {
+			if t.Expect.answerRegexp, err = regexp.Compile(t.Expect.AnswerMatches); err != nil {
+				return nil, fmt.Errorf("suite %s: task %q: invalid answer_matches: %w", path, t.Name, err)
+			}
+		}
+	}
+	return s, nil
+}
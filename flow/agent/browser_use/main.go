@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/cloudwego/eino-ext/components/tool/browseruse"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent"
+	"github.com/cloudwego/eino/flow/agent/react"
+	"github.com/cloudwego/eino/schema"
+	template "github.com/cloudwego/eino/utils/callbacks"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// logLimit is the number of characters of a tool result printed, pages are long
+const logLimit = 300
+
+// This example gives a ReAct agent a real browser with the browser-use tool of eino-ext. The tool drives a headless
+// Chrome through one action per call, such as go_to_url, click_element, scroll_down or extract_content, so the agent
+// can navigate a site like a person would: open a page, read it, click a link, and answer about what it saw.
+//
+// It needs Chrome or Chromium installed locally. Run with -headless=false to watch the agent browse.
+func main() {
+	url := flag.String("url", "https://github.com/cloudwego/eino", "the page to start from")
+	question := flag.String("q", "What is this project, what is its latest release and when was it published?", "the question about the page")
+	headless := flag.Bool("headless", true, "run the browser without a window")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	cm := models.MustChatModel(ctx, models.WithTemperature(0))
+
+	browser, err := browseruse.NewBrowserUseTool(ctx, &browseruse.Config{Headless: *headless})
+	if err != nil {
+		logs.Fatalf("NewBrowserUseTool failed, err=%v", err)
+	}
+	// closes the browser, it would outlive the program otherwise, so no logs.Fatalf from here on
+	defer browser.Cleanup()
+
+	persona, err := prompt.MustGet("agent_browser_use_persona").Format(ctx, nil)
+	if err != nil {
+		logs.Errorf("Format failed, err=%v", err)
+		return
+	}
+
+	ra, err := react.NewAgent(ctx, &react.AgentConfig{
+		Model:           cm,
+		ToolsConfig:     compose.ToolsNodeConfig{Tools: []tool.BaseTool{browser}},
+		MessageModifier: react.NewPersonaModifier(persona[0].Content),
+		// every browser action is a tool call, a few pages take a dozen of them
+		MaxStep: 41,
+	})
+	if err != nil {
+		logs.Errorf("NewAgent failed, err=%v", err)
+		return
+	}
+
+	cb := react.BuildAgentCallback(&template.ModelCallbackHandler{}, &template.ToolCallbackHandler{
+		OnStart: func(ctx context.Context, info *callbacks.RunInfo, input *tool.CallbackInput) context.Context {
+			logs.Infof("browser: %s", input.ArgumentsInJSON)
+			return ctx
+		},
+		OnEnd: func(ctx context.Context, info *callbacks.RunInfo, output *tool.CallbackOutput) context.Context {
+			logs.Infof("result: %s", truncate(output.Response, logLimit))
+			return ctx
+		},
+	})
+
+	out, err := ra.Generate(ctx, []*schema.Message{
+		schema.UserMessage(fmt.Sprintf("Start from %s. %s", *url, *question)),
+	}, agent.WithComposeOptions(compose.WithCallbacks(cb)))
+	if err != nil {
+		logs.Errorf("Generate failed, err=%v", err)
+		return
+	}
+
+	logs.Infof("question: %s", *question)
+	logs.Tokenf("%s\n", out.Content)
+}
+
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "..."
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent"
+	"github.com/cloudwego/eino/flow/agent/react"
+	"github.com/cloudwego/eino/schema"
+	template "github.com/cloudwego/eino/utils/callbacks"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+type runCodeInput struct {
+	Language string `json:"language" jsonschema:"description=go or python"`
+	Code     string `json:"code" jsonschema:"description=the whole program. In Go a main package with a main function"`
+}
+
+// This example is a code interpreter: a ReAct agent with a single tool running the code written by the model in a
+// sandbox and returning stdout, stderr and the exit code. Compile errors and crashes are returned to the model as
+// results, not as errors, so the agent reads them and tries again; see sandbox.go for what the sandbox restricts.
+// The code of every run and its result are printed.
+func main() {
+	question := flag.String("q", "", "the question, a few sample questions if empty")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	sb := newSandbox(ctx)
+	if sb.isolateNet == nil {
+		logs.Errorf("unshare is not available, the code will run with network access")
+	}
+
+	runCode, err := utils.InferTool("run_code", "run a Go or Python program and return its exit code, stdout and stderr",
+		func(ctx context.Context, in *runCodeInput) (*runResult, error) {
+			return sb.run(ctx, in.Language, in.Code)
+		})
+	if err != nil {
+		logs.Fatalf("InferTool failed, err=%v", err)
+	}
+
+	cm := models.MustChatModel(ctx, models.WithTemperature(0))
+
+	persona, err := prompt.MustGet("agent_code_interpreter_persona").Format(ctx, nil)
+	if err != nil {
+		logs.Fatalf("Format failed, err=%v", err)
+	}
+
+	ra, err := react.NewAgent(ctx, &react.AgentConfig{
+		Model:           cm,
+		ToolsConfig:     compose.ToolsNodeConfig{Tools: []tool.BaseTool{runCode}},
+		MessageModifier: react.NewPersonaModifier(persona[0].Content),
+		// up to 8 runs, fixing code can take a few rounds
+		MaxStep: 17,
+	})
+	if err != nil {
+		logs.Fatalf("NewAgent failed, err=%v", err)
+	}
+
+	cb := react.BuildAgentCallback(&template.ModelCallbackHandler{}, &template.ToolCallbackHandler{
+		OnStart: func(ctx context.Context, info *callbacks.RunInfo, input *tool.CallbackInput) context.Context {
+			logs.Infof("run_code: %s", input.ArgumentsInJSON)
+			return ctx
+		},
+		OnEnd: func(ctx context.Context, info *callbacks.RunInfo, output *tool.CallbackOutput) context.Context {
+			logs.Infof("result: %s", output.Response)
+			return ctx
+		},
+	})
+
+	questions := []string{
+		"What is the 10001st prime number? Use Go.",
+		"How many Sundays fell on the first of the month during the twentieth century, from 1 Jan 1901 to 31 Dec 2000?",
+		"What are the last ten digits of the sum 1^1 + 2^2 + 3^3 + ... + 1000^1000? Use Python.",
+	}
+	if *question != "" {
+		questions = []string{*question}
+	}
+
+	for _, q := range questions {
+		logs.Infof("question: %s", q)
+		out, err := ra.Generate(ctx, []*schema.Message{schema.UserMessage(q)}, agent.WithComposeOptions(compose.WithCallbacks(cb)))
+		if err != nil {
+			logs.Errorf("Generate failed, err=%v", err)
+			continue
+		}
+		logs.Tokenf("%s\n", out.Content)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+const (
+	langGo     = "go"
+	langPython = "python"
+
+	defaultTimeout   = 10 * time.Second
+	defaultMaxOutput = 8 << 10
+)
+
+// sandbox runs untrusted code written by the model. It is a restricted environment, not a security boundary:
+//
+//   - every run gets a fresh temp dir as working and home directory, removed afterwards
+//   - the environment is reduced to PATH and what the toolchains need, no credentials leak from ours
+//   - compilation and execution are killed after the timeout, and the output is truncated to maxOutput
+//   - on Linux the program runs in a new network namespace with unshare, so it has no network at all.
+//     Where unshare is not available, only the proxy variables point to a dead address, which stops
+//     well-behaved HTTP clients and nothing else.
+//
+// Run it in a container or a VM for anything beyond a demo.
+type sandbox struct {
+	timeout   time.Duration
+	maxOutput int
+	// isolateNet is the command prefix running a program without network, nil when not available
+	isolateNet []string
+	goCache    string
+}
+
+// runResult is what the model gets back from a run.
+type runResult struct {
+	// Stage is "compile" or "run", the step that produced the result.
+	Stage    string `json:"stage"`
+	ExitCode int    `json:"exit_code"`
+	Stdout   string `json:"stdout,omitempty"`
+	Stderr   string `json:"stderr,omitempty"`
+	TimedOut bool   `json:"timed_out,omitempty"`
+}
+
+func newSandbox(ctx context.Context) *sandbox {
+	s := &sandbox{timeout: defaultTimeout, maxOutput: defaultMaxOutput}
+
+	if runtime.GOOS == "linux" {
+		// unprivileged user namespaces can be disabled, check that it works before relying on it
+		prefix := []string{"unshare", "--net", "--map-root-user", "--"}
+		if err := exec.CommandContext(ctx, prefix[0], append(prefix[1:], "true")...).Run(); err == nil {
+			s.isolateNet = prefix
+		}
+	}
+
+	// share the build cache of the user, compiling the standard library for every run is slow
+	if out, err := exec.CommandContext(ctx, "go", "env", "GOCACHE").Output(); err == nil {
+		s.goCache = string(bytes.TrimSpace(out))
+	}
+	return s
+}
+
+// run writes code to a temp dir and runs it. The returned error is for failures of the sandbox itself,
+// failures of the code are reported in the result.
+func (s *sandbox) run(ctx context.Context, lang, code string) (*runResult, error) {
+	dir, err := os.MkdirTemp("", "sandbox-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	switch lang {
+	case langGo:
+		if err = os.WriteFile(filepath.Join(dir, "main.go"), []byte(code), 0o600); err != nil {
+			return nil, err
+		}
+		// build first so that the timeout of the run applies to the program, not to the compiler
+		res, err := s.exec(ctx, dir, "compile", nil, "go", "build", "-o", "prog", "main.go")
+		if err != nil || res.ExitCode != 0 || res.TimedOut {
+			return res, err
+		}
+		return s.exec(ctx, dir, "run", s.isolateNet, filepath.Join(dir, "prog"))
+	case langPython:
+		if err = os.WriteFile(filepath.Join(dir, "main.py"), []byte(code), 0o600); err != nil {
+			return nil, err
+		}
+		return s.exec(ctx, dir, "run", s.isolateNet, "python3", "-I", "main.py")
+	default:
+		return nil, fmt.Errorf("unsupported language %q, use go or python", lang)
+	}
+}
+
+func (s *sandbox) exec(ctx context.Context, dir, stage string, prefix []string, name string, args ...string) (*runResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	argv := append(append(append([]string{}, prefix...), name), args...)
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = dir
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + dir,
+		"TMPDIR=" + dir,
+		"GOCACHE=" + s.goCache,
+		"GOPATH=" + filepath.Join(dir, "gopath"),
+		// stdlib only: no module download, no toolchain switch
+		"GOPROXY=off",
+		"GOTOOLCHAIN=local",
+		"GO111MODULE=off",
+		"HTTP_PROXY=http://127.0.0.1:9",
+		"HTTPS_PROXY=http://127.0.0.1:9",
+	}
+	// do not wait for grandchildren still holding the pipes after the process is killed
+	cmd.WaitDelay = time.Second
+
+	stdout := &limitedBuffer{max: s.maxOutput}
+	stderr := &limitedBuffer{max: s.maxOutput}
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+
+	err := cmd.Run()
+	res := &runResult{Stage: stage, Stdout: stdout.String(), Stderr: stderr.String()}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		res.TimedOut, res.ExitCode = true, -1
+		return res, nil
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		res.ExitCode = exitErr.ExitCode()
+	default:
+		return nil, fmt.Errorf("%s failed: %w", name, err)
+	}
+	return res, nil
+}
+
+// limitedBuffer keeps the first max bytes written to it and drops the rest.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string {
+	if b.truncated {
+		return b.buf.String() + "\n... output truncated"
+	}
+	return b.buf.String()
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+const (
+	confidenceHigh = "high"
+	confidenceLow  = "low"
+)
+
+// articles is the help center of a made-up note taking app
+var articles = []*schema.Document{
+	{ID: "reset-password", Content: "Reset your password: on the login page click 'Forgot password', enter the email of your account " +
+		"and follow the link we send you. The link expires after 30 minutes. If no email arrives, check the spam folder."},
+	{ID: "change-email", Content: "Change the email of your account in Settings > Account > Email. We send a confirmation link to the " +
+		"new address, the change only applies once it is confirmed."},
+	{ID: "plans", Content: "Plans: Free has 3 notebooks and 100 MB of storage. Pro costs 8 USD per month or 80 USD per year, " +
+		"with unlimited notebooks and 20 GB. Team costs 12 USD per user per month and adds shared notebooks and admin controls."},
+	{ID: "cancel", Content: "Cancel a subscription in Settings > Billing > Cancel plan. The plan stays active until the end of the " +
+		"billing period, then the account goes back to Free. Notes are kept, notebooks beyond the Free limit become read-only."},
+	{ID: "refunds", Content: "Refunds: yearly plans can be refunded in full within 14 days of the payment. Monthly plans are not " +
+		"refunded. Refund requests are handled by the billing team, the assistant cannot issue them."},
+	{ID: "sync", Content: "Sync issues: notes sync when the app is online. If a note does not appear on another device, check that " +
+		"both devices use the same account, then use Settings > Sync > Sync now. Conflicting edits are kept as two copies of the note."},
+	{ID: "export", Content: "Export notes in Settings > Data > Export, as Markdown files in a zip or as a single PDF. " +
+		"Attachments are included in the zip export only."},
+	{ID: "offline", Content: "The desktop and mobile apps work offline. Changes made offline sync automatically once the device is online again."},
+}
+
+// kbSearchInput is the input of the search_kb tool.
+type kbSearchInput struct {
+	Query string `json:"query" jsonschema:"description=the question of the customer rephrased as a search query"`
+}
+
+type kbArticle struct {
+	ID      string  `json:"id"`
+	Content string  `json:"content"`
+	Score   float64 `json:"score"`
+}
+
+// kbSearchOutput tells the agent how confident the retrieval is, so that the decision to escalate does not depend
+// only on the model judging the articles itself.
+type kbSearchOutput struct {
+	Confidence string       `json:"confidence"`
+	Articles   []*kbArticle `json:"articles"`
+}
+
+// newKnowledgeBase indexes the articles and returns the search_kb tool. minScore is the similarity of the best article
+// below which the confidence is low, it depends on the embedding model.
+func newKnowledgeBase(ctx context.Context, emb embedding.Embedder, minScore float64) (tool.InvokableTool, error) {
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 3})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = store.Store(ctx, articles); err != nil {
+		return nil, err
+	}
+
+	return utils.InferTool("search_kb", "search the help center articles. Always search before answering a question about the product",
+		func(ctx context.Context, in *kbSearchInput) (*kbSearchOutput, error) {
+			docs, err := store.Retrieve(ctx, in.Query)
+			if err != nil {
+				return nil, err
+			}
+
+			out := &kbSearchOutput{Confidence: confidenceLow}
+			for _, d := range docs {
+				out.Articles = append(out.Articles, &kbArticle{ID: d.ID, Content: d.Content, Score: d.Score()})
+			}
+			// the store returns the best article first
+			if len(docs) > 0 && docs[0].Score() >= minScore {
+				out.Confidence = confidenceHigh
+			}
+			return out, nil
+		})
+}
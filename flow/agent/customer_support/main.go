@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent"
+	"github.com/cloudwego/eino/flow/agent/react"
+	"github.com/cloudwego/eino/schema"
+	template "github.com/cloudwego/eino/utils/callbacks"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example is a customer-support agent mixing RAG with a business action. The agent answers from a small help
+// center indexed in a vector store; the search tool reports a confidence computed from the retrieval score, so that
+// questions the help center does not cover are not answered from the general knowledge of the model but escalated
+// to a human with the escalate_to_human tool, which files a ticket.
+//
+// The three sample customers show an answered question, a question outside the help center, and a refund request.
+func main() {
+	minScore := flag.Float64("min-score", 0.6, "similarity of the best article below which the search is not confident, depends on the embedding model")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("NewEmbedder failed, err=%v", err)
+	}
+	searchKB, err := newKnowledgeBase(ctx, emb, *minScore)
+	if err != nil {
+		logs.Fatalf("newKnowledgeBase failed, err=%v", err)
+	}
+
+	desk := &helpDesk{}
+	cb := react.BuildAgentCallback(&template.ModelCallbackHandler{}, &template.ToolCallbackHandler{
+		OnStart: func(ctx context.Context, info *callbacks.RunInfo, input *tool.CallbackInput) context.Context {
+			logs.Infof("%s: %s", info.Name, input.ArgumentsInJSON)
+			return ctx
+		},
+	})
+
+	conversations := []struct {
+		customer string
+		message  string
+	}{
+		{"ana@example.com", "Hi, I forgot my password and can't log in. What should I do?"},
+		{"li@example.com", "Can I share a single note with someone who doesn't have an account, with a public link?"},
+		{"sam@example.com", "I paid for the yearly Pro plan 5 days ago by mistake, I wanted monthly. I want my money back."},
+	}
+
+	for _, c := range conversations {
+		// the agent is built per customer, the escalation tool files tickets in their name
+		escalate, err := desk.escalateTool(c.customer)
+		if err != nil {
+			logs.Fatalf("escalateTool failed, err=%v", err)
+		}
+		ra, err := newSupportAgent(ctx, []tool.BaseTool{searchKB, escalate})
+		if err != nil {
+			logs.Fatalf("newSupportAgent failed, err=%v", err)
+		}
+
+		logs.Infof("%s: %s", c.customer, c.message)
+		out, err := ra.Generate(ctx, []*schema.Message{schema.UserMessage(c.message)}, agent.WithComposeOptions(compose.WithCallbacks(cb)))
+		if err != nil {
+			logs.Errorf("Generate failed, err=%v", err)
+			continue
+		}
+		logs.Tokenf("%s\n", out.Content)
+	}
+
+	for _, t := range desk.list() {
+		logs.Infof("ticket %s [%s] from %s: %s (reason: %s)", t.ID, t.Priority, t.Customer, t.Summary, t.Reason)
+	}
+}
+
+func newSupportAgent(ctx context.Context, tools []tool.BaseTool) (*react.Agent, error) {
+	cm, err := models.NewChatModel(ctx, models.WithTemperature(0))
+	if err != nil {
+		return nil, err
+	}
+	persona, err := prompt.MustGet("agent_customer_support_persona").Format(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return react.NewAgent(ctx, &react.AgentConfig{
+		Model:           cm,
+		ToolsConfig:     compose.ToolsNodeConfig{Tools: tools},
+		MessageModifier: react.NewPersonaModifier(persona[0].Content),
+		MaxStep:         12,
+	})
+}
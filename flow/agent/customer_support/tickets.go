@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+// Ticket is what a human agent receives when the assistant escalates.
+type Ticket struct {
+	ID       string    `json:"id"`
+	Customer string    `json:"customer"`
+	Priority string    `json:"priority"`
+	Summary  string    `json:"summary"`
+	Reason   string    `json:"reason"`
+	Created  time.Time `json:"created"`
+}
+
+type escalateInput struct {
+	Summary  string `json:"summary" jsonschema:"description=what the customer needs in two sentences for the human agent"`
+	Reason   string `json:"reason" jsonschema:"description=why the assistant could not handle it"`
+	Priority string `json:"priority" jsonschema:"description=low or normal or urgent"`
+}
+
+type escalateOutput struct {
+	TicketID     string `json:"ticket_id"`
+	ResponseTime string `json:"expected_response_time"`
+}
+
+// helpDesk stands for the ticketing system, such as Zendesk or Jira, it only keeps the tickets in memory.
+type helpDesk struct {
+	mu      sync.Mutex
+	tickets []*Ticket
+}
+
+func (h *helpDesk) list() []*Ticket {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]*Ticket(nil), h.tickets...)
+}
+
+// escalateTool returns the escalate_to_human tool, filing tickets for customer.
+func (h *helpDesk) escalateTool(customer string) (tool.InvokableTool, error) {
+	return utils.InferTool("escalate_to_human",
+		"file a ticket for the support team. Use it when the help center does not answer the question, when the customer "+
+			"asks for something only a human can do such as a refund, or when the customer asks for a human",
+		func(ctx context.Context, in *escalateInput) (*escalateOutput, error) {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			t := &Ticket{
+				ID:       fmt.Sprintf("SUP-%d", 1000+len(h.tickets)+1),
+				Customer: customer,
+				Priority: in.Priority,
+				Summary:  in.Summary,
+				Reason:   in.Reason,
+				Created:  time.Now(),
+			}
+			h.tickets = append(h.tickets, t)
+
+			eta := "2 business days"
+			if in.Priority == "urgent" {
+				eta = "4 hours"
+			}
+			return &escalateOutput{TicketID: t.ID, ResponseTime: eta}, nil
+		})
+}
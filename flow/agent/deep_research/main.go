@@ -0,0 +1,80 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/cloudwego/eino-ext/components/tool/duckduckgo"
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-examples/internal/cost"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/tracing"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example is a deep-research agent: it splits a question into search queries, searches and reads the results
+// one query at a time, keeps what it learns as notes with their source in the graph state, queues follow-up queries
+// where the results were thin, and finally writes a markdown report citing its sources.
+//
+// Unlike a ReAct agent, which decides its next search from the whole conversation, the loop here is explicit and the
+// context stays small: each note-taking call only sees the results of one query, and the writer only sees the notes.
+func main() {
+	question := flag.String("q", "How do the main Go LLM application frameworks compare in features and adoption in 2025?", "the research question")
+	maxSearches := flag.Int("max-searches", defaultMaxSearches, "the max number of searches")
+	out := flag.String("out", "", "file to write the report to, printed if empty")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+	// the runs of the agent are exported as spans when OTEL_EXPORTER_OTLP_ENDPOINT is set, e.g. to a local Jaeger
+	shutdown := tracing.MustSetup(ctx, nil)
+	defer shutdown(ctx)
+
+	cm := models.MustChatModel(ctx, models.WithTemperature(0.2))
+
+	search, err := duckduckgo.NewTool(ctx, &duckduckgo.Config{})
+	if err != nil {
+		logs.Fatalf("NewTool failed, err=%v", err)
+	}
+
+	r, err := buildResearcher(ctx, cm, search, *maxSearches)
+	if err != nil {
+		logs.Fatalf("buildResearcher failed, err=%v", err)
+	}
+
+	tracker := cost.NewTracker()
+	report, err := r.Invoke(ctx, *question, compose.WithCallbacks(tracker.Handler()))
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	logs.Infof("%d searches, %d sources", len(report.Searches), len(report.Sources))
+	tracker.Report("usage")
+	if *out == "" {
+		logs.Tokenf("%s", report.Markdown)
+		return
+	}
+	if err = os.WriteFile(*out, []byte(report.Markdown), 0o644); err != nil {
+		logs.Fatalf("write report failed, err=%v", err)
+	}
+	logs.Infof("report written to %s", *out)
+}
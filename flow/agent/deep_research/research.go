@@ -0,0 +1,287 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/tokens"
+)
+
+const (
+	nodePlan   = "plan"
+	nodeSearch = "search"
+	nodeNotes  = "take_notes"
+	nodeWrite  = "write"
+
+	defaultMaxSearches = 8
+	// maxResultTokens bounds the search results given to the note taker
+	maxResultTokens = 1500
+)
+
+// Note is one fact learned during the research.
+type Note struct {
+	Query  string
+	Fact   string
+	Source string
+}
+
+// Report is the output of the research.
+type Report struct {
+	Question string
+	Markdown string
+	Sources  []string
+	Searches []string
+}
+
+// researchState is the working memory of the agent: what is left to search and what was learned so far.
+type researchState struct {
+	question string
+	pending  []string
+	searched []string
+	notes    []*Note
+}
+
+// sources returns the distinct sources of the notes, in the order they were found.
+func (s *researchState) sources() []string {
+	seen := map[string]bool{}
+	var res []string
+	for _, n := range s.notes {
+		if n.Source != "" && !seen[n.Source] {
+			seen[n.Source] = true
+			res = append(res, n.Source)
+		}
+	}
+	return res
+}
+
+type searchPage struct {
+	query   string
+	results string
+}
+
+// buildResearcher wires the research loop:
+//
+//	START -> plan -> search -> take_notes --(queries left and budget left)--> search
+//	                                      +--(done)--> write -> END
+//
+// Every query is searched and read separately, and the note taker can queue follow-up queries, so the research
+// goes deeper where the first results were thin. maxSearches bounds the whole loop.
+func buildResearcher(ctx context.Context, cm model.ChatModel, search tool.InvokableTool, maxSearches int) (compose.Runnable[string, *Report], error) {
+	if maxSearches <= 0 {
+		maxSearches = defaultMaxSearches
+	}
+
+	g := compose.NewGraph[string, *Report](compose.WithGenLocalState(func(ctx context.Context) *researchState {
+		return &researchState{}
+	}))
+
+	_ = g.AddLambdaNode(nodePlan, compose.InvokableLambda(func(ctx context.Context, question string) (string, error) {
+		var plan struct {
+			Queries []string `json:"queries"`
+		}
+		if err := generateJSON(ctx, cm, "agent_deep_research_plan", map[string]any{"question": question}, &plan); err != nil {
+			return "", err
+		}
+		if len(plan.Queries) == 0 {
+			plan.Queries = []string{question}
+		}
+		logs.Infof("plan: %s", strings.Join(plan.Queries, " | "))
+
+		err := compose.ProcessState[*researchState](ctx, func(_ context.Context, s *researchState) error {
+			s.question = question
+			s.pending = plan.Queries
+			return nil
+		})
+		return question, err
+	}))
+
+	_ = g.AddLambdaNode(nodeSearch, compose.InvokableLambda(func(ctx context.Context, _ string) (*searchPage, error) {
+		var query string
+		err := compose.ProcessState[*researchState](ctx, func(_ context.Context, s *researchState) error {
+			query, s.pending = s.pending[0], s.pending[1:]
+			s.searched = append(s.searched, query)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		args, _ := json.Marshal(map[string]any{"query": query})
+		results, err := search.InvokableRun(ctx, string(args))
+		if err != nil {
+			// one failed search should not end the research, the notes will say nothing was found
+			results = fmt.Sprintf("search failed: %v", err)
+		}
+		results = tokens.TrimText(results, maxResultTokens)
+		logs.Infof("search: %s", query)
+		return &searchPage{query: query, results: results}, nil
+	}))
+
+	_ = g.AddLambdaNode(nodeNotes, compose.InvokableLambda(func(ctx context.Context, page *searchPage) (string, error) {
+		var question string
+		err := compose.ProcessState[*researchState](ctx, func(_ context.Context, s *researchState) error {
+			question = s.question
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+
+		var out struct {
+			Findings []struct {
+				Fact   string `json:"fact"`
+				Source string `json:"source"`
+			} `json:"findings"`
+			FollowUp []string `json:"follow_up"`
+		}
+		if err = generateJSON(ctx, cm, "agent_deep_research_notes", map[string]any{
+			"question": question,
+			"query":    page.query,
+			"results":  page.results,
+		}, &out); err != nil {
+			return "", err
+		}
+
+		err = compose.ProcessState[*researchState](ctx, func(_ context.Context, s *researchState) error {
+			for _, f := range out.Findings {
+				s.notes = append(s.notes, &Note{Query: page.query, Fact: f.Fact, Source: f.Source})
+				logs.Infof("note: %s", f.Fact)
+			}
+			for _, q := range out.FollowUp {
+				if !contains(s.searched, q) && !contains(s.pending, q) {
+					s.pending = append(s.pending, q)
+				}
+			}
+			return nil
+		})
+		return page.query, err
+	}))
+
+	_ = g.AddLambdaNode(nodeWrite, compose.InvokableLambda(func(ctx context.Context, _ string) (*Report, error) {
+		report := &Report{}
+		var notes string
+		err := compose.ProcessState[*researchState](ctx, func(_ context.Context, s *researchState) error {
+			report.Question, report.Sources = s.question, s.sources()
+			report.Searches = append(report.Searches, s.searched...)
+			notes = formatNotes(s.notes, report.Sources)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		in, err := prompt.MustGet("agent_deep_research_write").Format(ctx, map[string]any{
+			"question": report.Question,
+			"notes":    notes,
+			"sources":  formatSources(report.Sources),
+		})
+		if err != nil {
+			return nil, err
+		}
+		msg, err := cm.Generate(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		report.Markdown = strings.TrimSpace(msg.Content) + "\n\n## Sources\n\n" + formatSources(report.Sources)
+		return report, nil
+	}))
+
+	_ = g.AddEdge(compose.START, nodePlan)
+	_ = g.AddEdge(nodePlan, nodeSearch)
+	_ = g.AddEdge(nodeSearch, nodeNotes)
+	_ = g.AddBranch(nodeNotes, compose.NewGraphBranch(func(ctx context.Context, _ string) (string, error) {
+		next := nodeWrite
+		err := compose.ProcessState[*researchState](ctx, func(_ context.Context, s *researchState) error {
+			if len(s.pending) > 0 && len(s.searched) < maxSearches {
+				next = nodeSearch
+			}
+			return nil
+		})
+		return next, err
+	}, map[string]bool{nodeSearch: true, nodeWrite: true}))
+	_ = g.AddEdge(nodeWrite, compose.END)
+
+	// every search runs search and take_notes
+	return g.Compile(ctx, compose.WithGraphName("deep_research"), compose.WithMaxRunSteps(2*maxSearches+10))
+}
+
+// generateJSON formats the prompt name with vars, asks the model for a JSON reply and decodes it into v.
+func generateJSON(ctx context.Context, cm model.ChatModel, name string, vars map[string]any, v any) error {
+	in, err := prompt.MustGet(name).Format(ctx, vars)
+	if err != nil {
+		return err
+	}
+	msg, err := cm.Generate(ctx, in)
+	if err != nil {
+		return err
+	}
+	content := strings.TrimSpace(msg.Content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+	if err = json.Unmarshal([]byte(strings.TrimSpace(content)), v); err != nil {
+		return fmt.Errorf("unexpected reply %q: %w", msg.Content, err)
+	}
+	return nil
+}
+
+// formatNotes renders the notes with the number of their source in sources.
+func formatNotes(notes []*Note, sources []string) string {
+	index := make(map[string]int, len(sources))
+	for i, s := range sources {
+		index[s] = i + 1
+	}
+	sb := strings.Builder{}
+	for _, n := range notes {
+		if i, ok := index[n.Source]; ok {
+			sb.WriteString(fmt.Sprintf("- %s [%d]\n", n.Fact, i))
+		} else {
+			sb.WriteString(fmt.Sprintf("- %s [no source]\n", n.Fact))
+		}
+	}
+	if len(notes) == 0 {
+		sb.WriteString("(no notes, the searches found nothing relevant)\n")
+	}
+	return sb.String()
+}
+
+func formatSources(sources []string) string {
+	sb := strings.Builder{}
+	for i, s := range sources {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, s))
+	}
+	return sb.String()
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if strings.EqualFold(l, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const (
+	nodeTemplate = "draft_prompt"
+	nodeGenerate = "generate"
+	nodeParse    = "parse"
+	nodeApprove  = "approve"
+	nodeRevise   = "revise"
+	nodeSend     = "send"
+	nodeDiscard  = "discard"
+
+	actionSend    = "send"
+	actionRevise  = "revise"
+	actionDiscard = "discard"
+)
+
+// decision is what the human answered about a draft.
+type decision struct {
+	email    *Email
+	action   string
+	feedback string
+}
+
+// approver shows the draft to a human and returns the decision. It blocks until the human answers.
+type approver func(ctx context.Context, e *Email) (*decision, error)
+
+type emailState struct {
+	recipient string
+	messages  []*schema.Message
+}
+
+// This example drafts an email with a chat template and a model, then waits for a human to approve it before it is
+// sent with the send_email tool:
+//
+//	START -> draft_prompt -> generate -> parse -> approve --(send)--> send -> END
+//	                            ^                   |  |
+//	                            +---- revise <------+  +--(discard)--> discard -> END
+//
+// Sending an email cannot be undone, so the model never calls the tool itself: it only writes the draft, and the graph
+// runs the tool once the human typed y. Any other answer than y or n is taken as instructions to revise the draft.
+//
+// Without SMTP_ADDR the tool runs dry and logs the email. To really send it, set SMTP_ADDR (host:port), SMTP_FROM and,
+// if the server needs it, SMTP_USER and SMTP_PASSWORD.
+func main() {
+	to := flag.String("to", "dana@example.com", "address of the recipient")
+	toName := flag.String("to-name", "Dana", "name of the recipient")
+	sender := flag.String("sender", "Alex from the platform team", "who the email is from")
+	tone := flag.String("tone", "friendly", "tone of the email")
+	purpose := flag.String("purpose", "the database migration planned on Saturday moves to next Tuesday 22:00, "+
+		"the service will be read-only for about 30 minutes, ask them to tell their users", "what the email is about")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	cm := models.MustChatModel(ctx, models.WithTemperature(0.3))
+
+	password, err := secrets.Get(ctx, "SMTP_PASSWORD")
+	if err != nil {
+		logs.Fatalf("read SMTP_PASSWORD failed, err=%v", err)
+	}
+	sendTool, err := newSendTool(&smtpConfig{
+		Addr:     config.String("SMTP_ADDR", ""),
+		User:     config.String("SMTP_USER", ""),
+		Password: password,
+		From:     config.String("SMTP_FROM", ""),
+	})
+	if err != nil {
+		logs.Fatalf("newSendTool failed, err=%v", err)
+	}
+
+	approve := consoleApprover(bufio.NewReader(os.Stdin))
+
+	g := compose.NewGraph[map[string]any, string](compose.WithGenLocalState(func(ctx context.Context) *emailState {
+		return &emailState{recipient: *to}
+	}))
+
+	_ = g.AddChatTemplateNode(nodeTemplate, prompt.MustGet("agent_email_approval_draft"), compose.WithStatePostHandler(
+		func(ctx context.Context, out []*schema.Message, s *emailState) ([]*schema.Message, error) {
+			s.messages = out
+			return out, nil
+		}))
+	_ = g.AddChatModelNode(nodeGenerate, cm, compose.WithStatePostHandler(
+		func(ctx context.Context, out *schema.Message, s *emailState) (*schema.Message, error) {
+			s.messages = append(s.messages, out)
+			return out, nil
+		}))
+	_ = g.AddLambdaNode(nodeParse, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*Email, error) {
+		var recipient string
+		err := compose.ProcessState[*emailState](ctx, func(_ context.Context, s *emailState) error {
+			recipient = s.recipient
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return parseDraft(recipient, msg.Content), nil
+	}))
+	_ = g.AddLambdaNode(nodeApprove, compose.InvokableLambda(func(ctx context.Context, e *Email) (*decision, error) {
+		return approve(ctx, e)
+	}))
+	_ = g.AddLambdaNode(nodeRevise, compose.InvokableLambda(func(ctx context.Context, d *decision) ([]*schema.Message, error) {
+		var msgs []*schema.Message
+		err := compose.ProcessState[*emailState](ctx, func(_ context.Context, s *emailState) error {
+			s.messages = append(s.messages, schema.UserMessage("Revise the email: "+d.feedback))
+			msgs = append(msgs, s.messages...)
+			return nil
+		})
+		return msgs, err
+	}))
+	_ = g.AddLambdaNode(nodeSend, compose.InvokableLambda(func(ctx context.Context, d *decision) (string, error) {
+		args, err := json.Marshal(d.email)
+		if err != nil {
+			return "", err
+		}
+		return sendTool.InvokableRun(ctx, string(args))
+	}))
+	_ = g.AddLambdaNode(nodeDiscard, compose.InvokableLambda(func(ctx context.Context, d *decision) (string, error) {
+		return "discarded, nothing was sent", nil
+	}))
+
+	_ = g.AddEdge(compose.START, nodeTemplate)
+	_ = g.AddEdge(nodeTemplate, nodeGenerate)
+	_ = g.AddEdge(nodeGenerate, nodeParse)
+	_ = g.AddEdge(nodeParse, nodeApprove)
+	_ = g.AddBranch(nodeApprove, compose.NewGraphBranch(func(ctx context.Context, d *decision) (string, error) {
+		switch d.action {
+		case actionSend:
+			return nodeSend, nil
+		case actionRevise:
+			return nodeRevise, nil
+		}
+		return nodeDiscard, nil
+	}, map[string]bool{nodeSend: true, nodeRevise: true, nodeDiscard: true}))
+	_ = g.AddEdge(nodeRevise, nodeGenerate)
+	_ = g.AddEdge(nodeSend, compose.END)
+	_ = g.AddEdge(nodeDiscard, compose.END)
+
+	r, err := g.Compile(ctx, compose.WithGraphName("email_approval"), compose.WithMaxRunSteps(100))
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	out, err := r.Invoke(ctx, map[string]any{
+		"sender":         *sender,
+		"tone":           *tone,
+		"recipient":      *to,
+		"recipient_name": *toName,
+		"purpose":        *purpose,
+	})
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+	logs.Infof("result: %s", out)
+}
+
+// parseDraft splits the reply of the model into subject and body. A reply without the Subject line is kept whole as
+// the body, the human sees it and can ask for a revision.
+func parseDraft(to, content string) *Email {
+	e := &Email{To: to}
+	content = strings.TrimSpace(content)
+	first, rest, _ := strings.Cut(content, "\n")
+	if subject, ok := strings.CutPrefix(strings.TrimSpace(first), "Subject:"); ok {
+		e.Subject = strings.TrimSpace(subject)
+		e.Body = strings.TrimSpace(rest)
+		return e
+	}
+	e.Body = content
+	return e
+}
+
+// consoleApprover asks on the terminal. In a real application the draft would be shown in a UI, or sent to a chat
+// for approval, and the answer would come back from there.
+func consoleApprover(in *bufio.Reader) approver {
+	return func(ctx context.Context, e *Email) (*decision, error) {
+		logs.Tokenf("\n----- draft -----\nTo: %s\nSubject: %s\n\n%s\n-----------------\n", e.To, e.Subject, e.Body)
+		logs.Tokenf("send it? y to send, n to discard, or type what to change: ")
+
+		line, err := in.ReadString('\n')
+		// at the end of the input, the empty answer discards the draft
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("read the answer failed: %w", err)
+		}
+		answer := strings.TrimSpace(line)
+
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return &decision{email: e, action: actionSend}, nil
+		case "n", "no", "":
+			return &decision{email: e, action: actionDiscard}, nil
+		}
+		return &decision{email: e, action: actionRevise, feedback: answer}, nil
+	}
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// Email is the input of the send_email tool.
+type Email struct {
+	To      string `json:"to" jsonschema:"description=address of the recipient"`
+	Subject string `json:"subject"`
+	Body    string `json:"body" jsonschema:"description=plain text body"`
+}
+
+type sendResult struct {
+	Sent   bool   `json:"sent"`
+	DryRun bool   `json:"dry_run,omitempty"`
+	Detail string `json:"detail"`
+}
+
+// smtpConfig is read from the environment. When Addr is empty the tool runs dry: it logs the email instead of
+// sending it, so that the example can run without a mail server.
+type smtpConfig struct {
+	Addr     string // host:port, e.g. smtp.example.com:587
+	User     string
+	Password string
+	From     string
+}
+
+// newSendTool returns the send_email tool. Sending is the side effect the approval step protects, so the tool is not
+// given to the model: the graph calls it only after a human approved the draft.
+func newSendTool(conf *smtpConfig) (tool.InvokableTool, error) {
+	return utils.InferTool("send_email", "send a plain text email",
+		func(ctx context.Context, e *Email) (*sendResult, error) {
+			if conf.Addr == "" {
+				logs.Infof("dry run, SMTP_ADDR is not set, the email is not sent:\nTo: %s\nSubject: %s\n\n%s", e.To, e.Subject, e.Body)
+				return &sendResult{DryRun: true, Detail: "SMTP_ADDR is not set"}, nil
+			}
+
+			host, _, err := net.SplitHostPort(conf.Addr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SMTP_ADDR %q: %w", conf.Addr, err)
+			}
+			var auth smtp.Auth
+			if conf.User != "" {
+				auth = smtp.PlainAuth("", conf.User, conf.Password, host)
+			}
+			if err = smtp.SendMail(conf.Addr, auth, conf.From, []string{e.To}, buildMessage(conf.From, e)); err != nil {
+				return nil, err
+			}
+			return &sendResult{Sent: true, Detail: "sent to " + e.To}, nil
+		})
+}
+
+func buildMessage(from string, e *Email) []byte {
+	sb := strings.Builder{}
+	sb.WriteString("From: " + from + "\r\n")
+	sb.WriteString("To: " + e.To + "\r\n")
+	sb.WriteString("Subject: " + e.Subject + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	sb.WriteString(strings.ReplaceAll(e.Body, "\n", "\r\n"))
+	return []byte(sb.String())
+}
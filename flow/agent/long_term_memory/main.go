@@ -0,0 +1,198 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/memory"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/store"
+	"github.com/cloudwego/eino-examples/internal/streamprint"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example gives a chat assistant a memory that survives across runs. At the end of a conversation
+// (type exit), the model writes down what is worth remembering as short standalone memories, which are
+// embedded and saved to disk. In the next conversations, each user message retrieves the relevant memories,
+// which are added to the system prompt for the rest of the conversation.
+//
+// Try it in two runs: tell it about yourself and your preferences, exit, then start a new run and ask
+// something those preferences matter for. Each -user has its own memory file.
+//
+// With -sessions, the conversation in progress is also kept in a store of internal/store after every turn, so that
+// a run stopped with Ctrl+C is picked up by the next one, e.g. -sessions sqlite://.cache/memory/sessions.db.
+func main() {
+	user := flag.String("user", "default", "whose memory to use")
+	dataDir := flag.String("data", ".cache/memory", "directory where the memories are stored")
+	topK := flag.Int("k", 4, "max number of memories recalled per message")
+	minScore := flag.Float64("min-score", 0.5, "min similarity of a recalled memory, depends on the embedding model")
+	maxHistory := flag.Int("max-history", 20, "messages of the conversation above which the older ones are summarized")
+	maxHistoryTokens := flag.Int("max-history-tokens", 4000, "tokens of the conversation above which the older messages are summarized, 0 to disable")
+	sessionsURL := flag.String("sessions", "", "url of the store of the conversations in progress, empty to keep none")
+	sessionTTL := flag.Duration("session-ttl", 2*time.Hour, "time after which a conversation in progress is forgotten")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	sess := &sessions{ttl: *sessionTTL}
+	if *sessionsURL != "" {
+		kv, err := store.Open(*sessionsURL)
+		if err != nil {
+			logs.Fatalf("open session store failed, err=%v", err)
+		}
+		defer kv.Close()
+		sess.kv = kv
+	}
+
+	cm := models.MustChatModel(ctx)
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("NewEmbedder failed, err=%v", err)
+	}
+
+	mem, err := openMemory(emb, cm, filepath.Join(*dataDir, *user+".json"), *topK, *minScore)
+	if err != nil {
+		logs.Fatalf("openMemory failed, err=%v", err)
+	}
+	logs.Infof("%d memories about %s, type exit to end the conversation", mem.len(), *user)
+
+	// long conversations are summarized as they go, the memories are still written from the whole transcript
+	summary, err := memory.NewSummary(&memory.SummaryConfig{
+		Model:       cm,
+		MaxMessages: *maxHistory,
+		MaxTokens:   *maxHistoryTokens,
+		KeepRecent:  6,
+	})
+	if err != nil {
+		logs.Fatalf("NewSummary failed, err=%v", err)
+	}
+
+	conv, err := sess.load(ctx, *user)
+	if err != nil {
+		logs.Fatalf("load session failed, err=%v", err)
+	}
+	if len(conv.Transcript) > 0 {
+		logs.Infof("picking up the conversation in progress, %d messages", len(conv.Transcript))
+	}
+
+	var (
+		history    = conv.History
+		transcript = conv.Transcript
+		recalled   []*schema.Document
+		seen       = map[string]bool{}
+	)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("\nYou: ")
+		if !scanner.Scan() {
+			break
+		}
+		text := strings.TrimSpace(scanner.Text())
+		if text == "exit" {
+			break
+		}
+		if text == "" {
+			continue
+		}
+
+		// memories recalled once stay in the prompt, the topic of a conversation drifts but the context it built does not
+		docs, err := mem.recall(ctx, text)
+		if err != nil {
+			logs.Fatalf("recall failed, err=%v", err)
+		}
+		for _, d := range docs {
+			if !seen[d.ID] {
+				seen[d.ID] = true
+				recalled = append(recalled, d)
+				logs.Infof("recalled (%.2f): %s", d.Score(), d.Content)
+			}
+		}
+
+		history = append(history, schema.UserMessage(text))
+		input, err := prompt.MustGet("agent_long_term_memory_assistant").Format(ctx, map[string]any{
+			"today":    time.Now().Format(dateLayout),
+			"memories": formatMemories(recalled),
+			"history":  history,
+		})
+		if err != nil {
+			logs.Fatalf("Format failed, err=%v", err)
+		}
+
+		sr, err := cm.Stream(ctx, input)
+		if err != nil {
+			logs.Fatalf("Stream failed, err=%v", err)
+		}
+		answer, err := streamAnswer(sr)
+		if err != nil {
+			logs.Fatalf("Stream failed, err=%v", err)
+		}
+		history = append(history, answer)
+		transcript = append(transcript, schema.UserMessage(text), answer)
+		if history, err = summary.Compact(ctx, history); err != nil {
+			logs.Fatalf("Compact failed, err=%v", err)
+		}
+		if err = sess.save(ctx, *user, &session{History: history, Transcript: transcript}); err != nil {
+			logs.Errorf("save session failed, err=%v", err)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		logs.Errorf("read stdin failed, err=%v", err)
+	}
+
+	facts, err := mem.remember(ctx, transcript, recalled)
+	if err != nil {
+		logs.Fatalf("remember failed, err=%v", err)
+	}
+	if err = sess.end(ctx, *user); err != nil {
+		logs.Errorf("end session failed, err=%v", err)
+	}
+	if len(facts) == 0 {
+		logs.Infof("nothing new to remember")
+		return
+	}
+	logs.Infof("remembered %d new memories:", len(facts))
+	for _, f := range facts {
+		logs.Tokenf("- %s\n", f)
+	}
+}
+
+// streamAnswer prints the answer as it is generated and returns it concatenated.
+func streamAnswer(sr *schema.StreamReader[*schema.Message]) (*schema.Message, error) {
+	fmt.Print("\nAssistant: ")
+	return streamprint.Print(sr)
+}
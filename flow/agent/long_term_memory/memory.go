@@ -0,0 +1,139 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+const (
+	metaCreatedAt = "created_at"
+	dateLayout    = "2006-01-02"
+)
+
+// longTermMemory stores short memories written at the end of each conversation, one document per memory,
+// and retrieves the ones relevant to what the user says in the next conversations.
+type longTermMemory struct {
+	store *vectorstore.MemoryStore
+	path  string
+	cm    model.ChatModel
+}
+
+// openMemory loads the memories saved at path by previous runs, if any.
+func openMemory(emb embedding.Embedder, cm model.ChatModel, path string, topK int, minScore float64) (*longTermMemory, error) {
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{
+		Embedding:      emb,
+		TopK:           topK,
+		ScoreThreshold: &minScore,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = store.Load(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("load memories failed: %w", err)
+	}
+	return &longTermMemory{store: store, path: path, cm: cm}, nil
+}
+
+func (m *longTermMemory) len() int {
+	return m.store.Len()
+}
+
+// recall returns the memories relevant to text, best first.
+func (m *longTermMemory) recall(ctx context.Context, text string) ([]*schema.Document, error) {
+	return m.store.Retrieve(ctx, text)
+}
+
+// remember asks the model what to remember from the conversation, stores it and saves the store.
+// known are the memories the conversation already had in its prompt, so that they are not written twice.
+func (m *longTermMemory) remember(ctx context.Context, conversation []*schema.Message, known []*schema.Document) ([]string, error) {
+	if len(conversation) == 0 {
+		return nil, nil
+	}
+
+	in, err := prompt.MustGet("agent_long_term_memory_remember").Format(ctx, map[string]any{
+		"known":        formatMemories(known),
+		"conversation": formatConversation(conversation),
+	})
+	if err != nil {
+		return nil, err
+	}
+	msg, err := m.cm.Generate(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	var facts []string
+	for _, line := range strings.Split(msg.Content, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "- ") {
+			continue
+		}
+		if fact := strings.TrimSpace(strings.TrimPrefix(line, "- ")); fact != "" {
+			facts = append(facts, fact)
+		}
+	}
+	if len(facts) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now().Format(dateLayout)
+	docs := make([]*schema.Document, 0, len(facts))
+	for _, f := range facts {
+		docs = append(docs, &schema.Document{Content: f, MetaData: map[string]any{metaCreatedAt: now}})
+	}
+	if _, err = m.store.Store(ctx, docs); err != nil {
+		return nil, err
+	}
+	if err = m.store.Save(m.path); err != nil {
+		return nil, fmt.Errorf("save memories failed: %w", err)
+	}
+	return facts, nil
+}
+
+// formatMemories renders memories with their date, so the model can tell an old preference from a recent one.
+func formatMemories(docs []*schema.Document) string {
+	if len(docs) == 0 {
+		return "(nothing yet)\n"
+	}
+	sb := strings.Builder{}
+	for _, d := range docs {
+		date, _ := d.MetaData[metaCreatedAt].(string)
+		sb.WriteString(fmt.Sprintf("- (%s) %s\n", date, d.Content))
+	}
+	return sb.String()
+}
+
+func formatConversation(msgs []*schema.Message) string {
+	sb := strings.Builder{}
+	for _, m := range msgs {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", m.Role, m.Content))
+	}
+	return sb.String()
+}
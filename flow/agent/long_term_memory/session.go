@@ -0,0 +1,74 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/history"
+	"github.com/cloudwego/eino-examples/internal/store"
+)
+
+// session is the conversation in progress of a user. Kept in a store, a conversation interrupted without exit is
+// picked up by the next run, until it expires; the memories are written once it ends. The messages are saved by
+// internal/history, the sessions saved by an older version of the example are still read.
+type session struct {
+	// History is what the model is given, compacted by the summary memory.
+	History history.Messages `json:"history"`
+	// Transcript is the whole conversation, the memories are written from it.
+	Transcript history.Messages `json:"transcript"`
+}
+
+// sessions keeps the sessions in kv, nil keeps none.
+type sessions struct {
+	kv  store.Store
+	ttl time.Duration
+}
+
+func sessionKey(user string) string {
+	return "session:" + user
+}
+
+// load returns the session of user, an empty one if there is none.
+func (s *sessions) load(ctx context.Context, user string) (*session, error) {
+	if s.kv == nil {
+		return &session{}, nil
+	}
+	sess, err := store.GetJSON[*session](ctx, s.kv, sessionKey(user))
+	if errors.Is(err, store.ErrNotFound) {
+		return &session{}, nil
+	}
+	return sess, err
+}
+
+// save saves the session after every turn, its expiry starting again.
+func (s *sessions) save(ctx context.Context, user string, sess *session) error {
+	if s.kv == nil {
+		return nil
+	}
+	return store.SetJSON(ctx, s.kv, sessionKey(user), sess, s.ttl)
+}
+
+// end deletes the session of a conversation which ended.
+func (s *sessions) end(ctx context.Context, user string) error {
+	if s.kv == nil {
+		return nil
+	}
+	return s.kv.Delete(ctx, sessionKey(user))
+}
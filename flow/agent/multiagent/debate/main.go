@@ -0,0 +1,214 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const (
+	nodePrepare     = "prepare"
+	nodeAffirmative = "affirmative"
+	nodeAffToList   = "affirmative_to_list"
+	nodeNegative    = "negative"
+	nodeNegToList   = "negative_to_list"
+	nodeJudgeInput  = "judge_input"
+	nodeJudge       = "judge"
+	nodeVerdict     = "verdict"
+)
+
+type turn struct {
+	Round   int
+	Speaker string
+	Content string
+}
+
+// debateState holds the transcript, the only thing the debaters and the judge share.
+type debateState struct {
+	topic      string
+	round      int
+	transcript []turn
+}
+
+func (s *debateState) formatTranscript() string {
+	if len(s.transcript) == 0 {
+		return "(no arguments yet, you open the debate)"
+	}
+	sb := strings.Builder{}
+	for _, t := range s.transcript {
+		sb.WriteString(fmt.Sprintf("Round %d, %s:\n%s\n\n", t.Round, t.Speaker, t.Content))
+	}
+	return sb.String()
+}
+
+// Verdict is the output of the graph.
+type Verdict struct {
+	Winner string `json:"winner"`
+	Reason string `json:"reason"`
+}
+
+// This example runs a debate between two chat models, then a third one picks the winner, all in one graph:
+//
+//	START -> prepare -> affirmative -> affirmative_to_list -> negative --(rounds left)--> negative_to_list -> affirmative
+//	                                                              |
+//	                                                              +--(last round)--> judge_input -> judge -> verdict -> END
+//
+// Turn management lives in the state: the StatePreHandler of each debater replaces its input with the transcript
+// seen from its side, and its StatePostHandler appends what it said. The branch after the negative side ends the loop
+// after -rounds rounds. The two sides can run on different models to compare them, see -affirmative-model and -negative-model.
+func main() {
+	rounds := flag.Int("rounds", 3, "number of rounds, each side speaks once per round")
+	motion := flag.String("motion", "Remote work should be the default for software teams.", "the motion debated")
+	affModel := flag.String("affirmative-model", "", "model of the affirmative side, default the model of MODEL_PROVIDER")
+	negModel := flag.String("negative-model", "", "model of the negative side, default the model of MODEL_PROVIDER")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	newModel := func(name string, temperature float32) model.ChatModel {
+		opts := []models.Option{models.WithTemperature(temperature)}
+		if name != "" {
+			opts = append(opts, models.WithModel(name))
+		}
+		return models.MustChatModel(ctx, opts...)
+	}
+
+	g := compose.NewGraph[string, *Verdict](compose.WithGenLocalState(func(ctx context.Context) *debateState {
+		return &debateState{round: 1}
+	}))
+
+	_ = g.AddLambdaNode(nodePrepare, compose.InvokableLambda(func(ctx context.Context, motion string) ([]*schema.Message, error) {
+		err := compose.ProcessState[*debateState](ctx, func(_ context.Context, s *debateState) error {
+			s.topic = motion
+			return nil
+		})
+		// the debaters build their own input from the state, so there is nothing to pass on
+		return nil, err
+	}))
+
+	// debater returns the handlers turning a model node into one side of the debate
+	debater := func(side, stance string) (compose.StatePreHandler[[]*schema.Message, *debateState], compose.StatePostHandler[*schema.Message, *debateState]) {
+		pre := func(ctx context.Context, _ []*schema.Message, s *debateState) ([]*schema.Message, error) {
+			return prompt.MustGet("multiagent_debate_debater").Format(ctx, map[string]any{
+				"motion":     s.topic,
+				"stance":     stance,
+				"transcript": s.formatTranscript(),
+				"round":      s.round,
+				"side":       side,
+			})
+		}
+		post := func(ctx context.Context, out *schema.Message, s *debateState) (*schema.Message, error) {
+			s.transcript = append(s.transcript, turn{Round: s.round, Speaker: side, Content: strings.TrimSpace(out.Content)})
+			logs.Infof("round %d, %s:\n%s", s.round, side, strings.TrimSpace(out.Content))
+			// the negative side closes a round
+			if side == nodeNegative {
+				s.round++
+			}
+			return out, nil
+		}
+		return pre, post
+	}
+
+	affPre, affPost := debater(nodeAffirmative, "FOR")
+	negPre, negPost := debater(nodeNegative, "AGAINST")
+	_ = g.AddChatModelNode(nodeAffirmative, newModel(*affModel, 0.8),
+		compose.WithStatePreHandler(affPre), compose.WithStatePostHandler(affPost), compose.WithNodeName(nodeAffirmative))
+	_ = g.AddChatModelNode(nodeNegative, newModel(*negModel, 0.8),
+		compose.WithStatePreHandler(negPre), compose.WithStatePostHandler(negPost), compose.WithNodeName(nodeNegative))
+	// a model outputs one message and takes a list, ToList adapts one to the other
+	_ = g.AddLambdaNode(nodeAffToList, compose.ToList[*schema.Message]())
+	_ = g.AddLambdaNode(nodeNegToList, compose.ToList[*schema.Message]())
+
+	_ = g.AddLambdaNode(nodeJudgeInput, compose.InvokableLambda(func(ctx context.Context, _ *schema.Message) ([]*schema.Message, error) {
+		var msgs []*schema.Message
+		err := compose.ProcessState[*debateState](ctx, func(ctx context.Context, s *debateState) error {
+			var err error
+			msgs, err = prompt.MustGet("multiagent_debate_judge").Format(ctx, map[string]any{
+				"motion":     s.topic,
+				"transcript": s.formatTranscript(),
+			})
+			return err
+		})
+		return msgs, err
+	}))
+	_ = g.AddChatModelNode(nodeJudge, newModel("", 0), compose.WithNodeName(nodeJudge))
+	_ = g.AddLambdaNode(nodeVerdict, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*Verdict, error) {
+		content := strings.TrimSpace(msg.Content)
+		content = strings.TrimPrefix(content, "```json")
+		content = strings.TrimPrefix(content, "```")
+		content = strings.TrimSuffix(content, "```")
+
+		v := &Verdict{}
+		if err := json.Unmarshal([]byte(strings.TrimSpace(content)), v); err != nil {
+			return nil, fmt.Errorf("unexpected verdict %q: %w", msg.Content, err)
+		}
+		return v, nil
+	}))
+
+	_ = g.AddEdge(compose.START, nodePrepare)
+	_ = g.AddEdge(nodePrepare, nodeAffirmative)
+	_ = g.AddEdge(nodeAffirmative, nodeAffToList)
+	_ = g.AddEdge(nodeAffToList, nodeNegative)
+	_ = g.AddBranch(nodeNegative, compose.NewGraphBranch(func(ctx context.Context, _ *schema.Message) (string, error) {
+		var round int
+		err := compose.ProcessState[*debateState](ctx, func(_ context.Context, s *debateState) error {
+			round = s.round
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		// the post handler of the negative side already moved to the next round
+		if round > *rounds {
+			return nodeJudgeInput, nil
+		}
+		return nodeNegToList, nil
+	}, map[string]bool{nodeNegToList: true, nodeJudgeInput: true}))
+	_ = g.AddEdge(nodeNegToList, nodeAffirmative)
+	_ = g.AddEdge(nodeJudgeInput, nodeJudge)
+	_ = g.AddEdge(nodeJudge, nodeVerdict)
+	_ = g.AddEdge(nodeVerdict, compose.END)
+
+	// each round runs 4 nodes
+	r, err := g.Compile(ctx, compose.WithGraphName("debate"), compose.WithMaxRunSteps(4**rounds+10))
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	logs.Infof("motion: %s", *motion)
+	v, err := r.Invoke(ctx, *motion)
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	logs.Infof("winner: %s", v.Winner)
+	logs.Tokenf("%s\n", v.Reason)
+}
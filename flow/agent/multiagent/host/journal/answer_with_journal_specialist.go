@@ -23,13 +23,13 @@ import (
 	"os"
 	"time"
 
-	"github.com/cloudwego/eino-ext/components/model/ollama"
-	"github.com/cloudwego/eino/components/prompt"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/flow/agent"
 	"github.com/cloudwego/eino/flow/agent/multiagent/host"
 	"github.com/cloudwego/eino/schema"
-	"github.com/ollama/ollama/api"
+
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
 )
 
 // search journal: user ask a question, this specialist load today's journal and ground its answer onto it.
@@ -53,15 +53,9 @@ func loadJournal(date string) (string, error) {
 	return string(content), nil
 }
 
-func newAnswerWithJournalSpecialist(ctx context.Context, baseURL, model string) (*host.Specialist, error) {
-	chatModel, err := ollama.NewChatModel(ctx, &ollama.ChatModelConfig{
-		BaseURL: baseURL,
-		Model:   model,
-
-		Options: &api.Options{
-			Temperature: 0.000001,
-		},
-	})
+func newAnswerWithJournalSpecialist(ctx context.Context) (*host.Specialist, error) {
+	// the specialists run on the local model of OLLAMA_BASE_URL and OLLAMA_MODEL whatever MODEL_PROVIDER is
+	chatModel, err := models.NewChatModel(ctx, models.WithProvider("ollama"), models.WithTemperature(0.000001))
 	if err != nil {
 		return nil, err
 	}
@@ -85,11 +79,10 @@ func newAnswerWithJournalSpecialist(ctx context.Context, baseURL, model string)
 		return nil, err
 	}
 
-	systemTpl := `Answer user's query based on journal content: {journal}'`
-	chatTpl := prompt.FromMessages(schema.FString,
-		schema.SystemMessage(systemTpl),
-		schema.UserMessage("{query}"),
-	)
+	chatTpl, err := prompt.Get("multiagent_journal_answer")
+	if err != nil {
+		return nil, err
+	}
 	if err = graph.AddChatTemplateNode("template", chatTpl); err != nil {
 		return nil, err
 	}
@@ -19,23 +19,29 @@ package main
 import (
 	"context"
 
-	"github.com/cloudwego/eino-ext/components/model/openai"
 	"github.com/cloudwego/eino/flow/agent/multiagent/host"
+
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
 )
 
-func newHost(ctx context.Context, baseURL, apiKey, modelName string) (*host.Host, error) {
-	chatModel, err := openai.NewChatModel(ctx, &openai.ChatModelConfig{
-		BaseURL: baseURL,
-		Model:   modelName,
-		//ByAzure: true,
-		APIKey: apiKey,
-	})
+func newHost(ctx context.Context) (*host.Host, error) {
+	chatModel, err := models.NewChatModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := prompt.Get("multiagent_journal_host")
+	if err != nil {
+		return nil, err
+	}
+	systemPrompt, err := tpl.Format(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	return &host.Host{
 		ChatModel:    chatModel,
-		SystemPrompt: "You can read and write journal on behalf of the user. When user asks a question, always answer with journal content.",
+		SystemPrompt: systemPrompt[0].Content,
 	}, nil
 }
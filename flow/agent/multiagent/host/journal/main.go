@@ -19,29 +19,25 @@ package main
 import (
 	"bufio"
 	"context"
-	"io"
 	"os"
 
 	"github.com/cloudwego/eino/flow/agent/multiagent/host"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/streamprint"
+	"github.com/cloudwego/eino-examples/internal/validate"
 )
 
 func main() {
-
-	openAIAPIKey := os.Getenv("OPENAI_API_KEY")
-	openAIBaseURL := os.Getenv("OPENAI_BASE_URL")
-	openAIModelName := os.Getenv("OPENAI_MODEL_NAME")
-
-	ollamaBaseURL := os.Getenv("OLLAMA_BASE_URL")
-	ollamaModel := os.Getenv("OLLAMA_MODEL")
+	validate.Must(validate.Model())
 
 	ctx := context.Background()
-	h, err := newHost(ctx, openAIBaseURL, openAIAPIKey, openAIModelName)
+	h, err := newHost(ctx)
 	if err != nil {
 		panic(err)
 	}
 
-	writer, err := newWriteJournalSpecialist(ctx, ollamaBaseURL, ollamaModel)
+	writer, err := newWriteJournalSpecialist(ctx)
 	if err != nil {
 		panic(err)
 	}
@@ -51,7 +47,7 @@ func main() {
 		panic(err)
 	}
 
-	answerer, err := newAnswerWithJournalSpecialist(ctx, ollamaBaseURL, ollamaModel)
+	answerer, err := newAnswerWithJournalSpecialist(ctx)
 	if err != nil {
 		panic(err)
 	}
@@ -98,19 +94,10 @@ func main() {
 			panic(err)
 		}
 
-		defer out.Close()
-
 		println("\nAnswer:")
 
-		for {
-			msg, err := out.Recv()
-			if err != nil {
-				if err == io.EOF {
-					break
-				}
-			}
-
-			print(msg.Content)
+		if _, err = streamprint.Print(out); err != nil {
+			panic(err)
 		}
 	}
 }
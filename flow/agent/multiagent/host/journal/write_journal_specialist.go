@@ -22,12 +22,13 @@ import (
 	"os"
 	"time"
 
-	"github.com/cloudwego/eino-ext/components/model/ollama"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/flow/agent"
 	"github.com/cloudwego/eino/flow/agent/multiagent/host"
 	"github.com/cloudwego/eino/schema"
-	"github.com/ollama/ollama/api"
+
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
 )
 
 // create a specialist who can append text to the right local journal file
@@ -76,15 +77,14 @@ func getJournalFilePath(dateStr string) (string, error) {
 	return filePath, nil
 }
 
-func newWriteJournalSpecialist(ctx context.Context, baseURL, model string) (*host.Specialist, error) {
-	chatModel, err := ollama.NewChatModel(ctx, &ollama.ChatModelConfig{
-		BaseURL: baseURL,
-		Model:   model,
+func newWriteJournalSpecialist(ctx context.Context) (*host.Specialist, error) {
+	// the specialists run on the local model of OLLAMA_BASE_URL and OLLAMA_MODEL whatever MODEL_PROVIDER is
+	chatModel, err := models.NewChatModel(ctx, models.WithProvider("ollama"), models.WithTemperature(0.000001))
+	if err != nil {
+		return nil, err
+	}
 
-		Options: &api.Options{
-			Temperature: 0.000001,
-		},
-	})
+	tpl, err := prompt.Get("multiagent_journal_write")
 	if err != nil {
 		return nil, err
 	}
@@ -99,11 +99,7 @@ func newWriteJournalSpecialist(ctx context.Context, baseURL, model string) (*hos
 	// I got up at 7:00 in the morning.
 	chain := compose.NewChain[[]*schema.Message, *schema.Message]()
 	chain.AppendLambda(compose.InvokableLambda(func(ctx context.Context, input []*schema.Message) ([]*schema.Message, error) {
-		systemMsg := &schema.Message{
-			Role:    schema.System,
-			Content: "You are responsible for preparing the user query for insertion into journal. The user's query is expected to contain the actual text the user want to write to journal, as well as convey the intention that this query should be written to journal. You job is to remove that intention from the user query, while preserving as much as possible the user's original query, and output ONLY the text to be written into journal",
-		}
-		return append([]*schema.Message{systemMsg}, input...), nil
+		return tpl.Format(ctx, map[string]any{"history": input})
 	})).
 		AppendChatModel(chatModel).
 		AppendLambda(compose.InvokableLambda(func(ctx context.Context, input *schema.Message) (*schema.Message, error) {
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"strconv"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+type calculateInput struct {
+	Expression string `json:"expression" jsonschema:"description=the expression to evaluate such as (3.5 + 2) * 4 / 3"`
+}
+
+type calculateOutput struct {
+	Expression string  `json:"expression"`
+	Result     float64 `json:"result,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// newCalculator returns the tool of the math specialist. Models are bad at arithmetic, the tool is not.
+// A bad expression is reported in the output rather than as an error, an error would abort the whole agent run
+// while the model can usually fix the expression and call again.
+func newCalculator() (tool.InvokableTool, error) {
+	return utils.InferTool("calculate", "evaluate an arithmetic expression and return the exact result. Supports + - * /, parentheses, sqrt(x) and pow(x, y)",
+		func(ctx context.Context, in *calculateInput) (*calculateOutput, error) {
+			out := &calculateOutput{Expression: in.Expression}
+			expr, err := parser.ParseExpr(in.Expression)
+			if err != nil {
+				out.Error = fmt.Sprintf("invalid expression: %v", err)
+				return out, nil
+			}
+			if out.Result, err = eval(expr); err != nil {
+				out.Error = err.Error()
+			}
+			return out, nil
+		})
+}
+
+// eval evaluates the arithmetic subset of Go expressions, all in float64 so 7/2 is 3.5
+func eval(expr ast.Expr) (float64, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.INT && e.Kind != token.FLOAT {
+			return 0, fmt.Errorf("unexpected literal %s", e.Value)
+		}
+		return strconv.ParseFloat(e.Value, 64)
+	case *ast.ParenExpr:
+		return eval(e.X)
+	case *ast.UnaryExpr:
+		x, err := eval(e.X)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.SUB:
+			return -x, nil
+		case token.ADD:
+			return x, nil
+		}
+		return 0, fmt.Errorf("unexpected operator %s", e.Op)
+	case *ast.BinaryExpr:
+		x, err := eval(e.X)
+		if err != nil {
+			return 0, err
+		}
+		y, err := eval(e.Y)
+		if err != nil {
+			return 0, err
+		}
+		switch e.Op {
+		case token.ADD:
+			return x + y, nil
+		case token.SUB:
+			return x - y, nil
+		case token.MUL:
+			return x * y, nil
+		case token.QUO:
+			if y == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			return x / y, nil
+		}
+		return 0, fmt.Errorf("unexpected operator %s", e.Op)
+	case *ast.CallExpr:
+		name, ok := e.Fun.(*ast.Ident)
+		if !ok {
+			return 0, fmt.Errorf("unexpected call")
+		}
+		args := make([]float64, 0, len(e.Args))
+		for _, a := range e.Args {
+			v, err := eval(a)
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, v)
+		}
+		switch {
+		case name.Name == "sqrt" && len(args) == 1:
+			return math.Sqrt(args[0]), nil
+		case name.Name == "pow" && len(args) == 2:
+			return math.Pow(args[0], args[1]), nil
+		}
+		return 0, fmt.Errorf("unknown function %s with %d arguments, only sqrt(x) and pow(x, y) are supported", name.Name, len(args))
+	}
+	return 0, fmt.Errorf("unsupported expression")
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/flow/agent/multiagent/host"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/streamprint"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example is the host pattern of eino (flow/agent/multiagent/host) with two specialists, math and search.
+// The host is a chat model that sees every specialist as a tool, built from its AgentMeta: it either answers
+// directly, or calls the tool of exactly one specialist, which then receives the original conversation and
+// whose answer is the final answer. There is no loop back to the host and no combining of answers.
+//
+// Compared with a hand-rolled supervisor, such as ../../plan_execute or ../../../plan_and_execute, the host pattern
+// is a few lines of configuration and the routing is a single model call, but it cannot chain specialists: a question
+// needing both a lookup and a computation goes to one of them only. Pick a supervisor graph when that matters.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	newModel := func() model.ChatModel {
+		return models.MustChatModel(ctx, models.WithTemperature(0))
+	}
+
+	mathSpecialist, err := newMathSpecialist(ctx, newModel())
+	if err != nil {
+		logs.Fatalf("newMathSpecialist failed, err=%v", err)
+	}
+	searchSpecialist, err := newSearchSpecialist(ctx, newModel())
+	if err != nil {
+		logs.Fatalf("newSearchSpecialist failed, err=%v", err)
+	}
+
+	hostPrompt, err := prompt.MustGet("multiagent_math_search_host").Format(ctx, nil)
+	if err != nil {
+		logs.Fatalf("Format failed, err=%v", err)
+	}
+	hostMA, err := host.NewMultiAgent(ctx, &host.MultiAgentConfig{
+		Host: host.Host{
+			ChatModel:    newModel(),
+			SystemPrompt: hostPrompt[0].Content,
+		},
+		Specialists: []*host.Specialist{mathSpecialist, searchSpecialist},
+		Name:        "math_search",
+	})
+	if err != nil {
+		logs.Fatalf("NewMultiAgent failed, err=%v", err)
+	}
+
+	questions := []string{
+		"A jacket costs 129.90 and is 35% off, then 8% sales tax is added. What do I pay?",
+		"Who designed the Go programming language, and in which year was it announced?",
+		"Hi, what can you help me with?",
+	}
+
+	for _, q := range questions {
+		logs.Infof("question: %s", q)
+		if err = ask(ctx, hostMA, q); err != nil {
+			logs.Errorf("ask failed, err=%v", err)
+		}
+	}
+}
+
+func ask(ctx context.Context, hostMA *host.MultiAgent, question string) error {
+	cb := &handOffLogger{}
+	sr, err := hostMA.Stream(ctx, []*schema.Message{schema.UserMessage(question)}, host.WithAgentCallbacks(cb))
+	if err != nil {
+		return err
+	}
+	if _, err = streamprint.Print(sr); err != nil {
+		return err
+	}
+
+	if cb.to == "" {
+		logs.Infof("answered by the host")
+	}
+	return nil
+}
+
+// handOffLogger is called when the host hands the question off to a specialist.
+type handOffLogger struct {
+	to string
+}
+
+func (l *handOffLogger) OnHandOff(ctx context.Context, info *host.HandOffInfo) context.Context {
+	l.to = info.ToAgentName
+	logs.Infof("hand off to %s, reason: %s", info.ToAgentName, info.Argument)
+	return ctx
+}
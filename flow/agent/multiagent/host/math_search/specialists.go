@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino-ext/components/tool/duckduckgo"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent/multiagent/host"
+	"github.com/cloudwego/eino/flow/agent/react"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+// newMathSpecialist returns a specialist backed by a ReAct agent with a calculator.
+func newMathSpecialist(ctx context.Context, cm model.ChatModel) (*host.Specialist, error) {
+	calculator, err := newCalculator()
+	if err != nil {
+		return nil, err
+	}
+	return newToolSpecialist(ctx, cm, "multiagent_math_search_math", calculator, host.AgentMeta{
+		Name:        "math",
+		IntendedUse: "solve math problems: arithmetic, percentages, unit prices, interest, anything that needs a computation",
+	})
+}
+
+// newSearchSpecialist returns a specialist backed by a ReAct agent with a DuckDuckGo search tool.
+func newSearchSpecialist(ctx context.Context, cm model.ChatModel) (*host.Specialist, error) {
+	search, err := duckduckgo.NewTool(ctx, &duckduckgo.Config{})
+	if err != nil {
+		return nil, err
+	}
+	return newToolSpecialist(ctx, cm, "multiagent_math_search_search", search, host.AgentMeta{
+		Name:        "search",
+		IntendedUse: "answer questions about facts, people, places or recent events that need looking up on the web",
+	})
+}
+
+// newToolSpecialist wraps a ReAct agent as a specialist. A specialist is either a ChatModel with a SystemPrompt, or
+// any agent exposed through Invokable and Streamable; the host does not care how the specialist works inside.
+// Each specialist gets its own ChatModel, the agent binds its tools to it, and its persona is the prompt name.
+func newToolSpecialist(ctx context.Context, cm model.ChatModel, name string, t tool.BaseTool, meta host.AgentMeta) (*host.Specialist, error) {
+	persona, err := prompt.MustGet(name).Format(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	ra, err := react.NewAgent(ctx, &react.AgentConfig{
+		Model:           cm,
+		ToolsConfig:     compose.ToolsNodeConfig{Tools: []tool.BaseTool{t}},
+		MessageModifier: react.NewPersonaModifier(persona[0].Content),
+		MaxStep:         10,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &host.Specialist{
+		AgentMeta:  meta,
+		Invokable:  ra.Generate,
+		Streamable: ra.Stream,
+	}, nil
+}
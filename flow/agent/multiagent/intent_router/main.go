@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino-ext/components/tool/duckduckgo"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example routes every user message with an intent classifier to a todo agent, a search agent or a plain
+// chat model. Unlike the host pattern in ../host, where the routing model also sees the specialists as tools,
+// the classifier here is a separate cheap call with a fixed set of intents, and the routing is an ordinary graph
+// branch that can be tested, logged, or overridden by rules.
+//
+// The conversation is shared: all the agents read the same history, and the agent of the previous turn is passed
+// to the classifier so follow-ups stay with it.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	newModel := func(temperature float32) model.ChatModel {
+		return models.MustChatModel(ctx, models.WithTemperature(temperature))
+	}
+
+	todos := &todoList{}
+	todoTools, err := todos.tools()
+	if err != nil {
+		logs.Fatalf("todo tools failed, err=%v", err)
+	}
+	todoAgent, err := newAgent(ctx, newModel(0), "multiagent_intent_router_todo", todoTools)
+	if err != nil {
+		logs.Fatalf("NewAgent failed, err=%v", err)
+	}
+
+	search, err := duckduckgo.NewTool(ctx, &duckduckgo.Config{})
+	if err != nil {
+		logs.Fatalf("NewTool failed, err=%v", err)
+	}
+	searchAgent, err := newAgent(ctx, newModel(0), "multiagent_intent_router_search", []tool.BaseTool{search})
+	if err != nil {
+		logs.Fatalf("NewAgent failed, err=%v", err)
+	}
+
+	r, err := buildRouter(ctx, newModel(0), newModel(0.7), todoAgent, searchAgent)
+	if err != nil {
+		logs.Fatalf("buildRouter failed, err=%v", err)
+	}
+
+	turns := []string{
+		"Add a todo to renew my passport before the end of May.",
+		"What documents do I need to renew a passport in France?",
+		"Ok, remind me to get the photos done too, by May 10.",
+		"What's on my list?",
+		"Tell me a short joke about paperwork.",
+		"Haha. Mark the photos one as done.",
+	}
+
+	req := &Request{}
+	for _, t := range turns {
+		logs.Infof("user: %s", t)
+		req.History = append(req.History, schema.UserMessage(t))
+
+		reply, err := r.Invoke(ctx, req)
+		if err != nil {
+			logs.Fatalf("Invoke failed, err=%v", err)
+		}
+
+		logs.Infof("routed to %s (%s)", reply.Intent, reply.Reason)
+		logs.Tokenf("%s\n", reply.Message.Content)
+
+		// only the answers are kept, the tool calls stay inside the agent that made them
+		req.History = append(req.History, schema.AssistantMessage(reply.Message.Content, nil))
+		req.LastIntent = reply.Intent
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent/react"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	intentTodo   = "todo"
+	intentSearch = "search"
+	intentChat   = "chat"
+
+	nodeClassify = "classify"
+	nodeFinish   = "finish"
+
+	// classifyWindow is the number of recent messages the classifier sees
+	classifyWindow = 6
+)
+
+// Request is one turn of the conversation: the history, ending with the new user message, and the
+// agent that handled the previous turn.
+type Request struct {
+	History    []*schema.Message
+	LastIntent string
+}
+
+// Reply is the answer of the agent the turn was routed to.
+type Reply struct {
+	Intent  string
+	Reason  string
+	Message *schema.Message
+}
+
+type routerState struct {
+	intent string
+	reason string
+}
+
+type classification struct {
+	Intent string `json:"intent"`
+	Reason string `json:"reason"`
+}
+
+// buildRouter wires the classifier and the three agents:
+//
+//	START -> classify --(todo)---> todo_agent ---+
+//	                  --(search)-> search_agent -+-> finish -> END
+//	                  --(chat)---> chat ---------+
+//
+// Every agent receives the whole conversation, so an agent picks up where another one left off, for example the
+// todo agent can add a reminder about something the search agent found. The classifier only sees the recent
+// messages and which agent answered last, which is enough to route follow-ups.
+func buildRouter(ctx context.Context, classifier, chat model.ChatModel, todoAgent, searchAgent *react.Agent) (compose.Runnable[*Request, *Reply], error) {
+	g := compose.NewGraph[*Request, *Reply](compose.WithGenLocalState(func(ctx context.Context) *routerState {
+		return &routerState{}
+	}))
+
+	_ = g.AddLambdaNode(nodeClassify, compose.InvokableLambda(func(ctx context.Context, req *Request) ([]*schema.Message, error) {
+		c, err := classify(ctx, classifier, req)
+		if err != nil {
+			return nil, err
+		}
+		err = compose.ProcessState[*routerState](ctx, func(_ context.Context, s *routerState) error {
+			s.intent, s.reason = c.Intent, c.Reason
+			return nil
+		})
+		// the agents all take the conversation, the intent goes through the state to the branch
+		return req.History, err
+	}))
+
+	// a react agent is a graph itself, it can be added as a node with the options it exports
+	todoGraph, todoOpts := todoAgent.ExportGraph()
+	_ = g.AddGraphNode(intentTodo, todoGraph, append(todoOpts, compose.WithNodeName("todo_agent"))...)
+	searchGraph, searchOpts := searchAgent.ExportGraph()
+	_ = g.AddGraphNode(intentSearch, searchGraph, append(searchOpts, compose.WithNodeName("search_agent"))...)
+	_ = g.AddChatModelNode(intentChat, chat, compose.WithStatePreHandler(
+		func(ctx context.Context, in []*schema.Message, _ *routerState) ([]*schema.Message, error) {
+			return prompt.MustGet("multiagent_intent_router_chat").Format(ctx, map[string]any{"history": in})
+		}), compose.WithNodeName("chat"))
+
+	_ = g.AddLambdaNode(nodeFinish, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*Reply, error) {
+		reply := &Reply{Message: msg}
+		err := compose.ProcessState[*routerState](ctx, func(_ context.Context, s *routerState) error {
+			reply.Intent, reply.Reason = s.intent, s.reason
+			return nil
+		})
+		return reply, err
+	}))
+
+	_ = g.AddEdge(compose.START, nodeClassify)
+	_ = g.AddBranch(nodeClassify, compose.NewGraphBranch(func(ctx context.Context, _ []*schema.Message) (string, error) {
+		var intent string
+		err := compose.ProcessState[*routerState](ctx, func(_ context.Context, s *routerState) error {
+			intent = s.intent
+			return nil
+		})
+		return intent, err
+	}, map[string]bool{intentTodo: true, intentSearch: true, intentChat: true}))
+	for _, n := range []string{intentTodo, intentSearch, intentChat} {
+		_ = g.AddEdge(n, nodeFinish)
+	}
+	_ = g.AddEdge(nodeFinish, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("intent_router"))
+}
+
+func classify(ctx context.Context, cm model.ChatModel, req *Request) (*classification, error) {
+	recent := req.History
+	if len(recent) > classifyWindow {
+		recent = recent[len(recent)-classifyWindow:]
+	}
+	sb := strings.Builder{}
+	for _, m := range recent {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", m.Role, m.Content))
+	}
+
+	last := req.LastIntent
+	if last == "" {
+		last = "nobody, this is the first message"
+	}
+	in, err := prompt.MustGet("multiagent_intent_router_classify").Format(ctx, map[string]any{
+		"last":         last,
+		"conversation": sb.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	msg, err := cm.Generate(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	content := strings.TrimSpace(msg.Content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+
+	c := &classification{}
+	if err = json.Unmarshal([]byte(strings.TrimSpace(content)), c); err != nil {
+		// a classifier that does not follow the format should not break the conversation
+		return &classification{Intent: intentChat, Reason: "unclassified"}, nil
+	}
+	switch c.Intent {
+	case intentTodo, intentSearch, intentChat:
+		return c, nil
+	}
+	return &classification{Intent: intentChat, Reason: fmt.Sprintf("unknown intent %q", c.Intent)}, nil
+}
+
+// newAgent builds a ReAct agent whose persona is the prompt name.
+func newAgent(ctx context.Context, cm model.ChatModel, name string, tools []tool.BaseTool) (*react.Agent, error) {
+	persona, err := prompt.MustGet(name).Format(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return react.NewAgent(ctx, &react.AgentConfig{
+		Model:           cm,
+		ToolsConfig:     compose.ToolsNodeConfig{Tools: tools},
+		MessageModifier: react.NewPersonaModifier(persona[0].Content),
+		MaxStep:         10,
+	})
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+type todo struct {
+	ID      int    `json:"id"`
+	Content string `json:"content"`
+	Due     string `json:"due,omitempty"`
+	Done    bool   `json:"done"`
+}
+
+// todoList is the data of the todo agent. It outlives a single run of the graph, like a database would.
+type todoList struct {
+	mu     sync.Mutex
+	todos  []*todo
+	nextID int
+}
+
+type addTodoInput struct {
+	Content string `json:"content"`
+	Due     string `json:"due,omitempty" jsonschema:"description=the due date as YYYY-MM-DD if the user gave one"`
+}
+
+type listTodosInput struct {
+	IncludeDone bool `json:"include_done,omitempty"`
+}
+
+type completeTodoInput struct {
+	ID int `json:"id"`
+}
+
+func (l *todoList) tools() ([]tool.BaseTool, error) {
+	add, err := utils.InferTool("add_todo", "add a todo item", func(ctx context.Context, in *addTodoInput) (*todo, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.nextID++
+		t := &todo{ID: l.nextID, Content: in.Content, Due: in.Due}
+		l.todos = append(l.todos, t)
+		return t, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := utils.InferTool("list_todos", "list the todo items", func(ctx context.Context, in *listTodosInput) ([]*todo, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		res := make([]*todo, 0, len(l.todos))
+		for _, t := range l.todos {
+			if !t.Done || in.IncludeDone {
+				cp := *t
+				res = append(res, &cp)
+			}
+		}
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	complete, err := utils.InferTool("complete_todo", "mark a todo item as done", func(ctx context.Context, in *completeTodoInput) (string, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for _, t := range l.todos {
+			if t.ID == in.ID {
+				t.Done = true
+				return fmt.Sprintf("todo %d is done", in.ID), nil
+			}
+		}
+		return fmt.Sprintf("there is no todo %d, list the todos to find its id", in.ID), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []tool.BaseTool{add, list, complete}, nil
+}
@@ -22,11 +22,9 @@ import (
 	"fmt"
 	"io"
 	"log"
-	"os"
 	"sync"
 
 	"github.com/bytedance/sonic"
-	"github.com/cloudwego/eino-ext/components/model/ark"
 	"github.com/cloudwego/eino-ext/components/model/deepseek"
 	callbacks2 "github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/model"
@@ -38,27 +36,19 @@ import (
 
 	"github.com/cloudwego/eino-examples/flow/agent/multiagent/plan_execute/debug"
 	"github.com/cloudwego/eino-examples/flow/agent/multiagent/plan_execute/tools"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
 )
 
 func main() {
-	ctx := context.Background()
+	validate.Must(validate.Model())
 
-	deepSeekModel, err := deepseek.NewChatModel(ctx, &deepseek.ChatModelConfig{
-		Model:   os.Getenv("DEEPSEEK_MODEL_ID"),
-		APIKey:  os.Getenv("DEEPSEEK_API_KEY"),
-		BaseURL: os.Getenv("DEEPSEEK_BASE_URL"),
-	})
-	if err != nil {
-		log.Fatalf("new DeepSeek model failed: %v", err)
-	}
+	ctx := context.Background()
 
-	arkModel, err := ark.NewChatModel(ctx, &ark.ChatModelConfig{
-		APIKey: os.Getenv("ARK_API_KEY"),
-		Model:  os.Getenv("ARK_MODEL_ID"),
-	})
-	if err != nil {
-		log.Fatalf("new Ark model failed: %v", err)
-	}
+	// 模型由 MODEL_PROVIDER 配置，见 internal/models。planner 与 reviser 用推理模型效果更好，如 DeepSeek R1，
+	// executor 则需要支持工具调用。executor 会 BindTools，因此不能与 planner 共用一个实例
+	plannerModel := models.MustChatModel(ctx)
+	executorModel := models.MustChatModel(ctx)
 
 	toolsConfig, err := tools.GetTools(ctx)
 	if err != nil {
@@ -69,12 +59,12 @@ func main() {
 	config := &Config{
 		// planner 在调试时大部分场景不需要真的去生成，可以用 mock 输出替代
 		PlannerModel: &debug.ChatModelDebugDecorator{
-			Model: deepSeekModel,
+			Model: plannerModel,
 		},
-		ExecutorModel: arkModel,
+		ExecutorModel: executorModel,
 		ToolsConfig:   compose.ToolsNodeConfig{Tools: toolsConfig},
 		ReviserModel: &debug.ChatModelDebugDecorator{
-			Model: deepSeekModel,
+			Model: plannerModel,
 		},
 	}
 
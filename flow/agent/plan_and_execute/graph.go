@@ -0,0 +1,233 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent/react"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodePlanner   = "planner"
+	nodeExecutor  = "executor"
+	nodeReplanner = "replanner"
+	nodeAnswer    = "answer"
+
+	defaultMaxReplans = 2
+	// maxRunSteps bounds the executor loop, one run step per executed step of the plan
+	maxRunSteps = 50
+)
+
+// Config configures the plan-and-execute agent.
+type Config struct {
+	// PlannerModel writes and revises the plan, and writes the final answer. It must not have tools bound.
+	PlannerModel model.ChatModel
+	// ExecutorModel runs one step at a time with Tools. The tools are bound to it, so do not share it with the planner.
+	ExecutorModel model.ChatModel
+	Tools         []tool.BaseTool
+	// MaxReplans is the number of times a failed step can be planned around, default 2.
+	MaxReplans int
+	// OnPlanUpdate is called with the plan after it is made, after every executed step, and after every replan.
+	OnPlanUpdate func(p *Plan)
+}
+
+// buildPlanExecute orchestrates the agent in a graph whose payload is the *Plan itself:
+//
+//	START -> planner -> executor --(steps left)--> executor
+//	                       |  ^
+//	                       |  +---------------- replanner <--(step failed)--+
+//	                       +--(all done, or failed and out of replans)--> answer -> END
+//
+// The executor is a ReAct agent running a single step with the tools, the plan only moves forward
+// after a step is done, and a failure sends the plan back to the planner with the error.
+func buildPlanExecute(ctx context.Context, config *Config) (compose.Runnable[string, *schema.Message], error) {
+	maxReplans := config.MaxReplans
+	if maxReplans <= 0 {
+		maxReplans = defaultMaxReplans
+	}
+	emit := func(p *Plan) {
+		if config.OnPlanUpdate != nil {
+			config.OnPlanUpdate(p)
+		}
+	}
+
+	toolList, err := describeTools(ctx, config.Tools)
+	if err != nil {
+		return nil, err
+	}
+
+	executor, err := react.NewAgent(ctx, &react.AgentConfig{
+		Model:       config.ExecutorModel,
+		ToolsConfig: compose.ToolsNodeConfig{Tools: config.Tools},
+		MaxStep:     12,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g := compose.NewGraph[string, *schema.Message]()
+
+	_ = g.AddLambdaNode(nodePlanner, compose.InvokableLambda(func(ctx context.Context, goal string) (*Plan, error) {
+		in, err := prompt.MustGet("agent_plan_and_execute_plan").Format(ctx, map[string]any{"tools": toolList, "goal": goal})
+		if err != nil {
+			return nil, err
+		}
+		msg, err := config.PlannerModel.Generate(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		steps, err := parseSteps(msg.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		p := &Plan{Goal: goal, Steps: steps}
+		emit(p)
+		return p, nil
+	}))
+
+	_ = g.AddLambdaNode(nodeExecutor, compose.InvokableLambda(func(ctx context.Context, p *Plan) (*Plan, error) {
+		step := p.next()
+		if step == nil {
+			return p, nil
+		}
+
+		in, err := prompt.MustGet("agent_plan_and_execute_execute").Format(ctx, map[string]any{
+			"goal":    p.Goal,
+			"results": formatResults(p.done()),
+			"step":    step.Description,
+		})
+		if err != nil {
+			return nil, err
+		}
+		msg, err := executor.Generate(ctx, in)
+		// a tool returning an error aborts the agent run, it counts as a failure of the step like a FAILED reply
+		switch {
+		case err != nil:
+			step.Status, step.Result = statusFailed, err.Error()
+		case strings.HasPrefix(strings.TrimSpace(msg.Content), "FAILED"):
+			step.Status, step.Result = statusFailed, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(msg.Content), "FAILED:"))
+		default:
+			step.Status, step.Result = statusDone, strings.TrimSpace(msg.Content)
+		}
+
+		emit(p)
+		return p, nil
+	}))
+
+	_ = g.AddLambdaNode(nodeReplanner, compose.InvokableLambda(func(ctx context.Context, p *Plan) (*Plan, error) {
+		failed := p.failed()
+		in, err := prompt.MustGet("agent_plan_and_execute_replan").Format(ctx, map[string]any{
+			"tools":   toolList,
+			"goal":    p.Goal,
+			"results": formatResults(p.done()),
+			"step":    failed.Description,
+			"error":   failed.Result,
+		})
+		if err != nil {
+			return nil, err
+		}
+		msg, err := config.PlannerModel.Generate(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		steps, err := parseSteps(msg.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		// keep what was done and the failed step for the record, drop the pending steps of the old plan
+		kept := make([]*Step, 0, len(p.Steps)+len(steps))
+		for _, s := range p.Steps {
+			switch s.Status {
+			case statusFailed:
+				s.Status = statusReplaced
+				kept = append(kept, s)
+			case statusDone, statusReplaced:
+				kept = append(kept, s)
+			}
+		}
+		p.Steps = append(kept, steps...)
+		p.Replans++
+
+		emit(p)
+		return p, nil
+	}))
+
+	_ = g.AddLambdaNode(nodeAnswer, compose.InvokableLambda(func(ctx context.Context, p *Plan) (*schema.Message, error) {
+		results := formatResults(p.done())
+		if failed := p.failed(); failed != nil {
+			results += fmt.Sprintf("- FAILED %s: %s\n", failed.Description, failed.Result)
+		}
+		in, err := prompt.MustGet("agent_plan_and_execute_answer").Format(ctx, map[string]any{"goal": p.Goal, "results": results})
+		if err != nil {
+			return nil, err
+		}
+		return config.PlannerModel.Generate(ctx, in)
+	}))
+
+	_ = g.AddEdge(compose.START, nodePlanner)
+	_ = g.AddEdge(nodePlanner, nodeExecutor)
+	_ = g.AddBranch(nodeExecutor, compose.NewGraphBranch(func(ctx context.Context, p *Plan) (string, error) {
+		if p.failed() != nil {
+			if p.Replans < maxReplans {
+				return nodeReplanner, nil
+			}
+			return nodeAnswer, nil
+		}
+		if p.next() != nil {
+			return nodeExecutor, nil
+		}
+		return nodeAnswer, nil
+	}, map[string]bool{nodeExecutor: true, nodeReplanner: true, nodeAnswer: true}))
+	_ = g.AddEdge(nodeReplanner, nodeExecutor)
+	_ = g.AddEdge(nodeAnswer, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("plan_and_execute"), compose.WithMaxRunSteps(maxRunSteps))
+}
+
+func describeTools(ctx context.Context, tools []tool.BaseTool) (string, error) {
+	sb := strings.Builder{}
+	for _, t := range tools {
+		info, err := t.Info(ctx)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(fmt.Sprintf("- %s: %s\n", info.Name, info.Desc))
+	}
+	return sb.String(), nil
+}
+
+func formatResults(steps []*Step) string {
+	if len(steps) == 0 {
+		return "(none)\n"
+	}
+	sb := strings.Builder{}
+	for _, s := range steps {
+		sb.WriteString(fmt.Sprintf("- %s: %s\n", s.Description, s.Result))
+	}
+	return sb.String()
+}
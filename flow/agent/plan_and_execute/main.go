@@ -0,0 +1,69 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/model"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example is a plan-and-execute agent: a planner splits the goal into steps up front, an executor runs them
+// one by one with tools, and when a step fails the planner revises the rest of the plan knowing what went wrong.
+// Compared with a plain ReAct agent, the plan is explicit, so progress can be shown and long tasks stay on track.
+// The plan is printed after every change. Unlike ../multiagent/plan_execute, which passes free-form messages
+// between its agents, the plan here is a typed struct carried from node to node.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	newModel := func() model.ChatModel {
+		return models.MustChatModel(ctx, models.WithTemperature(0))
+	}
+
+	tools, err := newTools()
+	if err != nil {
+		logs.Fatalf("newTools failed, err=%v", err)
+	}
+
+	r, err := buildPlanExecute(ctx, &Config{
+		PlannerModel:  newModel(),
+		ExecutorModel: newModel(),
+		Tools:         tools,
+		OnPlanUpdate: func(p *Plan) {
+			logs.Infof("%s", p)
+		},
+	})
+	if err != nil {
+		logs.Fatalf("buildPlanExecute failed, err=%v", err)
+	}
+
+	// there is no EMEA region in the data, the first plan usually asks for it and has to be revised
+	goal := "Compare the Q2 revenue of EMEA with North America in USD, and tell which one grew more since Q1."
+	out, err := r.Invoke(ctx, goal)
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	logs.Infof("goal: %s", goal)
+	logs.Tokenf("%s\n", out.Content)
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type stepStatus string
+
+const (
+	statusPending stepStatus = "pending"
+	statusDone    stepStatus = "done"
+	statusFailed  stepStatus = "failed"
+	// statusReplaced marks a failed step the replanner has already worked around
+	statusReplaced stepStatus = "replaced"
+)
+
+// Step is one step of the plan. Result holds the output of the executor, or the reason of the failure.
+type Step struct {
+	Description string
+	Status      stepStatus
+	Result      string
+}
+
+// Plan is what flows through the graph: every node takes the plan and returns it updated.
+type Plan struct {
+	Goal    string
+	Steps   []*Step
+	Replans int
+}
+
+// next returns the first pending step, nil when there is none left.
+func (p *Plan) next() *Step {
+	for _, s := range p.Steps {
+		if s.Status == statusPending {
+			return s
+		}
+	}
+	return nil
+}
+
+// failed returns the step that failed and has not been replanned yet, nil if there is none.
+func (p *Plan) failed() *Step {
+	for _, s := range p.Steps {
+		if s.Status == statusFailed {
+			return s
+		}
+	}
+	return nil
+}
+
+// done returns the steps done so far, with their results.
+func (p *Plan) done() []*Step {
+	var steps []*Step
+	for _, s := range p.Steps {
+		if s.Status == statusDone {
+			steps = append(steps, s)
+		}
+	}
+	return steps
+}
+
+// String renders the plan as a checklist, e.g.
+//
+//	[x] 1. list the regions -> europe, middle_east, ...
+//	[ ] 2. get the Q2 sales of europe
+func (p *Plan) String() string {
+	marks := map[stepStatus]string{statusPending: "[ ]", statusDone: "[x]", statusFailed: "[!]", statusReplaced: "[~]"}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("plan for %q, replanned %d times:\n", p.Goal, p.Replans))
+	for i, s := range p.Steps {
+		sb.WriteString(fmt.Sprintf("  %s %d. %s", marks[s.Status], i+1, s.Description))
+		if s.Result != "" {
+			sb.WriteString(" -> " + oneLine(s.Result, 100))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// parseSteps reads the {"steps": [...]} reply of the planner.
+func parseSteps(content string) ([]*Step, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+
+	var out struct {
+		Steps []string `json:"steps"`
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(content)), &out); err != nil {
+		return nil, fmt.Errorf("unexpected plan %q: %w", content, err)
+	}
+
+	steps := make([]*Step, 0, len(out.Steps))
+	for _, d := range out.Steps {
+		if d = strings.TrimSpace(d); d != "" {
+			steps = append(steps, &Step{Description: d, Status: statusPending})
+		}
+	}
+	return steps, nil
+}
+
+func oneLine(s string, max int) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) > max {
+		return s[:max] + "..."
+	}
+	return s
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+// revenue in millions of local currency, by region and quarter
+var revenue = map[string]map[string]float64{
+	"europe":        {"Q1": 41.2, "Q2": 44.0},
+	"middle_east":   {"Q1": 6.1, "Q2": 7.9},
+	"africa":        {"Q1": 3.4, "Q2": 3.3},
+	"north_america": {"Q1": 58.5, "Q2": 60.1},
+	"asia_pacific":  {"Q1": 5210, "Q2": 5530},
+}
+
+var currencies = map[string]string{
+	"europe":        "EUR",
+	"middle_east":   "USD",
+	"africa":        "USD",
+	"north_america": "USD",
+	"asia_pacific":  "JPY",
+}
+
+// usdRates is the value of one unit of the currency in USD
+var usdRates = map[string]float64{"USD": 1, "EUR": 1.08, "JPY": 0.0067}
+
+type listRegionsInput struct{}
+
+type getSalesInput struct {
+	Region  string `json:"region" jsonschema:"description=the region name as returned by list_regions"`
+	Quarter string `json:"quarter" jsonschema:"description=the quarter such as Q1"`
+}
+
+type sales struct {
+	Region   string  `json:"region"`
+	Quarter  string  `json:"quarter"`
+	Revenue  float64 `json:"revenue_millions"`
+	Currency string  `json:"currency"`
+}
+
+type convertInput struct {
+	Amount float64 `json:"amount"`
+	From   string  `json:"from" jsonschema:"description=ISO code of the currency of amount"`
+	To     string  `json:"to" jsonschema:"description=ISO code of the target currency"`
+}
+
+// newTools returns the tools of the executor. get_sales only knows the fine grained regions, so a plan asking
+// for "EMEA" fails and has to be revised, which is what this example wants to show.
+func newTools() ([]tool.BaseTool, error) {
+	listRegions, err := utils.InferTool("list_regions", "list the names of the sales regions",
+		func(ctx context.Context, _ *listRegionsInput) ([]string, error) {
+			regions := make([]string, 0, len(revenue))
+			for r := range revenue {
+				regions = append(regions, r)
+			}
+			sort.Strings(regions)
+			return regions, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	getSales, err := utils.InferTool("get_sales", "get the revenue of one region for one quarter, in millions of the local currency",
+		func(ctx context.Context, in *getSalesInput) (*sales, error) {
+			region := strings.ToLower(strings.TrimSpace(in.Region))
+			byQuarter, ok := revenue[region]
+			if !ok {
+				return nil, fmt.Errorf("unknown region %q, call list_regions for the valid names", in.Region)
+			}
+			quarter := strings.ToUpper(strings.TrimSpace(in.Quarter))
+			v, ok := byQuarter[quarter]
+			if !ok {
+				return nil, fmt.Errorf("no data for quarter %q", in.Quarter)
+			}
+			return &sales{Region: region, Quarter: quarter, Revenue: v, Currency: currencies[region]}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	convert, err := utils.InferTool("convert_currency", "convert an amount from one currency to another",
+		func(ctx context.Context, in *convertInput) (float64, error) {
+			from, ok := usdRates[strings.ToUpper(in.From)]
+			if !ok {
+				return 0, fmt.Errorf("unknown currency %q", in.From)
+			}
+			to, ok := usdRates[strings.ToUpper(in.To)]
+			if !ok {
+				return 0, fmt.Errorf("unknown currency %q", in.To)
+			}
+			return in.Amount * from / to, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return []tool.BaseTool{listRegions, getSales, convert}, nil
+}
@@ -22,9 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
 
-	"github.com/cloudwego/eino-ext/components/model/openai"
 	"github.com/cloudwego/eino/callbacks"
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/compose"
@@ -34,36 +32,30 @@ import (
 
 	"github.com/cloudwego/eino-examples/flow/agent/react/tools"
 	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/streamprint"
+	"github.com/cloudwego/eino-examples/internal/validate"
 )
 
 func main() {
-	openAIAPIKey := os.Getenv("OPENAI_API_KEY")
-	// openAIBaseURL := os.Getenv("OPENAI_BASE_URL")
-	openAIModelName := os.Getenv("OPENAI_MODEL_NAME")
+	validate.Must(validate.Model())
 
 	ctx := context.Background()
 
-	// prepare chat model
-	chatModel, err := openai.NewChatModel(ctx, &openai.ChatModelConfig{
-		// BaseURL: openAIBaseURL, // if using Azure or other models conforming to OpenAI protocol, set this to your model's base URL
-		APIKey: openAIAPIKey,
-		Model:  openAIModelName,
-		// ByAzure:    true, // if using Azure's OpenAI API, set this to true
-		APIVersion: "2024-06-01",
-	})
-	if err != nil {
-		logs.Errorf("failed to create chat model: %v", err)
-		return
-	}
+	// prepare chat model, MODEL_PROVIDER picks the provider, e.g. azure for Azure OpenAI, see internal/models
+	chatModel := models.MustChatModel(ctx)
 
 	// prepare tools
 	restaurantTool := tools.GetRestaurantTool() // 查询餐厅信息的工具
 	dishTool := tools.GetDishTool()             // 查询餐厅菜品信息的工具
 
-	// prepare persona (system prompt) (optional)
-	persona := `# Character:
-你是一个帮助用户推荐餐厅和菜品的助手，根据用户的需要，查询餐厅信息并推荐，查询餐厅的菜品并推荐。
-`
+	// prepare persona (system prompt) (optional), from prompts/agent_react_persona.prompt
+	persona, err := prompt.MustGet("agent_react_persona").Format(ctx, nil)
+	if err != nil {
+		logs.Errorf("failed to format persona: %v", err)
+		return
+	}
 
 	ragent, err := react.NewAgent(ctx, &react.AgentConfig{
 		Model: chatModel,
@@ -71,7 +63,7 @@ func main() {
 			Tools: []tool.BaseTool{restaurantTool, dishTool},
 		},
 
-		MessageModifier: react.NewPersonaModifier(persona),
+		MessageModifier: react.NewPersonaModifier(persona[0].Content),
 	})
 	if err != nil {
 		logs.Errorf("failed to create agent: %v", err)
@@ -102,24 +94,12 @@ func main() {
 		return
 	}
 
-	defer sr.Close() // remember to close the stream
-
 	logs.Infof("\n\n===== start streaming =====\n\n")
 
-	for {
-		msg, err := sr.Recv()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				// finish
-				break
-			}
-			// error
-			logs.Infof("failed to recv: %v", err)
-			return
-		}
-
-		// 打字机打印
-		logs.Tokenf("%v", msg.Content)
+	// 打字机打印
+	if _, err = streamprint.Print(sr); err != nil {
+		logs.Infof("failed to recv: %v", err)
+		return
 	}
 
 	logs.Infof("\n\n===== finished =====\n")
@@ -0,0 +1,206 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent"
+	"github.com/cloudwego/eino/flow/agent/react"
+	"github.com/cloudwego/eino/schema"
+	template "github.com/cloudwego/eino/utils/callbacks"
+
+	"github.com/cloudwego/eino-examples/internal/cost"
+	"github.com/cloudwego/eino-examples/internal/errors"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/memory"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/streamprint"
+	"github.com/cloudwego/eino-examples/internal/tracing"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const (
+	// maxToolRounds is the number of model calls with tool calls the agent may make before it must answer
+	maxToolRounds = 6
+	// maxHistory is the number of messages of the conversation sent to the model, besides the system prompt
+	maxHistory = 12
+	// summarizeAfter is the length of the conversation kept between turns above which the older turns are summarized
+	summarizeAfter = 8
+)
+
+// This example shows the options of the ReAct agent in flow/agent/react, on a shop assistant with three tools:
+//
+//   - MessageModifier builds the system prompt on every model call, with today's date, and trims the history
+//   - MaxStep bounds the number of tool rounds, a model stuck calling tools fails instead of looping forever
+//   - ToolReturnDirectly makes the output of place_order the answer, without another model call
+//   - StreamToolCallChecker finds tool calls anywhere in the stream, not only in the first chunk
+//   - the callbacks built with react.BuildAgentCallback log every model and tool call
+//
+// The answer is streamed to the terminal, and the conversation is kept across turns.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+	// the runs of the agent are exported as spans when OTEL_EXPORTER_OTLP_ENDPOINT is set, e.g. to a local Jaeger
+	shutdown := tracing.MustSetup(ctx, nil)
+	defer shutdown(ctx)
+
+	cm := models.MustChatModel(ctx, models.WithTemperature(0))
+
+	tools, err := newTools()
+	if err != nil {
+		logs.Fatalf("newTools failed, err=%v", err)
+	}
+
+	ra, err := react.NewAgent(ctx, &react.AgentConfig{
+		Model:       cm,
+		ToolsConfig: compose.ToolsNodeConfig{Tools: tools},
+		// the modifier sees the whole conversation, including the tool calls of the current run, on every model call
+		MessageModifier: func(ctx context.Context, input []*schema.Message) []*schema.Message {
+			res := make([]*schema.Message, 0, maxHistory+2)
+			res = append(res, formatPersona(ctx, time.Now()))
+			if len(input) > 0 && memory.IsSummary(input[0]) {
+				res = append(res, input[0])
+				input = input[1:]
+			}
+			return append(res, trimHistory(input, maxHistory)...)
+		},
+		// every tool round runs the model and the tools node, and the final answer is one more model call
+		MaxStep:               2*maxToolRounds + 1,
+		ToolReturnDirectly:    map[string]struct{}{toolPlaceOrder: {}},
+		StreamToolCallChecker: toolCallChecker,
+	})
+	if err != nil {
+		logs.Fatalf("NewAgent failed, err=%v", err)
+	}
+
+	cb := react.BuildAgentCallback(&template.ModelCallbackHandler{
+		OnStart: func(ctx context.Context, info *callbacks.RunInfo, input *model.CallbackInput) context.Context {
+			logs.Infof("model called with %d messages", len(input.Messages))
+			return ctx
+		},
+	}, &template.ToolCallbackHandler{
+		OnStart: func(ctx context.Context, info *callbacks.RunInfo, input *tool.CallbackInput) context.Context {
+			logs.Infof("tool %s called with %s", info.Name, input.ArgumentsInJSON)
+			return ctx
+		},
+		OnEnd: func(ctx context.Context, info *callbacks.RunInfo, output *tool.CallbackOutput) context.Context {
+			logs.Infof("tool %s returned %s", info.Name, output.Response)
+			return ctx
+		},
+		OnError: func(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
+			logs.Errorf("tool %s failed with %s, err=%v", info.Name, errors.KindOf(err), err)
+			return ctx
+		},
+	})
+
+	// the older turns are summarized instead of dropped, the products the user asked about stay known
+	summary, err := memory.NewSummary(&memory.SummaryConfig{Model: cm, MaxMessages: summarizeAfter, KeepRecent: 4})
+	if err != nil {
+		logs.Fatalf("NewSummary failed, err=%v", err)
+	}
+
+	turns := []string{
+		"I need a quiet keyboard for an open space, under 100 dollars. What do you have in stock?",
+		"I'll take two of the wireless one, please order them.",
+	}
+
+	tracker := cost.NewTracker()
+	var history []*schema.Message
+	for _, t := range turns {
+		logs.Infof("user: %s", t)
+		history = append(history, schema.UserMessage(t))
+
+		answer, err := streamAnswer(ctx, ra, history, agent.WithComposeOptions(compose.WithCallbacks(cb, tracker.Handler())))
+		if err != nil {
+			logs.Fatalf("streamAnswer failed, err=%v", err)
+		}
+		// only the final answer is kept, the tool calls of the run are not part of the history
+		history = append(history, schema.AssistantMessage(answer.Content, nil))
+		if history, err = summary.Compact(ctx, history); err != nil {
+			logs.Fatalf("Compact failed, err=%v", err)
+		}
+	}
+	tracker.Report("usage")
+}
+
+// streamAnswer prints the answer as it is generated and returns it concatenated.
+func streamAnswer(ctx context.Context, ra *react.Agent, history []*schema.Message, opts ...agent.AgentOption) (*schema.Message, error) {
+	sr, err := ra.Stream(ctx, history, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return streamprint.Print(sr)
+}
+
+// formatPersona returns the system message of the agent with the date of now. A modifier cannot return an error, and a
+// prompt which does not format is a bug of the example, so it exits.
+func formatPersona(ctx context.Context, now time.Time) *schema.Message {
+	msgs, err := prompt.MustGet("agent_react_full_persona").Format(ctx, map[string]any{
+		"today": now.Format("Monday, January 2, 2006"),
+	})
+	if err != nil {
+		logs.Fatalf("format persona failed, err=%v", err)
+	}
+	return msgs[0]
+}
+
+// trimHistory keeps at most limit messages, starting at a user message so that a tool result is never separated
+// from the assistant message calling the tool. If the current run alone is longer than limit, it is kept whole.
+func trimHistory(msgs []*schema.Message, limit int) []*schema.Message {
+	if len(msgs) <= limit {
+		return msgs
+	}
+	last := 0
+	for i, m := range msgs {
+		if m.Role != schema.User {
+			continue
+		}
+		last = i
+		if len(msgs)-i <= limit {
+			return msgs[i:]
+		}
+	}
+	return msgs[last:]
+}
+
+// toolCallChecker reads the stream until it finds a tool call. The default checker of the agent only looks at the
+// first chunk, which is enough for OpenAI models but not for models writing some text before calling a tool.
+// The checker must close the stream.
+func toolCallChecker(_ context.Context, sr *schema.StreamReader[*schema.Message]) (bool, error) {
+	defer sr.Close()
+	for {
+		msg, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if len(msg.ToolCalls) > 0 {
+			return true, nil
+		}
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+)
+
+const toolPlaceOrder = "place_order"
+
+type product struct {
+	SKU   string   `json:"sku"`
+	Name  string   `json:"name"`
+	Price float64  `json:"price"`
+	Tags  []string `json:"tags"`
+}
+
+var catalog = []*product{
+	{SKU: "KB-101", Name: "Compact mechanical keyboard, brown switches", Price: 89, Tags: []string{"keyboard", "mechanical", "compact"}},
+	{SKU: "KB-205", Name: "Full size mechanical keyboard, silent red switches", Price: 129, Tags: []string{"keyboard", "mechanical", "silent"}},
+	{SKU: "KB-310", Name: "Low profile wireless keyboard", Price: 59, Tags: []string{"keyboard", "wireless", "silent"}},
+	{SKU: "MS-020", Name: "Ergonomic vertical mouse", Price: 45, Tags: []string{"mouse", "ergonomic", "wireless"}},
+	{SKU: "MS-031", Name: "Silent click wireless mouse", Price: 25, Tags: []string{"mouse", "wireless", "silent"}},
+}
+
+type shop struct {
+	mu     sync.Mutex
+	stock  map[string]int
+	orders int
+}
+
+type searchInput struct {
+	Keywords []string `json:"keywords" jsonschema:"description=words describing the product such as silent or keyboard"`
+	MaxPrice float64  `json:"max_price,omitempty" jsonschema:"description=the highest acceptable price in USD and 0 for no limit"`
+}
+
+type stockInput struct {
+	SKU string `json:"sku"`
+}
+
+type stockOutput struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+type orderInput struct {
+	SKU      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+// newTools returns the tools of the shop assistant. place_order is configured as a return-directly tool in main,
+// so its output is the answer of the agent and the model cannot paraphrase an order confirmation.
+func newTools() ([]tool.BaseTool, error) {
+	s := &shop{stock: map[string]int{"KB-101": 4, "KB-205": 0, "KB-310": 12, "MS-020": 3, "MS-031": 30}}
+
+	search, err := utils.InferTool("search_products", "search the catalog by keywords, a product matches if it has all the keywords",
+		func(ctx context.Context, in *searchInput) ([]*product, error) {
+			var found []*product
+			for _, p := range catalog {
+				if in.MaxPrice > 0 && p.Price > in.MaxPrice {
+					continue
+				}
+				if matchAll(p, in.Keywords) {
+					found = append(found, p)
+				}
+			}
+			return found, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	checkStock, err := utils.InferTool("check_stock", "get the quantity in stock of a product",
+		func(ctx context.Context, in *stockInput) (*stockOutput, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			q, ok := s.stock[in.SKU]
+			if !ok {
+				return nil, fmt.Errorf("unknown sku %q", in.SKU)
+			}
+			return &stockOutput{SKU: in.SKU, Quantity: q}, nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	placeOrder, err := utils.InferTool(toolPlaceOrder, "order a product for the user, only call it once the user has confirmed the product and the quantity",
+		func(ctx context.Context, in *orderInput) (string, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if in.Quantity <= 0 {
+				return "", fmt.Errorf("invalid quantity %d", in.Quantity)
+			}
+			if s.stock[in.SKU] < in.Quantity {
+				return fmt.Sprintf("Sorry, only %d of %s left in stock, the order was not placed.", s.stock[in.SKU], in.SKU), nil
+			}
+			s.stock[in.SKU] -= in.Quantity
+			s.orders++
+			return fmt.Sprintf("Order #%04d confirmed: %d x %s.", s.orders, in.Quantity, in.SKU), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return []tool.BaseTool{search, checkStock, placeOrder}, nil
+}
+
+func matchAll(p *product, keywords []string) bool {
+	text := strings.ToLower(p.Name + " " + strings.Join(p.Tags, " "))
+	for _, k := range keywords {
+		if !strings.Contains(text, strings.ToLower(k)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const (
+	nodePrepare   = "prepare"
+	nodeGenerator = "generator"
+	nodeReview    = "review"
+	nodeCritic    = "critic"
+	nodeJudge     = "judge"
+	nodeRevise    = "revise"
+	nodeFinish    = "finish"
+
+	maxRounds = 3
+	// passScore is the average rubric score, out of 5, a draft needs to be accepted
+	passScore = 4.0
+)
+
+const task = `Write the announcement of a new feature for our changelog, at most 120 words.
+Facts: scheduled exports are now available on the Team and Enterprise plans. Exports can run daily or weekly,
+to S3 or Google Cloud Storage, in CSV or Parquet. They are set up in Settings > Exports. The Free plan is not included.`
+
+// rubric is given to the critic, and to the generator with the feedback of the critic.
+const rubric = `- accuracy: every claim is in the facts, nothing is invented (1-5)
+- completeness: plans, schedule, destinations, formats and where to set it up are all mentioned (1-5)
+- length: at most 120 words, 5 if well under, 1 if over (1-5)
+- tone: plain and factual, no hype words such as "revolutionary" or "game-changing" (1-5)`
+
+// critique is the verdict of the critic on one draft.
+type critique struct {
+	Scores   map[string]int `json:"scores"`
+	Feedback string         `json:"feedback"`
+}
+
+func (c *critique) average() float64 {
+	if len(c.Scores) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, s := range c.Scores {
+		sum += s
+	}
+	return float64(sum) / float64(len(c.Scores))
+}
+
+// Result is the output of the loop: the accepted draft, or the best one if no draft passed within maxRounds.
+type Result struct {
+	Answer string
+	Score  float64
+	Rounds int
+	Passed bool
+}
+
+type reflectionState struct {
+	task      string
+	rounds    int
+	draft     string
+	bestDraft string
+	bestScore float64
+}
+
+// This example is a reflection loop: a generator drafts, a critic scores the draft against a rubric, and the
+// feedback goes back to the generator until the score passes or maxRounds is reached:
+//
+//	START -> prepare -> generator -> review -> critic -> judge --(pass or max rounds)--> finish -> END
+//	                     ^                                |
+//	                     +------------ revise <--(fail)---+
+//
+// generator and critic are the two model nodes, the lambdas around them only build their inputs and parse the verdict.
+// The critic runs with temperature 0 so the same draft gets the same score, the generator keeps some creativity.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	generator := models.MustChatModel(ctx, models.WithTemperature(0.7))
+	critic := models.MustChatModel(ctx, models.WithTemperature(0))
+
+	g := compose.NewGraph[string, *Result](compose.WithGenLocalState(func(ctx context.Context) *reflectionState {
+		return &reflectionState{}
+	}))
+
+	_ = g.AddLambdaNode(nodePrepare, compose.InvokableLambda(func(ctx context.Context, task string) ([]*schema.Message, error) {
+		err := compose.ProcessState[*reflectionState](ctx, func(_ context.Context, s *reflectionState) error {
+			s.task = task
+			return nil
+		})
+		return []*schema.Message{schema.UserMessage(task)}, err
+	}))
+	_ = g.AddChatModelNode(nodeGenerator, generator)
+
+	// keep the draft and ask the critic about it
+	_ = g.AddLambdaNode(nodeReview, compose.InvokableLambda(func(ctx context.Context, draft *schema.Message) ([]*schema.Message, error) {
+		var task string
+		err := compose.ProcessState[*reflectionState](ctx, func(_ context.Context, s *reflectionState) error {
+			s.rounds++
+			s.draft = draft.Content
+			task = s.task
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return prompt.MustGet("agent_reflection_critic").Format(ctx, map[string]any{
+			"task":   task,
+			"rubric": rubric,
+			"draft":  draft.Content,
+		})
+	}))
+	_ = g.AddChatModelNode(nodeCritic, critic)
+
+	_ = g.AddLambdaNode(nodeJudge, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*critique, error) {
+		c, err := parseCritique(msg.Content)
+		if err != nil {
+			return nil, err
+		}
+
+		err = compose.ProcessState[*reflectionState](ctx, func(_ context.Context, s *reflectionState) error {
+			score := c.average()
+			logs.Infof("round %d: score %.2f %v\n%s\nfeedback: %s", s.rounds, score, c.Scores, s.draft, c.Feedback)
+			if score > s.bestScore || s.bestDraft == "" {
+				s.bestDraft, s.bestScore = s.draft, score
+			}
+			return nil
+		})
+		return c, err
+	}))
+
+	// the generator sees its previous draft and the feedback, as a conversation, so it revises instead of starting over
+	_ = g.AddLambdaNode(nodeRevise, compose.InvokableLambda(func(ctx context.Context, c *critique) ([]*schema.Message, error) {
+		var msgs []*schema.Message
+		err := compose.ProcessState[*reflectionState](ctx, func(ctx context.Context, s *reflectionState) error {
+			var err error
+			msgs, err = prompt.MustGet("agent_reflection_revise").Format(ctx, map[string]any{
+				"task":     s.task,
+				"draft":    s.draft,
+				"rubric":   rubric,
+				"feedback": c.Feedback,
+			})
+			return err
+		})
+		return msgs, err
+	}))
+
+	_ = g.AddLambdaNode(nodeFinish, compose.InvokableLambda(func(ctx context.Context, c *critique) (*Result, error) {
+		var res *Result
+		err := compose.ProcessState[*reflectionState](ctx, func(_ context.Context, s *reflectionState) error {
+			if c.average() >= passScore {
+				res = &Result{Answer: s.draft, Score: c.average(), Rounds: s.rounds, Passed: true}
+				return nil
+			}
+			res = &Result{Answer: s.bestDraft, Score: s.bestScore, Rounds: s.rounds}
+			return nil
+		})
+		return res, err
+	}))
+
+	_ = g.AddEdge(compose.START, nodePrepare)
+	_ = g.AddEdge(nodePrepare, nodeGenerator)
+	_ = g.AddEdge(nodeGenerator, nodeReview)
+	_ = g.AddEdge(nodeReview, nodeCritic)
+	_ = g.AddEdge(nodeCritic, nodeJudge)
+	_ = g.AddBranch(nodeJudge, compose.NewGraphBranch(func(ctx context.Context, c *critique) (string, error) {
+		if c.average() >= passScore {
+			return nodeFinish, nil
+		}
+
+		var rounds int
+		err := compose.ProcessState[*reflectionState](ctx, func(_ context.Context, s *reflectionState) error {
+			rounds = s.rounds
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if rounds >= maxRounds {
+			return nodeFinish, nil
+		}
+		return nodeRevise, nil
+	}, map[string]bool{nodeRevise: true, nodeFinish: true}))
+	_ = g.AddEdge(nodeRevise, nodeGenerator)
+	_ = g.AddEdge(nodeFinish, compose.END)
+
+	// one round runs generator, review, critic, judge and revise
+	r, err := g.Compile(ctx, compose.WithGraphName("reflection"), compose.WithMaxRunSteps(5*maxRounds+10))
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	res, err := r.Invoke(ctx, task)
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	if res.Passed {
+		logs.Infof("accepted after %d rounds with score %.2f", res.Rounds, res.Score)
+	} else {
+		logs.Infof("no draft passed in %d rounds, best score %.2f", res.Rounds, res.Score)
+	}
+	logs.Tokenf("%s\n", res.Answer)
+}
+
+func parseCritique(content string) (*critique, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+
+	c := &critique{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(content)), c); err != nil {
+		return nil, fmt.Errorf("unexpected critique %q: %w", content, err)
+	}
+	if len(c.Scores) == 0 {
+		return nil, fmt.Errorf("critique without scores: %q", content)
+	}
+	return c, nil
+}
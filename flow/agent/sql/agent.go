@@ -0,0 +1,185 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodePrepare  = "prepare"
+	nodeGenerate = "generate"
+	nodeExecute  = "execute"
+	nodeFix      = "fix"
+	nodeAnswer   = "answer"
+
+	maxAttempts = 3
+)
+
+// Answer is the output of the agent.
+type Answer struct {
+	Question string
+	SQL      string
+	Result   *queryResult
+	Text     string
+	Attempts int
+	// Err is the error of the last attempt if no query succeeded within maxAttempts.
+	Err error
+}
+
+// execution is the outcome of one attempt.
+type execution struct {
+	sql    string
+	result *queryResult
+	err    error
+}
+
+type sqlState struct {
+	question string
+	// messages is the conversation with the model, every attempt and every error are added to it
+	messages []*schema.Message
+	attempts int
+}
+
+var sqlBlock = regexp.MustCompile("(?s)```(?:sql|sqlite)?\\s*(.*?)```")
+
+// buildSQLAgent builds the self-correcting loop:
+//
+//	START -> prepare -> generate -> execute --(ok, or out of attempts)--> answer -> END
+//	                       ^           |
+//	                       +--- fix <--+ (error)
+//
+// The error of a failed query goes back to the model as the next message of the same conversation, so the model
+// sees its previous attempts and does not repeat them. The schema is read from the database once, when building.
+func buildSQLAgent(ctx context.Context, db *sql.DB, cm model.ChatModel) (compose.Runnable[string, *Answer], error) {
+	dbSchema, err := describeSchema(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("read schema failed: %w", err)
+	}
+
+	g := compose.NewGraph[string, *Answer](compose.WithGenLocalState(func(ctx context.Context) *sqlState {
+		return &sqlState{}
+	}))
+
+	_ = g.AddLambdaNode(nodePrepare, compose.InvokableLambda(func(ctx context.Context, question string) ([]*schema.Message, error) {
+		msgs, err := prompt.MustGet("agent_sql_generate").Format(ctx, map[string]any{"schema": dbSchema, "question": question})
+		if err != nil {
+			return nil, err
+		}
+		err = compose.ProcessState[*sqlState](ctx, func(_ context.Context, s *sqlState) error {
+			s.question = question
+			s.messages = msgs
+			return nil
+		})
+		return msgs, err
+	}))
+
+	_ = g.AddChatModelNode(nodeGenerate, cm, compose.WithStatePostHandler(
+		func(ctx context.Context, out *schema.Message, s *sqlState) (*schema.Message, error) {
+			s.messages = append(s.messages, out)
+			s.attempts++
+			return out, nil
+		}))
+
+	_ = g.AddLambdaNode(nodeExecute, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*execution, error) {
+		exec := &execution{sql: extractSQL(msg.Content)}
+		// a failing query is not an error of the graph, it is what the fix loop is for
+		exec.result, exec.err = runQuery(ctx, db, exec.sql)
+		return exec, nil
+	}))
+
+	_ = g.AddLambdaNode(nodeFix, compose.InvokableLambda(func(ctx context.Context, exec *execution) ([]*schema.Message, error) {
+		var msgs []*schema.Message
+		err := compose.ProcessState[*sqlState](ctx, func(ctx context.Context, s *sqlState) error {
+			var err error
+			s.messages, err = prompt.MustGet("agent_sql_fix").Format(ctx, map[string]any{"history": s.messages, "error": exec.err})
+			msgs = append(msgs, s.messages...)
+			return err
+		})
+		return msgs, err
+	}))
+
+	_ = g.AddLambdaNode(nodeAnswer, compose.InvokableLambda(func(ctx context.Context, exec *execution) (*Answer, error) {
+		ans := &Answer{SQL: exec.sql, Result: exec.result, Err: exec.err}
+		err := compose.ProcessState[*sqlState](ctx, func(_ context.Context, s *sqlState) error {
+			ans.Question, ans.Attempts = s.question, s.attempts
+			return nil
+		})
+		if err != nil || exec.err != nil {
+			return ans, err
+		}
+
+		in, err := prompt.MustGet("agent_sql_answer").Format(ctx, map[string]any{
+			"question": ans.Question,
+			"sql":      ans.SQL,
+			"result":   ans.Result,
+		})
+		if err != nil {
+			return nil, err
+		}
+		msg, err := cm.Generate(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		ans.Text = strings.TrimSpace(msg.Content)
+		return ans, nil
+	}))
+
+	_ = g.AddEdge(compose.START, nodePrepare)
+	_ = g.AddEdge(nodePrepare, nodeGenerate)
+	_ = g.AddEdge(nodeGenerate, nodeExecute)
+	_ = g.AddBranch(nodeExecute, compose.NewGraphBranch(func(ctx context.Context, exec *execution) (string, error) {
+		if exec.err == nil {
+			return nodeAnswer, nil
+		}
+		var attempts int
+		err := compose.ProcessState[*sqlState](ctx, func(_ context.Context, s *sqlState) error {
+			attempts = s.attempts
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		if attempts >= maxAttempts {
+			return nodeAnswer, nil
+		}
+		return nodeFix, nil
+	}, map[string]bool{nodeFix: true, nodeAnswer: true}))
+	_ = g.AddEdge(nodeFix, nodeGenerate)
+	_ = g.AddEdge(nodeAnswer, compose.END)
+
+	// every attempt runs generate, execute and fix
+	return g.Compile(ctx, compose.WithGraphName("sql_agent"), compose.WithMaxRunSteps(3*maxAttempts+10))
+}
+
+// extractSQL returns the content of the first sql code block, or the whole reply if the model forgot the block.
+func extractSQL(content string) string {
+	if m := sqlBlock.FindStringSubmatch(content); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return strings.TrimSpace(content)
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const (
+	// maxRows is the number of rows read from a result, more would not fit in the prompt of the answer anyway
+	maxRows      = 50
+	queryTimeout = 5 * time.Second
+)
+
+//go:embed shop.sql
+var sampleDB string
+
+// openDB opens the sqlite file at path read-only, or an in-memory copy of the sample database if path is empty.
+// The connection is read-only in both cases, the model writes the queries and must not be able to change the data.
+func openDB(ctx context.Context, path string) (*sql.DB, error) {
+	if path != "" {
+		db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=ro", path))
+		if err != nil {
+			return nil, fmt.Errorf("open %s failed: %w", path, err)
+		}
+		return db, db.PingContext(ctx)
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	// every connection to :memory: is a new empty database, keep a single one
+	db.SetMaxOpenConns(1)
+	if _, err = db.ExecContext(ctx, sampleDB); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("load sample database failed: %w", err)
+	}
+	if _, err = db.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// describeSchema returns the CREATE statements of the tables, comments included, which is what the model needs to
+// write queries: the column names, their types, and the hints in the comments such as the date format.
+func describeSchema(ctx context.Context, db *sql.DB) (string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT sql FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var stmts []string
+	for rows.Next() {
+		var s string
+		if err = rows.Scan(&s); err != nil {
+			return "", err
+		}
+		stmts = append(stmts, s+";")
+	}
+	return strings.Join(stmts, "\n\n"), rows.Err()
+}
+
+// queryResult is the result of a query, at most maxRows rows.
+type queryResult struct {
+	Columns   []string
+	Rows      [][]any
+	Truncated bool
+}
+
+// String renders the result as a table with one row per line.
+func (r *queryResult) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(strings.Join(r.Columns, " | "))
+	sb.WriteString("\n")
+	for _, row := range r.Rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = fmt.Sprint(v)
+		}
+		sb.WriteString(strings.Join(cells, " | "))
+		sb.WriteString("\n")
+	}
+	if len(r.Rows) == 0 {
+		sb.WriteString("(no rows)\n")
+	}
+	if r.Truncated {
+		sb.WriteString(fmt.Sprintf("(only the first %d rows are shown)\n", maxRows))
+	}
+	return sb.String()
+}
+
+// runQuery runs a single SELECT statement. Its errors are meant for the model, so they say what to fix.
+func runQuery(ctx context.Context, db *sql.DB, query string) (*queryResult, error) {
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	if query == "" {
+		return nil, errors.New("the query is empty")
+	}
+	if strings.Contains(query, ";") {
+		return nil, errors.New("only one statement is allowed")
+	}
+	if first := strings.ToUpper(strings.Fields(query)[0]); first != "SELECT" && first != "WITH" {
+		return nil, fmt.Errorf("only SELECT queries are allowed, got %s", first)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	res := &queryResult{Columns: cols}
+	for rows.Next() {
+		if len(res.Rows) == maxRows {
+			res.Truncated = true
+			break
+		}
+		values := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err = rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		res.Rows = append(res.Rows, values)
+	}
+	return res, rows.Err()
+}
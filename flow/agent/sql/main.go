@@ -0,0 +1,80 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example answers questions about a database in natural language: the model writes a SQL query from the
+// schema, the query runs, and its error, if any, goes back to the model until the query works. The answer shows
+// the query it is based on, so it can be checked.
+//
+// It runs on a sample shop database bundled in shop.sql, loaded into memory. Point -db to a sqlite file to ask
+// about your own data, it is opened read-only. Needs cgo for the sqlite driver.
+func main() {
+	dbPath := flag.String("db", "", "sqlite file to query, the bundled sample database if empty")
+	question := flag.String("q", "", "the question, a few sample questions if empty")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	db, err := openDB(ctx, *dbPath)
+	if err != nil {
+		logs.Fatalf("openDB failed, err=%v", err)
+	}
+	defer db.Close()
+
+	cm := models.MustChatModel(ctx, models.WithTemperature(0))
+
+	r, err := buildSQLAgent(ctx, db, cm)
+	if err != nil {
+		logs.Fatalf("buildSQLAgent failed, err=%v", err)
+	}
+
+	questions := []string{
+		"Who are the three customers who spent the most in 2024, refunds excluded?",
+		"What is the revenue of each product category, from the most to the least?",
+		"Which country has the highest average order value?",
+	}
+	if *question != "" {
+		questions = []string{*question}
+	}
+
+	for _, q := range questions {
+		ans, err := r.Invoke(ctx, q)
+		if err != nil {
+			logs.Fatalf("Invoke failed, err=%v", err)
+		}
+
+		logs.Infof("question: %s", ans.Question)
+		logs.Tokenf("%s\n", ans.SQL)
+		if ans.Err != nil {
+			logs.Errorf("no working query after %d attempts, last error: %v", ans.Attempts, ans.Err)
+			continue
+		}
+		logs.Tokenf("%s", ans.Result)
+		logs.Infof("answer after %d attempts:", ans.Attempts)
+		logs.Tokenf("%s\n", ans.Text)
+	}
+}
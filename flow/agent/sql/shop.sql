@@ -0,0 +1,95 @@
+-- sample database of the SQL agent: a small online shop
+CREATE TABLE customers (
+    id         INTEGER PRIMARY KEY,
+    name       TEXT NOT NULL,
+    country    TEXT NOT NULL,
+    signed_up  TEXT NOT NULL -- YYYY-MM-DD
+);
+
+CREATE TABLE products (
+    id        INTEGER PRIMARY KEY,
+    name      TEXT NOT NULL,
+    category  TEXT NOT NULL,
+    price     REAL NOT NULL -- USD
+);
+
+CREATE TABLE orders (
+    id           INTEGER PRIMARY KEY,
+    customer_id  INTEGER NOT NULL REFERENCES customers (id),
+    ordered_at   TEXT NOT NULL, -- YYYY-MM-DD
+    status       TEXT NOT NULL  -- paid, shipped, refunded
+);
+
+CREATE TABLE order_items (
+    order_id    INTEGER NOT NULL REFERENCES orders (id),
+    product_id  INTEGER NOT NULL REFERENCES products (id),
+    quantity    INTEGER NOT NULL,
+    unit_price  REAL NOT NULL, -- USD, the price at the time of the order
+    PRIMARY KEY (order_id, product_id)
+);
+
+INSERT INTO customers VALUES
+    (1, 'Alice Martin', 'France', '2023-02-11'),
+    (2, 'Bob Chen', 'Canada', '2023-05-30'),
+    (3, 'Carla Rossi', 'Italy', '2023-09-02'),
+    (4, 'David Kim', 'USA', '2023-11-19'),
+    (5, 'Emma Schulz', 'Germany', '2024-01-08'),
+    (6, 'Farid Haddad', 'France', '2024-03-21'),
+    (7, 'Grace Lee', 'USA', '2024-04-15'),
+    (8, 'Hugo Silva', 'Brazil', '2024-06-03'),
+    (9, 'Ines Dubois', 'France', '2024-09-27'),
+    (10, 'Jack Brown', 'USA', '2024-11-30');
+
+INSERT INTO products VALUES
+    (1, 'Compact mechanical keyboard', 'keyboards', 89.0),
+    (2, 'Silent full size keyboard', 'keyboards', 129.0),
+    (3, 'Wireless low profile keyboard', 'keyboards', 59.0),
+    (4, 'Ergonomic vertical mouse', 'mice', 45.0),
+    (5, 'Silent wireless mouse', 'mice', 25.0),
+    (6, 'XL desk mat', 'accessories', 19.0),
+    (7, 'USB-C hub 7 ports', 'accessories', 39.0),
+    (8, 'Wrist rest', 'accessories', 15.0);
+
+INSERT INTO orders VALUES
+    (1, 1, '2024-01-05', 'shipped'),
+    (2, 2, '2024-01-17', 'shipped'),
+    (3, 3, '2024-02-02', 'refunded'),
+    (4, 1, '2024-02-20', 'shipped'),
+    (5, 4, '2024-03-03', 'shipped'),
+    (6, 5, '2024-03-28', 'shipped'),
+    (7, 6, '2024-04-10', 'shipped'),
+    (8, 7, '2024-05-01', 'shipped'),
+    (9, 4, '2024-05-22', 'shipped'),
+    (10, 2, '2024-06-14', 'shipped'),
+    (11, 8, '2024-07-07', 'shipped'),
+    (12, 5, '2024-08-19', 'refunded'),
+    (13, 7, '2024-09-09', 'shipped'),
+    (14, 9, '2024-10-12', 'shipped'),
+    (15, 1, '2024-11-25', 'shipped'),
+    (16, 10, '2024-12-02', 'paid'),
+    (17, 4, '2024-12-18', 'paid'),
+    (18, 6, '2025-01-06', 'paid'),
+    (19, 3, '2025-01-15', 'paid'),
+    (20, 9, '2025-02-01', 'paid');
+
+INSERT INTO order_items VALUES
+    (1, 1, 1, 89.0), (1, 6, 1, 19.0),
+    (2, 2, 1, 129.0),
+    (3, 4, 2, 45.0),
+    (4, 5, 2, 25.0), (4, 8, 1, 15.0),
+    (5, 2, 1, 129.0), (5, 4, 1, 45.0), (5, 7, 1, 39.0),
+    (6, 3, 1, 59.0),
+    (7, 1, 1, 89.0), (7, 5, 1, 25.0),
+    (8, 3, 2, 59.0), (8, 6, 2, 19.0),
+    (9, 7, 3, 39.0),
+    (10, 5, 4, 25.0),
+    (11, 1, 1, 85.0), (11, 4, 1, 45.0),
+    (12, 2, 1, 129.0),
+    (13, 6, 1, 19.0), (13, 5, 1, 25.0),
+    (14, 3, 1, 59.0), (14, 4, 1, 45.0),
+    (15, 2, 1, 119.0), (15, 6, 1, 19.0),
+    (16, 1, 2, 89.0),
+    (17, 5, 3, 25.0), (17, 6, 1, 19.0),
+    (18, 7, 1, 39.0),
+    (19, 3, 1, 59.0), (19, 5, 1, 25.0),
+    (20, 4, 1, 45.0);
@@ -0,0 +1,230 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+const (
+	nodePrepare  = "prepare"
+	nodeGenerate = "generate"
+	nodeValidate = "validate"
+	nodeFix      = "fix"
+	nodeFinish   = "finish"
+
+	maxAttempts = 3
+	sampleRows  = 5
+)
+
+const sampleCSV = `month,region,revenue
+2024-01,North,120
+2024-01,South,95
+2024-02,North,132
+2024-02,South,101
+2024-03,North,128
+2024-03,South,118
+2024-04,North,141
+2024-04,South,125
+2024-05,North,150
+2024-05,South,122
+2024-06,North,162
+2024-06,South,139`
+
+const htmlPage = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <script src="https://cdn.jsdelivr.net/npm/vega@5"></script>
+  <script src="https://cdn.jsdelivr.net/npm/vega-lite@5"></script>
+  <script src="https://cdn.jsdelivr.net/npm/vega-embed@6"></script>
+</head>
+<body>
+  <div id="chart"></div>
+  <script>vegaEmbed("#chart", %s);</script>
+</body>
+</html>
+`
+
+// Chart is the output of the graph.
+type Chart struct {
+	Spec     map[string]any
+	Attempts int
+}
+
+type chartState struct {
+	table    *table
+	messages []*schema.Message
+	attempts int
+}
+
+// validation is the outcome of one attempt.
+type validation struct {
+	spec map[string]any
+	err  error
+}
+
+// This example turns a table and a request in plain words into a chart. The model writes a Vega-Lite spec, the
+// program checks it against the columns of the table and sends the problems back until it is valid, then inlines
+// the data and writes the spec and an HTML page rendering it:
+//
+//	START -> prepare -> generate -> validate --(valid, or out of attempts)--> finish -> END
+//	                       ^            |
+//	                       +--- fix <---+ (invalid)
+//
+// The model never sees nor writes the full data, only the columns and a few rows, so the size of the table does not
+// matter and the rows cannot be altered. Open the HTML file in a browser to see the chart.
+func main() {
+	csvPath := flag.String("csv", "", "CSV file with a header line, a sample table if empty")
+	request := flag.String("request", "Compare the monthly revenue of the two regions over time.", "what the chart should show")
+	outDir := flag.String("out", ".", "directory where chart.vl.json and chart.html are written")
+	flag.Parse()
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	data := sampleCSV
+	if *csvPath != "" {
+		b, err := os.ReadFile(*csvPath)
+		if err != nil {
+			logs.Fatalf("read %s failed, err=%v", *csvPath, err)
+		}
+		data = string(b)
+	}
+	t, err := parseCSV(data)
+	if err != nil {
+		logs.Fatalf("parseCSV failed, err=%v", err)
+	}
+
+	cm := models.MustChatModel(ctx, models.WithTemperature(0))
+
+	g := compose.NewGraph[string, *Chart](compose.WithGenLocalState(func(ctx context.Context) *chartState {
+		return &chartState{table: t}
+	}))
+
+	_ = g.AddLambdaNode(nodePrepare, compose.InvokableLambda(func(ctx context.Context, request string) ([]*schema.Message, error) {
+		var msgs []*schema.Message
+		err := compose.ProcessState[*chartState](ctx, func(ctx context.Context, s *chartState) error {
+			var err error
+			s.messages, err = prompt.MustGet("agent_text_to_chart_spec").Format(ctx, map[string]any{
+				"table":   s.table.describe(sampleRows),
+				"request": request,
+			})
+			msgs = s.messages
+			return err
+		})
+		return msgs, err
+	}))
+	_ = g.AddChatModelNode(nodeGenerate, cm, compose.WithStatePostHandler(
+		func(ctx context.Context, out *schema.Message, s *chartState) (*schema.Message, error) {
+			s.messages = append(s.messages, out)
+			s.attempts++
+			return out, nil
+		}))
+	_ = g.AddLambdaNode(nodeValidate, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*validation, error) {
+		var v *validation
+		err := compose.ProcessState[*chartState](ctx, func(_ context.Context, s *chartState) error {
+			spec, err := validateSpec(msg.Content, s.table)
+			v = &validation{spec: spec, err: err}
+			if err != nil {
+				logs.Infof("attempt %d is invalid: %v", s.attempts, err)
+			}
+			return nil
+		})
+		return v, err
+	}))
+	_ = g.AddLambdaNode(nodeFix, compose.InvokableLambda(func(ctx context.Context, v *validation) ([]*schema.Message, error) {
+		var msgs []*schema.Message
+		err := compose.ProcessState[*chartState](ctx, func(ctx context.Context, s *chartState) error {
+			var err error
+			s.messages, err = prompt.MustGet("agent_text_to_chart_fix").Format(ctx, map[string]any{
+				"history":  s.messages,
+				"problems": v.err.Error(),
+			})
+			msgs = append(msgs, s.messages...)
+			return err
+		})
+		return msgs, err
+	}))
+	_ = g.AddLambdaNode(nodeFinish, compose.InvokableLambda(func(ctx context.Context, v *validation) (*Chart, error) {
+		var attempts int
+		err := compose.ProcessState[*chartState](ctx, func(_ context.Context, s *chartState) error {
+			attempts = s.attempts
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if v.err != nil {
+			return nil, fmt.Errorf("no valid spec after %d attempts: %w", attempts, v.err)
+		}
+		return &Chart{Spec: v.spec, Attempts: attempts}, nil
+	}))
+
+	_ = g.AddEdge(compose.START, nodePrepare)
+	_ = g.AddEdge(nodePrepare, nodeGenerate)
+	_ = g.AddEdge(nodeGenerate, nodeValidate)
+	_ = g.AddBranch(nodeValidate, compose.NewGraphBranch(func(ctx context.Context, v *validation) (string, error) {
+		next := nodeFinish
+		err := compose.ProcessState[*chartState](ctx, func(_ context.Context, s *chartState) error {
+			if v.err != nil && s.attempts < maxAttempts {
+				next = nodeFix
+			}
+			return nil
+		})
+		return next, err
+	}, map[string]bool{nodeFix: true, nodeFinish: true}))
+	_ = g.AddEdge(nodeFix, nodeGenerate)
+	_ = g.AddEdge(nodeFinish, compose.END)
+
+	r, err := g.Compile(ctx, compose.WithGraphName("text_to_chart"), compose.WithMaxRunSteps(3*maxAttempts+10))
+	if err != nil {
+		logs.Fatalf("Compile failed, err=%v", err)
+	}
+
+	chart, err := r.Invoke(ctx, *request)
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	spec, err := json.MarshalIndent(chart.Spec, "", "  ")
+	if err != nil {
+		logs.Fatalf("marshal spec failed, err=%v", err)
+	}
+	specPath := filepath.Join(*outDir, "chart.vl.json")
+	htmlPath := filepath.Join(*outDir, "chart.html")
+	if err = os.WriteFile(specPath, spec, 0o644); err != nil {
+		logs.Fatalf("write %s failed, err=%v", specPath, err)
+	}
+	if err = os.WriteFile(htmlPath, []byte(fmt.Sprintf(htmlPage, spec)), 0o644); err != nil {
+		logs.Fatalf("write %s failed, err=%v", htmlPath, err)
+	}
+	logs.Infof("valid spec after %d attempts, written to %s and %s", chart.Attempts, specPath, htmlPath)
+}
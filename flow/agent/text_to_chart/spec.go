@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const vegaLiteSchema = "https://vega.github.io/schema/vega-lite/v5.json"
+
+var (
+	validMarks = map[string]bool{
+		"bar": true, "line": true, "point": true, "area": true, "arc": true, "rect": true,
+		"tick": true, "circle": true, "square": true, "text": true, "boxplot": true,
+	}
+	validTypes  = map[string]bool{"quantitative": true, "nominal": true, "ordinal": true, "temporal": true}
+	dateLayouts = []string{"2006-01-02", "2006-01", "2006-01-02 15:04:05", time.RFC3339}
+)
+
+// table is the parsed CSV input, with the type of each column guessed from its values.
+type table struct {
+	columns []string
+	types   map[string]string
+	rows    []map[string]any
+}
+
+// parseCSV reads a CSV with a header line. Numbers are converted so that the chart gets numbers, not strings.
+func parseCSV(data string) (*table, error) {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, errors.New("the CSV needs a header line and at least one row")
+	}
+
+	t := &table{columns: records[0], types: map[string]string{}}
+	for i, col := range t.columns {
+		t.types[col] = guessType(records[1:], i)
+	}
+	for _, rec := range records[1:] {
+		row := make(map[string]any, len(rec))
+		for i, v := range rec {
+			col := t.columns[i]
+			if t.types[col] == "quantitative" {
+				row[col], _ = strconv.ParseFloat(v, 64)
+				continue
+			}
+			row[col] = v
+		}
+		t.rows = append(t.rows, row)
+	}
+	return t, nil
+}
+
+func guessType(records [][]string, col int) string {
+	numbers, dates := 0, 0
+	for _, rec := range records {
+		if _, err := strconv.ParseFloat(rec[col], 64); err == nil {
+			numbers++
+		}
+		for _, l := range dateLayouts {
+			if _, err := time.Parse(l, rec[col]); err == nil {
+				dates++
+				break
+			}
+		}
+	}
+	switch {
+	case numbers == len(records):
+		return "quantitative"
+	case dates == len(records):
+		return "temporal"
+	}
+	return "nominal"
+}
+
+// describe lists the columns, their types and the first rows, which is all the model needs to write the spec.
+func (t *table) describe(sampleRows int) string {
+	sb := strings.Builder{}
+	sb.WriteString("Columns:\n")
+	for _, c := range t.columns {
+		sb.WriteString(fmt.Sprintf("- %s (%s)\n", c, t.types[c]))
+	}
+	sb.WriteString(fmt.Sprintf("%d rows, the first ones:\n", len(t.rows)))
+	for i, r := range t.rows {
+		if i == sampleRows {
+			break
+		}
+		b, _ := json.Marshal(r)
+		sb.Write(b)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// validateSpec checks the spec written by the model against the data and returns it ready to render, with the data
+// inlined. The errors are meant for the model to fix its spec, they say what is wrong and what is allowed.
+// It checks what models get wrong in practice, it is not a full Vega-Lite schema validation.
+func validateSpec(content string, t *table) (map[string]any, error) {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+
+	spec := map[string]any{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(content)), &spec); err != nil {
+		return nil, fmt.Errorf("the reply is not a JSON object: %v", err)
+	}
+
+	var problems []string
+
+	mark := spec["mark"]
+	if m, ok := mark.(map[string]any); ok {
+		mark = m["type"]
+	}
+	if m, _ := mark.(string); !validMarks[m] {
+		problems = append(problems, fmt.Sprintf("mark %v is not one of %s", mark, strings.Join(sortedKeys(validMarks), ", ")))
+	}
+
+	encoding, ok := spec["encoding"].(map[string]any)
+	if !ok || len(encoding) == 0 {
+		problems = append(problems, "encoding is missing")
+	}
+	for _, channel := range sortedKeys(encoding) {
+		defs := []any{encoding[channel]}
+		// tooltip can be a list of field definitions
+		if list, ok := encoding[channel].([]any); ok {
+			defs = list
+		}
+		for _, d := range defs {
+			def, ok := d.(map[string]any)
+			if !ok {
+				problems = append(problems, fmt.Sprintf("encoding.%s must be an object", channel))
+				continue
+			}
+			field, hasField := def["field"].(string)
+			if hasField {
+				if _, known := t.types[field]; !known {
+					problems = append(problems, fmt.Sprintf("encoding.%s.field %q is not a column, use one of %s", channel, field, strings.Join(t.columns, ", ")))
+				}
+			} else if def["aggregate"] != "count" && def["value"] == nil && def["datum"] == nil {
+				problems = append(problems, fmt.Sprintf("encoding.%s has no field", channel))
+			}
+			if typ, ok := def["type"].(string); ok && !validTypes[typ] {
+				problems = append(problems, fmt.Sprintf("encoding.%s.type %q is not one of %s", channel, typ, strings.Join(sortedKeys(validTypes), ", ")))
+			} else if !ok && hasField {
+				problems = append(problems, fmt.Sprintf("encoding.%s.type is missing, the column is %s", channel, t.types[field]))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "\n"))
+	}
+
+	// the data comes from the input, never from the model, which would have to copy every row without a mistake
+	spec["$schema"] = vegaLiteSchema
+	spec["data"] = map[string]any{"values": t.rows}
+	return spec, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -0,0 +1,227 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+const (
+	nodeSelect = "select_tools"
+	nodeModel  = "model"
+	nodeTools  = "tools"
+)
+
+type selectionState struct {
+	messages []*schema.Message
+	tools    []*schema.ToolInfo
+}
+
+// Requirements:
+//   - ARK_API_KEY / ARK_EMBEDDING_MODEL for embedding
+//   - MODEL_PROVIDER and the keys of the provider for the chat model, see internal/models
+//
+// This example gives an agent a catalog of 35 tools, but sends only the k most relevant ones with each request:
+//
+//	START -> select_tools -> model --(tool calls)--> tools -> model
+//	                               --(answer)--> END
+//
+// The descriptions of the tools are embedded once, in a vectorstore.MemoryStore. select_tools embeds the user
+// message and keeps the k closest tools, which the model node passes with model.WithTools for every call of the
+// run. Fewer tools means a shorter prompt, and fewer look-alike tools to pick the wrong one from; the cost is a
+// query the embedding does not match with the right tool, raise -k if the selection misses some.
+func main() {
+	k := flag.Int("k", 4, "number of tools sent to the model per turn, 0 sends all of them")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	cm := models.MustChatModel(ctx)
+
+	index, err := indexTools(ctx, emb, *k)
+	if err != nil {
+		logs.Fatalf("indexTools failed, err=%v", err)
+	}
+
+	runner, err := buildGraph(ctx, cm, index)
+	if err != nil {
+		logs.Fatalf("buildGraph failed, err=%v", err)
+	}
+
+	logs.Infof("%d tools in the catalog, %d bytes of tool definitions in total", len(catalog), toolsSize(catalog))
+	for _, q := range []string{
+		"Is it going to rain in Berlin this weekend?",
+		"Move 200 euros of my team lunch to an expense report, it was for a client meeting.",
+		"Find a 30 minute slot with anna@example.com and lee@example.com and book a room for it.",
+	} {
+		logs.Infof("user: %s", q)
+		in, err := prompt.MustGet("agent_tool_selection_assistant").Format(ctx, map[string]any{"question": q})
+		if err != nil {
+			logs.Fatalf("Format failed, err=%v", err)
+		}
+		out, err := runner.Invoke(ctx, in)
+		if err != nil {
+			logs.Fatalf("Invoke failed, err=%v", err)
+		}
+		logs.Infof("assistant: %s", out.Content)
+	}
+}
+
+// indexTools embeds "name: description" of every tool, the document ID being the name of the tool.
+// With k <= 0 there is no selection, and no index.
+func indexTools(ctx context.Context, emb embedding.Embedder, k int) (retriever.Retriever, error) {
+	if k <= 0 {
+		return nil, nil
+	}
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: k})
+	if err != nil {
+		return nil, err
+	}
+	docs := make([]*schema.Document, 0, len(catalog))
+	for _, info := range catalog {
+		docs = append(docs, &schema.Document{ID: info.Name, Content: info.Name + ": " + info.Desc})
+	}
+	if _, err = store.Store(ctx, docs); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func buildGraph(ctx context.Context, cm model.ChatModel, index retriever.Retriever) (compose.Runnable[[]*schema.Message, *schema.Message], error) {
+	tn, err := compose.NewToolNode(ctx, &compose.ToolsNodeConfig{Tools: newTools()})
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*schema.ToolInfo, len(catalog))
+	for _, info := range catalog {
+		byName[info.Name] = info
+	}
+
+	g := compose.NewGraph[[]*schema.Message, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *selectionState {
+		return &selectionState{}
+	}))
+
+	// the tools are selected once per run from the last user message, the tool rounds keep the same ones
+	_ = g.AddLambdaNode(nodeSelect, compose.InvokableLambda(func(ctx context.Context, in []*schema.Message) ([]*schema.Message, error) {
+		selected := catalog
+		if index != nil {
+			docs, err := index.Retrieve(ctx, lastUserMessage(in))
+			if err != nil {
+				return nil, err
+			}
+			selected = make([]*schema.ToolInfo, 0, len(docs))
+			names := make([]string, 0, len(docs))
+			for _, d := range docs {
+				selected = append(selected, byName[d.ID])
+				names = append(names, fmt.Sprintf("%s (%.2f)", d.ID, d.Score()))
+			}
+			logs.Infof("selected tools: %s", strings.Join(names, ", "))
+		}
+		logs.Infof("%d bytes of tool definitions sent instead of %d", toolsSize(selected), toolsSize(catalog))
+
+		err := compose.ProcessState[*selectionState](ctx, func(_ context.Context, s *selectionState) error {
+			s.tools = selected
+			return nil
+		})
+		return in, err
+	}))
+	_ = g.AddLambdaNode(nodeModel, compose.InvokableLambda(func(ctx context.Context, in []*schema.Message) (*schema.Message, error) {
+		var (
+			msgs  []*schema.Message
+			tools []*schema.ToolInfo
+		)
+		err := compose.ProcessState[*selectionState](ctx, func(_ context.Context, s *selectionState) error {
+			s.messages = append(s.messages, in...)
+			msgs, tools = s.messages, s.tools
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		// the tools of the call replace the ones bound to the model, if any
+		return cm.Generate(ctx, msgs, model.WithTools(tools))
+	}),
+		compose.WithStatePostHandler(func(ctx context.Context, out *schema.Message, s *selectionState) (*schema.Message, error) {
+			s.messages = append(s.messages, out)
+			return out, nil
+		}),
+	)
+	_ = g.AddToolsNode(nodeTools, tn)
+
+	_ = g.AddEdge(compose.START, nodeSelect)
+	_ = g.AddEdge(nodeSelect, nodeModel)
+	_ = g.AddBranch(nodeModel, compose.NewGraphBranch(func(ctx context.Context, msg *schema.Message) (string, error) {
+		if len(msg.ToolCalls) > 0 {
+			return nodeTools, nil
+		}
+		return compose.END, nil
+	}, map[string]bool{nodeTools: true, compose.END: true}))
+	_ = g.AddEdge(nodeTools, nodeModel)
+
+	return g.Compile(ctx, compose.WithGraphName("tool_selection"), compose.WithMaxRunSteps(20))
+}
+
+func lastUserMessage(msgs []*schema.Message) string {
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Role == schema.User {
+			return msgs[i].Content
+		}
+	}
+	return ""
+}
+
+// toolsSize is the size of the tool definitions as JSON, about what they add to every request.
+func toolsSize(tools []*schema.ToolInfo) int {
+	size := 0
+	for _, t := range tools {
+		s, err := t.ParamsOneOf.ToOpenAPIV3()
+		if err != nil {
+			continue
+		}
+		b, _ := json.Marshal(s)
+		size += len(t.Name) + len(t.Desc) + len(b)
+	}
+	return size
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+)
+
+// str is a required string parameter.
+func str(desc string) *schema.ParameterInfo {
+	return &schema.ParameterInfo{Type: schema.String, Desc: desc, Required: true}
+}
+
+// num is a required number parameter.
+func num(desc string) *schema.ParameterInfo {
+	return &schema.ParameterInfo{Type: schema.Number, Desc: desc, Required: true}
+}
+
+type params = map[string]*schema.ParameterInfo
+
+// catalog is the toolbox of an office assistant, too many tools to send all of them with every request.
+var catalog = []*schema.ToolInfo{
+	{Name: "get_weather", Desc: "get the current weather of a city", ParamsOneOf: schema.NewParamsOneOfByParams(params{"city": str("city name")})},
+	{Name: "get_forecast", Desc: "get the weather forecast of a city for the next days", ParamsOneOf: schema.NewParamsOneOfByParams(params{"city": str("city name"), "days": num("number of days, 1 to 7")})},
+	{Name: "convert_currency", Desc: "convert an amount of money from one currency to another at today's rate", ParamsOneOf: schema.NewParamsOneOfByParams(params{"amount": num("amount"), "from": str("ISO code, e.g. EUR"), "to": str("ISO code, e.g. USD")})},
+	{Name: "get_stock_price", Desc: "get the latest price of a stock by its ticker", ParamsOneOf: schema.NewParamsOneOfByParams(params{"ticker": str("ticker, e.g. AAPL")})},
+	{Name: "search_flights", Desc: "search flights between two airports on a date", ParamsOneOf: schema.NewParamsOneOfByParams(params{"from": str("IATA code of the departure airport"), "to": str("IATA code of the arrival airport"), "date": str("YYYY-MM-DD")})},
+	{Name: "book_flight", Desc: "book a flight found by search_flights", ParamsOneOf: schema.NewParamsOneOfByParams(params{"flight_id": str("id returned by search_flights")})},
+	{Name: "search_hotels", Desc: "search hotels in a city for a stay", ParamsOneOf: schema.NewParamsOneOfByParams(params{"city": str("city name"), "check_in": str("YYYY-MM-DD"), "nights": num("number of nights")})},
+	{Name: "book_hotel", Desc: "book a hotel room found by search_hotels", ParamsOneOf: schema.NewParamsOneOfByParams(params{"hotel_id": str("id returned by search_hotels")})},
+	{Name: "list_events", Desc: "list the calendar events of the user on a day", ParamsOneOf: schema.NewParamsOneOfByParams(params{"date": str("YYYY-MM-DD")})},
+	{Name: "create_event", Desc: "create a calendar event and invite people to it", ParamsOneOf: schema.NewParamsOneOfByParams(params{"title": str("title"), "start": str("YYYY-MM-DD HH:MM"), "minutes": num("duration in minutes"), "attendees": str("comma separated emails")})},
+	{Name: "cancel_event", Desc: "cancel a calendar event", ParamsOneOf: schema.NewParamsOneOfByParams(params{"event_id": str("id of the event")})},
+	{Name: "find_free_slot", Desc: "find a time slot when all the given people are free in their calendars", ParamsOneOf: schema.NewParamsOneOfByParams(params{"attendees": str("comma separated emails"), "minutes": num("duration in minutes")})},
+	{Name: "send_email", Desc: "send an email", ParamsOneOf: schema.NewParamsOneOfByParams(params{"to": str("email address"), "subject": str("subject"), "body": str("body")})},
+	{Name: "search_email", Desc: "search the mailbox of the user", ParamsOneOf: schema.NewParamsOneOfByParams(params{"query": str("words to search for")})},
+	{Name: "read_email", Desc: "read an email found by search_email", ParamsOneOf: schema.NewParamsOneOfByParams(params{"email_id": str("id of the email")})},
+	{Name: "send_chat_message", Desc: "send a message to a colleague or a channel of the team chat", ParamsOneOf: schema.NewParamsOneOfByParams(params{"channel": str("channel or user name"), "text": str("message")})},
+	{Name: "search_files", Desc: "search the shared drive for documents", ParamsOneOf: schema.NewParamsOneOfByParams(params{"query": str("words to search for")})},
+	{Name: "read_file", Desc: "read a document of the shared drive", ParamsOneOf: schema.NewParamsOneOfByParams(params{"file_id": str("id of the file")})},
+	{Name: "share_file", Desc: "share a document of the shared drive with someone", ParamsOneOf: schema.NewParamsOneOfByParams(params{"file_id": str("id of the file"), "email": str("email address")})},
+	{Name: "create_todo", Desc: "add a task to the todo list of the user", ParamsOneOf: schema.NewParamsOneOfByParams(params{"title": str("task"), "due": str("YYYY-MM-DD")})},
+	{Name: "list_todos", Desc: "list the open tasks of the todo list", ParamsOneOf: schema.NewParamsOneOfByParams(params{})},
+	{Name: "complete_todo", Desc: "mark a task of the todo list as done", ParamsOneOf: schema.NewParamsOneOfByParams(params{"todo_id": str("id of the task")})},
+	{Name: "create_note", Desc: "write a note in the notebook of the user", ParamsOneOf: schema.NewParamsOneOfByParams(params{"title": str("title"), "text": str("content")})},
+	{Name: "search_notes", Desc: "search the notes of the user", ParamsOneOf: schema.NewParamsOneOfByParams(params{"query": str("words to search for")})},
+	{Name: "calculate", Desc: "evaluate an arithmetic expression", ParamsOneOf: schema.NewParamsOneOfByParams(params{"expression": str("e.g. (12.5*3)/4")})},
+	{Name: "convert_units", Desc: "convert a quantity between units of length, weight, volume or temperature", ParamsOneOf: schema.NewParamsOneOfByParams(params{"value": num("value"), "from": str("unit, e.g. km"), "to": str("unit, e.g. mi")})},
+	{Name: "get_time_in", Desc: "get the current local time in a city or time zone", ParamsOneOf: schema.NewParamsOneOfByParams(params{"place": str("city or IANA time zone")})},
+	{Name: "translate_text", Desc: "translate a text into another language", ParamsOneOf: schema.NewParamsOneOfByParams(params{"text": str("text"), "language": str("target language")})},
+	{Name: "get_news", Desc: "get the latest news headlines on a topic", ParamsOneOf: schema.NewParamsOneOfByParams(params{"topic": str("topic")})},
+	{Name: "get_directions", Desc: "get the travel time and route between two addresses", ParamsOneOf: schema.NewParamsOneOfByParams(params{"from": str("address"), "to": str("address"), "mode": str("driving, walking or transit")})},
+	{Name: "lookup_employee", Desc: "look up a colleague in the company directory: email, team, manager, office", ParamsOneOf: schema.NewParamsOneOfByParams(params{"name": str("full or partial name")})},
+	{Name: "submit_expense", Desc: "submit an expense report for reimbursement", ParamsOneOf: schema.NewParamsOneOfByParams(params{"amount": num("amount"), "currency": str("ISO code"), "category": str("travel, meals or equipment"), "description": str("what it was for")})},
+	{Name: "request_time_off", Desc: "request days of vacation or sick leave", ParamsOneOf: schema.NewParamsOneOfByParams(params{"from": str("YYYY-MM-DD"), "to": str("YYYY-MM-DD"), "kind": str("vacation or sick")})},
+	{Name: "book_meeting_room", Desc: "book a meeting room of the office", ParamsOneOf: schema.NewParamsOneOfByParams(params{"start": str("YYYY-MM-DD HH:MM"), "minutes": num("duration in minutes"), "people": num("number of people")})},
+	{Name: "open_it_ticket", Desc: "open a ticket with the IT support for a broken laptop, an access or a software", ParamsOneOf: schema.NewParamsOneOfByParams(params{"summary": str("what is wrong")})},
+}
+
+// demoTool answers every call with its arguments, the example is about which tools the model sees, not what they do.
+type demoTool struct {
+	info *schema.ToolInfo
+}
+
+func (d *demoTool) Info(ctx context.Context) (*schema.ToolInfo, error) {
+	return d.info, nil
+}
+
+func (d *demoTool) InvokableRun(ctx context.Context, argumentsInJSON string, opts ...tool.Option) (string, error) {
+	out, err := json.Marshal(map[string]any{
+		"tool":      d.info.Name,
+		"arguments": json.RawMessage(argumentsInJSON),
+		"result":    "ok, sample data of the demo",
+	})
+	return string(out), err
+}
+
+func newTools() []tool.BaseTool {
+	tools := make([]tool.BaseTool, 0, len(catalog))
+	for _, info := range catalog {
+		tools = append(tools, &demoTool{info: info})
+	}
+	return tools
+}
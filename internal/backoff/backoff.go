@@ -0,0 +1,49 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package backoff computes the waits between the attempts of a retried call, shared by chatmodel.RetryChatModel,
+// which retries the calls of a chat model, and httpx.RetryTransport, which retries the requests of an HTTP client.
+package backoff
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Exponential returns the wait before the retry following attempt, counted from 0: initial doubled on every attempt,
+// capped at max, with equal jitter, i.e. between half of it and all of it, so that the clients failed at the same
+// moment do not retry at the same moment.
+func Exponential(attempt int, initial, max time.Duration) time.Duration {
+	d := initial << attempt
+	if d <= 0 || d > max {
+		d = max
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// Sleep waits for d, returns the error of ctx if it is done before that.
+func Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
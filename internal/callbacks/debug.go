@@ -0,0 +1,226 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbacks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	ecallbacks "github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+)
+
+// DebugConfig configures a Debugger.
+type DebugConfig struct {
+	// Output is where the lines are written, default os.Stdout.
+	Output io.Writer
+	// MaxLen truncates the input and output summaries to MaxLen characters, default 120, negative to never truncate.
+	MaxLen int
+}
+
+// WithDebug returns the call option printing every run of the graph and of its nodes with a new Debugger, to be
+// passed to Invoke or Stream of any compiled graph or chain:
+//
+//	out, err := runner.Invoke(ctx, input, callbacks.WithDebug(nil))
+func WithDebug(config *DebugConfig) compose.Option {
+	return compose.WithCallbacks(NewDebugger(config).Handler())
+}
+
+// Debugger prints the start of every run, with a summary of its input, and its end, with its duration and a summary
+// of its output or its error. The runs nested in another one, such as the nodes of a graph or of a subgraph, are
+// indented below it:
+//
+//	▶ BlogPost [Graph] in: {"topic":"how to write a useful postmortem"}
+//	  ▶ ChatTemplate [Default] in: {"topic":"how to write a useful postmortem"}
+//	  ◀ ChatTemplate 0s out: [{"role":"system","content":"Write the outline of a short…
+//	  ▶ ChatModel [OpenAI] in: [{"role":"system","content":"Write the outline of a short…
+//
+// A streamed output is summarized once drained, its end printed then.
+type Debugger struct {
+	mu     sync.Mutex
+	out    io.Writer
+	maxLen int
+	wg     sync.WaitGroup
+}
+
+// NewDebugger returns a debugger configured by config, nil for the defaults.
+func NewDebugger(config *DebugConfig) *Debugger {
+	d := &Debugger{out: os.Stdout, maxLen: 120}
+	if config != nil {
+		if config.Output != nil {
+			d.out = config.Output
+		}
+		if config.MaxLen != 0 {
+			d.maxLen = config.MaxLen
+		}
+	}
+	return d
+}
+
+// Wait waits for the pending streams to be drained and their end printed.
+func (d *Debugger) Wait() {
+	d.wg.Wait()
+}
+
+// Handler returns the callback handler to be passed to compose.WithCallbacks or callbacks.InitCallbacks.
+func (d *Debugger) Handler() ecallbacks.Handler {
+	return ecallbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *ecallbacks.RunInfo, input ecallbacks.CallbackInput) context.Context {
+			ctx, f := d.start(ctx)
+			d.printf(f.depth, "▶ %s in: %s", label(info, true), d.summary(nodeInput(info, input)))
+			return ctx
+		}).
+		OnStartWithStreamInputFn(func(ctx context.Context, info *ecallbacks.RunInfo,
+			input *schema.StreamReader[ecallbacks.CallbackInput]) context.Context {
+
+			input.Close()
+			ctx, f := d.start(ctx)
+			d.printf(f.depth, "▶ %s in: (stream)", label(info, true))
+			return ctx
+		}).
+		OnEndFn(func(ctx context.Context, info *ecallbacks.RunInfo, output ecallbacks.CallbackOutput) context.Context {
+			f := d.frame(ctx)
+			d.printf(f.depth, "◀ %s %v out: %s", label(info, false), f.elapsed(), d.summary(nodeOutput(info, output)))
+			return ctx
+		}).
+		OnEndWithStreamOutputFn(func(ctx context.Context, info *ecallbacks.RunInfo,
+			output *schema.StreamReader[ecallbacks.CallbackOutput]) context.Context {
+
+			f := d.frame(ctx)
+			d.wg.Add(1)
+			go func() {
+				defer d.wg.Done()
+				defer output.Close()
+				var errMsg string
+				out := concatChunks(concatOutput(info, output, &errMsg))
+				if errMsg != "" {
+					d.printf(f.depth, "✗ %s %v stream err: %s", label(info, false), f.elapsed(), errMsg)
+					return
+				}
+				d.printf(f.depth, "◀ %s %v out (stream): %s", label(info, false), f.elapsed(), d.summary(out))
+			}()
+			return ctx
+		}).
+		OnErrorFn(func(ctx context.Context, info *ecallbacks.RunInfo, err error) context.Context {
+			f := d.frame(ctx)
+			d.printf(f.depth, "✗ %s %v err: %v", label(info, false), f.elapsed(), err)
+			return ctx
+		}).
+		Build()
+}
+
+type debugKey struct{}
+
+// debugFrame is the run a context belongs to, the runs started from it are one level deeper.
+type debugFrame struct {
+	depth int
+	start time.Time
+}
+
+func (f *debugFrame) elapsed() time.Duration {
+	return time.Since(f.start).Round(time.Millisecond)
+}
+
+func (d *Debugger) start(ctx context.Context) (context.Context, *debugFrame) {
+	f := &debugFrame{start: time.Now()}
+	if parent, ok := ctx.Value(debugKey{}).(*debugFrame); ok {
+		f.depth = parent.depth + 1
+	}
+	return context.WithValue(ctx, debugKey{}, f), f
+}
+
+// frame returns the run ending with ctx, a new one if its start was not seen, e.g. with a handler attached later.
+func (d *Debugger) frame(ctx context.Context) *debugFrame {
+	if f, ok := ctx.Value(debugKey{}).(*debugFrame); ok {
+		return f
+	}
+	return &debugFrame{start: time.Now()}
+}
+
+// printf prints a record at depth, the continuation lines of a multi-line one, e.g. a graph error, are indented
+// under it.
+func (d *Debugger) printf(depth int, format string, args ...any) {
+	indent := strings.Repeat("  ", depth)
+	msg := strings.TrimRight(fmt.Sprintf(format, args...), "\n")
+	line := indent + strings.ReplaceAll(msg, "\n", "\n"+indent+"  ")
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, _ = fmt.Fprintln(d.out, line)
+}
+
+// summary is v as one line of JSON, truncated to maxLen characters.
+func (d *Debugger) summary(v any) string {
+	var s string
+	if str, ok := v.(string); ok {
+		s = fmt.Sprintf("%q", str)
+	} else if b, err := json.Marshal(v); err == nil {
+		s = string(b)
+	} else {
+		s = fmt.Sprintf("%v", v)
+	}
+	if runes := []rune(s); d.maxLen > 0 && len(runes) > d.maxLen {
+		s = string(runes[:d.maxLen]) + "…"
+	}
+	return s
+}
+
+// concatChunks concatenates the message chunks streamed by a node other than a ChatModel, e.g. a graph ending with
+// one, to summarize the message rather than its first chunks.
+func concatChunks(out any) any {
+	chunks, ok := out.([]any)
+	if !ok || len(chunks) == 0 {
+		return out
+	}
+	msgs := make([]*schema.Message, 0, len(chunks))
+	for _, c := range chunks {
+		msg, ok := c.(*schema.Message)
+		if !ok {
+			return out
+		}
+		msgs = append(msgs, msg)
+	}
+	if msg, err := schema.ConcatMessages(msgs); err == nil {
+		return msg
+	}
+	return out
+}
+
+// label names a run with its name, e.g. the name of a graph or of a node added with compose.WithNodeName, or else
+// its component, followed by its component or type on start.
+func label(info *ecallbacks.RunInfo, withKind bool) string {
+	if info == nil {
+		return "?"
+	}
+	name := info.Name
+	kind := info.Type
+	if name == "" {
+		name = string(info.Component)
+	} else if kind == "" {
+		kind = string(info.Component)
+	}
+	if !withKind || kind == "" {
+		return name
+	}
+	return name + " [" + kind + "]"
+}
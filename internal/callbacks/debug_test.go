@@ -0,0 +1,77 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbacks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/mock"
+	"github.com/cloudwego/eino-examples/internal/testutil"
+)
+
+func TestDebugHandler(t *testing.T) {
+	ctx := context.Background()
+	cm := mock.NewChatModel(
+		mock.Reply("Eino is a framework for LLM applications."),
+		mock.Fail(errors.New("rate limited")),
+		mock.Reply("It is written in Go."),
+	)
+
+	r, err := compose.NewChain[map[string]any, *schema.Message]().
+		AppendChatTemplate(prompt.FromMessages(schema.FString, schema.UserMessage("{question}"))).
+		AppendChatModel(cm).
+		Compile(ctx, compose.WithGraphName("QA"))
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	d := NewDebugger(&DebugConfig{Output: &buf, MaxLen: 60})
+	debug := compose.WithCallbacks(d.Handler())
+
+	_, err = r.Invoke(ctx, map[string]any{"question": "What is Eino?"}, debug)
+	assert.NoError(t, err)
+
+	_, err = r.Invoke(ctx, map[string]any{"question": "And then?"}, debug)
+	assert.ErrorContains(t, err, "rate limited")
+
+	testutil.AssertGolden(t, "debug", buf.String(), testutil.Durations)
+
+	// the ends of the streamed runs are printed in no given order, once the stream is drained
+	buf.Reset()
+	sr, err := r.Stream(ctx, map[string]any{"question": strings.Repeat("In which language is it written? ", 3)}, debug)
+	assert.NoError(t, err)
+	for {
+		if _, err = sr.Recv(); err != nil {
+			break
+		}
+	}
+	assert.ErrorIs(t, err, io.EOF)
+	d.Wait()
+
+	assert.Contains(t, buf.String(), `  ◀ ChatModel `)
+	assert.Contains(t, buf.String(), `out (stream): {"role":"assistant","content":"It is written in Go."}`)
+	assert.Equal(t, 6, strings.Count(buf.String(), "\n"))
+}
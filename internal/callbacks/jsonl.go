@@ -0,0 +1,217 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package callbacks provides reusable callback handlers for the examples.
+package callbacks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	ecallbacks "github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	ucallbacks "github.com/cloudwego/eino/utils/callbacks"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// JSONLRecord is one line written by JSONLLogger, i.e. one ChatModel call.
+type JSONLRecord struct {
+	Time      time.Time          `json:"time"`
+	LatencyMS int64              `json:"latency_ms"`
+	Name      string             `json:"name,omitempty"`
+	Type      string             `json:"type,omitempty"`
+	Model     string             `json:"model,omitempty"`
+	Config    *model.Config      `json:"config,omitempty"`
+	Input     []*schema.Message  `json:"input"`
+	Tools     []*schema.ToolInfo `json:"tools,omitempty"`
+	Output    *schema.Message    `json:"output,omitempty"`
+	Usage     *model.TokenUsage  `json:"usage,omitempty"`
+	Stream    bool               `json:"stream,omitempty"`
+	Error     string             `json:"error,omitempty"`
+}
+
+// JSONLLogger is a ChatModel callback handler appending every input/output pair to a JSONL file,
+// the file can be used for later analysis or as a fine-tuning dataset.
+type JSONLLogger struct {
+	mu sync.Mutex
+	f  *os.File
+	wg sync.WaitGroup
+}
+
+// NewJSONLLogger opens path in append mode, the parent directory is created if needed.
+func NewJSONLLogger(path string) (*JSONLLogger, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("create log dir failed: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open jsonl log failed: %w", err)
+	}
+
+	return &JSONLLogger{f: f}, nil
+}
+
+// Handler returns the callback handler to be passed to compose.WithCallbacks or callbacks.InitCallbacks.
+func (l *JSONLLogger) Handler() ecallbacks.Handler {
+	return ucallbacks.NewHandlerHelper().ChatModel(&ucallbacks.ModelCallbackHandler{
+		OnStart:               l.onStart,
+		OnEnd:                 l.onEnd,
+		OnEndWithStreamOutput: l.onEndWithStreamOutput,
+		OnError:               l.onError,
+	}).Handler()
+}
+
+// Close waits for the pending streams to be drained and closes the file.
+func (l *JSONLLogger) Close() error {
+	l.wg.Wait()
+	return l.f.Close()
+}
+
+type pendingKey struct{}
+
+type pending struct {
+	start time.Time
+	input *model.CallbackInput
+}
+
+func (l *JSONLLogger) onStart(ctx context.Context, _ *ecallbacks.RunInfo, input *model.CallbackInput) context.Context {
+	return context.WithValue(ctx, pendingKey{}, &pending{start: time.Now(), input: input})
+}
+
+func (l *JSONLLogger) onEnd(ctx context.Context, info *ecallbacks.RunInfo, output *model.CallbackOutput) context.Context {
+	rec := newRecord(ctx, info)
+	fillOutput(rec, output)
+	l.write(rec)
+	return ctx
+}
+
+func (l *JSONLLogger) onEndWithStreamOutput(ctx context.Context, info *ecallbacks.RunInfo,
+	output *schema.StreamReader[*model.CallbackOutput]) context.Context {
+
+	rec := newRecord(ctx, info)
+	rec.Stream = true
+
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		defer output.Close()
+
+		var (
+			chunks []*schema.Message
+			last   *model.CallbackOutput
+		)
+		for {
+			frame, err := output.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				rec.Error = err.Error()
+				break
+			}
+			if frame.Message != nil {
+				chunks = append(chunks, frame.Message)
+			}
+			if frame.TokenUsage != nil || frame.Config != nil {
+				last = frame
+			}
+		}
+
+		if len(chunks) > 0 {
+			msg, err := schema.ConcatMessages(chunks)
+			if err != nil {
+				rec.Error = err.Error()
+			}
+			rec.Output = msg
+		}
+		if last != nil {
+			rec.Usage = last.TokenUsage
+			if rec.Config == nil && last.Config != nil {
+				rec.Config = last.Config
+				rec.Model = last.Config.Model
+			}
+		}
+		rec.LatencyMS = time.Since(rec.Time).Milliseconds()
+		l.write(rec)
+	}()
+
+	return ctx
+}
+
+func (l *JSONLLogger) onError(ctx context.Context, info *ecallbacks.RunInfo, err error) context.Context {
+	rec := newRecord(ctx, info)
+	rec.Error = err.Error()
+	l.write(rec)
+	return ctx
+}
+
+func newRecord(ctx context.Context, info *ecallbacks.RunInfo) *JSONLRecord {
+	rec := &JSONLRecord{Time: time.Now()}
+	if info != nil {
+		rec.Name = info.Name
+		rec.Type = info.Type
+	}
+
+	if p, ok := ctx.Value(pendingKey{}).(*pending); ok && p.input != nil {
+		rec.Time = p.start
+		rec.Input = p.input.Messages
+		rec.Tools = p.input.Tools
+		rec.Config = p.input.Config
+	}
+	if rec.Config != nil {
+		rec.Model = rec.Config.Model
+	}
+	rec.LatencyMS = time.Since(rec.Time).Milliseconds()
+	return rec
+}
+
+func fillOutput(rec *JSONLRecord, output *model.CallbackOutput) {
+	if output == nil {
+		return
+	}
+	rec.Output = output.Message
+	rec.Usage = output.TokenUsage
+	if rec.Config == nil && output.Config != nil {
+		rec.Config = output.Config
+		rec.Model = output.Config.Model
+	}
+}
+
+func (l *JSONLLogger) write(rec *JSONLRecord) {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		logs.Errorf("marshal jsonl record failed, err=%v", err)
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err = l.f.Write(append(b, '\n')); err != nil {
+		logs.Errorf("write jsonl record failed, err=%v", err)
+	}
+}
@@ -0,0 +1,374 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package callbacks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	ecallbacks "github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+)
+
+// NodeRecord is the input and output of one run of a graph node.
+// Input and Output are the values the node received and returned, e.g. the messages of a ChatModel node rather
+// than its *model.CallbackInput, so that they can be decoded and given to the node again.
+type NodeRecord struct {
+	Node      string          `json:"node"`
+	Component string          `json:"component,omitempty"`
+	Type      string          `json:"type,omitempty"`
+	Start     time.Time       `json:"start"`
+	LatencyMS int64           `json:"latency_ms"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	Output    json.RawMessage `json:"output,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	// Stream is set when the output was a stream, Output is then the concatenated message for a ChatModel and
+	// the list of the chunks otherwise.
+	Stream bool `json:"stream,omitempty"`
+}
+
+// Recording is the list of the node runs of a graph execution, in the order they ended.
+type Recording struct {
+	Nodes []*NodeRecord `json:"nodes"`
+}
+
+// Node returns the last run of node, nil if the node did not run.
+func (r *Recording) Node(node string) *NodeRecord {
+	for i := len(r.Nodes) - 1; i >= 0; i-- {
+		if r.Nodes[i].Node == node {
+			return r.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// Save writes the recording as indented JSON, the parent directory is created if needed.
+func (r *Recording) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadRecording reads a recording written by Recording.Save.
+func LoadRecording(path string) (*Recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	rec := &Recording{}
+	if err = json.Unmarshal(data, rec); err != nil {
+		return nil, fmt.Errorf("decode recording %s failed: %w", path, err)
+	}
+	return rec, nil
+}
+
+// DecodeInput decodes the recorded input of node into the input type of the node, to run the graph again from it.
+func DecodeInput[T any](r *Recording, node string) (T, error) {
+	var v T
+	nr := r.Node(node)
+	if nr == nil {
+		return v, fmt.Errorf("node %s not in the recording", node)
+	}
+	if len(nr.Input) == 0 {
+		return v, fmt.Errorf("node %s has no recorded input", node)
+	}
+	err := json.Unmarshal(nr.Input, &v)
+	return v, err
+}
+
+// DecodeOutput decodes the recorded output of node.
+func DecodeOutput[T any](r *Recording, node string) (T, error) {
+	var v T
+	nr := r.Node(node)
+	if nr == nil {
+		return v, fmt.Errorf("node %s not in the recording", node)
+	}
+	if len(nr.Output) == 0 {
+		return v, fmt.Errorf("node %s has no recorded output", node)
+	}
+	err := json.Unmarshal(nr.Output, &v)
+	return v, err
+}
+
+// Recorder records the inputs and outputs of the nodes of a graph.
+// The nodes of a graph have no name in their callbacks unless added with compose.WithNodeName, so the recorder is
+// given to the graph as one handler per node key, see Options.
+type Recorder struct {
+	mu  sync.Mutex
+	rec *Recording
+	wg  sync.WaitGroup
+}
+
+// NewRecorder returns a recorder with an empty recording.
+func NewRecorder() *Recorder {
+	return &Recorder{rec: &Recording{}}
+}
+
+// Options returns the call options recording the given nodes of the graph, to be passed to Invoke or Stream.
+func (r *Recorder) Options(nodes ...string) []compose.Option {
+	opts := make([]compose.Option, 0, len(nodes))
+	for _, node := range nodes {
+		opts = append(opts, compose.WithCallbacks(r.handler(node)).DesignateNode(node))
+	}
+	return opts
+}
+
+// Recording waits for the pending streams to be drained and returns what was recorded so far.
+func (r *Recorder) Recording() *Recording {
+	r.wg.Wait()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Recording{Nodes: append([]*NodeRecord(nil), r.rec.Nodes...)}
+}
+
+type recordKey struct{}
+
+// nestedRun marks the context of the runs nested in a recorded node.
+type nestedRun struct{}
+
+func (r *Recorder) handler(node string) ecallbacks.Handler {
+	return ecallbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *ecallbacks.RunInfo, input ecallbacks.CallbackInput) context.Context {
+			// the handler is also called by the nodes of a subgraph or of an agent, only the outer run is recorded
+			if ctx.Value(recordKey{}) != nil {
+				return context.WithValue(ctx, recordKey{}, nestedRun{})
+			}
+			nr := newNodeRecord(node, info)
+			nr.Input = marshal(nodeInput(info, input), &nr.Error)
+			return context.WithValue(ctx, recordKey{}, nr)
+		}).
+		OnStartWithStreamInputFn(func(ctx context.Context, info *ecallbacks.RunInfo,
+			input *schema.StreamReader[ecallbacks.CallbackInput]) context.Context {
+
+			input.Close()
+			if ctx.Value(recordKey{}) != nil {
+				return context.WithValue(ctx, recordKey{}, nestedRun{})
+			}
+			return context.WithValue(ctx, recordKey{}, newNodeRecord(node, info))
+		}).
+		OnEndFn(func(ctx context.Context, info *ecallbacks.RunInfo, output ecallbacks.CallbackOutput) context.Context {
+			if nr := r.own(ctx, node); nr != nil {
+				nr.Output = marshal(nodeOutput(info, output), &nr.Error)
+				r.add(nr)
+			}
+			return ctx
+		}).
+		OnEndWithStreamOutputFn(func(ctx context.Context, info *ecallbacks.RunInfo,
+			output *schema.StreamReader[ecallbacks.CallbackOutput]) context.Context {
+
+			nr := r.own(ctx, node)
+			if nr == nil {
+				output.Close()
+				return ctx
+			}
+			nr.Stream = true
+			r.wg.Add(1)
+			go func() {
+				defer r.wg.Done()
+				defer output.Close()
+				nr.Output = marshal(concatOutput(info, output, &nr.Error), &nr.Error)
+				r.add(nr)
+			}()
+			return ctx
+		}).
+		OnErrorFn(func(ctx context.Context, info *ecallbacks.RunInfo, err error) context.Context {
+			if nr := r.own(ctx, node); nr != nil {
+				nr.Error = err.Error()
+				r.add(nr)
+			}
+			return ctx
+		}).
+		Build()
+}
+
+// own returns the record started by the handler of node, nil for the end of a nested run.
+func (r *Recorder) own(ctx context.Context, node string) *NodeRecord {
+	nr, ok := ctx.Value(recordKey{}).(*NodeRecord)
+	if !ok || nr.Node != node {
+		return nil
+	}
+	nr.LatencyMS = time.Since(nr.Start).Milliseconds()
+	return nr
+}
+
+func (r *Recorder) add(nr *NodeRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rec.Nodes = append(r.rec.Nodes, nr)
+}
+
+func newNodeRecord(node string, info *ecallbacks.RunInfo) *NodeRecord {
+	nr := &NodeRecord{Node: node, Start: time.Now()}
+	if info != nil {
+		nr.Component = string(info.Component)
+		nr.Type = info.Type
+	}
+	return nr
+}
+
+// nodeInput unwraps the callback input of the components into the input of the node.
+func nodeInput(info *ecallbacks.RunInfo, input ecallbacks.CallbackInput) any {
+	if info == nil {
+		return input
+	}
+	switch info.Component {
+	case components.ComponentOfChatModel:
+		if in := model.ConvCallbackInput(input); in != nil {
+			return in.Messages
+		}
+	case components.ComponentOfPrompt:
+		if in := prompt.ConvCallbackInput(input); in != nil {
+			return in.Variables
+		}
+	case components.ComponentOfRetriever:
+		if in := retriever.ConvCallbackInput(input); in != nil {
+			return in.Query
+		}
+	case components.ComponentOfEmbedding:
+		if in := embedding.ConvCallbackInput(input); in != nil {
+			return in.Texts
+		}
+	case components.ComponentOfIndexer:
+		if in := indexer.ConvCallbackInput(input); in != nil {
+			return in.Docs
+		}
+	case components.ComponentOfTool:
+		if in := tool.ConvCallbackInput(input); in != nil {
+			return in.ArgumentsInJSON
+		}
+	case components.ComponentOfLoader:
+		if in := document.ConvLoaderCallbackInput(input); in != nil {
+			return in.Source
+		}
+	case components.ComponentOfTransformer:
+		if in := document.ConvTransformerCallbackInput(input); in != nil {
+			return in.Input
+		}
+	}
+	return input
+}
+
+// nodeOutput unwraps the callback output of the components into the output of the node.
+func nodeOutput(info *ecallbacks.RunInfo, output ecallbacks.CallbackOutput) any {
+	if info == nil {
+		return output
+	}
+	switch info.Component {
+	case components.ComponentOfChatModel:
+		if out := model.ConvCallbackOutput(output); out != nil {
+			return out.Message
+		}
+	case components.ComponentOfPrompt:
+		if out := prompt.ConvCallbackOutput(output); out != nil {
+			return out.Result
+		}
+	case components.ComponentOfRetriever:
+		if out := retriever.ConvCallbackOutput(output); out != nil {
+			return out.Docs
+		}
+	case components.ComponentOfEmbedding:
+		if out := embedding.ConvCallbackOutput(output); out != nil {
+			return out.Embeddings
+		}
+	case components.ComponentOfIndexer:
+		if out := indexer.ConvCallbackOutput(output); out != nil {
+			return out.IDs
+		}
+	case components.ComponentOfTool:
+		if out := tool.ConvCallbackOutput(output); out != nil {
+			return out.Response
+		}
+	case components.ComponentOfLoader:
+		if out := document.ConvLoaderCallbackOutput(output); out != nil {
+			return out.Docs
+		}
+	case components.ComponentOfTransformer:
+		if out := document.ConvTransformerCallbackOutput(output); out != nil {
+			return out.Output
+		}
+	}
+	return output
+}
+
+// concatOutput drains a stream output: the chunks of a ChatModel are concatenated into one message, the chunks of
+// the other nodes are kept as a list.
+func concatOutput(info *ecallbacks.RunInfo, output *schema.StreamReader[ecallbacks.CallbackOutput], errMsg *string) any {
+	var chunks []any
+	var msgs []*schema.Message
+	isModel := info != nil && info.Component == components.ComponentOfChatModel
+	for {
+		frame, err := output.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			*errMsg = err.Error()
+			break
+		}
+		if !isModel {
+			chunks = append(chunks, nodeOutput(info, frame))
+			continue
+		}
+		if out := model.ConvCallbackOutput(frame); out != nil && out.Message != nil {
+			msgs = append(msgs, out.Message)
+		}
+	}
+	if !isModel {
+		return chunks
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	msg, err := schema.ConcatMessages(msgs)
+	if err != nil {
+		*errMsg = err.Error()
+	}
+	return msg
+}
+
+func marshal(v any, errMsg *string) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		*errMsg = fmt.Sprintf("marshal failed: %v", err)
+		return nil
+	}
+	return b
+}
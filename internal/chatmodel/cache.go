@@ -0,0 +1,248 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chatmodel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// CacheConfig configures CachedChatModel.
+type CacheConfig struct {
+	// Dir is the directory of the cache files, created if missing.
+	Dir string
+	// Provider and ModelName identify the wrapped model, e.g. openai and gpt-4o, so that switching to another model
+	// does not serve the answers of the previous one.
+	Provider  string
+	ModelName string
+}
+
+// CachedChatModel serves repeated prompts from an on-disk cache.
+// The cache key is the hash of (provider, model name, messages, common options, bound tools),
+// so changing any of them, e.g. the temperature, results in a cache miss.
+// It is meant for demo and development loops, not for production.
+type CachedChatModel struct {
+	Model model.ChatModel
+
+	dir       string
+	provider  string
+	modelName string
+
+	mu    sync.RWMutex
+	tools []*schema.ToolInfo
+}
+
+// NewCachedChatModel wraps m with a cache persisted under config.Dir.
+func NewCachedChatModel(m model.ChatModel, config *CacheConfig) (*CachedChatModel, error) {
+	if err := os.MkdirAll(config.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("create cache dir failed: %w", err)
+	}
+
+	return &CachedChatModel{
+		Model:     m,
+		dir:       config.Dir,
+		provider:  config.Provider,
+		modelName: config.ModelName,
+	}, nil
+}
+
+func (c *CachedChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	key, err := c.key(input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, ok := c.load(key); ok {
+		if c.IsCallbacksEnabled() {
+			ctx = callbacks.OnStart(ctx, &model.CallbackInput{Messages: input})
+			callbacks.OnEnd(ctx, &model.CallbackOutput{Message: cached})
+		}
+		return cached, nil
+	}
+
+	out, err := c.Model.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.save(key, out)
+	return out, nil
+}
+
+func (c *CachedChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	key, err := c.key(input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, ok := c.load(key); ok {
+		sr := schema.StreamReaderFromArray([]*schema.Message{cached})
+		if c.IsCallbacksEnabled() {
+			ctx = callbacks.OnStart(ctx, &model.CallbackInput{Messages: input})
+			outStream := schema.StreamReaderWithConvert(sr, func(m *schema.Message) (*model.CallbackOutput, error) {
+				return &model.CallbackOutput{Message: m}, nil
+			})
+			_, outStream = callbacks.OnEndWithStreamOutput(ctx, outStream)
+			sr = schema.StreamReaderWithConvert(outStream, func(o *model.CallbackOutput) (*schema.Message, error) {
+				return o.Message, nil
+			})
+		}
+		return sr, nil
+	}
+
+	sr, err := c.Model.Stream(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// one copy goes to the caller, the other one is concatenated and saved once the stream completes successfully
+	copies := sr.Copy(2)
+	go func() {
+		defer copies[1].Close()
+
+		var chunks []*schema.Message
+		for {
+			chunk, err := copies[1].Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return
+			}
+			chunks = append(chunks, chunk)
+		}
+
+		msg, err := schema.ConcatMessages(chunks)
+		if err != nil {
+			logs.Errorf("concat stream messages failed, skip caching, err=%v", err)
+			return
+		}
+		c.save(key, msg)
+	}()
+
+	return copies[0], nil
+}
+
+func (c *CachedChatModel) BindTools(tools []*schema.ToolInfo) error {
+	if err := c.Model.BindTools(tools); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.tools = tools
+	c.mu.Unlock()
+	return nil
+}
+
+// IsCallbacksEnabled exposes whether the inner ChatModel has its own callbacks aspect,
+// in which case callbacks are also triggered manually on cache hits.
+func (c *CachedChatModel) IsCallbacksEnabled() bool {
+	checker, ok := c.Model.(components.Checker)
+	if ok {
+		return checker.IsCallbacksEnabled()
+	}
+
+	return false
+}
+
+func (c *CachedChatModel) key(input []*schema.Message, opts ...model.Option) (string, error) {
+	c.mu.RLock()
+	tools := c.tools
+	c.mu.RUnlock()
+
+	b, err := json.Marshal(struct {
+		Provider  string             `json:"provider"`
+		ModelName string             `json:"model_name"`
+		Messages  []*schema.Message  `json:"messages"`
+		Options   *model.Options     `json:"options"`
+		Tools     []*schema.ToolInfo `json:"tools"`
+	}{
+		Provider:  c.provider,
+		ModelName: c.modelName,
+		Messages:  input,
+		Options:   model.GetCommonOptions(&model.Options{}, opts...),
+		Tools:     tools,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshal cache key failed: %w", err)
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func (c *CachedChatModel) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+func (c *CachedChatModel) load(key string) (*schema.Message, bool) {
+	b, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	msg := &schema.Message{}
+	if err = json.Unmarshal(b, msg); err != nil {
+		logs.Errorf("unmarshal cached message failed, key=%s, err=%v", key, err)
+		return nil, false
+	}
+	return msg, true
+}
+
+func (c *CachedChatModel) save(key string, msg *schema.Message) {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		logs.Errorf("marshal message for cache failed, err=%v", err)
+		return
+	}
+
+	// write to a temp file of its own then rename, so that a concurrent reader never sees a partial file and two
+	// writers of the same key never write to the same file
+	f, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		logs.Errorf("create cache file failed, err=%v", err)
+		return
+	}
+	_, err = f.Write(b)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		logs.Errorf("write cache file failed, err=%v", err)
+		_ = os.Remove(f.Name())
+		return
+	}
+	if err = os.Rename(f.Name(), c.path(key)); err != nil {
+		logs.Errorf("rename cache file failed, err=%v", err)
+		_ = os.Remove(f.Name())
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chatmodel
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/mock"
+)
+
+func newCache(t *testing.T, dir, provider, modelName string) (*CachedChatModel, *mock.ChatModel) {
+	inner := mock.Echo()
+	c, err := NewCachedChatModel(inner, &CacheConfig{Dir: dir, Provider: provider, ModelName: modelName})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return c, inner
+}
+
+func TestCacheHit(t *testing.T) {
+	ctx := context.Background()
+	c, inner := newCache(t, t.TempDir(), "openai", "gpt-4o")
+	inner.Add(mock.Reply("first answer"))
+
+	msg, err := c.Generate(ctx, input)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "first answer", msg.Content)
+	}
+	msg, err = c.Generate(ctx, input)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "first answer", msg.Content)
+	}
+	assert.Len(t, inner.Calls(), 1, "the second call is served from the cache")
+}
+
+func TestCacheMiss(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	c, _ := newCache(t, dir, "openai", "gpt-4o")
+	_, err := c.Generate(ctx, input)
+	assert.NoError(t, err)
+
+	// another provider or model does not get the answers of the first one
+	for _, id := range [][2]string{{"ark", "gpt-4o"}, {"openai", "gpt-4o-mini"}} {
+		other, inner := newCache(t, dir, id[0], id[1])
+		_, err = other.Generate(ctx, input)
+		assert.NoError(t, err)
+		assert.Len(t, inner.Calls(), 1, id)
+	}
+
+	c, inner := newCache(t, dir, "openai", "gpt-4o")
+	_, err = c.Generate(ctx, input, model.WithTemperature(0.2))
+	assert.NoError(t, err)
+	_, err = c.Generate(ctx, []*schema.Message{schema.UserMessage("hello")})
+	assert.NoError(t, err)
+	_, err = c.Generate(ctx, input, model.WithTools([]*schema.ToolInfo{{Name: "search"}}))
+	assert.NoError(t, err)
+	assert.NoError(t, c.BindTools([]*schema.ToolInfo{{Name: "weather"}}))
+	_, err = c.Generate(ctx, input)
+	assert.NoError(t, err)
+	assert.Len(t, inner.Calls(), 4, "options, messages, tools and bound tools are part of the key")
+}
+
+func TestCacheStream(t *testing.T) {
+	ctx := context.Background()
+	c, inner := newCache(t, t.TempDir(), "openai", "gpt-4o")
+	inner.Add(mock.Reply("streamed answer"))
+
+	sr, err := c.Stream(ctx, input)
+	if !assert.NoError(t, err) {
+		return
+	}
+	msg, err := concatStream(sr)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "streamed answer", msg.Content)
+	}
+
+	// the stream is saved once completed, in the background
+	key, _ := c.key(input)
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(c.path(key))
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+
+	sr, err = c.Stream(ctx, input)
+	if assert.NoError(t, err) {
+		msg, err = concatStream(sr)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "streamed answer", msg.Content)
+		}
+	}
+	assert.Len(t, inner.Calls(), 1)
+}
+
+func TestCacheCorruptFile(t *testing.T) {
+	ctx := context.Background()
+	c, inner := newCache(t, t.TempDir(), "openai", "gpt-4o")
+	key, _ := c.key(input)
+	assert.NoError(t, os.WriteFile(c.path(key), []byte("{not json"), 0644))
+
+	msg, err := c.Generate(ctx, input)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "[mock] hi", msg.Content)
+	}
+	// the corrupt file is replaced by the new answer
+	_, err = c.Generate(ctx, input)
+	assert.NoError(t, err)
+	assert.Len(t, inner.Calls(), 1)
+
+	entries, _ := os.ReadDir(c.dir)
+	assert.Len(t, entries, 1, "no temp file is left")
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chatmodel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// FallbackConfig configures FallbackChatModel.
+type FallbackConfig struct {
+	// Models are tried in order, the first one is the primary model.
+	Models []model.ChatModel
+	// Timeout limits each attempt, for Stream it limits the time to the first chunk. 0 means no limit.
+	Timeout time.Duration
+	// ShouldFallback decides whether to try the next model on err, default is to fall back on any error
+	// except the cancellation of the caller's context.
+	ShouldFallback func(err error) bool
+}
+
+// FallbackChatModel calls the models one by one until one of them succeeds,
+// e.g. OpenAI as primary, Ark or Ollama as secondary.
+type FallbackChatModel struct {
+	models         []model.ChatModel
+	timeout        time.Duration
+	shouldFallback func(err error) bool
+}
+
+func NewFallbackChatModel(config *FallbackConfig) (*FallbackChatModel, error) {
+	if config == nil || len(config.Models) == 0 {
+		return nil, errors.New("at least one model is required")
+	}
+
+	f := &FallbackChatModel{
+		models:         config.Models,
+		timeout:        config.Timeout,
+		shouldFallback: config.ShouldFallback,
+	}
+	if f.shouldFallback == nil {
+		f.shouldFallback = func(err error) bool {
+			return !errors.Is(err, context.Canceled)
+		}
+	}
+
+	return f, nil
+}
+
+func (f *FallbackChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	var errs []error
+	for i, m := range f.models {
+		out, err := f.generate(ctx, m, input, opts...)
+		if err == nil {
+			return out, nil
+		}
+
+		errs = append(errs, fmt.Errorf("model[%d]: %w", i, err))
+		if ctx.Err() != nil || !f.shouldFallback(err) {
+			break
+		}
+
+		logs.Errorf("model[%d] generate failed, fall back to the next model, err=%v", i, err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+func (f *FallbackChatModel) generate(ctx context.Context, m model.ChatModel, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	if f.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, f.timeout)
+		defer cancel()
+	}
+
+	return m.Generate(ctx, input, opts...)
+}
+
+func (f *FallbackChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	var errs []error
+	for i, m := range f.models {
+		out, err := f.stream(ctx, m, input, opts...)
+		if err == nil {
+			return out, nil
+		}
+
+		errs = append(errs, fmt.Errorf("model[%d]: %w", i, err))
+		if ctx.Err() != nil || !f.shouldFallback(err) {
+			break
+		}
+
+		logs.Errorf("model[%d] stream failed, fall back to the next model, err=%v", i, err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// stream waits for the first chunk before deciding the model is healthy,
+// because many providers only report errors (e.g. 429) once the stream is read.
+// The timeout covers both the creation of the stream and its first chunk.
+func (f *FallbackChatModel) stream(ctx context.Context, m model.ChatModel, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	type recvResult struct {
+		sr  *schema.StreamReader[*schema.Message]
+		msg *schema.Message
+		err error
+	}
+
+	firstCh := make(chan recvResult, 1)
+	go func() {
+		sr, err := m.Stream(ctx, input, opts...)
+		if err != nil {
+			firstCh <- recvResult{err: err}
+			return
+		}
+		msg, err := sr.Recv()
+		firstCh <- recvResult{sr: sr, msg: msg, err: err}
+	}()
+
+	var timeout <-chan time.Time
+	if f.timeout > 0 {
+		timer := time.NewTimer(f.timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var first recvResult
+	select {
+	case first = <-firstCh:
+	case <-timeout:
+		cancel()
+		go func() {
+			if late := <-firstCh; late.sr != nil {
+				late.sr.Close()
+			}
+		}()
+		return nil, fmt.Errorf("no chunk received within %v", f.timeout)
+	}
+
+	if first.sr == nil {
+		cancel()
+		return nil, first.err
+	}
+	sr := first.sr
+
+	if first.err != nil && !errors.Is(first.err, io.EOF) {
+		cancel()
+		sr.Close()
+		return nil, first.err
+	}
+
+	// re-emit the first chunk, then forward the rest of the stream
+	out, sw := schema.Pipe[*schema.Message](1)
+	go func() {
+		defer func() {
+			sr.Close()
+			sw.Close()
+			cancel()
+		}()
+
+		if errors.Is(first.err, io.EOF) {
+			return
+		}
+		if closed := sw.Send(first.msg, nil); closed {
+			return
+		}
+
+		for {
+			msg, err := sr.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if closed := sw.Send(msg, err); closed || err != nil {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// BindTools binds the tools to every model, so that any of them can take over.
+func (f *FallbackChatModel) BindTools(tools []*schema.ToolInfo) error {
+	for i, m := range f.models {
+		if err := m.BindTools(tools); err != nil {
+			return fmt.Errorf("bind tools to model[%d] failed: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// IsCallbacksEnabled returns true only if every inner model triggers callbacks by itself.
+func (f *FallbackChatModel) IsCallbacksEnabled() bool {
+	for _, m := range f.models {
+		checker, ok := m.(components.Checker)
+		if !ok || !checker.IsCallbacksEnabled() {
+			return false
+		}
+	}
+
+	return true
+}
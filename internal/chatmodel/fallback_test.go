@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chatmodel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/mock"
+)
+
+func TestFallbackGenerate(t *testing.T) {
+	primary := mock.NewChatModel(mock.Fail(errRateLimited))
+	secondary := mock.NewChatModel(mock.Reply("from secondary"))
+	f, err := NewFallbackChatModel(&FallbackConfig{Models: []model.ChatModel{primary, secondary}})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	msg, err := f.Generate(context.Background(), input)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "from secondary", msg.Content)
+	}
+	assert.Len(t, primary.Calls(), 1)
+	assert.Len(t, secondary.Calls(), 1)
+}
+
+func TestFallbackAllFail(t *testing.T) {
+	primary := mock.NewChatModel(mock.Fail(errRateLimited))
+	secondary := mock.NewChatModel(mock.Fail(errBadRequest))
+	f, _ := NewFallbackChatModel(&FallbackConfig{Models: []model.ChatModel{primary, secondary}})
+
+	_, err := f.Generate(context.Background(), input)
+	assert.ErrorIs(t, err, errRateLimited)
+	assert.ErrorIs(t, err, errBadRequest)
+	assert.Contains(t, err.Error(), "model[1]")
+}
+
+func TestFallbackShouldFallback(t *testing.T) {
+	primary := mock.NewChatModel(mock.Fail(errBadRequest))
+	secondary := mock.NewChatModel(mock.Reply("never"))
+	f, _ := NewFallbackChatModel(&FallbackConfig{
+		Models:         []model.ChatModel{primary, secondary},
+		ShouldFallback: IsRetryableError,
+	})
+
+	_, err := f.Generate(context.Background(), input)
+	assert.ErrorIs(t, err, errBadRequest)
+	assert.Empty(t, secondary.Calls())
+}
+
+func TestFallbackStream(t *testing.T) {
+	// the error of the primary comes with the first chunk, as with most providers
+	primary := mock.NewChatModel(mock.Fail(errRateLimited))
+	secondary := mock.NewChatModel(&mock.Response{Message: schema.AssistantMessage("from secondary", nil)})
+	f, _ := NewFallbackChatModel(&FallbackConfig{Models: []model.ChatModel{primary, secondary}})
+
+	sr, err := f.Stream(context.Background(), input)
+	if !assert.NoError(t, err) {
+		return
+	}
+	msg, err := concatStream(sr)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "from secondary", msg.Content)
+	}
+}
+
+func TestFallbackTimeout(t *testing.T) {
+	slow := &mock.Response{Message: schema.AssistantMessage("too slow", nil), Delay: time.Second}
+	primary := mock.NewChatModel(slow, slow)
+	secondary := mock.NewChatModel(mock.Reply("fast"), mock.Reply("fast stream"))
+	f, _ := NewFallbackChatModel(&FallbackConfig{Models: []model.ChatModel{primary, secondary}, Timeout: 10 * time.Millisecond})
+
+	msg, err := f.Generate(context.Background(), input)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "fast", msg.Content)
+	}
+
+	sr, err := f.Stream(context.Background(), input)
+	if assert.NoError(t, err) {
+		msg, err = concatStream(sr)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "fast stream", msg.Content)
+		}
+	}
+}
+
+func TestFallbackConfig(t *testing.T) {
+	_, err := NewFallbackChatModel(nil)
+	assert.Error(t, err)
+	_, err = NewFallbackChatModel(&FallbackConfig{})
+	assert.Error(t, err)
+
+	primary, secondary := mock.NewChatModel(mock.Reply("a")), mock.NewChatModel()
+	f, _ := NewFallbackChatModel(&FallbackConfig{Models: []model.ChatModel{primary, secondary}})
+	assert.NoError(t, f.BindTools([]*schema.ToolInfo{{Name: "search"}}))
+	_, _ = f.Generate(context.Background(), input)
+	if calls := primary.Calls(); assert.Len(t, calls, 1) {
+		assert.Len(t, calls[0].Tools, 1)
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chatmodel
+
+import (
+	"context"
+	"time"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/backoff"
+	"github.com/cloudwego/eino-examples/internal/errors"
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+)
+
+// RetryConfig controls how RetryChatModel retries failed calls.
+type RetryConfig struct {
+	// MaxRetries is the max number of retries after the first attempt, 0 for none, negative for the default 3.
+	MaxRetries int
+	// InitialBackoff is the wait time before the first retry, doubled on every retry, default 500ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait time between two attempts, default 10s.
+	MaxBackoff time.Duration
+	// ShouldRetry decides whether an error is transient, default IsRetryableError.
+	ShouldRetry func(err error) bool
+}
+
+// RetryChatModel decorates a ChatModel, retrying Generate and Stream on transient
+// failures (429 / 5xx / network timeout) with exponential backoff and jitter.
+// For Stream, only the creation of the stream is retried, errors in the middle of a stream are returned as is.
+type RetryChatModel struct {
+	Model model.ChatModel
+
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	shouldRetry    func(err error) bool
+}
+
+// NewRetryChatModel wraps m with retry, config can be nil to use the defaults, i.e. 3 retries.
+func NewRetryChatModel(m model.ChatModel, config *RetryConfig) *RetryChatModel {
+	if config == nil {
+		config = &RetryConfig{MaxRetries: defaultMaxRetries}
+	}
+
+	r := &RetryChatModel{
+		Model:          m,
+		maxRetries:     config.MaxRetries,
+		initialBackoff: config.InitialBackoff,
+		maxBackoff:     config.MaxBackoff,
+		shouldRetry:    config.ShouldRetry,
+	}
+
+	if r.maxRetries < 0 {
+		r.maxRetries = defaultMaxRetries
+	}
+	if r.initialBackoff <= 0 {
+		r.initialBackoff = defaultInitialBackoff
+	}
+	if r.maxBackoff <= 0 {
+		r.maxBackoff = defaultMaxBackoff
+	}
+	if r.shouldRetry == nil {
+		r.shouldRetry = IsRetryableError
+	}
+
+	return r
+}
+
+func (r *RetryChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	var (
+		out *schema.Message
+		err error
+	)
+
+	for attempt := 0; ; attempt++ {
+		out, err = r.Model.Generate(ctx, input, opts...)
+		if err == nil || !r.needRetry(attempt, err) {
+			return out, err
+		}
+
+		if err = r.wait(ctx, attempt, err); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *RetryChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	var (
+		out *schema.StreamReader[*schema.Message]
+		err error
+	)
+
+	for attempt := 0; ; attempt++ {
+		out, err = r.Model.Stream(ctx, input, opts...)
+		if err == nil || !r.needRetry(attempt, err) {
+			return out, err
+		}
+
+		if err = r.wait(ctx, attempt, err); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *RetryChatModel) BindTools(tools []*schema.ToolInfo) error {
+	return r.Model.BindTools(tools)
+}
+
+// IsCallbacksEnabled exposes whether the inner ChatModel has its own callbacks aspect,
+// so that callbacks are not triggered twice for one call.
+func (r *RetryChatModel) IsCallbacksEnabled() bool {
+	checker, ok := r.Model.(components.Checker)
+	if ok {
+		return checker.IsCallbacksEnabled()
+	}
+
+	return false
+}
+
+func (r *RetryChatModel) needRetry(attempt int, err error) bool {
+	return attempt < r.maxRetries && r.shouldRetry(err)
+}
+
+// wait sleeps for the backoff of the given attempt, returns an error if ctx is done before that.
+func (r *RetryChatModel) wait(ctx context.Context, attempt int, lastErr error) error {
+	d := backoff.Exponential(attempt, r.initialBackoff, r.maxBackoff)
+	logs.Infof("chat model call failed, retry %d/%d after %v, err=%v", attempt+1, r.maxRetries, d, lastErr)
+
+	if err := backoff.Sleep(ctx, d); err != nil {
+		return errors.Join(err, lastErr)
+	}
+	return nil
+}
+
+// IsRetryableError reports whether err looks like a transient failure worth retrying:
+// network timeouts, rate limiting (429) and server side errors (5xx), see errors.Retryable.
+func IsRetryableError(err error) bool {
+	return errors.Retryable(err)
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package chatmodel
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/mock"
+)
+
+var (
+	errRateLimited = errors.New("error, status code: 429, message: rate limit reached")
+	errBadRequest  = errors.New("error, status code: 400, message: invalid model")
+)
+
+var input = []*schema.Message{schema.UserMessage("hi")}
+
+func TestRetryGenerate(t *testing.T) {
+	inner := mock.NewChatModel(mock.Fail(errRateLimited), mock.Fail(errRateLimited), mock.Reply("ok"))
+	r := NewRetryChatModel(inner, &RetryConfig{MaxRetries: 2, InitialBackoff: time.Millisecond})
+
+	msg, err := r.Generate(context.Background(), input)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ok", msg.Content)
+	}
+	assert.Len(t, inner.Calls(), 3)
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	inner := mock.NewChatModel(mock.Fail(errRateLimited), mock.Fail(errRateLimited), mock.Reply("too late"))
+	r := NewRetryChatModel(inner, &RetryConfig{MaxRetries: 1, InitialBackoff: time.Millisecond})
+
+	_, err := r.Generate(context.Background(), input)
+	assert.ErrorIs(t, err, errRateLimited)
+	assert.Len(t, inner.Calls(), 2)
+
+	// a bad request is not transient
+	inner = mock.NewChatModel(mock.Fail(errBadRequest), mock.Reply("never"))
+	r = NewRetryChatModel(inner, &RetryConfig{MaxRetries: 3, InitialBackoff: time.Millisecond})
+	_, err = r.Generate(context.Background(), input)
+	assert.ErrorIs(t, err, errBadRequest)
+	assert.Len(t, inner.Calls(), 1)
+}
+
+func TestRetryMaxRetries(t *testing.T) {
+	assert.Equal(t, 3, NewRetryChatModel(mock.Echo(), nil).maxRetries)
+	assert.Equal(t, 3, NewRetryChatModel(mock.Echo(), &RetryConfig{MaxRetries: -1}).maxRetries)
+
+	inner := mock.NewChatModel(mock.Fail(errRateLimited), mock.Reply("never"))
+	r := NewRetryChatModel(inner, &RetryConfig{MaxRetries: 0})
+	_, err := r.Generate(context.Background(), input)
+	assert.ErrorIs(t, err, errRateLimited)
+	assert.Len(t, inner.Calls(), 1, "0 disables the retries")
+}
+
+func TestRetryStream(t *testing.T) {
+	inner := mock.NewChatModel(mock.Fail(errRateLimited), mock.Reply("streamed"))
+	r := NewRetryChatModel(inner, &RetryConfig{MaxRetries: 1, InitialBackoff: time.Millisecond})
+
+	sr, err := r.Stream(context.Background(), input)
+	if !assert.NoError(t, err) {
+		return
+	}
+	msg, err := concatStream(sr)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "streamed", msg.Content)
+	}
+	assert.Len(t, inner.Calls(), 2)
+}
+
+func TestRetryCanceled(t *testing.T) {
+	inner := mock.NewChatModel(mock.Fail(errRateLimited), mock.Reply("never"))
+	r := NewRetryChatModel(inner, &RetryConfig{MaxRetries: 1, InitialBackoff: time.Minute, MaxBackoff: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := r.Generate(ctx, input)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, err, errRateLimited)
+	assert.Len(t, inner.Calls(), 1)
+}
+
+func concatStream(sr *schema.StreamReader[*schema.Message]) (*schema.Message, error) {
+	defer sr.Close()
+	var chunks []*schema.Message
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			return schema.ConcatMessages(chunks)
+		}
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package checkpoint saves the local state of a graph run after its nodes, keyed by a run ID, so that the run can be
+// inspected or picked up again after the process stopped.
+//
+// The graphs of eino save nothing by themselves in this version; a checkpoint is written by the StatePostHandler
+// returned by PostHandler, set on the nodes that should be checkpointed. The run ID travels in the context.
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino/compose"
+)
+
+// ErrNotFound is returned by Store.Load when there is no checkpoint for the run.
+var ErrNotFound = errors.New("checkpoint not found")
+
+// Checkpoint is the state of a run after a node.
+type Checkpoint struct {
+	RunID string `json:"run_id"`
+	// Node is the node after which the checkpoint was written.
+	Node string `json:"node"`
+	// Step counts the checkpoints of the run, starting at 1.
+	Step int `json:"step"`
+	// State is the local state of the graph, as JSON.
+	State   json.RawMessage `json:"state"`
+	SavedAt time.Time       `json:"saved_at"`
+}
+
+// Store keeps the latest checkpoint of every run.
+type Store interface {
+	Save(ctx context.Context, cp *Checkpoint) error
+	// Load returns ErrNotFound if the run has no checkpoint.
+	Load(ctx context.Context, runID string) (*Checkpoint, error)
+	// Delete removes the checkpoint of the run, deleting a missing one is not an error.
+	Delete(ctx context.Context, runID string) error
+}
+
+type runIDKey struct{}
+
+// WithRunID returns a context in which the checkpoints are saved under runID.
+func WithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+// RunID returns the run ID of the context, empty if there is none.
+func RunID(ctx context.Context) string {
+	id, _ := ctx.Value(runIDKey{}).(string)
+	return id
+}
+
+// PostHandler returns a StatePostHandler running update, which stores the output of node in the state, then saving
+// the state. update can be nil. A node has a single post handler, update is where its own one goes.
+//
+// The state is marshaled with encoding/json, so its checkpointed fields must be exported. Without a run ID in the
+// context, nothing is saved. The handler runs while the graph holds the lock of the state, the state saved is the one
+// the next node will see. A failed save fails the run: a run that cannot be picked up again should not go on as if it
+// could.
+func PostHandler[O, S any](store Store, node string, update compose.StatePostHandler[O, S]) compose.StatePostHandler[O, S] {
+	return func(ctx context.Context, out O, state S) (O, error) {
+		if update != nil {
+			var err error
+			if out, err = update(ctx, out, state); err != nil {
+				return out, err
+			}
+		}
+		runID := RunID(ctx)
+		if runID == "" {
+			return out, nil
+		}
+		return out, Save(ctx, store, runID, node, state)
+	}
+}
+
+// Save marshals state and saves it as the checkpoint of runID after node, one step after the previous checkpoint.
+func Save(ctx context.Context, store Store, runID, node string, state any) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal state of run %s failed: %w", runID, err)
+	}
+
+	step := 1
+	prev, err := store.Load(ctx, runID)
+	switch {
+	case err == nil:
+		step = prev.Step + 1
+	case !errors.Is(err, ErrNotFound):
+		return err
+	}
+
+	return store.Save(ctx, &Checkpoint{
+		RunID:   runID,
+		Node:    node,
+		Step:    step,
+		State:   data,
+		SavedAt: time.Now(),
+	})
+}
+
+// LoadState loads the checkpoint of runID and unmarshals its state into state.
+func LoadState(ctx context.Context, store Store, runID string, state any) (*Checkpoint, error) {
+	cp, err := store.Load(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(cp.State, state); err != nil {
+		return nil, fmt.Errorf("unmarshal state of run %s failed: %w", runID, err)
+	}
+	return cp, nil
+}
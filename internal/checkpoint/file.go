@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileStore keeps one JSON file per run in a directory, for runs on a single machine.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates dir if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create checkpoint dir failed: %w", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (f *FileStore) Save(ctx context.Context, cp *Checkpoint) error {
+	path, err := f.path(cp.RunID)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// written next to the checkpoint and renamed over it, a crash in the middle leaves the previous one whole
+	tmp, err := os.CreateTemp(f.dir, cp.RunID+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("save checkpoint of run %s failed: %w", cp.RunID, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save checkpoint of run %s failed: %w", cp.RunID, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("save checkpoint of run %s failed: %w", cp.RunID, err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("save checkpoint of run %s failed: %w", cp.RunID, err)
+	}
+	return nil
+}
+
+func (f *FileStore) Load(ctx context.Context, runID string) (*Checkpoint, error) {
+	path, err := f.path(runID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint of run %s failed: %w", runID, err)
+	}
+
+	cp := &Checkpoint{}
+	if err = json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("decode checkpoint of run %s failed: %w", runID, err)
+	}
+	return cp, nil
+}
+
+func (f *FileStore) Delete(ctx context.Context, runID string) error {
+	path, err := f.path(runID)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("delete checkpoint of run %s failed: %w", runID, err)
+	}
+	return nil
+}
+
+// path rejects the run IDs which are not a plain file name, a run ID must not write outside of the directory.
+func (f *FileStore) path(runID string) (string, error) {
+	if runID == "" || runID == "." || runID == ".." || strings.ContainsAny(runID, `/\`) {
+		return "", fmt.Errorf("invalid run id %q", runID)
+	}
+	return filepath.Join(f.dir, runID+".json"), nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/store"
+)
+
+// KVConfig configures KVStore.
+type KVConfig struct {
+	Store store.Store
+	// KeyPrefix is put before the run ID, default "checkpoint:".
+	KeyPrefix string
+	// TTL expires the checkpoints of runs not saved for that long, 0 keeps them until deleted.
+	TTL time.Duration
+}
+
+// KVStore keeps the checkpoints in a store of internal/store. With Redis, the checkpoints are shared by every
+// process of a service: a run started by one instance can be picked up by another.
+type KVStore struct {
+	kv     store.Store
+	prefix string
+	ttl    time.Duration
+}
+
+func NewKVStore(config *KVConfig) (*KVStore, error) {
+	if config == nil || config.Store == nil {
+		return nil, fmt.Errorf("kv checkpoint store needs a store")
+	}
+	prefix := config.KeyPrefix
+	if prefix == "" {
+		prefix = "checkpoint:"
+	}
+	return &KVStore{kv: config.Store, prefix: prefix, ttl: config.TTL}, nil
+}
+
+func (k *KVStore) Save(ctx context.Context, cp *Checkpoint) error {
+	if err := store.SetJSON(ctx, k.kv, k.prefix+cp.RunID, cp, k.ttl); err != nil {
+		return fmt.Errorf("save checkpoint of run %s failed: %w", cp.RunID, err)
+	}
+	return nil
+}
+
+func (k *KVStore) Load(ctx context.Context, runID string) (*Checkpoint, error) {
+	cp, err := store.GetJSON[*Checkpoint](ctx, k.kv, k.prefix+runID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load checkpoint of run %s failed: %w", runID, err)
+	}
+	return cp, nil
+}
+
+func (k *KVStore) Delete(ctx context.Context, runID string) error {
+	if err := k.kv.Delete(ctx, k.prefix+runID); err != nil {
+		return fmt.Errorf("delete checkpoint of run %s failed: %w", runID, err)
+	}
+	return nil
+}
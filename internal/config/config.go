@@ -0,0 +1,395 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package config merges the configuration of an example from, by increasing precedence:
+//
+//	defaults   Options.Defaults
+//	YAML file  Options.File, or the file named by EINO_CONFIG
+//	.env       Options.EnvFiles, ".env" by default, a missing file is skipped
+//	env        the environment of the process
+//	flags      the flags set on the command line, not their defaults
+//
+// Keys are case-insensitive and ".", "-" and "_" are the same, so the flag -max-tokens, the env MAX_TOKENS and the
+// YAML key max_tokens are one key, and so are the env OPENAI_API_KEY and the YAML
+//
+//	openai:
+//	  api_key: sk-...
+//
+// Before Init, the package functions read the environment only, as os.Getenv does.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const (
+	SourceDefault = "default"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+)
+
+// Options configures Load.
+type Options struct {
+	// Defaults are the values of the keys set nowhere else.
+	Defaults map[string]string
+	// File is a YAML file, default the value of EINO_CONFIG, none if both are empty. It must exist when set.
+	File string
+	// EnvFiles are dotenv files, default ".env". Missing files are skipped, the first file wins on a key set in two.
+	EnvFiles []string
+	// FlagSet is read after it was parsed, default flag.CommandLine. Only the flags set on the command line count.
+	FlagSet *flag.FlagSet
+	// ExportEnv sets the .env values missing from the environment of the process, as godotenv.Load does, for the
+	// libraries reading the environment themselves, e.g. HTTPS_PROXY.
+	ExportEnv bool
+}
+
+type value struct {
+	value  string
+	source string
+}
+
+// Config is a merged configuration, read with the typed accessors.
+type Config struct {
+	values map[string]value
+	// env reads the environment at call time, only for the default config before Init
+	env bool
+}
+
+// Load merges the sources of opts, nil means the defaults of Options.
+func Load(opts *Options) (*Config, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	c := &Config{values: make(map[string]value)}
+	c.set(opts.Defaults, SourceDefault)
+
+	file := opts.File
+	if file == "" {
+		file = os.Getenv("EINO_CONFIG")
+	}
+	if file != "" {
+		values, err := readYAML(file)
+		if err != nil {
+			return nil, fmt.Errorf("read config file %s failed: %w", file, err)
+		}
+		c.set(values, file)
+	}
+
+	// read before the .env files are exported, so that a value exported from one is not taken for the environment
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+
+	envFiles := opts.EnvFiles
+	if envFiles == nil {
+		envFiles = []string{".env"}
+	}
+	// reversed, so that the first file overrides the next ones
+	for i := len(envFiles) - 1; i >= 0; i-- {
+		values, err := godotenv.Read(envFiles[i])
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read env file %s failed: %w", envFiles[i], err)
+		}
+		c.set(values, envFiles[i])
+		if opts.ExportEnv {
+			for k, v := range values {
+				if _, ok := os.LookupEnv(k); !ok {
+					_ = os.Setenv(k, v)
+				}
+			}
+		}
+	}
+
+	c.set(env, SourceEnv)
+
+	fs := opts.FlagSet
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+	if !fs.Parsed() {
+		return nil, errors.New("the flags must be parsed before Load")
+	}
+	flags := make(map[string]string)
+	fs.Visit(func(f *flag.Flag) {
+		flags[f.Name] = f.Value.String()
+	})
+	c.set(flags, SourceFlag)
+
+	return c, nil
+}
+
+func (c *Config) set(values map[string]string, source string) {
+	for k, v := range values {
+		c.values[normalize(k)] = value{value: v, source: source}
+	}
+}
+
+// normalize makes keys case-insensitive and ".", "-" and "_" equivalent, MAX_TOKENS being the normal form.
+func normalize(key string) string {
+	return strings.NewReplacer(".", "_", "-", "_").Replace(strings.ToUpper(strings.TrimSpace(key)))
+}
+
+// readYAML flattens the nested mappings of the file into dotted keys, lists are joined with commas.
+func readYAML(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	values := make(map[string]string)
+	flatten("", doc, values)
+	return values, nil
+}
+
+func flatten(prefix string, v any, values map[string]string) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if prefix != "" {
+				k = prefix + "." + k
+			}
+			flatten(k, child, values)
+		}
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+		values[prefix] = strings.Join(items, ",")
+	case nil:
+		values[prefix] = ""
+	default:
+		values[prefix] = fmt.Sprint(v)
+	}
+}
+
+// Lookup returns the value of key and whether it is set, an empty value being set.
+func (c *Config) Lookup(key string) (string, bool) {
+	if c.env {
+		if v, ok := os.LookupEnv(key); ok {
+			return v, true
+		}
+		return os.LookupEnv(normalize(key))
+	}
+	v, ok := c.values[normalize(key)]
+	return v.value, ok
+}
+
+// Source returns where the value of key comes from: SourceDefault, the path of a file, SourceEnv or SourceFlag;
+// empty if key is not set.
+func (c *Config) Source(key string) string {
+	if c.env {
+		if _, ok := c.Lookup(key); ok {
+			return SourceEnv
+		}
+		return ""
+	}
+	return c.values[normalize(key)].source
+}
+
+// String returns the value of key, def if it is not set or empty.
+func (c *Config) String(key, def string) string {
+	if v, ok := c.Lookup(key); ok && v != "" {
+		return v
+	}
+	return def
+}
+
+// MustString returns the value of key and exits if it is not set or empty, naming the key and how to set it.
+func (c *Config) MustString(key string) string {
+	v := c.String(key, "")
+	if v == "" {
+		logs.Fatalf("%s is required, set it in the environment, .env or the config file", normalize(key))
+	}
+	return v
+}
+
+// Int returns the value of key, def if it is not set, empty or not an integer, the latter with a warning.
+func (c *Config) Int(key string, def int) int {
+	return parse(c, key, def, strconv.Atoi)
+}
+
+// Float returns the value of key, def if it is not set, empty or not a number, the latter with a warning.
+func (c *Config) Float(key string, def float64) float64 {
+	return parse(c, key, def, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+}
+
+// Bool returns the value of key, def if it is not set, empty or not a boolean, the latter with a warning.
+// 1, t, true, yes and on are true, case-insensitive.
+func (c *Config) Bool(key string, def bool) bool {
+	return parse(c, key, def, func(s string) (bool, error) {
+		switch strings.ToLower(s) {
+		case "yes", "on":
+			return true, nil
+		case "no", "off":
+			return false, nil
+		}
+		return strconv.ParseBool(s)
+	})
+}
+
+// Duration returns the value of key, such as 30s or 1h30m, def if it is not set, empty or not a duration, the latter
+// with a warning.
+func (c *Config) Duration(key string, def time.Duration) time.Duration {
+	return parse(c, key, def, time.ParseDuration)
+}
+
+// Strings splits the comma separated value of key, trimming the items and dropping the empty ones; def if it is not
+// set or empty.
+func (c *Config) Strings(key string, def []string) []string {
+	v := c.String(key, "")
+	if v == "" {
+		return def
+	}
+	var items []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Require returns an error listing the keys that are not set or empty, to check them all at once on startup.
+func (c *Config) Require(keys ...string) error {
+	var missing []string
+	for _, k := range keys {
+		if c.String(k, "") == "" {
+			missing = append(missing, normalize(k))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing configuration: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Keys returns the normalized keys set, sorted. The default config lists none before Init, it reads the environment
+// at call time.
+func (c *Config) Keys() []string {
+	keys := make([]string, 0, len(c.values))
+	for k := range c.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func parse[T any](c *Config, key string, def T, parse func(string) (T, error)) T {
+	v := strings.TrimSpace(c.String(key, ""))
+	if v == "" {
+		return def
+	}
+	parsed, err := parse(v)
+	if err != nil {
+		logs.Warnf("invalid %s=%q from %s, using %v: %v", normalize(key), v, c.Source(key), def, err)
+		return def
+	}
+	return parsed
+}
+
+var (
+	mu  sync.RWMutex
+	std = &Config{env: true}
+)
+
+// Init loads the configuration behind the package functions, after flag.Parse.
+func Init(opts *Options) error {
+	c, err := Load(opts)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	std = c
+	return nil
+}
+
+// MustInit is Init exiting on error.
+func MustInit(opts *Options) {
+	if err := Init(opts); err != nil {
+		logs.Fatalf("config.Init failed, err=%v", err)
+	}
+}
+
+// Default returns the configuration behind the package functions.
+func Default() *Config {
+	mu.RLock()
+	defer mu.RUnlock()
+	return std
+}
+
+func Lookup(key string) (string, bool) {
+	return Default().Lookup(key)
+}
+
+func Source(key string) string {
+	return Default().Source(key)
+}
+
+func String(key, def string) string {
+	return Default().String(key, def)
+}
+
+func MustString(key string) string {
+	return Default().MustString(key)
+}
+
+func Int(key string, def int) int {
+	return Default().Int(key, def)
+}
+
+func Float(key string, def float64) float64 {
+	return Default().Float(key, def)
+}
+
+func Bool(key string, def bool) bool {
+	return Default().Bool(key, def)
+}
+
+func Duration(key string, def time.Duration) time.Duration {
+	return Default().Duration(key, def)
+}
+
+func Strings(key string, def []string) []string {
+	return Default().Strings(key, def)
+}
+
+func Require(keys ...string) error {
+	return Default().Require(keys...)
+}
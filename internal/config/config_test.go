@@ -0,0 +1,213 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func parsedFlags(t *testing.T, args ...string) *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("config-test-flag", "flag default", "")
+	fs.String("config-test-unset", "unset default", "")
+	assert.NoError(t, fs.Parse(args))
+	return fs
+}
+
+func TestPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	file := writeFile(t, dir, "config.yaml", `
+config_test:
+  flag: yaml
+  env: yaml
+  dotenv: yaml
+  yaml: yaml
+`)
+	envFile := writeFile(t, dir, ".env", "CONFIG_TEST_FLAG=dotenv\nCONFIG_TEST_ENV=dotenv\nCONFIG_TEST_DOTENV=dotenv\n")
+	t.Setenv("CONFIG_TEST_FLAG", "env")
+	t.Setenv("CONFIG_TEST_ENV", "env")
+
+	c, err := Load(&Options{
+		Defaults: map[string]string{"config_test_flag": "default", "config_test_default": "default", "config_test_yaml": "default"},
+		File:     file,
+		EnvFiles: []string{envFile},
+		FlagSet:  parsedFlags(t, "-config-test-flag", "flag"),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	for key, want := range map[string][2]string{
+		"CONFIG_TEST_FLAG":    {"flag", SourceFlag},
+		"CONFIG_TEST_ENV":     {"env", SourceEnv},
+		"CONFIG_TEST_DOTENV":  {"dotenv", envFile},
+		"CONFIG_TEST_YAML":    {"yaml", file},
+		"CONFIG_TEST_DEFAULT": {"default", SourceDefault},
+	} {
+		assert.Equal(t, want[0], c.String(key, ""), key)
+		assert.Equal(t, want[1], c.Source(key), key)
+	}
+
+	// the default of a flag which was not set is not a value
+	_, ok := c.Lookup("config-test-unset")
+	assert.False(t, ok)
+	assert.Equal(t, "", c.Source("config-test-unset"))
+}
+
+func TestEnvFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := writeFile(t, dir, "first.env", "CONFIG_TEST_A=first\n")
+	second := writeFile(t, dir, "second.env", "CONFIG_TEST_A=second\nCONFIG_TEST_EXPORTED=second\n")
+	t.Setenv("CONFIG_TEST_EXPORTED", "")
+	_ = os.Unsetenv("CONFIG_TEST_EXPORTED")
+
+	c, err := Load(&Options{
+		EnvFiles:  []string{first, filepath.Join(dir, "missing.env"), second},
+		FlagSet:   parsedFlags(t),
+		ExportEnv: true,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "first", c.String("CONFIG_TEST_A", ""))
+	assert.Equal(t, "second", os.Getenv("CONFIG_TEST_EXPORTED"))
+	// an exported value is still from its file
+	assert.Equal(t, second, c.Source("CONFIG_TEST_EXPORTED"))
+}
+
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := Load(&Options{File: filepath.Join(dir, "missing.yaml"), FlagSet: parsedFlags(t)})
+	assert.ErrorContains(t, err, "read config file")
+
+	_, err = Load(&Options{File: writeFile(t, dir, "bad.yaml", "a: [b"), FlagSet: parsedFlags(t)})
+	assert.ErrorContains(t, err, "read config file")
+
+	_, err = Load(&Options{EnvFiles: []string{}, FlagSet: flag.NewFlagSet("test", flag.ContinueOnError)})
+	assert.ErrorContains(t, err, "the flags must be parsed before Load")
+}
+
+func TestNormalize(t *testing.T) {
+	for _, key := range []string{"MAX_TOKENS", "max_tokens", "max-tokens", "max.tokens", " Max-Tokens "} {
+		assert.Equal(t, "MAX_TOKENS", normalize(key), key)
+	}
+
+	c, err := Load(&Options{
+		Defaults: map[string]string{"openai.api-key": "sk-test"},
+		EnvFiles: []string{},
+		FlagSet:  parsedFlags(t),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "sk-test", c.String("OPENAI_API_KEY", ""))
+	assert.Equal(t, "sk-test", c.String("openai.api_key", ""))
+	assert.Contains(t, c.Keys(), "OPENAI_API_KEY")
+}
+
+func TestFlattenYAML(t *testing.T) {
+	file := writeFile(t, t.TempDir(), "config.yaml", `
+openai:
+  api_key: sk-test
+  model:
+    name: gpt-4o
+  temperature: 0.5
+stop: [a, b]
+empty:
+`)
+	values, err := readYAML(file)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{
+			"openai.api_key":     "sk-test",
+			"openai.model.name":  "gpt-4o",
+			"openai.temperature": "0.5",
+			"stop":               "a,b",
+			"empty":              "",
+		}, values)
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	c, err := Load(&Options{
+		Defaults: map[string]string{
+			"string":   "value",
+			"empty":    "",
+			"int":      "42",
+			"float":    "0.5",
+			"bool":     "yes",
+			"off":      "off",
+			"duration": "1m30s",
+			"strings":  " a, ,b ,",
+			"invalid":  "x",
+		},
+		EnvFiles: []string{},
+		FlagSet:  parsedFlags(t),
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "value", c.String("string", "def"))
+	assert.Equal(t, "def", c.String("empty", "def"))
+	assert.Equal(t, "def", c.String("config_test_missing", "def"))
+	v, ok := c.Lookup("empty")
+	assert.True(t, ok)
+	assert.Equal(t, "", v)
+
+	assert.Equal(t, 42, c.Int("int", 1))
+	assert.Equal(t, 1, c.Int("invalid", 1))
+	assert.Equal(t, 0.5, c.Float("float", 1))
+	assert.Equal(t, 1.0, c.Float("invalid", 1))
+	assert.True(t, c.Bool("bool", false))
+	assert.False(t, c.Bool("off", true))
+	assert.True(t, c.Bool("invalid", true))
+	assert.Equal(t, 90*time.Second, c.Duration("duration", 0))
+	assert.Equal(t, time.Second, c.Duration("invalid", time.Second))
+	assert.Equal(t, []string{"a", "b"}, c.Strings("strings", nil))
+	assert.Equal(t, []string{"def"}, c.Strings("empty", []string{"def"}))
+
+	assert.NoError(t, c.Require("string", "int"))
+	assert.EqualError(t, c.Require("string", "empty", "config-test-missing"),
+		"missing configuration: EMPTY, CONFIG_TEST_MISSING")
+}
+
+func TestDefaultBeforeInit(t *testing.T) {
+	c := &Config{env: true}
+	t.Setenv("CONFIG_TEST_ENV", "env")
+
+	assert.Equal(t, "env", c.String("CONFIG_TEST_ENV", ""))
+	// the environment is read at call time, the key normalized if it is not set as is
+	assert.Equal(t, "env", c.String("config-test-env", ""))
+	assert.Equal(t, SourceEnv, c.Source("config.test.env"))
+	assert.Equal(t, "", c.Source("CONFIG_TEST_MISSING"))
+
+	t.Setenv("CONFIG_TEST_ENV", "changed")
+	assert.Equal(t, "changed", c.String("CONFIG_TEST_ENV", ""))
+}
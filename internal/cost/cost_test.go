@@ -0,0 +1,101 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cost
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/mock"
+)
+
+func TestLookup(t *testing.T) {
+	for name, want := range map[string]float64{
+		"gpt-4o":                 2.5,
+		"GPT-4o-2024-08-06":      2.5,
+		"gpt-4o-mini-2024-07-18": 0.15,
+		"openai/gpt-4o-mini":     0.15,
+		"o1-2024-12-17":          15,
+	} {
+		p, ok := Lookup(name)
+		if assert.True(t, ok, name) {
+			assert.Equal(t, want, p.Input, name)
+		}
+	}
+	for _, name := range []string{"o10", "llama3", ""} {
+		_, ok := Lookup(name)
+		assert.False(t, ok, name)
+	}
+
+	SetPrice("llama3", Price{Currency: USD})
+	_, ok := Lookup("llama3:8b")
+	assert.True(t, ok)
+}
+
+func TestEstimate(t *testing.T) {
+	c, ok := Estimate("gpt-4o", 1000, 500)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.0075, c.Amount, 1e-12)
+	assert.Equal(t, USD, c.Currency)
+	assert.Equal(t, "$0.0075 (¥0.054)", c.String())
+
+	c, _ = Estimate("deepseek-chat", 1_000_000, 1_000_000)
+	assert.Equal(t, "¥10.00 ($1.39)", c.String())
+	assert.InDelta(t, 10/CNYPerUSD+0.0075, Cost{Currency: USD, Amount: 0.0075}.Add(c).Amount, 1e-12)
+}
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewTracker()
+	tracker.Add("gpt-4o-mini", &model.TokenUsage{PromptTokens: 1000, CompletionTokens: 100})
+	tracker.Add("gpt-4o-mini", &model.TokenUsage{PromptTokens: 1000, CompletionTokens: 100})
+	tracker.Add("deepseek-chat", &model.TokenUsage{PromptTokens: 1000, CompletionTokens: 1000})
+
+	// a model without callbacks of its own is counted from the usage of its messages, without name nor price
+	reply := mock.Reply("hi")
+	reply.Message.ResponseMeta = &schema.ResponseMeta{Usage: &schema.TokenUsage{PromptTokens: 10, CompletionTokens: 2}}
+	chain, err := compose.NewChain[[]*schema.Message, *schema.Message]().
+		AppendChatModel(mock.NewChatModel(reply, mock.Reply("streamed"))).
+		Compile(ctx)
+	assert.NoError(t, err)
+	_, err = chain.Invoke(ctx, nil, compose.WithCallbacks(tracker.Handler()))
+	assert.NoError(t, err)
+	sr, err := chain.Stream(ctx, nil, compose.WithCallbacks(tracker.Handler()))
+	assert.NoError(t, err)
+	sr.Close()
+
+	models := tracker.Models()
+	assert.Equal(t, Usage{Calls: 2, PromptTokens: 2000, CompletionTokens: 200, Cost: Cost{Amount: 0.00042, Currency: USD}},
+		roundCost(models["gpt-4o-mini"]))
+	assert.Equal(t, Usage{Calls: 2, PromptTokens: 10, CompletionTokens: 2, Unpriced: 2}, models[unknownModel])
+
+	total := tracker.Total()
+	assert.Equal(t, 5, total.Calls)
+	assert.Equal(t, USD, total.Cost.Currency)
+	assert.InDelta(t, 0.00042+0.01/CNYPerUSD, total.Cost.Amount, 1e-12)
+	assert.Contains(t, total.String(), "+ 2 unpriced calls")
+}
+
+func roundCost(u Usage) Usage {
+	u.Cost.Amount = float64(int64(u.Cost.Amount*1e8+0.5)) / 1e8
+	return u
+}
@@ -0,0 +1,184 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cost estimates what the model calls of an example cost, from the token usage reported by the model and
+// a table of list prices. A Tracker is a callback handler adding up the usage and cost of the calls of a run:
+//
+//	tracker := cost.NewTracker()
+//	out, err := runner.Invoke(ctx, in, compose.WithCallbacks(tracker.Handler()))
+//	tracker.Report("summary")
+//
+// The prices are the public list prices in early 2025, without discount nor cache, and change often: check them
+// with the provider, and set the price of another model with SetPrice. A model of unknown price, e.g. a local one,
+// is counted but not priced.
+package cost
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Currency is the currency of a price.
+type Currency string
+
+const (
+	USD Currency = "USD"
+	CNY Currency = "CNY"
+)
+
+// CNYPerUSD converts between the currencies, for the totals of models priced in both.
+var CNYPerUSD = 7.2
+
+// Price is the price of a model per million tokens.
+type Price struct {
+	Currency Currency
+	// Input is the price of a million prompt tokens.
+	Input float64
+	// Output is the price of a million completion tokens.
+	Output float64
+}
+
+var (
+	mu sync.RWMutex
+	// prices are keyed by the name of the model, or the start of it, see Lookup
+	prices = map[string]Price{
+		"gpt-4o":            {USD, 2.5, 10},
+		"gpt-4o-mini":       {USD, 0.15, 0.6},
+		"gpt-4.1":           {USD, 2, 8},
+		"gpt-4.1-mini":      {USD, 0.4, 1.6},
+		"gpt-4.1-nano":      {USD, 0.1, 0.4},
+		"gpt-4-turbo":       {USD, 10, 30},
+		"gpt-3.5-turbo":     {USD, 0.5, 1.5},
+		"o1":                {USD, 15, 60},
+		"o1-mini":           {USD, 1.1, 4.4},
+		"o3-mini":           {USD, 1.1, 4.4},
+		"claude-3-7-sonnet": {USD, 3, 15},
+		"claude-3-5-sonnet": {USD, 3, 15},
+		"claude-3-5-haiku":  {USD, 0.8, 4},
+		"claude-3-opus":     {USD, 15, 75},
+		"claude-3-haiku":    {USD, 0.25, 1.25},
+		"deepseek-chat":     {CNY, 2, 8},
+		"deepseek-reasoner": {CNY, 4, 16},
+		"doubao-pro-32k":    {CNY, 0.8, 2},
+		"doubao-pro-128k":   {CNY, 5, 9},
+		"doubao-lite-32k":   {CNY, 0.3, 0.6},
+		"doubao-1.5-pro":    {CNY, 0.8, 2},
+		"doubao-1.5-lite":   {CNY, 0.3, 0.6},
+		"qwen-max":          {CNY, 2.4, 9.6},
+		"qwen-plus":         {CNY, 0.8, 2},
+		"qwen-turbo":        {CNY, 0.3, 0.6},
+	}
+)
+
+// SetPrice sets the price of model, and of the models whose name starts with it and have no price of their own.
+func SetPrice(model string, price Price) {
+	mu.Lock()
+	defer mu.Unlock()
+	prices[strings.ToLower(model)] = price
+}
+
+// Lookup returns the price of model: of its name, or else of the longest name it starts with, so that a dated
+// version, e.g. gpt-4o-2024-08-06, gets the price of gpt-4o. The name of a router, e.g. deepseek/deepseek-chat, is
+// priced by what follows the last slash.
+func Lookup(model string) (Price, bool) {
+	name := strings.ToLower(model)
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	if p, ok := prices[name]; ok {
+		return p, true
+	}
+	best := ""
+	for prefix := range prices {
+		if len(prefix) > len(best) && strings.HasPrefix(name, prefix) && boundary(name, len(prefix)) {
+			best = prefix
+		}
+	}
+	if best == "" {
+		return Price{}, false
+	}
+	return prices[best], true
+}
+
+// boundary tells whether the prefix of length n ends a part of name, so that o1 prices o1-2024-12-17 but not o10.
+func boundary(name string, n int) bool {
+	return n == len(name) || name[n] == '-' || name[n] == ':' || name[n] == '@'
+}
+
+// Cost is an amount in a currency.
+type Cost struct {
+	Amount   float64
+	Currency Currency
+}
+
+// Estimate returns the cost of a call of model with the given token counts, false if its price is unknown.
+func Estimate(model string, promptTokens, completionTokens int) (Cost, bool) {
+	p, ok := Lookup(model)
+	if !ok {
+		return Cost{}, false
+	}
+	amount := (float64(promptTokens)*p.Input + float64(completionTokens)*p.Output) / 1e6
+	return Cost{Amount: amount, Currency: p.Currency}, true
+}
+
+// In returns c converted to currency at CNYPerUSD.
+func (c Cost) In(currency Currency) Cost {
+	switch {
+	case c.Currency == currency || c.Amount == 0:
+		return Cost{Amount: c.Amount, Currency: currency}
+	case c.Currency == USD && currency == CNY:
+		return Cost{Amount: c.Amount * CNYPerUSD, Currency: CNY}
+	case c.Currency == CNY && currency == USD:
+		return Cost{Amount: c.Amount / CNYPerUSD, Currency: USD}
+	}
+	return c
+}
+
+// Add returns the sum of c and other, in the currency of c, or of other if c is zero.
+func (c Cost) Add(other Cost) Cost {
+	if c.Currency == "" {
+		return other
+	}
+	return Cost{Amount: c.Amount + other.In(c.Currency).Amount, Currency: c.Currency}
+}
+
+// String formats c in its currency and the other one, e.g. $0.0123 (¥0.0886). Small amounts keep 4 significant
+// digits, an example run costing fractions of a cent.
+func (c Cost) String() string {
+	if c.Currency == "" {
+		return "$0"
+	}
+	other := USD
+	if c.Currency == USD {
+		other = CNY
+	}
+	return fmt.Sprintf("%s (%s)", format(c), format(c.In(other)))
+}
+
+func format(c Cost) string {
+	symbol := "$"
+	if c.Currency == CNY {
+		symbol = "¥"
+	}
+	if c.Amount >= 1 || c.Amount == 0 {
+		return fmt.Sprintf("%s%.2f", symbol, c.Amount)
+	}
+	return fmt.Sprintf("%s%.4g", symbol, c.Amount)
+}
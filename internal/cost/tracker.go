@@ -0,0 +1,246 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	ucallbacks "github.com/cloudwego/eino/utils/callbacks"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// unknownModel names the calls of a model not telling its name, e.g. a model without callbacks of its own.
+const unknownModel = "unknown"
+
+// Usage is the usage of one model, or of all, by the calls of a run.
+type Usage struct {
+	Calls            int
+	PromptTokens     int
+	CompletionTokens int
+	// Cost is the cost of the priced calls.
+	Cost Cost
+	// Unpriced counts the calls of a model of unknown price, or not reporting its usage.
+	Unpriced int
+}
+
+func (u Usage) String() string {
+	s := fmt.Sprintf("calls=%d prompt_tokens=%d completion_tokens=%d", u.Calls, u.PromptTokens, u.CompletionTokens)
+	switch {
+	case u.Unpriced == u.Calls:
+		return s + " cost=unknown"
+	case u.Unpriced > 0:
+		return fmt.Sprintf("%s cost=%s + %d unpriced calls", s, u.Cost, u.Unpriced)
+	}
+	return s + " cost=" + u.Cost.String()
+}
+
+func (u *Usage) add(other Usage) {
+	u.Calls += other.Calls
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.Cost = u.Cost.Add(other.Cost)
+	u.Unpriced += other.Unpriced
+}
+
+// Tracker adds up the usage and cost of the ChatModel calls it is the callback handler of, by model. It is safe for
+// concurrent use, e.g. by the parallel branches of a graph.
+type Tracker struct {
+	mu      sync.Mutex
+	byModel map[string]*Usage
+	wg      sync.WaitGroup
+}
+
+// NewTracker returns an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{byModel: make(map[string]*Usage)}
+}
+
+// Handler returns the callback handler to be passed to compose.WithCallbacks or callbacks.InitCallbacks.
+func (t *Tracker) Handler() callbacks.Handler {
+	return ucallbacks.NewHandlerHelper().ChatModel(&ucallbacks.ModelCallbackHandler{
+		OnStart:               t.onStart,
+		OnEnd:                 t.onEnd,
+		OnEndWithStreamOutput: t.onEndWithStreamOutput,
+	}).Handler()
+}
+
+type modelKey struct{}
+
+func (t *Tracker) onStart(ctx context.Context, _ *callbacks.RunInfo, input *model.CallbackInput) context.Context {
+	if input != nil && input.Config != nil {
+		return context.WithValue(ctx, modelKey{}, input.Config.Model)
+	}
+	return ctx
+}
+
+func (t *Tracker) onEnd(ctx context.Context, _ *callbacks.RunInfo, output *model.CallbackOutput) context.Context {
+	name, _ := ctx.Value(modelKey{}).(string)
+	if output != nil {
+		t.Add(modelName(name, output.Config), usageOf(output))
+	}
+	return ctx
+}
+
+func (t *Tracker) onEndWithStreamOutput(ctx context.Context, _ *callbacks.RunInfo,
+	output *schema.StreamReader[*model.CallbackOutput]) context.Context {
+
+	name, _ := ctx.Value(modelKey{}).(string)
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		defer output.Close()
+
+		var (
+			usage  *model.TokenUsage
+			config *model.Config
+		)
+		for {
+			frame, err := output.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				// the call is counted, with what it reported before failing
+				break
+			}
+			if u := usageOf(frame); u != nil {
+				usage = u
+			}
+			if frame.Config != nil {
+				config = frame.Config
+			}
+		}
+		t.Add(modelName(name, config), usage)
+	}()
+	return ctx
+}
+
+// usageOf returns the usage of output, reported in the callback output by the model or else in the response meta
+// of its message.
+func usageOf(output *model.CallbackOutput) *model.TokenUsage {
+	if output == nil {
+		return nil
+	}
+	if output.TokenUsage != nil {
+		return output.TokenUsage
+	}
+	if output.Message != nil && output.Message.ResponseMeta != nil && output.Message.ResponseMeta.Usage != nil {
+		u := output.Message.ResponseMeta.Usage
+		return &model.TokenUsage{PromptTokens: u.PromptTokens, CompletionTokens: u.CompletionTokens, TotalTokens: u.TotalTokens}
+	}
+	return nil
+}
+
+func modelName(name string, config *model.Config) string {
+	if name == "" && config != nil {
+		name = config.Model
+	}
+	if name == "" {
+		return unknownModel
+	}
+	return name
+}
+
+// Add counts a call of the model name with usage, nil if the model did not report it.
+func (t *Tracker) Add(name string, usage *model.TokenUsage) {
+	u := Usage{Calls: 1}
+	if usage != nil {
+		u.PromptTokens, u.CompletionTokens = usage.PromptTokens, usage.CompletionTokens
+	}
+	if c, ok := Estimate(name, u.PromptTokens, u.CompletionTokens); ok && usage != nil {
+		u.Cost = c
+	} else {
+		u.Unpriced = 1
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	m, ok := t.byModel[name]
+	if !ok {
+		m = &Usage{}
+		t.byModel[name] = m
+	}
+	m.add(u)
+}
+
+// Wait waits for the streams of the calls to end, so that they are counted.
+func (t *Tracker) Wait() {
+	t.wg.Wait()
+}
+
+// Models returns the usage of every model called, once the streams ended.
+func (t *Tracker) Models() map[string]Usage {
+	t.Wait()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	res := make(map[string]Usage, len(t.byModel))
+	for name, u := range t.byModel {
+		res[name] = *u
+	}
+	return res
+}
+
+// Total returns the usage of all the models, once the streams ended. The costs in both currencies are added up in
+// dollars.
+func (t *Tracker) Total() Usage {
+	var total Usage
+	models := t.Models()
+	currencies := map[Currency]bool{}
+	for _, u := range models {
+		if u.Cost.Currency != "" {
+			currencies[u.Cost.Currency] = true
+		}
+	}
+	if len(currencies) > 1 {
+		total.Cost = Cost{Currency: USD}
+	}
+	for _, u := range models {
+		total.add(u)
+	}
+	return total
+}
+
+// Report logs the usage of every model and the total, under title.
+func (t *Tracker) Report(title string) {
+	models := t.Models()
+	if len(models) == 0 {
+		logs.Infof("%s: no model call", title)
+		return
+	}
+	names := make([]string, 0, len(models))
+	for n := range models {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	if len(names) > 1 {
+		for _, n := range names {
+			logs.Infof("%s: %s %s", title, n, models[n])
+		}
+		logs.Infof("%s: total %s", title, t.Total())
+		return
+	}
+	logs.Infof("%s: %s %s", title, names[0], models[names[0]])
+}
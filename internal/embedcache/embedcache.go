@@ -0,0 +1,187 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package embedcache caches the vectors of an embedder in a store of internal/store, keyed by the model and the
+// hash of the text, so that embedding a text again costs nothing: re-indexing an unchanged corpus, or the unchanged
+// chunks of an edited file, makes no request.
+//
+//	emb, err := embedcache.New(&embedcache.Config{Embedder: arkEmb, Store: kv, Model: "doubao-embedding"})
+package embedcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/store"
+)
+
+// Config configures Embedder.
+type Config struct {
+	Embedder embedding.Embedder
+	Store    store.Store
+	// Model is the model of Embedder, the vectors of two models are never mixed. A model given by
+	// embedding.WithModel on a call takes precedence.
+	Model string
+	// KeyPrefix is put before the model and the hash, default "embedding:".
+	KeyPrefix string
+	// TTL expires the vectors not embedded again for that long, 0 keeps them until deleted.
+	TTL time.Duration
+}
+
+// Embedder embeds with its inner embedder the texts whose vectors are not in the store, in one request, and returns
+// the others from the store. A store failing is logged and the texts embedded, the cache never fails a call.
+type Embedder struct {
+	Embedder embedding.Embedder
+
+	kv     store.Store
+	model  string
+	prefix string
+	ttl    time.Duration
+
+	hits, misses atomic.Int64
+}
+
+var _ embedding.Embedder = (*Embedder)(nil)
+
+func New(config *Config) (*Embedder, error) {
+	if config == nil || config.Embedder == nil || config.Store == nil {
+		return nil, fmt.Errorf("embedding cache needs an embedder and a store")
+	}
+	if config.Model == "" {
+		return nil, fmt.Errorf("embedding cache needs the model, the vectors of two models cannot be mixed")
+	}
+	prefix := config.KeyPrefix
+	if prefix == "" {
+		prefix = "embedding:"
+	}
+	return &Embedder{Embedder: config.Embedder, kv: config.Store, model: config.Model, prefix: prefix, ttl: config.TTL}, nil
+}
+
+func (e *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	model := e.model
+	if o := embedding.GetCommonOptions(&embedding.Options{}, opts...); o.Model != nil && *o.Model != "" {
+		model = *o.Model
+	}
+
+	vectors := make([][]float64, len(texts))
+	// the indexes of each text to embed, a text repeated in texts is embedded once
+	missing := make(map[string][]int)
+	var toEmbed []string
+	for i, t := range texts {
+		if v, ok := e.get(ctx, model, t); ok {
+			vectors[i] = v
+			continue
+		}
+		if _, ok := missing[t]; !ok {
+			toEmbed = append(toEmbed, t)
+		}
+		missing[t] = append(missing[t], i)
+	}
+	e.hits.Add(int64(len(texts) - len(toEmbed)))
+	e.misses.Add(int64(len(toEmbed)))
+	if len(toEmbed) == 0 {
+		return vectors, nil
+	}
+
+	embedded, err := e.Embedder.EmbedStrings(ctx, toEmbed, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(embedded) != len(toEmbed) {
+		return nil, fmt.Errorf("embedder returned %d vectors for %d texts", len(embedded), len(toEmbed))
+	}
+	for j, t := range toEmbed {
+		for _, i := range missing[t] {
+			vectors[i] = embedded[j]
+		}
+		e.set(ctx, model, t, embedded[j])
+	}
+	return vectors, nil
+}
+
+// Stats returns the number of texts served from the store and embedded so far.
+func (e *Embedder) Stats() (hits, misses int) {
+	return int(e.hits.Load()), int(e.misses.Load())
+}
+
+// IsCallbacksEnabled keeps the callbacks aspect of the inner Embedder, which is called for the misses only.
+func (e *Embedder) IsCallbacksEnabled() bool {
+	checker, ok := e.Embedder.(components.Checker)
+	if ok {
+		return checker.IsCallbacksEnabled()
+	}
+
+	return false
+}
+
+func (e *Embedder) key(model, text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return e.prefix + model + ":" + hex.EncodeToString(sum[:])
+}
+
+func (e *Embedder) get(ctx context.Context, model, text string) ([]float64, bool) {
+	data, err := e.kv.Get(ctx, e.key(model, text))
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, false
+	}
+	if err != nil {
+		logs.Warnf("read embedding cache failed, err=%v", err)
+		return nil, false
+	}
+	v, err := decode(data)
+	if err != nil {
+		logs.Warnf("decode cached embedding failed, err=%v", err)
+		return nil, false
+	}
+	return v, true
+}
+
+func (e *Embedder) set(ctx context.Context, model, text string, v []float64) {
+	if err := e.kv.Set(ctx, e.key(model, text), encode(v), e.ttl); err != nil {
+		logs.Warnf("write embedding cache failed, err=%v", err)
+	}
+}
+
+// encode writes v as little endian float64s, exact and a third of the size of JSON.
+func encode(v []float64) []byte {
+	data := make([]byte, 8*len(v))
+	for i, f := range v {
+		binary.LittleEndian.PutUint64(data[8*i:], math.Float64bits(f))
+	}
+	return data
+}
+
+func decode(data []byte) ([]float64, error) {
+	if len(data)%8 != 0 {
+		return nil, fmt.Errorf("cached vector of %d bytes is not a multiple of 8", len(data))
+	}
+	v := make([]float64, len(data)/8)
+	for i := range v {
+		v[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+	return v, nil
+}
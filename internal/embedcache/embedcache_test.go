@@ -0,0 +1,95 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package embedcache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/store"
+)
+
+// countingEmbedder embeds a text as its length, and records the texts it was asked for.
+type countingEmbedder struct {
+	calls [][]string
+}
+
+func (c *countingEmbedder) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	c.calls = append(c.calls, texts)
+	vectors := make([][]float64, len(texts))
+	for i, t := range texts {
+		vectors[i] = []float64{float64(len(t)), 0.1}
+	}
+	return vectors, nil
+}
+
+func TestOnlyMissesAreEmbedded(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingEmbedder{}
+	kv := store.NewMemoryStore()
+	emb, err := New(&Config{Embedder: inner, Store: kv, Model: "m1"})
+	assert.NoError(t, err)
+
+	got, err := emb.EmbedStrings(ctx, []string{"a", "bb", "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float64{{1, 0.1}, {2, 0.1}, {1, 0.1}}, got)
+	assert.Equal(t, [][]string{{"a", "bb"}}, inner.calls)
+
+	got, err = emb.EmbedStrings(ctx, []string{"bb", "ccc"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float64{{2, 0.1}, {3, 0.1}}, got)
+	assert.Equal(t, []string{"ccc"}, inner.calls[1])
+
+	_, err = emb.EmbedStrings(ctx, []string{"a", "bb", "ccc"})
+	assert.NoError(t, err)
+	assert.Len(t, inner.calls, 2)
+
+	hits, misses := emb.Stats()
+	assert.Equal(t, 5, hits)
+	assert.Equal(t, 3, misses)
+}
+
+func TestModelsAreNotMixed(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingEmbedder{}
+	kv := store.NewMemoryStore()
+	emb, err := New(&Config{Embedder: inner, Store: kv, Model: "m1"})
+	assert.NoError(t, err)
+
+	_, err = emb.EmbedStrings(ctx, []string{"a"})
+	assert.NoError(t, err)
+	_, err = emb.EmbedStrings(ctx, []string{"a"}, embedding.WithModel("m2"))
+	assert.NoError(t, err)
+	assert.Len(t, inner.calls, 2)
+
+	keys, err := kv.List(ctx, "embedding:")
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+}
+
+func TestEncodeIsExact(t *testing.T) {
+	v := []float64{0.1, -3.14159265358979, 1e-300}
+	got, err := decode(encode(v))
+	assert.NoError(t, err)
+	assert.Equal(t, v, got)
+
+	_, err = decode([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
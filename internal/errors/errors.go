@@ -0,0 +1,260 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package errors classifies the failures of models and tools by kind, so that a retry or a fallback branches on what
+// failed rather than on the text of the error:
+//
+//	switch errors.KindOf(err) {
+//	case errors.RateLimit:
+//		// wait and retry
+//	case errors.ContextLength:
+//		// trim the history, or fall back to a model with a larger window
+//	}
+//
+// or errors.Is(err, errors.ErrRateLimit). The providers report their failures in different ways, mostly in the text
+// of the error with the HTTP status, which KindOf reads; an error wrapped by Wrap keeps its kind once classified. The
+// package also has the functions of the standard errors package, to be imported instead of it.
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kind is the class of a failure.
+type Kind string
+
+const (
+	Unknown Kind = "unknown"
+	// Canceled is the cancellation of the context of the caller.
+	Canceled Kind = "canceled"
+	// Timeout is a deadline exceeded or a network timeout.
+	Timeout Kind = "timeout"
+	// RateLimit is too many requests, retried after a while.
+	RateLimit Kind = "rate_limit"
+	// Quota is the quota or the balance of the account exhausted, not retried.
+	Quota Kind = "quota"
+	// Auth is a missing, invalid or unauthorized API key.
+	Auth Kind = "auth"
+	// ContextLength is a prompt longer than the context window of the model.
+	ContextLength Kind = "context_length"
+	// ContentFilter is a prompt or an answer blocked by the moderation of the provider.
+	ContentFilter Kind = "content_filter"
+	// Unavailable is a server error or a network failure of the provider, retried after a while.
+	Unavailable Kind = "unavailable"
+	// BadRequest is any other request rejected by the provider, e.g. an unknown model.
+	BadRequest Kind = "bad_request"
+	// ToolNotFound is a call of a tool the model was not given.
+	ToolNotFound Kind = "tool_not_found"
+	// InvalidArguments is a tool called with arguments that do not match its parameters.
+	InvalidArguments Kind = "invalid_arguments"
+	// Panic is a panic recovered from a call, KindOf never returns it.
+	Panic Kind = "panic"
+)
+
+// Error is a failure with its kind.
+type Error struct {
+	Kind Kind
+	// StatusCode is the HTTP status of the response of the provider, 0 if there was none.
+	StatusCode int
+	// Tool is the name of the tool that failed, empty for a model.
+	Tool string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return string(e.Kind)
+	}
+	if e.Tool != "" {
+		return fmt.Sprintf("tool %s: %v", e.Tool, e.Err)
+	}
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches the sentinels of the kinds, e.g. errors.Is(err, ErrRateLimit).
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Err == nil && t.Kind == e.Kind
+}
+
+// The sentinels of the kinds, matched by Is whatever the error of that kind.
+var (
+	ErrTimeout          = &Error{Kind: Timeout}
+	ErrRateLimit        = &Error{Kind: RateLimit}
+	ErrQuota            = &Error{Kind: Quota}
+	ErrAuth             = &Error{Kind: Auth}
+	ErrContextLength    = &Error{Kind: ContextLength}
+	ErrContentFilter    = &Error{Kind: ContentFilter}
+	ErrUnavailable      = &Error{Kind: Unavailable}
+	ErrBadRequest       = &Error{Kind: BadRequest}
+	ErrToolNotFound     = &Error{Kind: ToolNotFound}
+	ErrInvalidArguments = &Error{Kind: InvalidArguments}
+)
+
+// Wrap returns err as an *Error of its kind, err itself if it is one already, nil if err is nil.
+func Wrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if stderrors.As(err, &e) && e.Err != nil {
+		return err
+	}
+	return &Error{Kind: KindOf(err), StatusCode: StatusCode(err), Err: err}
+}
+
+// WrapTool returns the failure of the tool name as an *Error of its kind, nil if err is nil.
+func WrapTool(name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: KindOf(err), StatusCode: StatusCode(err), Tool: name, Err: err}
+}
+
+// Retryable reports whether err is transient: a rate limit, a timeout or the provider unavailable.
+func Retryable(err error) bool {
+	switch KindOf(err) {
+	case RateLimit, Timeout, Unavailable:
+		return true
+	}
+	return false
+}
+
+// patterns are looked for in the lowercased text of the errors, in order, the first match giving the kind. They cover
+// the error codes and messages of OpenAI and the compatible APIs, Azure, Claude, Ark and Ollama.
+var patterns = []struct {
+	kind  Kind
+	texts []string
+}{
+	{ContextLength, []string{"context_length_exceeded", "maximum context length", "context window", "prompt is too long",
+		"too many tokens", "exceeds the model's", "input length exceeds", "context length"}},
+	{ContentFilter, []string{"content_filter", "content management policy", "content_policy_violation",
+		"sensitivecontentdetected", "sensitive content", "safety system", "flagged as potentially"}},
+	{Quota, []string{"insufficient_quota", "exceeded your current quota", "quota exceeded", "accountoverdue",
+		"insufficient balance", "credit balance is too low"}},
+	{RateLimit, []string{"rate_limit", "rate limit", "ratelimit", "too many requests", "requests per min", "tokens per min"}},
+	{Auth, []string{"invalid_api_key", "incorrect api key", "invalid api key", "authentication", "unauthorized",
+		"permission_denied", "permission denied", "invalid x-api-key"}},
+	{ToolNotFound, []string{"not found in toolsnode indexes"}},
+	{InvalidArguments, []string{"failed to unmarshal arguments"}},
+	{Unavailable, []string{"overloaded", "service unavailable", "bad gateway", "connection refused", "connection reset",
+		"no such host", "unexpected eof"}},
+}
+
+// KindOf returns the kind of err, Unknown if it cannot tell, and "" for nil.
+func KindOf(err error) Kind {
+	if err == nil {
+		return ""
+	}
+	var e *Error
+	if stderrors.As(err, &e) && e.Err != nil {
+		return e.Kind
+	}
+	if stderrors.Is(err, context.Canceled) {
+		return Canceled
+	}
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return Timeout
+	}
+	var netErr net.Error
+	if stderrors.As(err, &netErr) && netErr.Timeout() {
+		return Timeout
+	}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if stderrors.As(err, &syntaxErr) || stderrors.As(err, &typeErr) {
+		return InvalidArguments
+	}
+
+	// the text is more precise than the status, e.g. a context length error is a 400
+	text := strings.ToLower(err.Error())
+	for _, p := range patterns {
+		for _, t := range p.texts {
+			if strings.Contains(text, t) {
+				return p.kind
+			}
+		}
+	}
+
+	switch code := StatusCode(err); {
+	case code == 0:
+		return Unknown
+	case code == 401 || code == 403:
+		return Auth
+	case code == 408:
+		return Timeout
+	case code == 413:
+		return ContextLength
+	case code == 429:
+		return RateLimit
+	case code >= 500:
+		return Unavailable
+	case code >= 400:
+		return BadRequest
+	}
+	return Unknown
+}
+
+// statusPatterns find the HTTP status in the text of the errors of the clients, e.g.
+// "error, status code: 429, status: 429 Too Many Requests, message: ..." of the OpenAI client.
+var statusPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`status code: (\d{3})`),
+	regexp.MustCompile(`(?i)status(?:code)?[=: ]+(\d{3})\b`),
+	regexp.MustCompile(`\b(\d{3}) (?:Too Many Requests|Unauthorized|Forbidden|Bad Request|Internal Server Error|Bad Gateway|Service Unavailable|Gateway Timeout)`),
+}
+
+// StatusCode returns the HTTP status of the response err comes from: of an error with a StatusCode method, or
+// else read from its text; 0 if it has none.
+func StatusCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var e *Error
+	if stderrors.As(err, &e) && e.StatusCode != 0 {
+		return e.StatusCode
+	}
+	var coder interface{ StatusCode() int }
+	if stderrors.As(err, &coder) {
+		return coder.StatusCode()
+	}
+	text := err.Error()
+	for _, re := range statusPatterns {
+		if m := re.FindStringSubmatch(text); len(m) == 2 {
+			code, _ := strconv.Atoi(m[1])
+			return code
+		}
+	}
+	return 0
+}
+
+// The functions of the standard errors package.
+
+func New(text string) error         { return stderrors.New(text) }
+func Is(err, target error) bool     { return stderrors.Is(err, target) }
+func As(err error, target any) bool { return stderrors.As(err, target) }
+func Unwrap(err error) error        { return stderrors.Unwrap(err) }
+func Join(errs ...error) error      { return stderrors.Join(errs...) }
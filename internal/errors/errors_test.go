@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type statusError int
+
+func (e statusError) Error() string   { return "request failed" }
+func (e statusError) StatusCode() int { return int(e) }
+
+func TestKindOf(t *testing.T) {
+	for text, want := range map[string]Kind{
+		"error, status code: 429, status: 429 Too Many Requests, message: Rate limit reached for gpt-4o":                                    RateLimit,
+		"error, status code: 429, status: 429 Too Many Requests, message: You exceeded your current quota":                                  Quota,
+		"error, status code: 401, status: 401 Unauthorized, message: Incorrect API key provided":                                            Auth,
+		"error, status code: 400, message: This model's maximum context length is 128000 tokens":                                            ContextLength,
+		"error, status code: 400, message: The response was filtered due to the prompt triggering Azure OpenAI's content management policy": ContentFilter,
+		"error, status code: 503, status: 503 Service Unavailable":                                                                          Unavailable,
+		"error, status code: 404, message: The model `gpt-5` does not exist":                                                                BadRequest,
+		"Error code: 529 - overloaded_error":                                                                                                Unavailable,
+		"[LocalFunc] failed to unmarshal arguments in json: invalid character":                                                              InvalidArguments,
+		"tool search not found in toolsNode indexes":                                                                                        ToolNotFound,
+		"something else": Unknown,
+	} {
+		assert.Equal(t, want, KindOf(New(text)), text)
+	}
+
+	assert.Equal(t, Kind(""), KindOf(nil))
+	assert.Equal(t, Canceled, KindOf(fmt.Errorf("generate: %w", context.Canceled)))
+	assert.Equal(t, Timeout, KindOf(fmt.Errorf("generate: %w", context.DeadlineExceeded)))
+	assert.Equal(t, RateLimit, KindOf(statusError(429)))
+	assert.Equal(t, 429, StatusCode(fmt.Errorf("wrapped: %w", statusError(429))))
+}
+
+func TestWrap(t *testing.T) {
+	cause := New("error, status code: 429, status: 429 Too Many Requests")
+	err := fmt.Errorf("model[0]: %w", Wrap(cause))
+
+	assert.True(t, Is(err, ErrRateLimit))
+	assert.False(t, Is(err, ErrAuth))
+	assert.True(t, Is(err, cause))
+	assert.True(t, Retryable(err))
+	var e *Error
+	if assert.True(t, As(err, &e)) {
+		assert.Equal(t, 429, e.StatusCode)
+	}
+	assert.Same(t, e, Wrap(e))
+	assert.Nil(t, Wrap(nil))
+
+	toolErr := WrapTool("search", New("[LocalFunc] failed to unmarshal arguments: unexpected end of JSON input"))
+	assert.True(t, Is(toolErr, ErrInvalidArguments))
+	assert.False(t, Retryable(toolErr))
+	assert.EqualError(t, toolErr, "tool search: [LocalFunc] failed to unmarshal arguments: unexpected end of JSON input")
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fewshot selects the labeled examples closest to an input and injects them into a ChatTemplate.
+package fewshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+const (
+	defaultK = 4
+
+	metaLabel = "fewshot_label"
+)
+
+// Example is an input and the expected answer, e.g. a text and its class.
+type Example struct {
+	Input string `json:"input"`
+	Label string `json:"label"`
+}
+
+// Config configures a Selector.
+type Config struct {
+	// Embedding embeds the examples and the inputs, required.
+	Embedding embedding.Embedder
+	// K is the number of examples selected, default 4.
+	K int
+	// MaxPerLabel caps the examples of one label, so that a few near duplicates do not fill the K slots with the
+	// same answer, which biases the model towards it. 0 means no cap.
+	MaxPerLabel int
+}
+
+// Selector stores labeled examples and selects the most similar ones to an input, by cosine similarity of their
+// embeddings.
+type Selector struct {
+	store       *vectorstore.MemoryStore
+	k           int
+	maxPerLabel int
+}
+
+func NewSelector(config *Config) (*Selector, error) {
+	if config == nil || config.Embedding == nil {
+		return nil, errors.New("embedding is required")
+	}
+	k := config.K
+	if k <= 0 {
+		k = defaultK
+	}
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: config.Embedding, TopK: k})
+	if err != nil {
+		return nil, err
+	}
+	return &Selector{store: store, k: k, maxPerLabel: config.MaxPerLabel}, nil
+}
+
+// Add embeds and stores examples. An example with the same input as a stored one replaces it.
+func (s *Selector) Add(ctx context.Context, examples ...*Example) error {
+	docs := make([]*schema.Document, 0, len(examples))
+	for _, e := range examples {
+		if e.Input == "" || e.Label == "" {
+			return fmt.Errorf("example %q: input and label are required", e.Input)
+		}
+		docs = append(docs, &schema.Document{Content: e.Input, MetaData: map[string]any{metaLabel: e.Label}})
+	}
+	_, err := s.store.Store(ctx, docs)
+	return err
+}
+
+// Len returns the number of stored examples.
+func (s *Selector) Len() int {
+	return s.store.Len()
+}
+
+// Select returns the examples most similar to input, the most similar last: in the prompt it is then the closest
+// to the input, where models weigh it the most.
+func (s *Selector) Select(ctx context.Context, input string) ([]*Example, error) {
+	topK := s.k
+	if s.maxPerLabel > 0 {
+		// fetch more so that the cap can skip some
+		topK = s.k * 3
+	}
+	docs, err := s.store.Retrieve(ctx, input, retriever.WithTopK(topK))
+	if err != nil {
+		return nil, err
+	}
+
+	perLabel := map[string]int{}
+	selected := make([]*Example, 0, s.k)
+	for _, d := range docs {
+		label, _ := d.MetaData[metaLabel].(string)
+		if s.maxPerLabel > 0 && perLabel[label] >= s.maxPerLabel {
+			continue
+		}
+		perLabel[label]++
+		selected = append(selected, &Example{Input: d.Content, Label: label})
+		if len(selected) == s.k {
+			break
+		}
+	}
+	for i, j := 0, len(selected)-1; i < j; i, j = i+1, j-1 {
+		selected[i], selected[j] = selected[j], selected[i]
+	}
+	return selected, nil
+}
+
+// Messages renders examples as pairs of user and assistant messages, the usual shape of few-shot prompts for chat
+// models.
+func Messages(examples []*Example) []*schema.Message {
+	msgs := make([]*schema.Message, 0, 2*len(examples))
+	for _, e := range examples {
+		msgs = append(msgs, schema.UserMessage(e.Input), schema.AssistantMessage(e.Label, nil))
+	}
+	return msgs
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fewshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	defaultQueryKey    = "query"
+	defaultExamplesKey = "examples"
+)
+
+// TemplateConfig configures a Template.
+type TemplateConfig struct {
+	// Selector selects the examples, required.
+	Selector *Selector
+	// Template renders the prompt, required. It must have a schema.MessagesPlaceholder(ExamplesKey, ...).
+	Template prompt.ChatTemplate
+	// QueryKey is the variable whose value the examples are selected for, default "query".
+	QueryKey string
+	// ExamplesKey is the variable the selected examples are given to Template in, default "examples".
+	ExamplesKey string
+}
+
+// Template is a ChatTemplate selecting the examples similar to a variable and injecting them into another
+// template, so that it can be used as a ChatTemplate node of a chain or graph.
+type Template struct {
+	selector    *Selector
+	tpl         prompt.ChatTemplate
+	queryKey    string
+	examplesKey string
+}
+
+var _ prompt.ChatTemplate = (*Template)(nil)
+
+func NewTemplate(config *TemplateConfig) (*Template, error) {
+	if config == nil || config.Selector == nil || config.Template == nil {
+		return nil, errors.New("selector and template are required")
+	}
+	t := &Template{
+		selector:    config.Selector,
+		tpl:         config.Template,
+		queryKey:    config.QueryKey,
+		examplesKey: config.ExamplesKey,
+	}
+	if t.queryKey == "" {
+		t.queryKey = defaultQueryKey
+	}
+	if t.examplesKey == "" {
+		t.examplesKey = defaultExamplesKey
+	}
+	return t, nil
+}
+
+// Format selects the examples for vars[QueryKey] and formats the template with them in vars[ExamplesKey].
+// vars is not modified.
+func (t *Template) Format(ctx context.Context, vars map[string]any, opts ...prompt.Option) ([]*schema.Message, error) {
+	query, ok := vars[t.queryKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("variable %s is missing or not a string", t.queryKey)
+	}
+	examples, err := t.selector.Select(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("select examples failed: %w", err)
+	}
+
+	withExamples := make(map[string]any, len(vars)+1)
+	for k, v := range vars {
+		withExamples[k] = v
+	}
+	withExamples[t.examplesKey] = Messages(examples)
+	return t.tpl.Format(ctx, withExamples, opts...)
+}
+
+func (t *Template) GetType() string {
+	return "FewShot"
+}
@@ -14,8 +14,51 @@
  * limitations under the License.
  */
 
+// Package gptr has the generic helpers of the examples, mostly for the optional parameters of the models, which are
+// pointers, nil meaning unset.
 package gptr
 
 func Of[T any](v T) *T {
 	return &v
 }
+
+// Deref returns *p, def if p is nil.
+func Deref[T any](p *T, def T) T {
+	if p == nil {
+		return def
+	}
+	return *p
+}
+
+// OfNonZero returns a pointer to v, nil if v is the zero value, for the flags whose zero value means unset.
+func OfNonZero[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+// PtrSlice returns pointers to copies of the elements of vs, modifying them does not modify vs.
+func PtrSlice[T any](vs []T) []*T {
+	if vs == nil {
+		return nil
+	}
+	ps := make([]*T, len(vs))
+	for i, v := range vs {
+		ps[i] = Of(v)
+	}
+	return ps
+}
+
+// DerefSlice returns the values of ps, def for the nil ones.
+func DerefSlice[T any](ps []*T, def T) []T {
+	if ps == nil {
+		return nil
+	}
+	vs := make([]T, len(ps))
+	for i, p := range ps {
+		vs[i] = Deref(p, def)
+	}
+	return vs
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gptr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOf(t *testing.T) {
+	v := 1
+	p := Of(v)
+	*p = 2
+	assert.Equal(t, 1, v)
+	assert.Equal(t, float32(0.7), *Of(float32(0.7)))
+}
+
+func TestDeref(t *testing.T) {
+	assert.Equal(t, 3, Deref(Of(3), 5))
+	assert.Equal(t, 5, Deref(nil, 5))
+	// a pointer to the zero value is set, not replaced by the default
+	assert.Equal(t, float32(0), Deref(Of(float32(0)), 0.7))
+}
+
+func TestOfNonZero(t *testing.T) {
+	assert.Nil(t, OfNonZero(0))
+	assert.Nil(t, OfNonZero(""))
+	assert.Equal(t, 256, *OfNonZero(256))
+}
+
+func TestPtrSlice(t *testing.T) {
+	vs := []string{"a", "b"}
+	ps := PtrSlice(vs)
+	if assert.Len(t, ps, 2) {
+		assert.Equal(t, "a", *ps[0])
+		*ps[1] = "c"
+	}
+	assert.Equal(t, []string{"a", "b"}, vs)
+	assert.Nil(t, PtrSlice[int](nil))
+	assert.Empty(t, PtrSlice([]int{}))
+	assert.NotNil(t, PtrSlice([]int{}))
+}
+
+func TestDerefSlice(t *testing.T) {
+	assert.Equal(t, []int{1, -1, 3}, DerefSlice([]*int{Of(1), nil, Of(3)}, -1))
+	assert.Nil(t, DerefSlice[int](nil, 0))
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gptr
+
+// Map returns f of every element of s, in order; nil for a nil s.
+func Map[T, R any](s []T, f func(T) R) []R {
+	if s == nil {
+		return nil
+	}
+	out := make([]R, len(s))
+	for i, v := range s {
+		out[i] = f(v)
+	}
+	return out
+}
+
+// Filter returns the elements of s for which keep is true, in order, in a new slice.
+func Filter[T any](s []T, keep func(T) bool) []T {
+	var out []T
+	for _, v := range s {
+		if keep(v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Find returns the first element of s for which match is true, and whether there is one.
+func Find[T any](s []T, match func(T) bool) (T, bool) {
+	for _, v := range s {
+		if match(v) {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gptr
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	assert.Equal(t, []string{"1", "2", "3"}, Map([]int{1, 2, 3}, strconv.Itoa))
+	assert.Nil(t, Map(nil, strconv.Itoa))
+	assert.Equal(t, []string{}, Map([]int{}, strconv.Itoa))
+}
+
+func TestFilter(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+	assert.Equal(t, []int{2, 4}, Filter([]int{1, 2, 3, 4}, even))
+	assert.Empty(t, Filter([]int{1, 3}, even))
+
+	s := []int{2, 3}
+	out := Filter(s, even)
+	out[0] = 10
+	assert.Equal(t, []int{2, 3}, s)
+}
+
+func TestFind(t *testing.T) {
+	v, ok := Find([]string{"a", "bb", "cc"}, func(s string) bool { return len(s) == 2 })
+	assert.True(t, ok)
+	assert.Equal(t, "bb", v)
+
+	v, ok = Find([]string{"a"}, func(s string) bool { return len(s) == 2 })
+	assert.False(t, ok)
+	assert.Equal(t, "", v)
+}
@@ -0,0 +1,292 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package history saves conversations, []*schema.Message, as JSON in a format of its own, versioned, so that the
+// sessions an example persisted are still read after the message type of Eino changes:
+//
+//	{"version": 1, "messages": [{"role": "user", "content": "hi"}, ...]}
+//
+// Tool calls and the multimodal parts of a message are kept. Unmarshal reads every version, older ones being
+// migrated as they are read; a bare JSON array, []*schema.Message marshaled as is, is version 0. The Messages type
+// does the same as a field of a larger JSON document, e.g. a session kept in internal/store.
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Version is the version written by Marshal.
+const Version = 1
+
+type document struct {
+	Version  int        `json:"version"`
+	Messages []*message `json:"messages"`
+}
+
+// message is a schema.Message in version 1. The index of a tool call is left out, it only orders the chunks of a
+// stream.
+type message struct {
+	Role         schema.RoleType    `json:"role"`
+	Content      string             `json:"content,omitempty"`
+	Parts        []*part            `json:"parts,omitempty"`
+	Name         string             `json:"name,omitempty"`
+	ToolCalls    []*toolCall        `json:"tool_calls,omitempty"`
+	ToolCallID   string             `json:"tool_call_id,omitempty"`
+	FinishReason string             `json:"finish_reason,omitempty"`
+	Usage        *schema.TokenUsage `json:"usage,omitempty"`
+	Extra        map[string]any     `json:"extra,omitempty"`
+}
+
+type toolCall struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type,omitempty"`
+	Name      string         `json:"name"`
+	Arguments string         `json:"arguments"`
+	Extra     map[string]any `json:"extra,omitempty"`
+}
+
+// part is a text, or a media given by URL or URI, whatever its kind.
+type part struct {
+	Type     schema.ChatMessagePartType `json:"type"`
+	Text     string                     `json:"text,omitempty"`
+	URL      string                     `json:"url,omitempty"`
+	URI      string                     `json:"uri,omitempty"`
+	MIMEType string                     `json:"mime_type,omitempty"`
+	Detail   schema.ImageURLDetail      `json:"detail,omitempty"`
+	Name     string                     `json:"name,omitempty"`
+	Extra    map[string]any             `json:"extra,omitempty"`
+}
+
+// Marshal returns msgs in the current version.
+func Marshal(msgs []*schema.Message) ([]byte, error) {
+	doc := &document{Version: Version, Messages: make([]*message, 0, len(msgs))}
+	for i, m := range msgs {
+		if m == nil {
+			return nil, fmt.Errorf("message %d is nil", i)
+		}
+		enc, err := fromSchema(m)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		doc.Messages = append(doc.Messages, enc)
+	}
+	return json.Marshal(doc)
+}
+
+// Unmarshal reads messages marshaled in any version up to Version.
+func Unmarshal(data []byte) ([]*schema.Message, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	if len(data) > 0 && data[0] == '[' {
+		// version 0 has the fields of schema.Message, read as they are
+		var msgs []*schema.Message
+		if err := json.Unmarshal(data, &msgs); err != nil {
+			return nil, fmt.Errorf("decode history of version 0 failed: %w", err)
+		}
+		return msgs, nil
+	}
+
+	var head struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return nil, fmt.Errorf("decode history failed: %w", err)
+	}
+	switch {
+	case head.Version > Version:
+		return nil, fmt.Errorf("history of version %d is newer than this program, which reads up to %d", head.Version, Version)
+	case head.Version < 1:
+		return nil, fmt.Errorf("history has no version")
+	}
+
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("decode history of version %d failed: %w", head.Version, err)
+	}
+	msgs := make([]*schema.Message, 0, len(doc.Messages))
+	for i, m := range doc.Messages {
+		if m == nil {
+			return nil, fmt.Errorf("message %d is null", i)
+		}
+		msgs = append(msgs, m.toSchema())
+	}
+	return msgs, nil
+}
+
+// Messages is marshaled by Marshal and unmarshaled by Unmarshal, to be a field of a JSON document.
+type Messages []*schema.Message
+
+func (m Messages) MarshalJSON() ([]byte, error) {
+	return Marshal(m)
+}
+
+func (m *Messages) UnmarshalJSON(data []byte) error {
+	msgs, err := Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	*m = msgs
+	return nil
+}
+
+// Load reads the file at path, no message if it does not exist.
+func Load(path string) ([]*schema.Message, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := Unmarshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("read %s failed: %w", path, err)
+	}
+	return msgs, nil
+}
+
+// Save writes msgs to path in the current version, a file of an older version being migrated. The file is replaced
+// at once, a reader never sees it half written.
+func Save(path string, msgs []*schema.Message) error {
+	data, err := Marshal(msgs)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func fromSchema(m *schema.Message) (*message, error) {
+	enc := &message{
+		Role:       m.Role,
+		Content:    m.Content,
+		Name:       m.Name,
+		ToolCallID: m.ToolCallID,
+		Extra:      m.Extra,
+	}
+	if m.ResponseMeta != nil {
+		enc.FinishReason = m.ResponseMeta.FinishReason
+		enc.Usage = m.ResponseMeta.Usage
+	}
+	for _, tc := range m.ToolCalls {
+		enc.ToolCalls = append(enc.ToolCalls, &toolCall{
+			ID:        tc.ID,
+			Type:      tc.Type,
+			Name:      tc.Function.Name,
+			Arguments: tc.Function.Arguments,
+			Extra:     tc.Extra,
+		})
+	}
+	for i, p := range m.MultiContent {
+		enc.Parts = append(enc.Parts, fromPart(p))
+		if enc.Parts[i] == nil {
+			return nil, fmt.Errorf("part %d of type %q is not supported", i, p.Type)
+		}
+	}
+	return enc, nil
+}
+
+// fromPart returns nil for a part of an unknown type, or without the media of its type.
+func fromPart(p schema.ChatMessagePart) *part {
+	enc := &part{Type: p.Type}
+	switch p.Type {
+	case schema.ChatMessagePartTypeText:
+		enc.Text = p.Text
+	case schema.ChatMessagePartTypeImageURL:
+		if p.ImageURL == nil {
+			return nil
+		}
+		enc.URL, enc.URI, enc.MIMEType, enc.Detail, enc.Extra = p.ImageURL.URL, p.ImageURL.URI, p.ImageURL.MIMEType, p.ImageURL.Detail, p.ImageURL.Extra
+	case schema.ChatMessagePartTypeAudioURL:
+		if p.AudioURL == nil {
+			return nil
+		}
+		enc.URL, enc.URI, enc.MIMEType, enc.Extra = p.AudioURL.URL, p.AudioURL.URI, p.AudioURL.MIMEType, p.AudioURL.Extra
+	case schema.ChatMessagePartTypeVideoURL:
+		if p.VideoURL == nil {
+			return nil
+		}
+		enc.URL, enc.URI, enc.MIMEType, enc.Extra = p.VideoURL.URL, p.VideoURL.URI, p.VideoURL.MIMEType, p.VideoURL.Extra
+	case schema.ChatMessagePartTypeFileURL:
+		if p.FileURL == nil {
+			return nil
+		}
+		enc.URL, enc.URI, enc.MIMEType, enc.Name, enc.Extra = p.FileURL.URL, p.FileURL.URI, p.FileURL.MIMEType, p.FileURL.Name, p.FileURL.Extra
+	default:
+		return nil
+	}
+	return enc
+}
+
+func (m *message) toSchema() *schema.Message {
+	msg := &schema.Message{
+		Role:       m.Role,
+		Content:    m.Content,
+		Name:       m.Name,
+		ToolCallID: m.ToolCallID,
+		Extra:      m.Extra,
+	}
+	if m.FinishReason != "" || m.Usage != nil {
+		msg.ResponseMeta = &schema.ResponseMeta{FinishReason: m.FinishReason, Usage: m.Usage}
+	}
+	for _, tc := range m.ToolCalls {
+		if tc == nil {
+			continue
+		}
+		msg.ToolCalls = append(msg.ToolCalls, schema.ToolCall{
+			ID:       tc.ID,
+			Type:     tc.Type,
+			Function: schema.FunctionCall{Name: tc.Name, Arguments: tc.Arguments},
+			Extra:    tc.Extra,
+		})
+	}
+	for _, p := range m.Parts {
+		if p != nil {
+			msg.MultiContent = append(msg.MultiContent, p.toSchema())
+		}
+	}
+	return msg
+}
+
+func (p *part) toSchema() schema.ChatMessagePart {
+	dec := schema.ChatMessagePart{Type: p.Type, Text: p.Text}
+	switch p.Type {
+	case schema.ChatMessagePartTypeImageURL:
+		dec.ImageURL = &schema.ChatMessageImageURL{URL: p.URL, URI: p.URI, MIMEType: p.MIMEType, Detail: p.Detail, Extra: p.Extra}
+	case schema.ChatMessagePartTypeAudioURL:
+		dec.AudioURL = &schema.ChatMessageAudioURL{URL: p.URL, URI: p.URI, MIMEType: p.MIMEType, Extra: p.Extra}
+	case schema.ChatMessagePartTypeVideoURL:
+		dec.VideoURL = &schema.ChatMessageVideoURL{URL: p.URL, URI: p.URI, MIMEType: p.MIMEType, Extra: p.Extra}
+	case schema.ChatMessagePartTypeFileURL:
+		dec.FileURL = &schema.ChatMessageFileURL{URL: p.URL, URI: p.URI, MIMEType: p.MIMEType, Name: p.Name, Extra: p.Extra}
+	}
+	return dec
+}
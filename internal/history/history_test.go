@@ -0,0 +1,118 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package history
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func conversation() []*schema.Message {
+	index := 0
+	return []*schema.Message{
+		schema.SystemMessage("You are a helpful assistant."),
+		{
+			Role: schema.User,
+			MultiContent: []schema.ChatMessagePart{
+				{Type: schema.ChatMessagePartTypeText, Text: "What is in this picture?"},
+				{Type: schema.ChatMessagePartTypeImageURL, ImageURL: &schema.ChatMessageImageURL{
+					URL: "data:image/png;base64,iVBORw0KGgo=", MIMEType: "image/png", Detail: schema.ImageURLDetailLow,
+				}},
+				{Type: schema.ChatMessagePartTypeFileURL, FileURL: &schema.ChatMessageFileURL{URI: "s3://bucket/spec.pdf", Name: "spec.pdf"}},
+			},
+		},
+		{
+			Role: schema.Assistant,
+			ToolCalls: []schema.ToolCall{{
+				Index:    &index,
+				ID:       "call_1",
+				Type:     "function",
+				Function: schema.FunctionCall{Name: "search", Arguments: `{"query":"eino"}`},
+			}},
+			ResponseMeta: &schema.ResponseMeta{FinishReason: "tool_calls", Usage: &schema.TokenUsage{PromptTokens: 12, CompletionTokens: 3, TotalTokens: 15}},
+		},
+		schema.ToolMessage(`{"results":[]}`, "call_1"),
+		schema.AssistantMessage("Nothing found.", nil),
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	want := conversation()
+	data, err := Marshal(want)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"version":1`)
+
+	got, err := Unmarshal(data)
+	assert.NoError(t, err)
+	// the index of a tool call is not kept
+	want[2].ToolCalls[0].Index = nil
+	assert.Equal(t, want, got)
+}
+
+func TestVersion0IsMigrated(t *testing.T) {
+	old, err := json.Marshal(conversation())
+	assert.NoError(t, err)
+
+	got, err := Unmarshal(old)
+	assert.NoError(t, err)
+	assert.Equal(t, conversation(), got)
+}
+
+func TestNewerVersionFails(t *testing.T) {
+	_, err := Unmarshal([]byte(`{"version":99,"messages":[]}`))
+	assert.ErrorContains(t, err, "version 99 is newer")
+
+	_, err = Unmarshal([]byte(`{"messages":[]}`))
+	assert.ErrorContains(t, err, "no version")
+}
+
+func TestUnsupportedPart(t *testing.T) {
+	_, err := Marshal([]*schema.Message{{Role: schema.User, MultiContent: []schema.ChatMessagePart{{Type: "hologram"}}}})
+	assert.ErrorContains(t, err, `part 0 of type "hologram" is not supported`)
+}
+
+func TestMessagesField(t *testing.T) {
+	type session struct {
+		History Messages `json:"history"`
+	}
+	data, err := json.Marshal(&session{History: conversation()})
+	assert.NoError(t, err)
+
+	var got session
+	assert.NoError(t, json.Unmarshal(data, &got))
+	assert.Len(t, got.History, 5)
+
+	// a session saved before the field had a type of its own
+	assert.NoError(t, json.Unmarshal([]byte(`{"history":[{"role":"user","content":"hi"}]}`), &got))
+	assert.Equal(t, Messages{schema.UserMessage("hi")}, got.History)
+}
+
+func TestLoadAndSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions", "alice.json")
+	msgs, err := Load(path)
+	assert.NoError(t, err)
+	assert.Empty(t, msgs)
+
+	assert.NoError(t, Save(path, conversation()[:2]))
+	msgs, err = Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, conversation()[:2], msgs)
+}
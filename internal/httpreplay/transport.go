@@ -0,0 +1,323 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package httpreplay records the HTTP exchanges of an example with a model provider to a cassette file, and replays
+// them later, so that the example runs end to end without network nor API key, e.g. in CI. It is configured with:
+//
+//	EINO_HTTP_REPLAY    off (default), record, replay, or auto: replay what was recorded and record the rest
+//	EINO_HTTP_CASSETTE  the cassette, default testdata/cassettes/<name of the program>.json
+//
+// A request matches a recorded one with the same method, URL and body, JSON bodies being compared whatever the order
+// of their keys; identical requests are replayed in the order they were recorded. The request headers are not
+// recorded, nor the query parameters holding a key, so a cassette holds no secret; the responses are recorded in
+// full, streams included.
+package httpreplay
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+type Mode string
+
+const (
+	ModeOff    Mode = "off"
+	ModeRecord Mode = "record"
+	ModeReplay Mode = "replay"
+	ModeAuto   Mode = "auto"
+)
+
+// redactedParams are the query parameters left out of the recorded URLs, they hold keys.
+var redactedParams = []string{"key", "api_key", "api-key", "access_token"}
+
+// Interaction is one recorded exchange.
+type Interaction struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	// BodyHash is the sha256 of the normalized body, the body itself is kept for reading only.
+	BodyHash string          `json:"body_hash"`
+	Body     json.RawMessage `json:"body,omitempty"`
+	Response *Response       `json:"response"`
+}
+
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// Cassette is the file of the interactions of a program.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Transport is a http.RoundTripper recording or replaying the exchanges of Next.
+type Transport struct {
+	Next http.RoundTripper
+
+	file *cassetteFile
+}
+
+// cassetteFile is shared by the transports on one path, e.g. of two models, so that they append to one cassette.
+type cassetteFile struct {
+	mode Mode
+	path string
+
+	mu       sync.Mutex
+	cassette *Cassette
+	// replayed counts the interactions replayed per key, to replay identical requests in order
+	replayed map[string]int
+}
+
+var (
+	filesMu sync.Mutex
+	files   = make(map[string]*cassetteFile)
+)
+
+// New returns a transport in mode, on the cassette at path, which is read now if it exists. It is required in
+// ModeReplay. The transports on one path share the cassette, in the mode of the first one.
+func New(next http.RoundTripper, mode Mode, path string) (*Transport, error) {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	switch mode {
+	case ModeOff, ModeRecord, ModeReplay, ModeAuto:
+	default:
+		return nil, fmt.Errorf("unknown replay mode %q, use off, record, replay or auto", mode)
+	}
+
+	filesMu.Lock()
+	defer filesMu.Unlock()
+	if f, ok := files[path]; ok {
+		return &Transport{Next: next, file: f}, nil
+	}
+	f, err := openCassette(mode, path)
+	if err != nil {
+		return nil, err
+	}
+	files[path] = f
+	return &Transport{Next: next, file: f}, nil
+}
+
+func openCassette(mode Mode, path string) (*cassetteFile, error) {
+	f := &cassetteFile{mode: mode, path: path, cassette: &Cassette{}, replayed: make(map[string]int)}
+	if mode == ModeOff || mode == ModeRecord {
+		// record starts a new cassette, overwritten on the first exchange
+		return f, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && mode == ModeAuto {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read cassette failed: %w", err)
+	}
+	if err = json.Unmarshal(data, f.cassette); err != nil {
+		return nil, fmt.Errorf("decode cassette %s failed: %w", path, err)
+	}
+	return f, nil
+}
+
+// Wrap returns next wrapped as configured by EINO_HTTP_REPLAY and EINO_HTTP_CASSETTE, next itself when off.
+func Wrap(next http.RoundTripper) (http.RoundTripper, error) {
+	mode := Mode(strings.ToLower(config.String("EINO_HTTP_REPLAY", string(ModeOff))))
+	if mode == ModeOff {
+		return next, nil
+	}
+	path := config.String("EINO_HTTP_CASSETTE", DefaultPath())
+	t, err := New(next, mode, path)
+	if err != nil {
+		return nil, err
+	}
+	logs.Infof("http %s with cassette %s", mode, path)
+	return t, nil
+}
+
+// DefaultPath is testdata/cassettes/<name of the program>.json, go run naming the program after its directory.
+func DefaultPath() string {
+	name := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	return filepath.Join("testdata", "cassettes", name+".json")
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f := t.file
+	if f.mode == ModeOff {
+		return t.Next.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	it := &Interaction{Method: req.Method, URL: redactURL(req.URL), BodyHash: hashBody(body)}
+	if json.Valid(body) {
+		it.Body = body
+	}
+
+	if f.mode != ModeRecord {
+		if recorded := f.find(it); recorded != nil {
+			return recorded.Response.toHTTP(req), nil
+		}
+		if f.mode == ModeReplay {
+			return nil, fmt.Errorf("httpreplay: no recorded response for %s %s in %s, record it with EINO_HTTP_REPLAY=record or auto",
+				it.Method, it.URL, f.path)
+		}
+	}
+
+	resp, err := t.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	// the stream is read in full before it is returned, a recorded stream arrives at once
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	it.Response = &Response{Status: resp.StatusCode, Header: recordedHeader(resp.Header), Body: string(respBody)}
+	if err = f.save(it); err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	return resp, nil
+}
+
+// find returns the next interaction matching it not replayed yet.
+func (f *cassetteFile) find(it *Interaction) *Interaction {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := it.Method + " " + it.URL + " " + it.BodyHash
+	skip := f.replayed[key]
+	for _, recorded := range f.cassette.Interactions {
+		if recorded.Method != it.Method || recorded.URL != it.URL || recorded.BodyHash != it.BodyHash {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		f.replayed[key]++
+		return recorded
+	}
+	return nil
+}
+
+// save appends it to the cassette and writes the whole file, so that an interrupted run keeps what it recorded.
+func (f *cassetteFile) save(it *Interaction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cassette.Interactions = append(f.cassette.Interactions, it)
+	if f.mode == ModeAuto {
+		// counted as replayed, an identical request later in this run is a new one
+		f.replayed[it.Method+" "+it.URL+" "+it.BodyHash]++
+	}
+
+	data, err := json.MarshalIndent(f.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+	// write to a temp file of its own then rename, so that two programs recording to one cassette, e.g. two test
+	// binaries, never write to the same temp file
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), f.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
+
+func (r *Response) toHTTP(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
+
+// recordedHeader keeps the headers telling how to read the body.
+func recordedHeader(h http.Header) http.Header {
+	kept := http.Header{}
+	for _, k := range []string{"Content-Type"} {
+		if v := h.Values(k); len(v) > 0 {
+			kept[k] = v
+		}
+	}
+	return kept
+}
+
+func redactURL(u *url.URL) string {
+	c := *u
+	q := c.Query()
+	for _, p := range redactedParams {
+		q.Del(p)
+	}
+	c.RawQuery = q.Encode()
+	c.User = nil
+	return c.String()
+}
+
+// hashBody hashes JSON bodies in a normal form, with sorted keys and no spaces.
+func hashBody(body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		if normal, err := json.Marshal(v); err == nil {
+			body = normal
+		}
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
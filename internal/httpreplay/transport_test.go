@@ -0,0 +1,224 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpreplay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newServer answers every request with its body and the number of requests it received so far.
+func newServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := hits.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "text/plain")
+		w.Header().Set("X-Request-Id", fmt.Sprint(n))
+		_, _ = fmt.Fprintf(w, "%d %s", n, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+// newClient returns a client in mode on the cassette at path, read again rather than shared with the transports of
+// a previous client.
+func newClient(t *testing.T, mode Mode, path string) *http.Client {
+	filesMu.Lock()
+	delete(files, path)
+	filesMu.Unlock()
+	tr, err := New(nil, mode, path)
+	assert.NoError(t, err)
+	return &http.Client{Transport: tr}
+}
+
+func post(t *testing.T, c *http.Client, url, body string) string {
+	resp, err := c.Post(url, "application/json", strings.NewReader(body))
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return string(b)
+}
+
+func readCassette(t *testing.T, path string) *Cassette {
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	c := &Cassette{}
+	assert.NoError(t, json.Unmarshal(data, c))
+	return c
+}
+
+func TestRecordReplay(t *testing.T) {
+	srv, hits := newServer(t)
+	path := filepath.Join(t.TempDir(), "cassettes", "test.json")
+
+	c := newClient(t, ModeRecord, path)
+	assert.Equal(t, `1 {"a":1,"b":2}`, post(t, c, srv.URL+"/chat?key=secret&v=1", `{"a":1,"b":2}`))
+	assert.Equal(t, "2 ping", post(t, c, srv.URL+"/chat", "ping"))
+	assert.Equal(t, "3 ping", post(t, c, srv.URL+"/chat", "ping"))
+
+	cassette := readCassette(t, path)
+	if assert.Len(t, cassette.Interactions, 3) {
+		first := cassette.Interactions[0]
+		assert.Equal(t, srv.URL+"/chat?v=1", first.URL)
+		assert.JSONEq(t, `{"a":1,"b":2}`, string(first.Body))
+		assert.Equal(t, http.Header{"Content-Type": {"text/plain"}}, first.Response.Header)
+		// a body which is not JSON is only hashed
+		assert.Nil(t, cassette.Interactions[1].Body)
+	}
+	data, _ := os.ReadFile(path)
+	assert.NotContains(t, string(data), "secret")
+
+	c = newClient(t, ModeReplay, path)
+	// the keys of a JSON body in another order, and the key of the query left out
+	assert.Equal(t, `1 {"a":1,"b":2}`, post(t, c, srv.URL+"/chat?v=1&key=other", `{"b": 2, "a": 1}`))
+	// identical requests are replayed in order
+	assert.Equal(t, "2 ping", post(t, c, srv.URL+"/chat", "ping"))
+	assert.Equal(t, "3 ping", post(t, c, srv.URL+"/chat", "ping"))
+	assert.Equal(t, int32(3), hits.Load())
+
+	// replayed as many times as it was recorded
+	_, err := c.Post(srv.URL+"/chat", "text/plain", strings.NewReader("ping"))
+	assert.ErrorContains(t, err, "no recorded response for POST "+srv.URL+"/chat")
+	_, err = c.Post(srv.URL+"/other", "text/plain", strings.NewReader("ping"))
+	assert.ErrorContains(t, err, "no recorded response")
+	assert.Equal(t, int32(3), hits.Load())
+}
+
+func TestAuto(t *testing.T) {
+	srv, hits := newServer(t)
+	path := filepath.Join(t.TempDir(), "test.json")
+
+	// a cassette which does not exist yet is started
+	c := newClient(t, ModeAuto, path)
+	assert.Equal(t, "1 ping", post(t, c, srv.URL, "ping"))
+	assert.Equal(t, "2 ping", post(t, c, srv.URL, "ping"))
+
+	c = newClient(t, ModeAuto, path)
+	assert.Equal(t, "1 ping", post(t, c, srv.URL, "ping"))
+	assert.Equal(t, "2 ping", post(t, c, srv.URL, "ping"))
+	assert.Equal(t, "3 ping", post(t, c, srv.URL, "ping"))
+	assert.Equal(t, "4 pong", post(t, c, srv.URL, "pong"))
+	assert.Equal(t, int32(4), hits.Load())
+	assert.Len(t, readCassette(t, path).Interactions, 4)
+
+	// record starts over
+	c = newClient(t, ModeRecord, path)
+	assert.Equal(t, "5 pong", post(t, c, srv.URL, "pong"))
+	assert.Len(t, readCassette(t, path).Interactions, 1)
+}
+
+func TestStream(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range []string{"Hello", " world"} {
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", chunk)
+			w.(http.Flusher).Flush()
+		}
+		_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "test.json")
+	want := "data: Hello\n\ndata:  world\n\ndata: [DONE]\n\n"
+
+	assert.Equal(t, want, post(t, newClient(t, ModeRecord, path), srv.URL, `{"stream":true}`))
+	srv.Close()
+
+	resp, err := newClient(t, ModeReplay, path).Post(srv.URL, "application/json", strings.NewReader(`{"stream":true}`))
+	if assert.NoError(t, err) {
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		b, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, want, string(b))
+	}
+}
+
+func TestConcurrentRecord(t *testing.T) {
+	srv, _ := newServer(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.json")
+	c := newClient(t, ModeRecord, path)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			post(t, c, srv.URL, fmt.Sprintf(`{"i":%d}`, i))
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, readCassette(t, path).Interactions, 20)
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "the temp files are renamed")
+}
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := New(nil, "play", filepath.Join(dir, "a.json"))
+	assert.ErrorContains(t, err, `unknown replay mode "play"`)
+
+	_, err = New(nil, ModeReplay, filepath.Join(dir, "missing.json"))
+	assert.ErrorContains(t, err, "read cassette failed")
+
+	corrupt := filepath.Join(dir, "corrupt.json")
+	assert.NoError(t, os.WriteFile(corrupt, []byte("{"), 0644))
+	_, err = New(nil, ModeAuto, corrupt)
+	assert.ErrorContains(t, err, "decode cassette")
+
+	// the transports on one path share the cassette, in the mode of the first one
+	shared := filepath.Join(dir, "shared.json")
+	first, err := New(nil, ModeRecord, shared)
+	assert.NoError(t, err)
+	second, err := New(nil, ModeReplay, shared)
+	assert.NoError(t, err)
+	assert.Same(t, first.file, second.file)
+	assert.Equal(t, ModeRecord, second.file.mode)
+}
+
+func TestWrap(t *testing.T) {
+	t.Setenv("EINO_HTTP_REPLAY", "off")
+	rt, err := Wrap(http.DefaultTransport)
+	assert.NoError(t, err)
+	assert.Same(t, http.DefaultTransport, rt)
+
+	t.Setenv("EINO_HTTP_REPLAY", "AUTO")
+	t.Setenv("EINO_HTTP_CASSETTE", filepath.Join(t.TempDir(), "wrap.json"))
+	rt, err = Wrap(http.DefaultTransport)
+	assert.NoError(t, err)
+	if assert.IsType(t, &Transport{}, rt) {
+		assert.Equal(t, ModeAuto, rt.(*Transport).file.mode)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package httpx builds the HTTP clients of the model providers and of the other services the examples call: proxy,
+// extra headers, retries of the transient failures, timeouts and request logging, configured by
+//
+//	EINO_HTTP_TIMEOUT                  the max duration of a whole exchange, streamed body included, default none
+//	EINO_HTTP_RESPONSE_HEADER_TIMEOUT  the max wait for the response headers, default none
+//	EINO_HTTP_MAX_RETRIES              the retries of a request failed with 429, 5xx or a network error, default 0
+//	EINO_HTTP_LOG                      log every request with its status and latency, default false
+//
+// The retries are off by default: the calls of a chat model are retried by chatmodel.RetryChatModel, which runs above
+// the limiter of internal/ratelimit, whereas a retry of the transport runs below it, bypassing the limiter, and
+// multiplies with the retries of the model.
+package httpx
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/httpreplay"
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// Config configures NewClient.
+type Config struct {
+	// ProxyKey is the config key of the proxy of this client only, e.g. OPENAI_PROXY, see NewProxyTransport.
+	ProxyKey string
+	// Headers are set on every request, e.g. the api-key header of Azure.
+	Headers map[string]string
+	// Timeout bounds a whole exchange, the streamed body of a response included, 0 for none. A long generation
+	// streams for minutes, ResponseHeaderTimeout is usually the better bound.
+	Timeout time.Duration
+	// ResponseHeaderTimeout bounds the wait for the response headers, 0 for none.
+	ResponseHeaderTimeout time.Duration
+	// Retry retries the transient failures, nil for none.
+	Retry *RetryConfig
+	// LogRequests logs every request with its status and latency.
+	LogRequests bool
+}
+
+// DefaultConfig returns the config set by the EINO_HTTP_* keys.
+func DefaultConfig() *Config {
+	return &Config{
+		Timeout:               config.Duration("EINO_HTTP_TIMEOUT", 0),
+		ResponseHeaderTimeout: config.Duration("EINO_HTTP_RESPONSE_HEADER_TIMEOUT", 0),
+		Retry:                 &RetryConfig{MaxRetries: config.Int("EINO_HTTP_MAX_RETRIES", 0)},
+		LogRequests:           config.Bool("EINO_HTTP_LOG", false),
+	}
+}
+
+// NewClient returns a client configured by config, nil for DefaultConfig. Its exchanges are also recorded or
+// replayed if configured, see internal/httpreplay. A request goes through, in order:
+//
+//	logging -> record / replay -> retry -> headers -> proxy
+//
+// so that a replayed exchange is logged, and a recorded one holds the response of the last attempt.
+func NewClient(config *Config) (*http.Client, error) {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	transport, err := NewProxyTransport(config.ProxyKey)
+	if err != nil {
+		return nil, err
+	}
+	transport.ResponseHeaderTimeout = config.ResponseHeaderTimeout
+
+	var rt http.RoundTripper = transport
+	if len(config.Headers) > 0 {
+		rt = &HeaderTransport{Next: rt, Headers: config.Headers}
+	}
+	if config.Retry != nil && config.Retry.MaxRetries > 0 {
+		rt = NewRetryTransport(rt, config.Retry)
+	}
+	if rt, err = httpreplay.Wrap(rt); err != nil {
+		return nil, err
+	}
+	if config.LogRequests {
+		rt = &LoggingTransport{Next: rt, Logger: logs.New("http")}
+	}
+	return &http.Client{Transport: rt, Timeout: config.Timeout}, nil
+}
+
+// HeaderTransport sets headers on every request, e.g. the api-key header of Azure and of some OpenAI compatible
+// gateways, which the OpenAI client does not send.
+type HeaderTransport struct {
+	Next    http.RoundTripper
+	Headers map[string]string
+}
+
+func (t *HeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper must not modify the request it is given
+	req = req.Clone(req.Context())
+	for key, value := range t.Headers {
+		req.Header.Set(key, value)
+	}
+	return t.Next.RoundTrip(req)
+}
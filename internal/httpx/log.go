@@ -0,0 +1,46 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpx
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// LoggingTransport logs every request with its status and latency, the latency of a stream being the wait for
+// its headers. The query parameters are left out of the logged URL, they can hold a key.
+type LoggingTransport struct {
+	Next   http.RoundTripper
+	Logger *logs.Logger
+}
+
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.Next.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+
+	u := *req.URL
+	u.RawQuery, u.User = "", nil
+	if err != nil {
+		t.Logger.Warnf("%s %s failed after %v, err=%v", req.Method, u.String(), latency, err)
+		return nil, err
+	}
+	t.Logger.Infof("%s %s %d in %v", req.Method, u.String(), resp.StatusCode, latency)
+	return resp, nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpx
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// NewProxyTransport returns a http.Transport choosing the proxy, in this order, from:
+//  1. the config key proxyKey (e.g. OPENAI_PROXY), for one client only, ignored if empty
+//  2. HTTPS_PROXY / HTTP_PROXY, following NO_PROXY
+//  3. ALL_PROXY, following NO_PROXY
+//
+// The proxy URL can be http://, https:// or socks5://, e.g. socks5://127.0.0.1:1080.
+func NewProxyTransport(proxyKey string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if raw := config.String(proxyKey, ""); proxyKey != "" && raw != "" {
+		proxyURL, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url in %s: %w", proxyKey, err)
+		}
+		logs.Infof("using proxy %s from %s", proxyURL.Redacted(), proxyKey)
+		transport.Proxy = http.ProxyURL(proxyURL)
+		return transport, nil
+	}
+
+	fromEnv := (&httpproxy.Config{
+		HTTPProxy:  getAny("HTTP_PROXY", "http_proxy"),
+		HTTPSProxy: getAny("HTTPS_PROXY", "https_proxy"),
+		NoProxy:    getAny("NO_PROXY", "no_proxy"),
+	}).ProxyFunc()
+
+	allProxy := getAny("ALL_PROXY", "all_proxy")
+	var fromAllProxy func(*url.URL) (*url.URL, error)
+	if allProxy != "" {
+		fromAllProxy = (&httpproxy.Config{
+			HTTPProxy:  allProxy,
+			HTTPSProxy: allProxy,
+			NoProxy:    getAny("NO_PROXY", "no_proxy"),
+		}).ProxyFunc()
+	}
+
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		proxyURL, err := fromEnv(req.URL)
+		if err != nil || proxyURL != nil || fromAllProxy == nil {
+			return proxyURL, err
+		}
+		return fromAllProxy(req.URL)
+	}
+	return transport, nil
+}
+
+// getAny returns the first of keys which is set, the lower-case proxy variables being distinct in the environment
+// before config.Init.
+func getAny(keys ...string) string {
+	for _, key := range keys {
+		if v := config.String(key, ""); v != "" {
+			return v
+		}
+	}
+	return ""
+}
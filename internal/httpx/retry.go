@@ -0,0 +1,152 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package httpx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/backoff"
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const (
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 10 * time.Second
+)
+
+// RetryConfig controls how RetryTransport retries failed requests.
+type RetryConfig struct {
+	// MaxRetries is the max number of retries after the first attempt.
+	MaxRetries int
+	// InitialBackoff is the wait time before the first retry, doubled on every retry, default 500ms.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait time between two attempts, Retry-After included, default 10s.
+	MaxBackoff time.Duration
+}
+
+// RetryTransport retries the requests failed with a network error, 429 or 5xx, with exponential backoff and
+// jitter, or after the Retry-After of the response if it has one. Unlike chatmodel.RetryChatModel, it retries every
+// request of a client, e.g. the calls of an embedder or of a tool, and reads the Retry-After of the server.
+//
+// A request body is read once and sent again on every attempt. A response is retried before its body is read only,
+// an error in the middle of a stream is returned as is.
+type RetryTransport struct {
+	next           http.RoundTripper
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func NewRetryTransport(next http.RoundTripper, config *RetryConfig) *RetryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if config == nil {
+		config = &RetryConfig{}
+	}
+	t := &RetryTransport{
+		next:           next,
+		maxRetries:     config.MaxRetries,
+		initialBackoff: config.InitialBackoff,
+		maxBackoff:     config.MaxBackoff,
+	}
+	if t.initialBackoff <= 0 {
+		t.initialBackoff = defaultInitialBackoff
+	}
+	if t.maxBackoff <= 0 {
+		t.maxBackoff = defaultMaxBackoff
+	}
+	return t
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	for attempt := 0; ; attempt++ {
+		r := req.Clone(req.Context())
+		if body != nil {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(body)), nil
+			}
+		}
+
+		resp, err := t.next.RoundTrip(r)
+		if attempt >= t.maxRetries || !retryable(req.Context(), resp, err) {
+			return resp, err
+		}
+
+		d := t.delay(attempt, resp)
+		if err == nil {
+			logs.Infof("%s %s returned %s, retry %d/%d after %v", req.Method, req.URL.Path, resp.Status, attempt+1, t.maxRetries, d)
+			// drained, so that the connection is reused
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			_ = resp.Body.Close()
+		} else {
+			logs.Infof("%s %s failed, retry %d/%d after %v, err=%v", req.Method, req.URL.Path, attempt+1, t.maxRetries, d, err)
+		}
+
+		if ctxErr := backoff.Sleep(req.Context(), d); ctxErr != nil {
+			return nil, errors.Join(ctxErr, err)
+		}
+	}
+}
+
+// retryable reports whether the attempt failed transiently: a network error, not a canceled request, 429 or 5xx.
+func retryable(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// delay is the Retry-After of resp, or else the exponential backoff of attempt, both capped at maxBackoff.
+func (t *RetryTransport) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(after, t.maxBackoff)
+		}
+	}
+	return backoff.Exponential(attempt, t.initialBackoff, t.maxBackoff)
+}
+
+// retryAfter parses a Retry-After header, in seconds or an HTTP date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
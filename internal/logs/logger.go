@@ -14,45 +14,352 @@
  * limitations under the License.
  */
 
+// Package logs is the logger of the examples: leveled, in colored text or JSON lines, with an optional prefix per
+// example or component. It is configured by environment variables, so that every example gets the same options:
+//
+//	EINO_LOG_LEVEL   debug, info (default), warn or error
+//	EINO_LOG_FORMAT  text (default) or json
+//	EINO_LOG_PREFIX  prefix of every line, e.g. the name of the example
+//	NO_COLOR         any value disables the colors of the text format
+//
+// EINO_LOG_FILE tees the lines to a file, without colors, rotated by size and age, see RotatingFile and
+// rotateConfigFromEnv for its options.
 package logs
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 )
 
 const (
 	// Color codes for terminal output
-	colorRed   = "\033[31m"
-	colorGreen = "\033[32m"
-	colorBrown = "\033[31;1m"
-	colorReset = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorGray   = "\033[90m"
+	colorBrown  = "\033[31;1m"
+	colorReset  = "\033[0m"
+
+	timeFormat = "2006-01-02 15:04:05"
 )
 
-func Infof(format string, args ...interface{}) {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	prefix := fmt.Sprintf("%s[INFO] %s ", colorGreen, timestamp)
+// Level is the severity of a log line, lines below the level of the logger are dropped.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelFatal
+)
+
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	case LevelFatal:
+		return "FATAL"
+	}
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+func (l Level) color() string {
+	switch l {
+	case LevelDebug:
+		return colorGray
+	case LevelInfo:
+		return colorGreen
+	case LevelWarn:
+		return colorYellow
+	}
+	return colorRed
+}
+
+// ParseLevel parses debug, info, warn or error, case-insensitive.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LevelDebug, nil
+	case "info", "":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %q", s)
+}
+
+// sink is the output shared by a logger and the loggers derived from it.
+type sink struct {
+	mu  sync.Mutex
+	out io.Writer
+	// file gets the same lines as out, never colored, nil if not set
+	file    io.Writer
+	level   Level
+	json    bool
+	noColor bool
+}
+
+// Logger writes leveled lines with a prefix and fields. Loggers derived with With or WithField share the output,
+// level and format of their parent, changing them on one changes them on all.
+type Logger struct {
+	sink   *sink
+	prefix string
+	fields map[string]any
+}
+
+var std = newStd()
+
+func newStd() *Logger {
+	s := &sink{out: os.Stdout, level: LevelInfo, noColor: os.Getenv("NO_COLOR") != ""}
+	if level, err := ParseLevel(os.Getenv("EINO_LOG_LEVEL")); err == nil {
+		s.level = level
+	} else {
+		fmt.Fprintf(os.Stderr, "%v, using info\n", err)
+	}
+	s.json = strings.EqualFold(os.Getenv("EINO_LOG_FORMAT"), "json")
+	if config, err := rotateConfigFromEnv(); err != nil {
+		fmt.Fprintf(os.Stderr, "log file disabled: %v\n", err)
+	} else if config != nil {
+		if s.file, err = NewRotatingFile(config); err != nil {
+			fmt.Fprintf(os.Stderr, "log file disabled: %v\n", err)
+		}
+	}
+	return &Logger{sink: s, prefix: os.Getenv("EINO_LOG_PREFIX")}
+}
+
+// Default returns the logger behind the package functions.
+func Default() *Logger {
+	return std
+}
+
+// New returns a logger with the given prefix, sharing the output of the default logger.
+func New(prefix string) *Logger {
+	return std.With(prefix)
+}
+
+// With returns a logger whose prefix is the prefix of l followed by prefix, e.g. "rag.retriever".
+func (l *Logger) With(prefix string) *Logger {
+	if l.prefix != "" && prefix != "" {
+		prefix = l.prefix + "." + prefix
+	} else if prefix == "" {
+		prefix = l.prefix
+	}
+	return &Logger{sink: l.sink, prefix: prefix, fields: l.fields}
+}
+
+// WithField returns a logger adding key=value to every line, as a field of its own in JSON.
+func (l *Logger) WithField(key string, value any) *Logger {
+	fields := make(map[string]any, len(l.fields)+1)
+	for k, v := range l.fields {
+		fields[k] = v
+	}
+	fields[key] = value
+	return &Logger{sink: l.sink, prefix: l.prefix, fields: fields}
+}
+
+// SetLevel drops the lines below level.
+func (l *Logger) SetLevel(level Level) {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	l.sink.level = level
+}
+
+// Enabled reports whether a line of level is written, to skip computing what would be dropped.
+func (l *Logger) Enabled(level Level) bool {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	return level >= l.sink.level
+}
+
+// SetJSON switches between colored text and JSON lines.
+func (l *Logger) SetJSON(enabled bool) {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	l.sink.json = enabled
+}
+
+// SetOutput changes where the lines are written, os.Stdout by default.
+func (l *Logger) SetOutput(w io.Writer) {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	l.sink.out = w
+}
+
+// SetFile tees the lines to w, e.g. a RotatingFile, without colors. nil stops the tee.
+func (l *Logger) SetFile(w io.Writer) {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	l.sink.file = w
+}
+
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.log(LevelDebug, format, args...)
+}
+
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.log(LevelInfo, format, args...)
+}
+
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.log(LevelWarn, format, args...)
+}
+
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.log(LevelError, format, args...)
+}
+
+// Fatalf logs at LevelFatal, which is never dropped, and exits with status 1.
+func (l *Logger) Fatalf(format string, args ...interface{}) {
+	l.log(LevelFatal, format, args...)
+	os.Exit(1)
+}
+
+// Tokenf writes streamed output as is, without level, time nor newline, so that the chunks of a stream print as
+// one text. In JSON, every call is a line of level "token".
+func (l *Logger) Tokenf(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
-	fmt.Printf("%s%s%s\n", prefix, message, colorReset)
+
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	if l.sink.json {
+		l.writeJSON("token", message)
+		return
+	}
+	if l.sink.file != nil {
+		_, _ = io.WriteString(l.sink.file, message)
+	}
+	if l.sink.noColor {
+		fmt.Fprint(l.sink.out, message)
+		return
+	}
+	fmt.Fprintf(l.sink.out, "%s%s%s", colorBrown, message, colorReset)
 }
 
-func Errorf(format string, args ...interface{}) {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	prefix := fmt.Sprintf("%s[ERROR] %s ", colorRed, timestamp)
+func (l *Logger) log(level Level, format string, args ...interface{}) {
+	l.sink.mu.Lock()
+	defer l.sink.mu.Unlock()
+	if level < l.sink.level {
+		return
+	}
+
 	message := fmt.Sprintf(format, args...)
-	fmt.Printf("%s%s%s\n", prefix, message, colorReset)
+	if l.sink.json {
+		l.writeJSON(strings.ToLower(level.String()), message)
+		return
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("[%s] %s ", level, time.Now().Format(timeFormat)))
+	if l.prefix != "" {
+		sb.WriteString("[" + l.prefix + "] ")
+	}
+	sb.WriteString(message)
+	for _, k := range sortedKeys(l.fields) {
+		sb.WriteString(fmt.Sprintf(" %s=%v", k, l.fields[k]))
+	}
+	line := sb.String()
+
+	if l.sink.file != nil {
+		_, _ = io.WriteString(l.sink.file, line+"\n")
+	}
+	if l.sink.noColor {
+		_, _ = io.WriteString(l.sink.out, line+"\n")
+		return
+	}
+	_, _ = io.WriteString(l.sink.out, level.color()+line+colorReset+"\n")
+}
+
+// writeJSON writes one line, the fields first so that they cannot override time, level, prefix and msg.
+// The caller holds the lock.
+func (l *Logger) writeJSON(level, message string) {
+	line := make(map[string]any, len(l.fields)+4)
+	for k, v := range l.fields {
+		line[k] = v
+	}
+	line["time"] = time.Now().Format(time.RFC3339Nano)
+	line["level"] = level
+	line["msg"] = message
+	if l.prefix != "" {
+		line["prefix"] = l.prefix
+	}
+	b, err := json.Marshal(line)
+	if err != nil {
+		b, _ = json.Marshal(map[string]any{"time": line["time"], "level": level, "msg": message, "log_error": err.Error()})
+	}
+	b = append(b, '\n')
+	_, _ = l.sink.out.Write(b)
+	if l.sink.file != nil {
+		_, _ = l.sink.file.Write(b)
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SetLevel, SetJSON, SetOutput, SetFile and SetPrefix configure the default logger, over the environment variables.
+
+func SetLevel(level Level) {
+	std.SetLevel(level)
+}
+
+func SetJSON(enabled bool) {
+	std.SetJSON(enabled)
+}
+
+func SetOutput(w io.Writer) {
+	std.SetOutput(w)
+}
+
+func SetFile(w io.Writer) {
+	std.SetFile(w)
+}
+
+// SetPrefix sets the prefix of the lines of the default logger, call it before deriving loggers from it.
+func SetPrefix(prefix string) {
+	std.prefix = prefix
+}
+
+func Debugf(format string, args ...interface{}) {
+	std.Debugf(format, args...)
+}
+
+func Infof(format string, args ...interface{}) {
+	std.Infof(format, args...)
+}
+
+func Warnf(format string, args ...interface{}) {
+	std.Warnf(format, args...)
+}
+
+func Errorf(format string, args ...interface{}) {
+	std.Errorf(format, args...)
 }
 
 func Tokenf(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	fmt.Printf("%s%s%s", colorBrown, message, colorReset)
+	std.Tokenf(format, args...)
 }
 
 func Fatalf(format string, args ...interface{}) {
-	timestamp := time.Now().Format("2006-01-02 15:04:05")
-	prefix := fmt.Sprintf("%s[FATAL] %s ", colorRed, timestamp)
-	message := fmt.Sprintf(format, args...)
-	fmt.Printf("%s%s%s\n", prefix, message, colorReset)
-	os.Exit(1)
+	std.Fatalf(format, args...)
 }
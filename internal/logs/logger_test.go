@@ -0,0 +1,147 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logs_test
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/testutil"
+)
+
+var colors = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// lines returns the lines of out without colors nor time.
+func lines(out string) []string {
+	out = colors.ReplaceAllString(out, "")
+	out = regexp.MustCompile(`\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2} `).ReplaceAllString(out, "")
+	return strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+}
+
+// reset restores the configuration of the default logger after the test.
+func reset(t *testing.T) {
+	t.Cleanup(func() {
+		logs.SetLevel(logs.LevelInfo)
+		logs.SetJSON(false)
+		logs.SetPrefix("")
+		logs.SetFile(nil)
+	})
+}
+
+func TestLevel(t *testing.T) {
+	reset(t)
+	logs.SetLevel(logs.LevelWarn)
+	assert.False(t, logs.Default().Enabled(logs.LevelInfo))
+	assert.True(t, logs.Default().Enabled(logs.LevelError))
+
+	out := testutil.CaptureOutput(t, func() {
+		logs.Debugf("debug %d", 1)
+		logs.Infof("info %d", 2)
+		logs.Warnf("warn %d", 3)
+		logs.Errorf("error %d", 4)
+	})
+	assert.Equal(t, []string{"[WARN] warn 3", "[ERROR] error 4"}, lines(out))
+
+	logs.SetLevel(logs.LevelDebug)
+	out = testutil.CaptureOutput(t, func() {
+		logs.Debugf("debug %d", 1)
+		// a derived logger shares the level of its parent
+		logs.New("child").Debugf("debug %d", 2)
+	})
+	assert.Equal(t, []string{"[DEBUG] debug 1", "[DEBUG] [child] debug 2"}, lines(out))
+}
+
+func TestParseLevel(t *testing.T) {
+	for s, want := range map[string]logs.Level{
+		"debug": logs.LevelDebug, "": logs.LevelInfo, "INFO": logs.LevelInfo, " warning ": logs.LevelWarn, "Error": logs.LevelError,
+	} {
+		level, err := logs.ParseLevel(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, want, level, s)
+	}
+	_, err := logs.ParseLevel("verbose")
+	assert.EqualError(t, err, `unknown log level "verbose"`)
+	assert.Equal(t, "LEVEL(9)", logs.Level(9).String())
+}
+
+func TestPrefix(t *testing.T) {
+	reset(t)
+	out := testutil.CaptureOutput(t, func() {
+		logs.New("rag").Infof("indexed")
+		logs.New("rag").With("retriever").WithField("top_k", 5).WithField("index", "docs").Infof("retrieved")
+		logs.New("rag").With("").Infof("same prefix")
+
+		logs.SetPrefix("example")
+		logs.Infof("started")
+		logs.New("model").Infof("called")
+	})
+	assert.Equal(t, []string{
+		"[INFO] [rag] indexed",
+		"[INFO] [rag.retriever] retrieved index=docs top_k=5",
+		"[INFO] [rag] same prefix",
+		"[INFO] [example] started",
+		"[INFO] [example.model] called",
+	}, lines(out))
+}
+
+func TestJSON(t *testing.T) {
+	reset(t)
+	logs.SetJSON(true)
+	out := testutil.CaptureOutput(t, func() {
+		logs.Infof("plain")
+		// the fields cannot override the fields of the line
+		logs.New("rag").WithField("docs", 3).WithField("level", "oops").Warnf("retrieved %d docs", 3)
+		logs.Tokenf("chunk")
+	})
+
+	var got []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var m map[string]any
+		if assert.NoError(t, json.Unmarshal([]byte(line), &m), line) {
+			assert.NotEmpty(t, m["time"])
+			delete(m, "time")
+			got = append(got, m)
+		}
+	}
+	assert.Equal(t, []map[string]any{
+		{"level": "info", "msg": "plain"},
+		{"level": "warn", "msg": "retrieved 3 docs", "prefix": "rag", "docs": float64(3)},
+		{"level": "token", "msg": "chunk"},
+	}, got)
+}
+
+func TestWrappers(t *testing.T) {
+	reset(t)
+	var file strings.Builder
+	logs.SetFile(&file)
+	out := testutil.CaptureOutput(t, func() {
+		logs.Infof("answer: %s", "42")
+		logs.Errorf("call failed, err=%v", "timeout")
+		logs.Tokenf("Hello")
+		logs.Tokenf(", world\n")
+	})
+	want := []string{"[INFO] answer: 42", "[ERROR] call failed, err=timeout", "Hello, world"}
+	assert.Equal(t, want, lines(out))
+	// the file gets the same lines, never colored
+	assert.NotContains(t, file.String(), "\x1b[")
+	assert.Equal(t, want, lines(file.String()))
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxSizeMB  = 10
+	defaultMaxBackups = 5
+
+	backupTimeFormat = "20060102-150405.000"
+)
+
+// RotateConfig configures a RotatingFile.
+type RotateConfig struct {
+	// Path of the current file, required. Its parent directory is created if needed.
+	Path string
+	// MaxSizeMB rotates the file before it grows over this size, default 10.
+	MaxSizeMB int
+	// MaxAge rotates the file once it was opened this long ago, 0 means no age limit.
+	MaxAge time.Duration
+	// MaxBackups is the number of rotated files kept, the oldest are deleted, default 5.
+	MaxBackups int
+}
+
+// RotatingFile is an io.Writer appending to a file which is renamed to <path>.<time> when it gets too big or too
+// old, a new file then taking its place. A single Write is never split across two files.
+type RotatingFile struct {
+	config RotateConfig
+
+	mu     sync.Mutex
+	f      *os.File
+	size   int64
+	opened time.Time
+	closed bool
+}
+
+func NewRotatingFile(config *RotateConfig) (*RotatingFile, error) {
+	if config == nil || config.Path == "" {
+		return nil, errors.New("path is required")
+	}
+	r := &RotatingFile{config: *config}
+	if r.config.MaxSizeMB <= 0 {
+		r.config.MaxSizeMB = defaultMaxSizeMB
+	}
+	if r.config.MaxBackups <= 0 {
+		r.config.MaxBackups = defaultMaxBackups
+	}
+	if err := os.MkdirAll(filepath.Dir(r.config.Path), 0755); err != nil {
+		return nil, err
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// rotateConfigFromEnv reads EINO_LOG_FILE, EINO_LOG_FILE_MAX_SIZE_MB, EINO_LOG_FILE_MAX_AGE (a duration such as 24h)
+// and EINO_LOG_FILE_MAX_BACKUPS. It returns nil without EINO_LOG_FILE.
+func rotateConfigFromEnv() (*RotateConfig, error) {
+	path := os.Getenv("EINO_LOG_FILE")
+	if path == "" {
+		return nil, nil
+	}
+	config := &RotateConfig{Path: path}
+	var err error
+	if v := os.Getenv("EINO_LOG_FILE_MAX_SIZE_MB"); v != "" {
+		if config.MaxSizeMB, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("EINO_LOG_FILE_MAX_SIZE_MB: %w", err)
+		}
+	}
+	if v := os.Getenv("EINO_LOG_FILE_MAX_AGE"); v != "" {
+		if config.MaxAge, err = time.ParseDuration(v); err != nil {
+			return nil, fmt.Errorf("EINO_LOG_FILE_MAX_AGE: %w", err)
+		}
+	}
+	if v := os.Getenv("EINO_LOG_FILE_MAX_BACKUPS"); v != "" {
+		if config.MaxBackups, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("EINO_LOG_FILE_MAX_BACKUPS: %w", err)
+		}
+	}
+	return config, nil
+}
+
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+	if r.f != nil {
+		tooBig := r.size > 0 && r.size+int64(len(p)) > int64(r.config.MaxSizeMB)<<20
+		tooOld := r.config.MaxAge > 0 && time.Since(r.opened) > r.config.MaxAge
+		if tooBig || tooOld {
+			if err := r.rotate(); err != nil {
+				// the line is not lost, it goes to the current file, and the next write tries again
+				fmt.Fprintf(os.Stderr, "rotate %s failed, err=%v\n", r.config.Path, err)
+			}
+		}
+	}
+	// the file could not be reopened after a rotation
+	if r.f == nil {
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the current file, the following writes fail.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closed = true
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
+
+// open opens the file in append mode, a file left by a previous run keeps growing until it is rotated. The age is
+// counted from the modification time of such a file, its first line is older than this run.
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.config.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	r.f, r.size, r.opened = f, info.Size(), time.Now()
+	if info.Size() > 0 {
+		r.opened = info.ModTime()
+	}
+	return nil
+}
+
+// rotate renames the file to a backup and opens a new one. The file is reopened whatever happens: after a failed
+// rename, the same file keeps growing, and if it cannot be reopened, r.f is left nil for Write to try again.
+func (r *RotatingFile) rotate() error {
+	err := r.f.Close()
+	r.f = nil
+	if err == nil {
+		backup := r.config.Path + "." + time.Now().Format(backupTimeFormat)
+		err = os.Rename(r.config.Path, backup)
+	}
+	if openErr := r.open(); openErr != nil {
+		return errors.Join(err, openErr)
+	}
+	if err != nil {
+		return err
+	}
+	r.opened = time.Now()
+	return r.prune()
+}
+
+// prune deletes the oldest backups beyond MaxBackups. The time in their names sorts as text.
+func (r *RotatingFile) prune() error {
+	matches, err := filepath.Glob(r.config.Path + ".*")
+	if err != nil {
+		return err
+	}
+	var backups []string
+	for _, m := range matches {
+		if _, err := time.Parse(backupTimeFormat, strings.TrimPrefix(m, r.config.Path+".")); err == nil {
+			backups = append(backups, m)
+		}
+	}
+	if len(backups) <= r.config.MaxBackups {
+		return nil
+	}
+	sort.Strings(backups)
+	for _, b := range backups[:len(backups)-r.config.MaxBackups] {
+		if err := os.Remove(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package logs
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// backups returns the contents of the rotated files of path, oldest first.
+func backups(t *testing.T, path string) []string {
+	matches, err := filepath.Glob(path + ".*")
+	assert.NoError(t, err)
+	sort.Strings(matches)
+	var res []string
+	for _, m := range matches {
+		b, err := os.ReadFile(m)
+		assert.NoError(t, err)
+		res = append(res, string(b))
+	}
+	return res
+}
+
+func read(t *testing.T, path string) string {
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	return string(b)
+}
+
+func TestRotateOnSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r, err := NewRotatingFile(&RotateConfig{Path: path, MaxSizeMB: 1})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer r.Close()
+
+	first := strings.Repeat("a", 700<<10)
+	second := strings.Repeat("b", 700<<10)
+	_, err = r.Write([]byte(first))
+	assert.NoError(t, err)
+	assert.Empty(t, backups(t, path))
+
+	// 1400 KB would go over 1 MB, the second write starts a new file
+	_, err = r.Write([]byte(second))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{first}, backups(t, path))
+	assert.Equal(t, second, read(t, path))
+}
+
+func TestRotateOnAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r, err := NewRotatingFile(&RotateConfig{Path: path, MaxAge: 20 * time.Millisecond})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer r.Close()
+
+	_, err = r.Write([]byte("old\n"))
+	assert.NoError(t, err)
+	_, err = r.Write([]byte("still young\n"))
+	assert.NoError(t, err)
+	assert.Empty(t, backups(t, path))
+
+	time.Sleep(30 * time.Millisecond)
+	_, err = r.Write([]byte("new\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"old\nstill young\n"}, backups(t, path))
+	assert.Equal(t, "new\n", read(t, path))
+}
+
+func TestPruneKeepsMaxBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r, err := NewRotatingFile(&RotateConfig{Path: path, MaxAge: time.Millisecond, MaxBackups: 2})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer r.Close()
+
+	// every write after the first rotates, the backups being named after the time to the millisecond
+	for _, line := range []string{"0", "1", "2", "3", "4"} {
+		_, err = r.Write([]byte(line))
+		assert.NoError(t, err)
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.Equal(t, []string{"2", "3"}, backups(t, path))
+	assert.Equal(t, "4", read(t, path))
+}
+
+func TestWriteAfterClose(t *testing.T) {
+	r, err := NewRotatingFile(&RotateConfig{Path: filepath.Join(t.TempDir(), "app.log")})
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, r.Close())
+
+	_, err = r.Write([]byte("x"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package memory holds strategies to keep the history of a conversation within the context of the model.
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/tokens"
+)
+
+// summaryExtraKey marks the rolling summary in Message.Extra, so that it is found again in the next history.
+const summaryExtraKey = "conversation_summary"
+
+// DefaultSummaryPrompt is the system prompt of the summarization call.
+const DefaultSummaryPrompt = `Summarize the conversation below for the assistant who will continue it.
+Keep the facts, names, numbers, decisions and preferences of the user, and the questions left open; drop greetings
+and small talk. If a previous summary is given, merge it with the new messages into a single summary.
+Write in the language of the conversation, at most 200 words, without any introduction.`
+
+// SummaryConfig configures Summary.
+type SummaryConfig struct {
+	// Model writes the summary, it can be a cheaper model than the one of the conversation.
+	Model model.ChatModel
+	// MaxMessages is the length of the history above which the older messages are summarized, default 20.
+	// The rolling summary counts as one message.
+	MaxMessages int
+	// MaxTokens, if set, also summarizes the older messages once the history is longer than MaxTokens tokens,
+	// counted with internal/tokens, e.g. when a few messages hold long tool results.
+	MaxTokens int
+	// KeepRecent is the number of the latest messages kept verbatim, default 6. The cut is moved back to a user
+	// message, so a tool result is never kept without the assistant message calling the tool.
+	KeepRecent int
+	// Prompt is the system prompt of the summarization call, default DefaultSummaryPrompt.
+	Prompt string
+}
+
+// Summary keeps a conversation short by replacing its older messages with a rolling summary: once the history is
+// longer than MaxMessages, everything but the KeepRecent latest messages is summarized, together with the previous
+// summary, into a single system message put first.
+//
+// Unlike a window, nothing is dropped silently, the name given at the first message is still known at the fiftieth;
+// it costs a model call every MaxMessages-KeepRecent messages. Summary keeps no state, the summary lives in the
+// history, which is compacted by Compact between turns, or by the node of Lambda in front of the model.
+type Summary struct {
+	config SummaryConfig
+}
+
+// NewSummary checks the config and fills in the defaults.
+func NewSummary(config *SummaryConfig) (*Summary, error) {
+	if config == nil || config.Model == nil {
+		return nil, fmt.Errorf("summary memory needs a model")
+	}
+	c := *config
+	if c.MaxMessages <= 0 {
+		c.MaxMessages = 20
+	}
+	if c.KeepRecent <= 0 {
+		c.KeepRecent = 6
+	}
+	if c.KeepRecent >= c.MaxMessages {
+		return nil, fmt.Errorf("KeepRecent (%d) must be less than MaxMessages (%d)", c.KeepRecent, c.MaxMessages)
+	}
+	if c.Prompt == "" {
+		c.Prompt = DefaultSummaryPrompt
+	}
+	return &Summary{config: c}, nil
+}
+
+// Compact returns the history unchanged while it is not longer than MaxMessages nor MaxTokens, otherwise the summary
+// followed by the recent messages. The messages before the summary, a system prompt for instance, are kept first.
+func (s *Summary) Compact(ctx context.Context, history []*schema.Message) ([]*schema.Message, error) {
+	if len(history) <= s.config.MaxMessages &&
+		(s.config.MaxTokens <= 0 || tokens.CountMessages(history) <= s.config.MaxTokens) {
+		return history, nil
+	}
+
+	head, previous, rest := splitSummary(history)
+	cut := len(rest) - s.config.KeepRecent
+	for cut > 0 && rest[cut].Role != schema.User {
+		cut--
+	}
+	if cut <= 0 {
+		// a single turn longer than KeepRecent, nothing before it to summarize
+		return history, nil
+	}
+
+	summary, err := s.summarize(ctx, previous, rest[:cut])
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*schema.Message, 0, len(head)+1+len(rest)-cut)
+	res = append(res, head...)
+	res = append(res, summary)
+	return append(res, rest[cut:]...), nil
+}
+
+// Lambda returns Compact as a node taking and returning the history, to put in front of the chat model of a graph.
+func (s *Summary) Lambda() *compose.Lambda {
+	return compose.InvokableLambda(s.Compact)
+}
+
+func (s *Summary) summarize(ctx context.Context, previous *schema.Message, msgs []*schema.Message) (*schema.Message, error) {
+	sb := strings.Builder{}
+	if previous != nil {
+		sb.WriteString("Previous summary:\n")
+		sb.WriteString(strings.TrimPrefix(previous.Content, summaryPrefix))
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString("New messages:\n")
+	for _, m := range msgs {
+		if m.Content == "" {
+			// assistant messages with tool calls only, their results follow as tool messages
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s: %s\n", m.Role, m.Content))
+	}
+
+	out, err := s.config.Model.Generate(ctx, []*schema.Message{
+		schema.SystemMessage(s.config.Prompt),
+		schema.UserMessage(sb.String()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("summarize conversation failed: %w", err)
+	}
+
+	msg := schema.SystemMessage(summaryPrefix + strings.TrimSpace(out.Content))
+	msg.Extra = map[string]any{summaryExtraKey: true}
+	return msg, nil
+}
+
+const summaryPrefix = "Summary of the conversation so far:\n"
+
+// IsSummary reports whether m is a rolling summary written by Summary.
+func IsSummary(m *schema.Message) bool {
+	if m == nil || m.Extra == nil {
+		return false
+	}
+	v, _ := m.Extra[summaryExtraKey].(bool)
+	return v
+}
+
+// splitSummary splits the history around the previous summary. Without one, the leading system messages are the head.
+func splitSummary(history []*schema.Message) (head []*schema.Message, summary *schema.Message, rest []*schema.Message) {
+	for i, m := range history {
+		if IsSummary(m) {
+			return history[:i], m, history[i+1:]
+		}
+	}
+	i := 0
+	for i < len(history) && history[i].Role == schema.System {
+		i++
+	}
+	return history[:i], nil, history[i:]
+}
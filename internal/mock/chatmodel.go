@@ -0,0 +1,287 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package mock provides a chat model answering from a script, to run the examples without an API key and to test
+// graphs and agents deterministically:
+//
+//	cm := mock.NewChatModel(
+//		mock.ToolCall("search", `{"query":"eino"}`),
+//		mock.Reply("Eino is a framework for LLM applications."),
+//	)
+//
+// Every Generate or Stream consumes the next response of the script and records its input, see Calls.
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+)
+
+// Response is one scripted turn of the model.
+type Response struct {
+	// Message is returned by Generate, and streamed by Stream.
+	Message *schema.Message
+	// Chunks are the contents streamed by Stream, default the words of Message.Content. The tool calls of Message
+	// follow in a last chunk.
+	Chunks []string
+	// Err is returned instead of Message. With Stream, it is returned by Recv after the chunks.
+	Err error
+	// Delay is waited before the response, and between chunks with Stream, unless the context is done.
+	Delay time.Duration
+}
+
+// Reply returns a response of an assistant message with content.
+func Reply(content string) *Response {
+	return &Response{Message: schema.AssistantMessage(content, nil)}
+}
+
+// ToolCall returns a response calling the tool name with the JSON arguments.
+func ToolCall(name, arguments string) *Response {
+	return ToolCalls(schema.ToolCall{Function: schema.FunctionCall{Name: name, Arguments: arguments}})
+}
+
+// ToolCalls returns a response calling several tools at once, their IDs and indexes are set if empty.
+func ToolCalls(calls ...schema.ToolCall) *Response {
+	for i := range calls {
+		if calls[i].ID == "" {
+			calls[i].ID = fmt.Sprintf("call_%d", i+1)
+		}
+		if calls[i].Index == nil {
+			index := i
+			calls[i].Index = &index
+		}
+		if calls[i].Type == "" {
+			calls[i].Type = "function"
+		}
+	}
+	return &Response{Message: schema.AssistantMessage("", calls)}
+}
+
+// Fail returns a response failing with err.
+func Fail(err error) *Response {
+	return &Response{Err: err}
+}
+
+// Call is the record of one call of the model.
+type Call struct {
+	Input []*schema.Message
+	// Tools are the tools bound or passed with model.WithTools.
+	Tools []*schema.ToolInfo
+	// Stream is true for a call of Stream.
+	Stream bool
+}
+
+// ChatModel is a model.ChatModel answering with its script. It is safe for concurrent use, the responses being
+// consumed in the order of the calls.
+//
+// It is also a model.ToolCallingChatModel, whose WithTools returns a model with the tools bound instead of binding
+// them on the shared instance.
+type ChatModel struct {
+	state *state
+	tools []*schema.ToolInfo
+}
+
+// state is shared by the models returned by WithTools, so that they consume one script.
+type state struct {
+	mu        sync.Mutex
+	responses []*Response
+	next      int
+	calls     []*Call
+	fallback  func(ctx context.Context, input []*schema.Message) (*Response, error)
+}
+
+var (
+	_ model.ChatModel            = (*ChatModel)(nil)
+	_ model.ToolCallingChatModel = (*ChatModel)(nil)
+)
+
+var errEmptyResponse = errors.New("mock: the response has neither message nor error")
+
+// NewChatModel returns a model answering with responses, in order. Once they are all consumed, the calls fail
+// unless a fallback is set.
+func NewChatModel(responses ...*Response) *ChatModel {
+	return &ChatModel{state: &state{responses: responses}}
+}
+
+// Echo returns a model answering every call with the last user message, for a runnable demo of any example.
+func Echo() *ChatModel {
+	m := NewChatModel()
+	m.SetFallback(func(ctx context.Context, input []*schema.Message) (*Response, error) {
+		for i := len(input) - 1; i >= 0; i-- {
+			if input[i].Role == schema.User {
+				return Reply("[mock] " + input[i].Content), nil
+			}
+		}
+		return Reply("[mock] hello"), nil
+	})
+	return m
+}
+
+// Add appends responses to the script.
+func (m *ChatModel) Add(responses ...*Response) {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+	m.state.responses = append(m.state.responses, responses...)
+}
+
+// SetFallback answers the calls once the script is consumed.
+func (m *ChatModel) SetFallback(fallback func(ctx context.Context, input []*schema.Message) (*Response, error)) {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+	m.state.fallback = fallback
+}
+
+// Calls returns the calls so far, in order.
+func (m *ChatModel) Calls() []*Call {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+	return append([]*Call(nil), m.state.calls...)
+}
+
+// Remaining returns the number of scripted responses not consumed yet.
+func (m *ChatModel) Remaining() int {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+	return len(m.state.responses) - m.state.next
+}
+
+func (m *ChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	resp, err := m.respond(ctx, input, false, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err = wait(ctx, resp.Delay); err != nil {
+		return nil, err
+	}
+	if resp.Err != nil {
+		return nil, resp.Err
+	}
+	if resp.Message == nil {
+		return nil, errEmptyResponse
+	}
+	return copyMessage(resp.Message), nil
+}
+
+func (m *ChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	resp, err := m.respond(ctx, input, true, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err = wait(ctx, resp.Delay); err != nil {
+		return nil, err
+	}
+	if resp.Message == nil {
+		if resp.Err != nil {
+			return nil, resp.Err
+		}
+		return nil, errEmptyResponse
+	}
+
+	chunks := resp.Chunks
+	if chunks == nil && resp.Message.Content != "" {
+		chunks = strings.SplitAfter(resp.Message.Content, " ")
+	}
+	sr, sw := schema.Pipe[*schema.Message](0)
+	go func() {
+		defer sw.Close()
+		for i, c := range chunks {
+			if i > 0 && wait(ctx, resp.Delay) != nil {
+				sw.Send(nil, ctx.Err())
+				return
+			}
+			if closed := sw.Send(&schema.Message{Role: schema.Assistant, Content: c}, nil); closed {
+				return
+			}
+		}
+		if len(resp.Message.ToolCalls) > 0 {
+			last := copyMessage(resp.Message)
+			last.Content = ""
+			if closed := sw.Send(last, nil); closed {
+				return
+			}
+		}
+		if resp.Err != nil {
+			sw.Send(nil, resp.Err)
+		}
+	}()
+	return sr, nil
+}
+
+// BindTools records the tools sent with the next calls, the model answering with its script whatever the tools.
+func (m *ChatModel) BindTools(tools []*schema.ToolInfo) error {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+	m.tools = tools
+	return nil
+}
+
+// WithTools returns a model with tools bound, consuming the same script as m.
+func (m *ChatModel) WithTools(tools []*schema.ToolInfo) (model.ToolCallingChatModel, error) {
+	return &ChatModel{state: m.state, tools: tools}, nil
+}
+
+func (m *ChatModel) GetType() string {
+	return "Mock"
+}
+
+// respond records the call and returns the next response.
+func (m *ChatModel) respond(ctx context.Context, input []*schema.Message, stream bool, opts []model.Option) (*Response, error) {
+	m.state.mu.Lock()
+	options := model.GetCommonOptions(&model.Options{Tools: m.tools}, opts...)
+	m.state.calls = append(m.state.calls, &Call{Input: input, Tools: options.Tools, Stream: stream})
+	n := len(m.state.calls)
+	if m.state.next < len(m.state.responses) {
+		resp := m.state.responses[m.state.next]
+		m.state.next++
+		m.state.mu.Unlock()
+		return resp, nil
+	}
+	fallback := m.state.fallback
+	m.state.mu.Unlock()
+
+	if fallback == nil {
+		return nil, fmt.Errorf("mock: no scripted response left for call %d", n)
+	}
+	return fallback(ctx, input)
+}
+
+// copyMessage returns a copy of msg, so that a caller modifying the returned message does not change the script.
+func copyMessage(msg *schema.Message) *schema.Message {
+	c := *msg
+	c.ToolCalls = append([]schema.ToolCall(nil), msg.ToolCalls...)
+	return &c
+}
+
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package mock
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScriptIsConsumedInOrder(t *testing.T) {
+	ctx := context.Background()
+	cm := NewChatModel(ToolCall("search", `{"query":"eino"}`), Reply("done"))
+	tools := []*schema.ToolInfo{{Name: "search"}}
+	assert.NoError(t, cm.BindTools(tools))
+
+	msg, err := cm.Generate(ctx, []*schema.Message{schema.UserMessage("find eino")})
+	assert.NoError(t, err)
+	if assert.Len(t, msg.ToolCalls, 1) {
+		assert.Equal(t, "search", msg.ToolCalls[0].Function.Name)
+		assert.Equal(t, `{"query":"eino"}`, msg.ToolCalls[0].Function.Arguments)
+		assert.Equal(t, "call_1", msg.ToolCalls[0].ID)
+	}
+
+	msg, err = cm.Generate(ctx, []*schema.Message{schema.UserMessage("again")}, model.WithTools(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "done", msg.Content)
+	assert.Equal(t, 0, cm.Remaining())
+
+	calls := cm.Calls()
+	if assert.Len(t, calls, 2) {
+		assert.Equal(t, "find eino", calls[0].Input[0].Content)
+		assert.Equal(t, tools, calls[0].Tools)
+		assert.Empty(t, calls[1].Tools)
+	}
+
+	_, err = cm.Generate(ctx, nil)
+	assert.ErrorContains(t, err, "no scripted response left for call 3")
+}
+
+func TestStreamConcatsToTheScriptedMessage(t *testing.T) {
+	ctx := context.Background()
+	want := schema.AssistantMessage("let me search", []schema.ToolCall{{
+		ID:       "call_1",
+		Function: schema.FunctionCall{Name: "search", Arguments: `{"query":"eino"}`},
+	}})
+	cm := NewChatModel(&Response{Message: want})
+
+	sr, err := cm.Stream(ctx, nil)
+	assert.NoError(t, err)
+	var chunks []*schema.Message
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		assert.NoError(t, err)
+		chunks = append(chunks, chunk)
+	}
+	assert.Len(t, chunks, 4)
+
+	got, err := schema.ConcatMessages(chunks)
+	assert.NoError(t, err)
+	assert.Equal(t, want.Content, got.Content)
+	assert.Equal(t, want.ToolCalls[0].Function, got.ToolCalls[0].Function)
+	assert.True(t, cm.Calls()[0].Stream)
+}
+
+func TestStreamErrorAfterChunks(t *testing.T) {
+	failure := errors.New("connection reset")
+	cm := NewChatModel(&Response{Message: schema.AssistantMessage("", nil), Chunks: []string{"a", "b"}, Err: failure})
+
+	sr, err := cm.Stream(context.Background(), nil)
+	assert.NoError(t, err)
+	var contents []string
+	for {
+		chunk, err := sr.Recv()
+		if err != nil {
+			assert.ErrorIs(t, err, failure)
+			break
+		}
+		contents = append(contents, chunk.Content)
+	}
+	assert.Equal(t, []string{"a", "b"}, contents)
+}
+
+func TestEchoAndWithTools(t *testing.T) {
+	ctx := context.Background()
+	cm := Echo()
+	cm.Add(Reply("scripted first"))
+
+	bound, err := cm.WithTools([]*schema.ToolInfo{{Name: "search"}})
+	assert.NoError(t, err)
+	msg, err := bound.Generate(ctx, []*schema.Message{schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	assert.Equal(t, "scripted first", msg.Content)
+
+	msg, err = cm.Generate(ctx, []*schema.Message{schema.SystemMessage("be brief"), schema.UserMessage("hi")})
+	assert.NoError(t, err)
+	assert.Equal(t, "[mock] hi", msg.Content)
+
+	calls := cm.Calls()
+	if assert.Len(t, calls, 2) {
+		assert.Len(t, calls[0].Tools, 1)
+		assert.Empty(t, calls[1].Tools)
+	}
+}
@@ -0,0 +1,454 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package models creates the chat model of the examples from the configuration, see internal/config, so that an
+// example runs on any provider without code change. MODEL_PROVIDER picks the provider, openai by default:
+//
+//	openai  OPENAI_API_KEY, OPENAI_BASE_URL, OPENAI_MODEL_NAME, OPENAI_PROXY
+//	azure   AZURE_OPENAI_API_KEY, AZURE_OPENAI_ENDPOINT, AZURE_OPENAI_DEPLOYMENT, AZURE_OPENAI_API_VERSION,
+//	        AZURE_OPENAI_PROXY
+//	ark     ARK_API_KEY, ARK_MODEL_ID, ARK_BASE_URL
+//	ollama  OLLAMA_BASE_URL (default http://localhost:11434), OLLAMA_MODEL (default llama2)
+//	claude  ANTHROPIC_API_KEY, CLAUDE_MODEL_NAME, ANTHROPIC_BASE_URL, ANTHROPIC_PROXY
+//	mock    nothing, the model answers with the last user message, see internal/mock
+//
+// The API keys can be references to secrets kept elsewhere, or be read from <key>_FILE, see internal/secrets.
+//
+// Claude is called through the OpenAI compatible endpoint of Anthropic, with the OpenAI client. The providers called
+// with the OpenAI client get the HTTP client of internal/httpx: retries, timeouts and logging set by EINO_HTTP_*,
+// recording and replaying set by EINO_HTTP_REPLAY, see internal/httpreplay.
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/model/ark"
+	"github.com/cloudwego/eino-ext/components/model/ollama"
+	"github.com/cloudwego/eino-ext/components/model/openai"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/ollama/ollama/api"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/httpx"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/mock"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+)
+
+const (
+	ProviderOpenAI = "openai"
+	ProviderAzure  = "azure"
+	ProviderArk    = "ark"
+	ProviderOllama = "ollama"
+	ProviderClaude = "claude"
+	ProviderMock   = "mock"
+)
+
+// Providers are the providers of MODEL_PROVIDER.
+var Providers = []string{ProviderOpenAI, ProviderAzure, ProviderArk, ProviderOllama, ProviderClaude, ProviderMock}
+
+const (
+	defaultAzureAPIVersion = "2024-06-01"
+	defaultOllamaBaseURL   = "http://localhost:11434"
+	defaultOllamaModel     = "llama2"
+	defaultClaudeBaseURL   = "https://api.anthropic.com/v1/"
+	// Anthropic requires max_tokens, the OpenAI client sends none unless set
+	defaultClaudeMaxTokens = 4096
+)
+
+type options struct {
+	provider    string
+	apiKey      string
+	baseURL     string
+	model       string
+	temperature *float32
+	topP        *float32
+	maxTokens   *int
+	stop        []string
+	headers     map[string]string
+	proxyKey    string
+	transport   func(http.RoundTripper) http.RoundTripper
+}
+
+// Option overrides the configuration of the chat model.
+type Option func(o *options)
+
+// WithProvider overrides MODEL_PROVIDER.
+func WithProvider(provider string) Option {
+	return func(o *options) {
+		o.provider = provider
+	}
+}
+
+// WithAPIKey overrides the API key of the provider.
+func WithAPIKey(apiKey string) Option {
+	return func(o *options) {
+		o.apiKey = apiKey
+	}
+}
+
+// WithBaseURL overrides the base URL, the endpoint for Azure.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithModel overrides the model name, the deployment for Azure and the endpoint ID for Ark.
+func WithModel(name string) Option {
+	return func(o *options) {
+		o.model = name
+	}
+}
+
+// WithTemperature sets the temperature of every call, which a model.WithTemperature of a call still overrides.
+func WithTemperature(temperature float32) Option {
+	return func(o *options) {
+		o.temperature = &temperature
+	}
+}
+
+func WithTopP(topP float32) Option {
+	return func(o *options) {
+		o.topP = &topP
+	}
+}
+
+func WithMaxTokens(maxTokens int) Option {
+	return func(o *options) {
+		o.maxTokens = &maxTokens
+	}
+}
+
+func WithStop(stop []string) Option {
+	return func(o *options) {
+		o.stop = stop
+	}
+}
+
+// WithHeaders sets headers on every request, for the providers called with the OpenAI client.
+func WithHeaders(headers map[string]string) Option {
+	return func(o *options) {
+		o.headers = headers
+	}
+}
+
+// WithProxyKey overrides the config key of the proxy of the provider, e.g. OPENAI_PROXY, see httpx.NewProxyTransport.
+func WithProxyKey(key string) Option {
+	return func(o *options) {
+		o.proxyKey = key
+	}
+}
+
+// WithTransport wraps the transport of the HTTP client, for the providers called with the OpenAI client, e.g. to add
+// to the requests the fields of a provider the client does not know, such as reasoning_effort. The wrapper sees the
+// requests before they are logged and recorded, see httpx.NewClient.
+func WithTransport(wrap func(next http.RoundTripper) http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = wrap
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.provider == "" {
+		o.provider = config.String("MODEL_PROVIDER", ProviderOpenAI)
+	}
+	o.provider = strings.ToLower(o.provider)
+	return o
+}
+
+// NewChatModel creates the chat model of the configured provider.
+func NewChatModel(ctx context.Context, opts ...Option) (model.ChatModel, error) {
+	o := newOptions(opts)
+	switch o.provider {
+	case ProviderOpenAI, ProviderClaude, ProviderAzure:
+		cm, err := newOpenAICompatible(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+		return cm, nil
+	case ProviderArk:
+		return newArk(ctx, o)
+	case ProviderOllama:
+		return newOllama(ctx, o)
+	case ProviderMock:
+		return mock.Echo(), nil
+	}
+	return nil, fmt.Errorf("unknown model provider %q, use %s, %s, %s, %s, %s or %s", o.provider,
+		ProviderOpenAI, ProviderAzure, ProviderArk, ProviderOllama, ProviderClaude, ProviderMock)
+}
+
+// NewOpenAIChatModel creates the chat model of the configured provider as an *openai.ChatModel, for the examples
+// needing its own methods, such as BindForcedTools. Only the providers called with the OpenAI client, openai, azure
+// and claude, have one.
+func NewOpenAIChatModel(ctx context.Context, opts ...Option) (*openai.ChatModel, error) {
+	o := newOptions(opts)
+	switch o.provider {
+	case ProviderOpenAI, ProviderClaude, ProviderAzure:
+		return newOpenAICompatible(ctx, o)
+	}
+	return nil, fmt.Errorf("model provider %q is not called with the OpenAI client, use %s, %s or %s", o.provider,
+		ProviderOpenAI, ProviderAzure, ProviderClaude)
+}
+
+func newOpenAICompatible(ctx context.Context, o *options) (*openai.ChatModel, error) {
+	switch o.provider {
+	case ProviderClaude:
+		if o.baseURL == "" {
+			o.baseURL = config.String("ANTHROPIC_BASE_URL", defaultClaudeBaseURL)
+		}
+		if o.maxTokens == nil {
+			WithMaxTokens(defaultClaudeMaxTokens)(o)
+		}
+		return newOpenAI(ctx, o, "ANTHROPIC_API_KEY", "ANTHROPIC_BASE_URL", "CLAUDE_MODEL_NAME", "ANTHROPIC_PROXY")
+	case ProviderAzure:
+		return newAzure(ctx, o)
+	}
+	return newOpenAI(ctx, o, "OPENAI_API_KEY", "OPENAI_BASE_URL", "OPENAI_MODEL_NAME", "OPENAI_PROXY")
+}
+
+// RequiredEnv returns the configuration keys the chat model of provider cannot do without, MODEL_PROVIDER if empty,
+// e.g. OPENAI_API_KEY and OPENAI_MODEL_NAME for openai. ollama and mock need none.
+func RequiredEnv(provider string) []string {
+	if provider == "" {
+		provider = config.String("MODEL_PROVIDER", ProviderOpenAI)
+	}
+	switch strings.ToLower(provider) {
+	case ProviderOpenAI:
+		return []string{"OPENAI_API_KEY", "OPENAI_MODEL_NAME"}
+	case ProviderClaude:
+		return []string{"ANTHROPIC_API_KEY", "CLAUDE_MODEL_NAME"}
+	case ProviderAzure:
+		return []string{"AZURE_OPENAI_API_KEY", "AZURE_OPENAI_ENDPOINT", "AZURE_OPENAI_DEPLOYMENT"}
+	case ProviderArk:
+		return []string{"ARK_API_KEY", "ARK_MODEL_ID"}
+	}
+	return nil
+}
+
+// ModelName returns the model configured for provider, MODEL_PROVIDER if empty: OPENAI_MODEL_NAME for openai, the
+// deployment for azure, the endpoint ID for ark. It is empty if not configured, and echo for mock.
+func ModelName(provider string) string {
+	if provider == "" {
+		provider = config.String("MODEL_PROVIDER", ProviderOpenAI)
+	}
+	switch strings.ToLower(provider) {
+	case ProviderOpenAI:
+		return config.String("OPENAI_MODEL_NAME", "")
+	case ProviderClaude:
+		return config.String("CLAUDE_MODEL_NAME", "")
+	case ProviderAzure:
+		return config.String("AZURE_OPENAI_DEPLOYMENT", "")
+	case ProviderArk:
+		return config.String("ARK_MODEL_ID", "")
+	case ProviderOllama:
+		return config.String("OLLAMA_MODEL", defaultOllamaModel)
+	case ProviderMock:
+		return "echo"
+	}
+	return ""
+}
+
+// MustChatModel is NewChatModel exiting on error, the one line setup of the examples.
+func MustChatModel(ctx context.Context, opts ...Option) model.ChatModel {
+	cm, err := NewChatModel(ctx, opts...)
+	if err != nil {
+		logs.Fatalf("models.NewChatModel failed, err=%v", err)
+	}
+	return cm
+}
+
+// MustOpenAIChatModel is NewOpenAIChatModel exiting on error.
+func MustOpenAIChatModel(ctx context.Context, opts ...Option) *openai.ChatModel {
+	cm, err := NewOpenAIChatModel(ctx, opts...)
+	if err != nil {
+		logs.Fatalf("models.NewOpenAIChatModel failed, err=%v", err)
+	}
+	return cm
+}
+
+// fill sets the options not given from the config keys, the API key being a secret, see internal/secrets.
+func (o *options) fill(ctx context.Context, apiKeyKey, baseURLKey, modelKey, proxyKey string) error {
+	if o.apiKey == "" {
+		apiKey, err := secrets.Get(ctx, apiKeyKey)
+		if err != nil {
+			return err
+		}
+		o.apiKey = apiKey
+	}
+	if o.baseURL == "" && baseURLKey != "" {
+		o.baseURL = config.String(baseURLKey, "")
+	}
+	if o.model == "" {
+		o.model = config.String(modelKey, "")
+	}
+	if o.proxyKey == "" {
+		o.proxyKey = proxyKey
+	}
+	if o.model == "" {
+		return fmt.Errorf("%s is not set", modelKey)
+	}
+	return nil
+}
+
+// newHTTPClient returns the client of internal/httpx configured by EINO_HTTP_*, going through the proxy of the
+// options, its transport wrapped by WithTransport.
+func newHTTPClient(o *options, headers map[string]string) (*http.Client, error) {
+	c := httpx.DefaultConfig()
+	c.ProxyKey = o.proxyKey
+	c.Headers = headers
+	client, err := httpx.NewClient(c)
+	if err != nil {
+		return nil, err
+	}
+	if o.transport != nil {
+		client.Transport = o.transport(client.Transport)
+	}
+	return client, nil
+}
+
+func newOpenAI(ctx context.Context, o *options, apiKeyKey, baseURLKey, modelKey, proxyKey string) (*openai.ChatModel, error) {
+	if err := o.fill(ctx, apiKeyKey, baseURLKey, modelKey, proxyKey); err != nil {
+		return nil, err
+	}
+	client, err := newHTTPClient(o, o.headers)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := openai.NewChatModel(ctx, &openai.ChatModelConfig{
+		APIKey:      o.apiKey,
+		BaseURL:     o.baseURL,
+		Model:       o.model,
+		HTTPClient:  client,
+		Temperature: o.temperature,
+		TopP:        o.topP,
+		MaxTokens:   o.maxTokens,
+		Stop:        o.stop,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+// newAzure differs from OpenAI in that:
+//  1. the base URL is the endpoint of the resource only, e.g. https://my-resource.openai.azure.com, the client
+//     appends /openai/deployments/{deployment}/chat/completions
+//  2. the model is the name of the deployment, not gpt-4o
+//  3. the api-version is a query parameter
+//  4. the key is sent in the api-key header instead of Authorization: Bearer
+func newAzure(ctx context.Context, o *options) (*openai.ChatModel, error) {
+	if err := o.fill(ctx, "AZURE_OPENAI_API_KEY", "AZURE_OPENAI_ENDPOINT", "AZURE_OPENAI_DEPLOYMENT", "AZURE_OPENAI_PROXY"); err != nil {
+		return nil, err
+	}
+	endpoint, err := normalizeAzureEndpoint(o.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{"api-key": o.apiKey}
+	for k, v := range o.headers {
+		headers[k] = v
+	}
+	client, err := newHTTPClient(o, headers)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := openai.NewChatModel(ctx, &openai.ChatModelConfig{
+		ByAzure:     true,
+		BaseURL:     endpoint,
+		APIVersion:  config.String("AZURE_OPENAI_API_VERSION", defaultAzureAPIVersion),
+		APIKey:      o.apiKey,
+		Model:       o.model,
+		HTTPClient:  client,
+		Temperature: o.temperature,
+		TopP:        o.topP,
+		MaxTokens:   o.maxTokens,
+		Stop:        o.stop,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+// normalizeAzureEndpoint fixes the most common mistake, a full request URL such as
+// https://my-resource.openai.azure.com/openai/deployments/gpt-4o/chat/completions?api-version=... given as the
+// endpoint, by keeping its scheme and host only.
+func normalizeAzureEndpoint(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid AZURE_OPENAI_ENDPOINT %q, expect something like https://my-resource.openai.azure.com", raw)
+	}
+	endpoint := u.Scheme + "://" + u.Host
+	if endpoint != strings.TrimSuffix(raw, "/") {
+		logs.Warnf("AZURE_OPENAI_ENDPOINT should only contain scheme and host, using %s instead of %s", endpoint, raw)
+	}
+	return endpoint, nil
+}
+
+func newArk(ctx context.Context, o *options) (model.ChatModel, error) {
+	if err := o.fill(ctx, "ARK_API_KEY", "ARK_BASE_URL", "ARK_MODEL_ID", ""); err != nil {
+		return nil, err
+	}
+	cm, err := ark.NewChatModel(ctx, &ark.ChatModelConfig{
+		APIKey:      o.apiKey,
+		BaseURL:     o.baseURL,
+		Model:       o.model,
+		Temperature: o.temperature,
+		TopP:        o.topP,
+		MaxTokens:   o.maxTokens,
+		Stop:        o.stop,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
+
+func newOllama(ctx context.Context, o *options) (model.ChatModel, error) {
+	if o.baseURL == "" {
+		o.baseURL = config.String("OLLAMA_BASE_URL", defaultOllamaBaseURL)
+	}
+	if o.model == "" {
+		o.model = config.String("OLLAMA_MODEL", defaultOllamaModel)
+	}
+	cfg := &ollama.ChatModelConfig{BaseURL: o.baseURL, Model: o.model}
+	if o.temperature != nil || o.topP != nil || o.maxTokens != nil || len(o.stop) > 0 {
+		cfg.Options = &api.Options{Stop: o.stop}
+		if o.temperature != nil {
+			cfg.Options.Temperature = *o.temperature
+		}
+		if o.topP != nil {
+			cfg.Options.TopP = *o.topP
+		}
+		if o.maxTokens != nil {
+			cfg.Options.NumPredict = *o.maxTokens
+		}
+	}
+	cm, err := ollama.NewChatModel(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type headerTransport struct {
+	next http.RoundTripper
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Wrapped", "yes")
+	return t.next.RoundTrip(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"wrapped=%s"},"finish_reason":"stop"}]}`,
+			r.Header.Get("X-Wrapped"))
+	}))
+	defer srv.Close()
+	t.Setenv("EINO_HTTP_REPLAY", "off")
+	ctx := context.Background()
+	input := []*schema.Message{schema.UserMessage("hi")}
+
+	for _, provider := range []string{ProviderOpenAI, ProviderClaude} {
+		cm, err := NewChatModel(ctx, WithProvider(provider), WithAPIKey("sk-test"), WithBaseURL(srv.URL),
+			WithModel("test"), WithTransport(func(next http.RoundTripper) http.RoundTripper {
+				return &headerTransport{next: next}
+			}))
+		if !assert.NoError(t, err) {
+			continue
+		}
+		out, err := cm.Generate(ctx, input)
+		if assert.NoError(t, err, provider) {
+			assert.Equal(t, "wrapped=yes", out.Content, provider)
+		}
+	}
+
+	cm, err := NewChatModel(ctx, WithProvider(ProviderOpenAI), WithAPIKey("sk-test"), WithBaseURL(srv.URL), WithModel("test"))
+	assert.NoError(t, err)
+	out, err := cm.Generate(ctx, input)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "wrapped=", out.Content)
+	}
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package outputcheck validates the output of a chat model and asks the model again with the validation error
+// until the output is valid.
+package outputcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const (
+	nodePrepare  = "prepare"
+	nodeGenerate = "generate"
+	nodeValidate = "validate"
+	nodeFeedback = "feedback"
+	nodeFail     = "fail"
+
+	defaultMaxRetries = 2
+)
+
+// Config configures the graph built by NewGraph.
+type Config struct {
+	Model    model.ChatModel
+	Validate Validator
+	// MaxRetries is the max number of calls after the first one, default 2.
+	MaxRetries int
+	// Feedback writes the user message sent back to the model after an invalid reply, default DefaultFeedback.
+	Feedback func(err error) string
+}
+
+// ValidationError is returned when the reply is still invalid after the retries.
+type ValidationError struct {
+	Attempts int
+	// Content is the last reply of the model.
+	Content string
+	Err     error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("output still invalid after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultFeedback tells the model what is wrong and to reply again with the output only.
+func DefaultFeedback(err error) string {
+	return fmt.Sprintf("Your reply is invalid:\n%v\nReply again with the corrected output only.", err)
+}
+
+type state struct {
+	messages []*schema.Message
+	attempts int
+	err      error
+}
+
+// NewGraph returns a graph which calls the model with the input messages, validates the reply, and on an invalid
+// reply appends it with the error to the conversation and calls the model again:
+//
+//	START -> prepare -> generate -> validate --(valid)--> END
+//	                       ^           |
+//	                       +-- feedback <--(invalid, retries left)
+//	                                   |
+//	                                   +--(invalid, no retry left)--> fail: *ValidationError
+//
+// The graph is returned uncompiled, so that it can be added to a larger graph with AddGraphNode, use Compile to run
+// it on its own. The output is the valid reply of the model.
+func NewGraph(config *Config) (*compose.Graph[[]*schema.Message, *schema.Message], error) {
+	if config == nil || config.Model == nil || config.Validate == nil {
+		return nil, errors.New("Model and Validate are required")
+	}
+	maxRetries := config.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+	feedback := config.Feedback
+	if feedback == nil {
+		feedback = DefaultFeedback
+	}
+
+	g := compose.NewGraph[[]*schema.Message, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *state {
+		return &state{}
+	}))
+
+	_ = g.AddLambdaNode(nodePrepare, compose.InvokableLambda(func(ctx context.Context, in []*schema.Message) ([]*schema.Message, error) {
+		err := compose.ProcessState[*state](ctx, func(_ context.Context, s *state) error {
+			// copied, the conversation grows with the retries and must not change the slice of the caller
+			s.messages = append([]*schema.Message(nil), in...)
+			return nil
+		})
+		return in, err
+	}))
+	_ = g.AddChatModelNode(nodeGenerate, config.Model, compose.WithStatePostHandler(
+		func(ctx context.Context, out *schema.Message, s *state) (*schema.Message, error) {
+			s.messages = append(s.messages, out)
+			s.attempts++
+			return out, nil
+		}))
+	_ = g.AddLambdaNode(nodeValidate, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*schema.Message, error) {
+		err := compose.ProcessState[*state](ctx, func(_ context.Context, s *state) error {
+			s.err = config.Validate(msg.Content)
+			if s.err != nil {
+				logs.Infof("attempt %d is invalid: %v", s.attempts, s.err)
+			}
+			return nil
+		})
+		return msg, err
+	}))
+	_ = g.AddLambdaNode(nodeFeedback, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) ([]*schema.Message, error) {
+		var msgs []*schema.Message
+		err := compose.ProcessState[*state](ctx, func(_ context.Context, s *state) error {
+			s.messages = append(s.messages, schema.UserMessage(feedback(s.err)))
+			msgs = append(msgs, s.messages...)
+			return nil
+		})
+		return msgs, err
+	}))
+	_ = g.AddLambdaNode(nodeFail, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*schema.Message, error) {
+		var verr *ValidationError
+		err := compose.ProcessState[*state](ctx, func(_ context.Context, s *state) error {
+			verr = &ValidationError{Attempts: s.attempts, Content: msg.Content, Err: s.err}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, verr
+	}))
+
+	_ = g.AddEdge(compose.START, nodePrepare)
+	_ = g.AddEdge(nodePrepare, nodeGenerate)
+	_ = g.AddEdge(nodeGenerate, nodeValidate)
+	_ = g.AddBranch(nodeValidate, compose.NewGraphBranch(func(ctx context.Context, msg *schema.Message) (string, error) {
+		next := compose.END
+		err := compose.ProcessState[*state](ctx, func(_ context.Context, s *state) error {
+			switch {
+			case s.err == nil:
+			case s.attempts <= maxRetries:
+				next = nodeFeedback
+			default:
+				next = nodeFail
+			}
+			return nil
+		})
+		return next, err
+	}, map[string]bool{compose.END: true, nodeFeedback: true, nodeFail: true}))
+	_ = g.AddEdge(nodeFeedback, nodeGenerate)
+	_ = g.AddEdge(nodeFail, compose.END)
+
+	return g, nil
+}
+
+// Compile builds the graph of NewGraph and compiles it with enough steps for the retries, the default limit of eino
+// stops a loop of more than a few retries. opts come after the defaults and can override them.
+func Compile(ctx context.Context, config *Config, opts ...compose.GraphCompileOption) (compose.Runnable[[]*schema.Message, *schema.Message], error) {
+	g, err := NewGraph(config)
+	if err != nil {
+		return nil, err
+	}
+	maxRetries := config.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultMaxRetries
+	}
+	// prepare, then generate, validate and feedback or fail for every attempt
+	opts = append([]compose.GraphCompileOption{
+		compose.WithGraphName("output_check"),
+		compose.WithMaxRunSteps(3*(maxRetries+1) + 2),
+	}, opts...)
+	return g.Compile(ctx, opts...)
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package outputcheck
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Validator checks the content of a model reply. The error is sent back to the model, so it should say what is wrong
+// in words the model can act on.
+type Validator func(content string) error
+
+// Regexp accepts a reply matching re, after trimming the spaces around it.
+func Regexp(re *regexp.Regexp) Validator {
+	return func(content string) error {
+		if !re.MatchString(strings.TrimSpace(content)) {
+			return fmt.Errorf("the reply must match the regular expression %s", re.String())
+		}
+		return nil
+	}
+}
+
+// JSONSchema accepts a reply that is a JSON value valid against s. Markdown code fences around the JSON are allowed,
+// use StripFences on the content before decoding it.
+func JSONSchema(s *openapi3.Schema) Validator {
+	return func(content string) error {
+		var v any
+		if err := json.Unmarshal([]byte(StripFences(content)), &v); err != nil {
+			return fmt.Errorf("the reply is not valid JSON: %v", err)
+		}
+		err := s.VisitJSON(v, openapi3.MultiErrors())
+		if err == nil {
+			return nil
+		}
+		return fmt.Errorf("the JSON does not match the schema:\n%s", strings.Join(schemaErrors(err), "\n"))
+	}
+}
+
+// JSONSchemaOf is JSONSchema with the schema inferred from T, the same way utils.InferTool infers the parameters of
+// a tool: fields without omitempty are required, and the jsonschema tag sets descriptions and enums.
+func JSONSchemaOf[T any]() (Validator, error) {
+	params, err := utils.GoStruct2ParamsOneOf[T]()
+	if err != nil {
+		return nil, err
+	}
+	s, err := params.ToOpenAPIV3()
+	if err != nil {
+		return nil, err
+	}
+	return JSONSchema(s), nil
+}
+
+// All accepts a reply accepted by every validator, the errors of all of them are returned together.
+func All(validators ...Validator) Validator {
+	return func(content string) error {
+		var errs []error
+		for _, v := range validators {
+			if err := v(content); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+}
+
+// StripFences removes the ```json fence models often put around JSON even when asked not to.
+func StripFences(content string) string {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "```") {
+		return content
+	}
+	content = strings.TrimPrefix(content, "```")
+	if nl := strings.IndexByte(content, '\n'); nl >= 0 {
+		content = content[nl+1:] // the language of the fence
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(content), "```"))
+}
+
+// schemaErrors flattens the errors of kin-openapi into one line per error, with the path of the value in the JSON,
+// leaving out the dump of the schema that SchemaError.Error adds.
+func schemaErrors(err error) []string {
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		var lines []string
+		for _, e := range multi {
+			lines = append(lines, schemaErrors(e)...)
+		}
+		return lines
+	}
+
+	var se *openapi3.SchemaError
+	if errors.As(err, &se) {
+		return []string{fmt.Sprintf("- /%s: %s", strings.Join(se.JSONPointer(), "/"), se.Reason)}
+	}
+	return []string{"- " + err.Error()}
+}
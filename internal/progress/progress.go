@@ -0,0 +1,230 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package progress shows how far a long loop of an example is, embedding a corpus, running a batch of prompts or an
+// evaluation suite. On a terminal, a bar is redrawn in place with the rate and the time left:
+//
+//	embedding ██████████████░░░░░░░░░░░░░░░░  560/1000  56%  42.1/s  eta 10s
+//
+// Off a terminal, e.g. in CI, or with EINO_LOG_FORMAT=json, a log line of internal/logs is written every Interval
+// instead, and one at the end:
+//
+//	bar := progress.New("embedding", len(texts), nil)
+//	defer bar.Done()
+//	for ... {
+//		bar.Add(1)
+//	}
+package progress
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const (
+	clearLine = "\r\033[K"
+	// redrawEvery bounds the redraws of the bar, a fast loop would spend its time drawing
+	redrawEvery = 100 * time.Millisecond
+)
+
+// Config configures a Bar.
+type Config struct {
+	// Output is where the bar is drawn, default stderr. The bar is drawn only if it is a terminal.
+	Output io.Writer
+	// Width is the number of characters of the bar, default 30.
+	Width int
+	// Interval is the time between the log lines off a terminal, default 10 seconds.
+	Interval time.Duration
+}
+
+// State is the progress at a point in time.
+type State struct {
+	Done  int
+	Total int
+	// Elapsed is the time since New.
+	Elapsed time.Duration
+	// Rate is the number done per second so far.
+	Rate float64
+	// ETA is the time left at Rate, 0 when unknown.
+	ETA time.Duration
+}
+
+// Bar is the progress of one loop. It is safe for concurrent use, e.g. by the workers of a batch.
+type Bar struct {
+	label    string
+	out      io.Writer
+	tty      bool
+	width    int
+	interval time.Duration
+	now      func() time.Time
+
+	mu       sync.Mutex
+	done     int
+	total    int
+	start    time.Time
+	lastDraw time.Time
+	finished bool
+}
+
+// New returns a Bar of total steps named label, total being 0 if unknown. config is nil for the defaults.
+func New(label string, total int, config *Config) *Bar {
+	if config == nil {
+		config = &Config{}
+	}
+	b := &Bar{label: label, total: total, out: config.Output, width: config.Width, interval: config.Interval, now: time.Now}
+	if b.out == nil {
+		b.out = os.Stderr
+	}
+	if f, ok := b.out.(*os.File); ok {
+		b.tty = isTerminal(f) && !strings.EqualFold(os.Getenv("EINO_LOG_FORMAT"), "json")
+	}
+	if b.width <= 0 {
+		b.width = 30
+	}
+	if b.interval <= 0 {
+		b.interval = 10 * time.Second
+	}
+	b.start = b.now()
+	b.lastDraw = b.start
+	return b
+}
+
+// Add counts n more steps done.
+func (b *Bar) Add(n int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done += n
+	b.update()
+}
+
+// Set sets the steps done, for a loop reporting its own count.
+func (b *Bar) Set(done int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done = done
+	b.update()
+}
+
+// Above runs f, e.g. printing a result of the loop, with the bar cleared, and draws the bar again below what f
+// printed.
+func (b *Bar) Above(f func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.tty || b.finished {
+		f()
+		return
+	}
+	_, _ = io.WriteString(b.out, clearLine)
+	f()
+	b.draw(b.state())
+}
+
+// Done ends the bar, with the final state on its own line. It can be called more than once, e.g. deferred.
+func (b *Bar) Done() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.finished {
+		return
+	}
+	b.finished = true
+	s := b.state()
+	if b.tty {
+		b.draw(s)
+		_, _ = io.WriteString(b.out, "\n")
+		return
+	}
+	logs.Infof("%s: %d done in %v, %.1f/s", b.label, s.Done, s.Elapsed.Round(time.Millisecond), s.Rate)
+}
+
+// State returns the progress now.
+func (b *Bar) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state()
+}
+
+func (b *Bar) state() State {
+	s := State{Done: b.done, Total: b.total, Elapsed: b.now().Sub(b.start)}
+	if s.Elapsed > 0 {
+		s.Rate = float64(s.Done) / s.Elapsed.Seconds()
+	}
+	if s.Rate > 0 && s.Total > s.Done {
+		s.ETA = time.Duration(float64(s.Total-s.Done) / s.Rate * float64(time.Second))
+	}
+	return s
+}
+
+// update draws the bar, or logs the state off a terminal, if it was not for a while.
+func (b *Bar) update() {
+	if b.finished {
+		return
+	}
+	now := b.now()
+	every := b.interval
+	if b.tty {
+		every = redrawEvery
+	}
+	// the last step is drawn at once on a terminal, off a terminal Done logs it
+	last := b.tty && b.total > 0 && b.done >= b.total
+	if now.Sub(b.lastDraw) < every && !last {
+		return
+	}
+	b.lastDraw = now
+	s := b.state()
+	if b.tty {
+		b.draw(s)
+		return
+	}
+	logs.Infof("%s", b.line(s, false))
+}
+
+func (b *Bar) draw(s State) {
+	_, _ = io.WriteString(b.out, clearLine+b.line(s, true))
+}
+
+// line formats s, with the bar itself on a terminal.
+func (b *Bar) line(s State, withBar bool) string {
+	var sb strings.Builder
+	sb.WriteString(b.label)
+	if s.Total <= 0 {
+		fmt.Fprintf(&sb, "  %d  %.1f/s  %v", s.Done, s.Rate, s.Elapsed.Round(time.Second))
+		return sb.String()
+	}
+
+	done := min(s.Done, s.Total)
+	if withBar {
+		filled := done * b.width / s.Total
+		sb.WriteString(" ")
+		sb.WriteString(strings.Repeat("█", filled))
+		sb.WriteString(strings.Repeat("░", b.width-filled))
+	}
+	fmt.Fprintf(&sb, "  %d/%d  %3d%%  %.1f/s", s.Done, s.Total, done*100/s.Total, s.Rate)
+	if s.ETA > 0 {
+		fmt.Fprintf(&sb, "  eta %v", s.ETA.Round(time.Second))
+	}
+	return sb.String()
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
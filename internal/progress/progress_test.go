@@ -0,0 +1,97 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package progress
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// fakeClock is advanced by the test.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func newTestBar(total int, tty bool) (*Bar, *fakeClock, *bytes.Buffer) {
+	out := &bytes.Buffer{}
+	clock := &fakeClock{t: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)}
+	b := New("embedding", total, &Config{Output: out, Width: 10, Interval: 10 * time.Second})
+	b.tty = tty
+	b.now = clock.now
+	b.start, b.lastDraw = clock.t, clock.t
+	return b, clock, out
+}
+
+func TestState(t *testing.T) {
+	b, clock, _ := newTestBar(100, false)
+	clock.t = clock.t.Add(10 * time.Second)
+	b.Add(25)
+
+	s := b.State()
+	assert.Equal(t, 25, s.Done)
+	assert.Equal(t, 2.5, s.Rate)
+	assert.Equal(t, 30*time.Second, s.ETA)
+}
+
+func TestTerminalBar(t *testing.T) {
+	b, clock, out := newTestBar(4, true)
+	b.Add(1)
+	assert.Empty(t, out.String(), "drawn at most every 100ms")
+
+	clock.t = clock.t.Add(time.Second)
+	b.Add(1)
+	assert.Equal(t, clearLine+"embedding █████░░░░░  2/4   50%  2.0/s  eta 1s", out.String())
+
+	out.Reset()
+	b.Above(func() { out.WriteString("result\n") })
+	assert.True(t, strings.HasPrefix(out.String(), clearLine+"result\n"+clearLine+"embedding"))
+
+	out.Reset()
+	b.Add(2)
+	b.Done()
+	b.Done()
+	assert.Equal(t, clearLine+"embedding ██████████  4/4  100%  4.0/s"+clearLine+"embedding ██████████  4/4  100%  4.0/s\n", out.String())
+}
+
+func TestLogLinesOffTerminal(t *testing.T) {
+	logged := &bytes.Buffer{}
+	logs.SetOutput(logged)
+	defer logs.SetOutput(os.Stdout)
+
+	b, clock, out := newTestBar(0, false)
+	b.Add(5)
+	assert.Empty(t, logged.String(), "logged every Interval")
+
+	clock.t = clock.t.Add(10 * time.Second)
+	b.Add(5)
+	assert.Contains(t, logged.String(), "embedding  10  1.0/s  10s")
+
+	b.Done()
+	assert.Contains(t, logged.String(), "embedding: 10 done in 10s, 1.0/s")
+	assert.Empty(t, out.String(), "no bar off a terminal")
+}
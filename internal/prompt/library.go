@@ -0,0 +1,338 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package prompt loads the prompts of the examples from files rather than Go string literals, so that a prompt is
+// read, diffed and tuned as text. A prompt file, <name>.prompt, is a list of messages, each introduced by a line
+// giving its role, the content being a text/template of the variables of the prompt:
+//
+//	# Lines starting with # before the first message are comments.
+//	--- system
+//	Answer the question based only on the documents below.
+//
+//	Documents:
+//	{{.documents}}
+//	--- placeholder chat_history?
+//	--- user
+//	{{.question}}
+//
+// The roles are system, user and assistant; placeholder inserts the messages of a variable, optional if its name
+// ends with a question mark. A missing variable fails the formatting.
+//
+// The prompts of the repository are in prompts/, embedded in the binary. To iterate on them without rebuilding:
+//
+//	EINO_PROMPTS_DIR=prompts EINO_PROMPTS_RELOAD=true go run ./rag/hybrid
+//
+// reads them from the directory, again whenever a file changes.
+package prompt
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	eprompt "github.com/cloudwego/eino/components/prompt"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/prompts"
+)
+
+const fileExt = ".prompt"
+
+// Config configures a Library.
+type Config struct {
+	// FS holds the prompt files, default the prompts/ directory of the repository, embedded.
+	FS fs.FS
+	// Dir, if set, is read instead of FS, so that the prompts can be edited without rebuilding.
+	Dir string
+	// Reload reads a prompt file of Dir again before formatting it, if it changed since.
+	Reload bool
+}
+
+// Library holds the prompts of a directory, by name.
+type Library struct {
+	fsys   fs.FS
+	reload bool
+
+	mu      sync.Mutex
+	prompts map[string]*parsed
+}
+
+// parsed is a prompt file parsed, with the modification time it was read at.
+type parsed struct {
+	messages []*message
+	modTime  time.Time
+}
+
+type message struct {
+	role schema.RoleType
+	// tpl is the content, nil for a placeholder
+	tpl *template.Template
+	// placeholder is the variable of the messages inserted
+	placeholder string
+	optional    bool
+}
+
+// NewLibrary reads and parses all the prompt files, so that an error in any of them fails now.
+func NewLibrary(config *Config) (*Library, error) {
+	if config == nil {
+		config = &Config{}
+	}
+	l := &Library{fsys: config.FS, reload: config.Reload && config.Dir != "", prompts: make(map[string]*parsed)}
+	if config.Dir != "" {
+		l.fsys = os.DirFS(config.Dir)
+	}
+	if l.fsys == nil {
+		l.fsys = prompts.FS
+	}
+
+	files, err := fs.Glob(l.fsys, "*"+fileExt)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		p, err := l.read(f)
+		if err != nil {
+			return nil, err
+		}
+		l.prompts[strings.TrimSuffix(f, fileExt)] = p
+	}
+	return l, nil
+}
+
+// Names returns the names of the prompts, sorted.
+func (l *Library) Names() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names := make([]string, 0, len(l.prompts))
+	for n := range l.prompts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Template returns the prompt name as a ChatTemplate, for a ChatTemplate node. With Reload, it formats the latest
+// version of the file, also once the graph is compiled.
+func (l *Library) Template(name string) (*Template, error) {
+	if _, err := l.get(name); err != nil {
+		return nil, err
+	}
+	return &Template{lib: l, name: name}, nil
+}
+
+// Format formats the prompt name with vars.
+func (l *Library) Format(ctx context.Context, name string, vars map[string]any) ([]*schema.Message, error) {
+	p, err := l.get(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.format(name, vars)
+}
+
+func (l *Library) get(name string) (*parsed, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	p, ok := l.prompts[name]
+	if !ok {
+		return nil, fmt.Errorf("prompt %q not found", name)
+	}
+	if !l.reload {
+		return p, nil
+	}
+
+	file := name + fileExt
+	info, err := fs.Stat(l.fsys, file)
+	if err != nil || !info.ModTime().After(p.modTime) {
+		return p, nil
+	}
+	reloaded, err := l.read(file)
+	if err != nil {
+		// kept at its last good version while the file is being edited
+		logs.Warnf("reload prompt %s failed, using the previous version, err=%v", name, err)
+		return p, nil
+	}
+	logs.Infof("prompt %s reloaded", name)
+	l.prompts[name] = reloaded
+	return reloaded, nil
+}
+
+func (l *Library) read(file string) (*parsed, error) {
+	info, err := fs.Stat(l.fsys, file)
+	if err != nil {
+		return nil, err
+	}
+	data, err := fs.ReadFile(l.fsys, file)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := parse(path.Base(file), string(data))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed{messages: msgs, modTime: info.ModTime()}, nil
+}
+
+// parse splits a prompt file into its messages.
+func parse(file, text string) ([]*message, error) {
+	var (
+		msgs    []*message
+		current *message
+		content []string
+	)
+	flush := func() error {
+		if current == nil || current.placeholder != "" {
+			return nil
+		}
+		body := strings.Trim(strings.Join(content, "\n"), "\n")
+		tpl, err := template.New(file).Option("missingkey=error").Parse(body)
+		if err != nil {
+			return err
+		}
+		current.tpl = tpl
+		return nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if !strings.HasPrefix(line, "--- ") {
+			if current == nil {
+				if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, "#") {
+					return nil, fmt.Errorf("%s:%d: text before the first message", file, n)
+				}
+				continue
+			}
+			if current.placeholder != "" && strings.TrimSpace(line) != "" {
+				return nil, fmt.Errorf("%s:%d: a placeholder has no content", file, n)
+			}
+			content = append(content, line)
+			continue
+		}
+
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		fields := strings.Fields(strings.TrimPrefix(line, "--- "))
+		current, content = &message{}, nil
+		switch {
+		case len(fields) == 1 && (fields[0] == "system" || fields[0] == "user" || fields[0] == "assistant"):
+			current.role = schema.RoleType(fields[0])
+		case len(fields) == 2 && fields[0] == "placeholder":
+			current.placeholder = strings.TrimSuffix(fields[1], "?")
+			current.optional = strings.HasSuffix(fields[1], "?")
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown message %q, use system, user, assistant or placeholder <variable>", file, n, line)
+		}
+		msgs = append(msgs, current)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("%s: no message", file)
+	}
+	return msgs, scanner.Err()
+}
+
+func (p *parsed) format(name string, vars map[string]any) ([]*schema.Message, error) {
+	res := make([]*schema.Message, 0, len(p.messages))
+	for _, m := range p.messages {
+		if m.placeholder != "" {
+			v, ok := vars[m.placeholder]
+			if !ok {
+				if m.optional {
+					continue
+				}
+				return nil, fmt.Errorf("prompt %s: variable %s is missing", name, m.placeholder)
+			}
+			msgs, ok := v.([]*schema.Message)
+			if !ok {
+				return nil, fmt.Errorf("prompt %s: variable %s is a %T, not []*schema.Message", name, m.placeholder, v)
+			}
+			res = append(res, msgs...)
+			continue
+		}
+
+		sb := strings.Builder{}
+		if err := m.tpl.Execute(&sb, vars); err != nil {
+			return nil, fmt.Errorf("prompt %s: %w", name, err)
+		}
+		res = append(res, &schema.Message{Role: m.role, Content: sb.String()})
+	}
+	return res, nil
+}
+
+// Template is a prompt of a Library as a ChatTemplate.
+type Template struct {
+	lib  *Library
+	name string
+}
+
+var _ eprompt.ChatTemplate = (*Template)(nil)
+
+func (t *Template) Format(ctx context.Context, vars map[string]any, _ ...eprompt.Option) ([]*schema.Message, error) {
+	return t.lib.Format(ctx, t.name, vars)
+}
+
+func (t *Template) GetType() string {
+	return "File"
+}
+
+var (
+	defaultOnce sync.Once
+	defaultLib  *Library
+	defaultErr  error
+)
+
+// Default returns the library of the prompts of the repository, read from EINO_PROMPTS_DIR if set, and reloaded if
+// EINO_PROMPTS_RELOAD is true.
+func Default() (*Library, error) {
+	defaultOnce.Do(func() {
+		defaultLib, defaultErr = NewLibrary(&Config{
+			Dir:    config.String("EINO_PROMPTS_DIR", ""),
+			Reload: config.Bool("EINO_PROMPTS_RELOAD", false),
+		})
+	})
+	return defaultLib, defaultErr
+}
+
+// Get returns the prompt name of the default library as a ChatTemplate.
+func Get(name string) (*Template, error) {
+	lib, err := Default()
+	if err != nil {
+		return nil, err
+	}
+	return lib.Template(name)
+}
+
+// MustGet is Get, exiting if the prompt cannot be loaded, for the main of the examples.
+func MustGet(name string) *Template {
+	t, err := Get(name)
+	if err != nil {
+		logs.Fatalf("load prompt %s failed, err=%v", name, err)
+	}
+	return t
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package prompt
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const answerPrompt = `# a comment
+--- system
+Answer from:
+{{.documents}}
+--- placeholder history?
+--- user
+{{.question}}
+`
+
+func TestFormat(t *testing.T) {
+	ctx := context.Background()
+	lib, err := NewLibrary(&Config{FS: fstest.MapFS{"answer.prompt": {Data: []byte(answerPrompt)}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"answer"}, lib.Names())
+
+	msgs, err := lib.Format(ctx, "answer", map[string]any{"documents": "[1] eino", "question": "what is eino?"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*schema.Message{
+		schema.SystemMessage("Answer from:\n[1] eino"),
+		schema.UserMessage("what is eino?"),
+	}, msgs)
+
+	tpl, err := lib.Template("answer")
+	assert.NoError(t, err)
+	msgs, err = tpl.Format(ctx, map[string]any{
+		"documents": "", "question": "and then?",
+		"history": []*schema.Message{schema.UserMessage("what is eino?"), schema.AssistantMessage("a framework", nil)},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, msgs, 4)
+	assert.Equal(t, "a framework", msgs[2].Content)
+
+	_, err = lib.Format(ctx, "answer", map[string]any{"documents": ""})
+	assert.ErrorContains(t, err, "question")
+	_, err = lib.Template("missing")
+	assert.ErrorContains(t, err, `prompt "missing" not found`)
+}
+
+func TestParseErrors(t *testing.T) {
+	for text, want := range map[string]string{
+		"hello\n--- user\nhi":         "text before the first message",
+		"--- tool\nhi":                "unknown message",
+		"--- placeholder history\nhi": "a placeholder has no content",
+		"--- user\n{{.question":       "unclosed action",
+		"# only a comment\n":          "no message",
+	} {
+		_, err := NewLibrary(&Config{FS: fstest.MapFS{"bad.prompt": {Data: []byte(text)}}})
+		assert.ErrorContains(t, err, want, text)
+	}
+}
+
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	file := filepath.Join(dir, "greet.prompt")
+	assert.NoError(t, os.WriteFile(file, []byte("--- user\nhello {{.name}}"), 0644))
+
+	lib, err := NewLibrary(&Config{Dir: dir, Reload: true})
+	assert.NoError(t, err)
+	tpl, err := lib.Template("greet")
+	assert.NoError(t, err)
+
+	// a mod time later than the one read, whatever the resolution of the file system
+	touch := func(text string, after time.Duration) {
+		assert.NoError(t, os.WriteFile(file, []byte(text), 0644))
+		later := time.Now().Add(after)
+		assert.NoError(t, os.Chtimes(file, later, later))
+	}
+	touch("--- user\nhi {{.name}}", time.Hour)
+	msgs, err := tpl.Format(ctx, map[string]any{"name": "eino"})
+	assert.NoError(t, err)
+	assert.Equal(t, "hi eino", msgs[0].Content)
+
+	// a broken edit keeps the last good version
+	touch("--- user\nhi {{.name", 2*time.Hour)
+	msgs, err = tpl.Format(ctx, map[string]any{"name": "eino"})
+	assert.NoError(t, err)
+	assert.Equal(t, "hi eino", msgs[0].Content)
+}
+
+func TestDefaultLibrary(t *testing.T) {
+	lib, err := NewLibrary(nil)
+	assert.NoError(t, err)
+	assert.Contains(t, lib.Names(), "rag_answer")
+}
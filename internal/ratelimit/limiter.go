@@ -0,0 +1,191 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ratelimit keeps the calls of an example within the quotas of the providers, client side, with token buckets
+// of requests and tokens per minute. The chat models and embedders of one provider share a limiter, so that a batch
+// job and an indexing job running in one process do not exceed the quota together:
+//
+//	cm = ratelimit.WrapChatModel(cm, ratelimit.Shared("openai"))
+//	emb = ratelimit.WrapEmbedder(emb, ratelimit.Shared("ark/embedding"))
+//
+// Shared limiters start from the preset of their name, see Presets, and are overridden with
+// EINO_RATELIMIT_<NAME>_RPM and EINO_RATELIMIT_<NAME>_TPM, e.g. EINO_RATELIMIT_ARK_EMBEDDING_TPM for ark/embedding.
+package ratelimit
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// Config is the limits of a Limiter, a zero limit meaning unlimited.
+type Config struct {
+	// RequestsPerMinute is the max number of calls started per minute.
+	RequestsPerMinute int
+	// TokensPerMinute is the max number of tokens, of the prompts and completions, per minute.
+	TokensPerMinute int
+}
+
+// Presets are the limits of the shared limiters, by provider and by provider/embedding for the embedders. They are the
+// limits of the entry tiers of the providers in early 2025, for their usual models; a paid tier has higher ones.
+var Presets = map[string]Config{
+	"openai":           {RequestsPerMinute: 500, TokensPerMinute: 30_000},
+	"openai/embedding": {RequestsPerMinute: 3_000, TokensPerMinute: 1_000_000},
+	"azure":            {RequestsPerMinute: 300, TokensPerMinute: 50_000},
+	"azure/embedding":  {RequestsPerMinute: 720, TokensPerMinute: 120_000},
+	"claude":           {RequestsPerMinute: 50, TokensPerMinute: 40_000},
+	"ark":              {RequestsPerMinute: 1_000, TokensPerMinute: 800_000},
+	"ark/embedding":    {RequestsPerMinute: 1_200, TokensPerMinute: 1_200_000},
+	"deepseek":         {RequestsPerMinute: 60},
+}
+
+// Limiter limits the requests and tokens per minute. It is safe for concurrent use.
+type Limiter struct {
+	name     string
+	requests *bucket
+	tokens   *bucket
+}
+
+// New returns a limiter of config, named name in its logs.
+func New(name string, config *Config) *Limiter {
+	l := &Limiter{name: name}
+	if config == nil {
+		return l
+	}
+	if config.RequestsPerMinute > 0 {
+		l.requests = newBucket(config.RequestsPerMinute, time.Minute)
+	}
+	if config.TokensPerMinute > 0 {
+		l.tokens = newBucket(config.TokensPerMinute, time.Minute)
+	}
+	return l
+}
+
+var (
+	sharedMu sync.Mutex
+	shared   = make(map[string]*Limiter)
+)
+
+// Shared returns the limiter of the process named name, e.g. openai or ark/embedding, created on the first call from
+// the preset of name and the configuration. A name without preset nor configuration is unlimited, e.g. ollama.
+func Shared(name string) *Limiter {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	if l, ok := shared[name]; ok {
+		return l
+	}
+
+	c := Presets[name]
+	key := "EINO_RATELIMIT_" + strings.NewReplacer("/", "_", "-", "_").Replace(strings.ToUpper(name))
+	c.RequestsPerMinute = config.Int(key+"_RPM", c.RequestsPerMinute)
+	c.TokensPerMinute = config.Int(key+"_TPM", c.TokensPerMinute)
+	if c.RequestsPerMinute > 0 || c.TokensPerMinute > 0 {
+		logs.Debugf("rate limit of %s: %d requests and %d tokens per minute", name, c.RequestsPerMinute, c.TokensPerMinute)
+	}
+
+	l := New(name, &c)
+	shared[name] = l
+	return l
+}
+
+// Wait blocks until one request and tokens are available, or ctx is done. tokens is an estimate, corrected with
+// Adjust once the actual usage is known.
+func (l *Limiter) Wait(ctx context.Context, tokens int) error {
+	if l.requests != nil {
+		if err := l.requests.wait(ctx, 1, l.name); err != nil {
+			return err
+		}
+	}
+	if l.tokens != nil && tokens > 0 {
+		return l.tokens.wait(ctx, tokens, l.name)
+	}
+	return nil
+}
+
+// Adjust counts delta more tokens than waited for, or fewer if negative.
+func (l *Limiter) Adjust(delta int) {
+	if l.tokens != nil && delta != 0 {
+		l.tokens.adjust(delta)
+	}
+}
+
+// LimitsTokens reports whether the limiter has a token limit, i.e. whether the tokens are worth estimating.
+func (l *Limiter) LimitsTokens() bool {
+	return l.tokens != nil
+}
+
+// bucket is a token bucket refilled continuously at limit/per, with a burst of limit.
+// The level is allowed to go negative after adjust, later callers wait until the debt is paid.
+type bucket struct {
+	mu       sync.Mutex
+	capacity float64
+	level    float64
+	rate     float64 // tokens per second
+	last     time.Time
+}
+
+func newBucket(limit int, per time.Duration) *bucket {
+	return &bucket{
+		capacity: float64(limit),
+		level:    float64(limit),
+		rate:     float64(limit) / per.Seconds(),
+		last:     time.Now(),
+	}
+}
+
+func (b *bucket) wait(ctx context.Context, n int, name string) error {
+	// a single request larger than the burst could never be served, cap it to a full bucket
+	need := min(float64(n), b.capacity)
+
+	for {
+		b.mu.Lock()
+		b.refill()
+		if b.level >= need {
+			b.level -= need
+			b.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((need - b.level) / b.rate * float64(time.Second))
+		b.mu.Unlock()
+
+		logs.Infof("rate limited by %s, waiting %v", name, delay.Round(time.Millisecond))
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (b *bucket) adjust(delta int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill()
+	b.level = min(b.level-float64(delta), b.capacity)
+}
+
+func (b *bucket) refill() {
+	now := time.Now()
+	b.level = min(b.level+now.Sub(b.last).Seconds()*b.rate, b.capacity)
+	b.last = now
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/gptr"
+)
+
+// ChatModel waits for its Limiter before every call, and corrects the tokens counted with the usage the model reports.
+type ChatModel struct {
+	Model model.ChatModel
+	// EstimateTokens estimates the prompt tokens before a call, default EstimateTokens.
+	EstimateTokens func(input []*schema.Message) int
+
+	limiter *Limiter
+}
+
+// WrapChatModel returns m limited by l.
+func WrapChatModel(m model.ChatModel, l *Limiter) *ChatModel {
+	return &ChatModel{Model: m, EstimateTokens: EstimateTokens, limiter: l}
+}
+
+func (r *ChatModel) Generate(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.Message, error) {
+	reserved, err := r.acquire(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := r.Model.Generate(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r.reconcile(reserved, out)
+	return out, nil
+}
+
+func (r *ChatModel) Stream(ctx context.Context, input []*schema.Message, opts ...model.Option) (*schema.StreamReader[*schema.Message], error) {
+	reserved, err := r.acquire(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	sr, err := r.Model.Stream(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	// usage is usually reported in the last chunk, reconcile only once when it shows up
+	reconciled := false
+	return schema.StreamReaderWithConvert(sr, func(chunk *schema.Message) (*schema.Message, error) {
+		if !reconciled {
+			reconciled = r.reconcile(reserved, chunk)
+		}
+		return chunk, nil
+	}), nil
+}
+
+func (r *ChatModel) BindTools(tools []*schema.ToolInfo) error {
+	return r.Model.BindTools(tools)
+}
+
+// IsCallbacksEnabled keeps the callbacks aspect of the inner ChatModel.
+func (r *ChatModel) IsCallbacksEnabled() bool {
+	checker, ok := r.Model.(components.Checker)
+	if ok {
+		return checker.IsCallbacksEnabled()
+	}
+
+	return false
+}
+
+// acquire waits for one request and the estimated tokens, returns the number of tokens reserved.
+func (r *ChatModel) acquire(ctx context.Context, input []*schema.Message, opts ...model.Option) (int, error) {
+	reserved := 0
+	if r.limiter.LimitsTokens() {
+		reserved = r.EstimateTokens(input) + gptr.Deref(model.GetCommonOptions(&model.Options{}, opts...).MaxTokens, 0)
+	}
+	if err := r.limiter.Wait(ctx, reserved); err != nil {
+		return 0, err
+	}
+	return reserved, nil
+}
+
+// reconcile corrects the limiter with the actual usage once it is known, returns whether it is done.
+func (r *ChatModel) reconcile(reserved int, out *schema.Message) bool {
+	if !r.limiter.LimitsTokens() || out == nil || out.ResponseMeta == nil || out.ResponseMeta.Usage == nil {
+		return false
+	}
+
+	actual := out.ResponseMeta.Usage.TotalTokens
+	if actual <= 0 {
+		return false
+	}
+	r.limiter.Adjust(actual - reserved)
+	return true
+}
+
+// Embedder waits for its Limiter before every request, counting the tokens of the texts by estimation, the
+// embedders not returning their usage.
+type Embedder struct {
+	Embedder embedding.Embedder
+
+	limiter *Limiter
+}
+
+// WrapEmbedder returns e limited by l. A request of EmbedStrings counts once, whatever the number of texts, as the
+// providers count it.
+func WrapEmbedder(e embedding.Embedder, l *Limiter) *Embedder {
+	return &Embedder{Embedder: e, limiter: l}
+}
+
+func (r *Embedder) EmbedStrings(ctx context.Context, texts []string, opts ...embedding.Option) ([][]float64, error) {
+	tokens := 0
+	for _, t := range texts {
+		tokens += estimateText(t)
+	}
+	if err := r.limiter.Wait(ctx, tokens); err != nil {
+		return nil, err
+	}
+	return r.Embedder.EmbedStrings(ctx, texts, opts...)
+}
+
+// IsCallbacksEnabled keeps the callbacks aspect of the inner Embedder.
+func (r *Embedder) IsCallbacksEnabled() bool {
+	checker, ok := r.Embedder.(components.Checker)
+	if ok {
+		return checker.IsCallbacksEnabled()
+	}
+
+	return false
+}
+
+// EstimateTokens is a cheap tokenizer free estimation of the prompt tokens,
+// roughly 4 bytes per token, which is about 4 characters of English or 1.3 characters of CJK.
+func EstimateTokens(input []*schema.Message) int {
+	n := 0
+	for _, msg := range input {
+		// role, separators etc.
+		n += 4
+		n += estimateText(msg.Content)
+		for _, tc := range msg.ToolCalls {
+			n += estimateText(tc.Function.Name + tc.Function.Arguments)
+		}
+	}
+	return n
+}
+
+func estimateText(s string) int {
+	return (len(s) + 3) / 4
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/mock"
+)
+
+func TestUnlimited(t *testing.T) {
+	l := New("test", nil)
+	assert.False(t, l.LimitsTokens())
+	for i := 0; i < 1000; i++ {
+		assert.NoError(t, l.Wait(context.Background(), 1000))
+	}
+}
+
+func TestWaitBlocksOnceTheBurstIsSpent(t *testing.T) {
+	// 600 per minute is one every 100ms, after a burst of 600
+	l := New("test", &Config{RequestsPerMinute: 600})
+	l.requests.level = 1
+
+	assert.NoError(t, l.Wait(context.Background(), 0))
+	start := time.Now()
+	assert.NoError(t, l.Wait(context.Background(), 0))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestWaitReturnsWhenTheContextIsDone(t *testing.T) {
+	l := New("test", &Config{TokensPerMinute: 60})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, l.Wait(ctx, 60))
+	assert.ErrorIs(t, l.Wait(ctx, 30), context.DeadlineExceeded)
+}
+
+func TestAdjustCountsTheActualUsage(t *testing.T) {
+	l := New("test", &Config{TokensPerMinute: 1000})
+	assert.NoError(t, l.Wait(context.Background(), 100))
+	// the call used 400 tokens, not the 100 estimated
+	l.Adjust(300)
+	assert.InDelta(t, 600, l.tokens.level, 1)
+
+	// more than the burst returned is capped to the burst
+	l.Adjust(-5000)
+	assert.InDelta(t, 1000, l.tokens.level, 1)
+}
+
+func TestSharedReadsPresetsAndConfig(t *testing.T) {
+	t.Setenv("EINO_RATELIMIT_OPENAI_EMBEDDING_TPM", "42")
+
+	l := Shared("openai/embedding")
+	assert.Same(t, l, Shared("openai/embedding"))
+	assert.Equal(t, float64(Presets["openai/embedding"].RequestsPerMinute), l.requests.capacity)
+	assert.Equal(t, float64(42), l.tokens.capacity)
+
+	assert.False(t, Shared("ollama").LimitsTokens())
+}
+
+func TestChatModelReconcilesWithUsage(t *testing.T) {
+	msg := schema.AssistantMessage("done", nil)
+	msg.ResponseMeta = &schema.ResponseMeta{Usage: &schema.TokenUsage{TotalTokens: 500}}
+	l := New("test", &Config{TokensPerMinute: 1000})
+	cm := WrapChatModel(mock.NewChatModel(&mock.Response{Message: msg}), l)
+
+	input := []*schema.Message{schema.UserMessage("12345678")}
+	_, err := cm.Generate(context.Background(), input)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, EstimateTokens(input))
+	assert.InDelta(t, 500, l.tokens.level, 1)
+}
+
+func TestEmbedderEstimatesTheTexts(t *testing.T) {
+	l := New("test", &Config{TokensPerMinute: 1000})
+	emb := WrapEmbedder(embedderFunc(func(texts []string) [][]float64 {
+		return make([][]float64, len(texts))
+	}), l)
+
+	vectors, err := emb.EmbedStrings(context.Background(), []string{"12345678", "1234"})
+	assert.NoError(t, err)
+	assert.Len(t, vectors, 2)
+	assert.InDelta(t, 997, l.tokens.level, 1)
+}
+
+type embedderFunc func(texts []string) [][]float64
+
+func (f embedderFunc) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	return f(texts), nil
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+var (
+	arkEmbedding = []string{"ARK_API_KEY", "ARK_EMBEDDING_MODEL"}
+	vikingDB     = []string{"VIKING_DB_HOST", "VIKING_DB_REGION", "VIKING_DB_AK", "VIKING_DB_SK"}
+
+	elasticsearch = Service{Name: "Elasticsearch", Env: "ES_ADDR", Addr: "http://localhost:9200",
+		Start: "docker compose -f components/retriever/es8/docker-compose.yml up -d"}
+	milvus = Service{Name: "Milvus", Env: "MILVUS_ADDR", Addr: "localhost:19530",
+		Start: "docker compose -f components/retriever/milvus/docker-compose.yml up -d"}
+	redisStack = Service{Name: "Redis Stack", Env: "REDIS_ADDR", Addr: "localhost:6379",
+		Start: "docker run -d -p 6379:6379 redis/redis-stack-server:latest"}
+)
+
+func init() {
+	for _, e := range []*Example{
+		// components
+		{Name: "components/document/loader/pdf", Description: "load a PDF and split it page by page, keeping the page numbers to cite"},
+		{Name: "components/document/loader/web", Description: "fetch a web page and extract its main content as a document"},
+		{Name: "components/document/parser/customparser", Description: "write a custom document parser"},
+		{Name: "components/document/parser/extparser", Description: "pick the parser of a file by its extension"},
+		{Name: "components/document/parser/textparser", Description: "parse plain text into a document"},
+		{Name: "components/document/transformer/markdown", Description: "split markdown by headers, keeping the heading path of every chunk"},
+		{Name: "components/document/transformer/recursive", Description: "split text recursively by size with overlap"},
+		{Name: "components/document/transformer/semantic", Description: "split text where the topic changes, by embedding similarity", Env: arkEmbedding},
+		{Name: "components/embedding/batch", Description: "embed a corpus in concurrent batches, with progress and throughput", Env: arkEmbedding},
+		{Name: "components/model/batch", Description: "run a file of prompts through a model with a pool of workers", Model: true},
+		{Name: "components/model/extraction", Description: "extract structured invoices from text and validate them", Model: true},
+		{Name: "components/model/fallback", Description: "fall back from OpenAI to Ark to a local Ollama model on failure"},
+		{Name: "components/model/reasoning", Description: "call a reasoning model with different reasoning efforts", Model: true},
+		{Name: "components/prompt/chat_prompt", Description: "format a chat template with variables and history"},
+		{Name: "components/prompt/fewshot", Description: "classify tickets with few-shot examples picked by similarity", Model: true, Env: arkEmbedding},
+		{Name: "components/prompt/translation", Description: "translate a markdown document paragraph by paragraph", Model: true},
+		{Name: "components/retriever/es8", Description: "index and retrieve documents with Elasticsearch 8", Env: arkEmbedding, Services: []Service{elasticsearch}},
+		{Name: "components/retriever/milvus", Description: "index and retrieve documents with Milvus", Env: arkEmbedding, Services: []Service{milvus}},
+		{Name: "components/retriever/multiquery", Description: "retrieve with several paraphrases of the query", Model: true, Env: vikingDB},
+		{Name: "components/retriever/redis", Description: "index and retrieve documents with Redis Stack", Env: arkEmbedding, Services: []Service{redisStack}},
+		{Name: "components/retriever/router", Description: "route a query to one or several retrievers", Env: vikingDB},
+		{Name: "components/tool/openapi3", Description: "turn the operations of an OpenAPI 3 spec into tools"},
+
+		// compose
+		{Name: "compose/chain", Description: "a chain with a branch and parallel nodes", Model: true},
+		{Name: "compose/graph/branch", Description: "route to a prompt by the intent classified by the model", Model: true},
+		{Name: "compose/graph/chain_of_density", Description: "summarize with denser and denser passes", Model: true},
+		{Name: "compose/graph/checkpoint", Description: "checkpoint a pipeline after every node and resume a failed run", Model: true},
+		{Name: "compose/graph/guardrail", Description: "moderate the input and the output of a model", Model: true},
+		{Name: "compose/graph/interrupt", Description: "interrupt a graph for a human decision and resume it later", Model: true},
+		{Name: "compose/graph/mapreduce", Description: "summarize a long document with map and reduce rounds", Model: true},
+		{Name: "compose/graph/nested_stream", Description: "stream the tokens of a nested model to an HTTP client as SSE", Model: true},
+		{Name: "compose/graph/output_retry", Description: "retry a model with the validation error of its output", Model: true},
+		{Name: "compose/graph/parallel", Description: "run three models in parallel and join their outputs", Model: true},
+		{Name: "compose/graph/refine", Description: "summarize a long document by refining the summary part by part", Model: true},
+		{Name: "compose/graph/replay", Description: "record the inputs of the nodes and replay a run from a node", Model: true},
+		{Name: "compose/graph/simple", Description: "the smallest graph, a template and a mock model"},
+		{Name: "compose/graph/state", Description: "read and write the state of a graph from its nodes"},
+		{Name: "compose/graph/stateful", Description: "an iterative search graph keeping its findings in the state", Model: true, Env: arkEmbedding},
+		{Name: "compose/graph/subgraph", Description: "compose graphs as nodes of another graph", Model: true, Env: arkEmbedding},
+		{Name: "compose/graph/tool_call_agent", Description: "an agent loop built from a model, a tools node and a branch", Model: true},
+		{Name: "compose/graph/tool_call_branch", Description: "branch on the tool calls of a model, streamed or not", Model: true},
+		{Name: "compose/graph/tool_call_once", Description: "call the tools once and answer with their results", Model: true},
+		{Name: "compose/graph/two_model_chat", Description: "a writer and a critic model taking turns on a joke", Model: true},
+		{Name: "compose/stream", Description: "copy, merge, convert and close streams", Model: true},
+		{Name: "compose/workflow/field_mapping", Description: "map the fields of structs between the nodes of a workflow", Model: true},
+
+		// devops
+		{Name: "devops/debug", Description: "debug chains and graphs with the Eino Dev plugin"},
+
+		// eval
+		{Name: "eval/run", Description: "evaluate an agent on a suite of tasks and report the scores", Model: true},
+
+		// flow
+		{Name: "flow/agent/browser_use", Description: "an agent browsing the web with Chrome", Model: true},
+		{Name: "flow/agent/code_interpreter", Description: "an agent writing and running code in a sandbox", Model: true},
+		{Name: "flow/agent/customer_support", Description: "a support agent answering from a help center or escalating", Model: true, Env: arkEmbedding},
+		{Name: "flow/agent/deep_research", Description: "search, take notes and write a cited report", Model: true},
+		{Name: "flow/agent/email_approval", Description: "an agent asking for approval before sending an email", Model: true},
+		{Name: "flow/agent/long_term_memory", Description: "an agent remembering facts about the user across sessions", Model: true, Env: arkEmbedding},
+		{Name: "flow/agent/multiagent/debate", Description: "two models debating a motion for several rounds", Model: true},
+		{Name: "flow/agent/multiagent/host/journal", Description: "a journal assistant whose host routes to write, read and answer specialists", Model: true, Services: []Service{ollama}},
+		{Name: "flow/agent/multiagent/host/math_search", Description: "a host routing to a math or a search specialist", Model: true},
+		{Name: "flow/agent/multiagent/intent_router", Description: "route every turn of a conversation to an agent by intent", Model: true},
+		{Name: "flow/agent/multiagent/plan_execute", Description: "a planner, an executor and a reviser agents", Model: true},
+		{Name: "flow/agent/plan_and_execute", Description: "plan the steps up front and revise the plan on failure", Model: true},
+		{Name: "flow/agent/react", Description: "a ReAct agent with tools", Model: true},
+		{Name: "flow/agent/react_full", Description: "the options of the ReAct agent on a shop assistant", Model: true},
+		{Name: "flow/agent/reflection", Description: "a generator and a critic improving a draft", Model: true},
+		{Name: "flow/agent/sql", Description: "answer questions about a SQLite database with SQL", Model: true},
+		{Name: "flow/agent/text_to_chart", Description: "turn a question about a table into an HTML chart", Model: true},
+		{Name: "flow/agent/tool_selection", Description: "pass only the tools relevant to the question", Model: true, Env: arkEmbedding},
+
+		// quickstart
+		{Name: "quickstart/chat", Description: "chat with a model from a template, with personas and options",
+			Env: []string{"CUSTOM_API_KEY", "CUSTOM_API_URL", "CUSTOM_MODEL_NAME"}},
+		{Name: "quickstart/function_call", Description: "call a tool by hand from the tool calls of a model", Model: true},
+		{Name: "quickstart/todoagent", Description: "an agent managing a todo list with tools", Model: true},
+
+		// rag
+		{Name: "rag", Description: "a complete RAG pipeline in one graph, from loading to answering", Model: true, Env: arkEmbedding},
+		{Name: "rag/agentic", Description: "an agent deciding when and what to retrieve", Model: true, Env: arkEmbedding},
+		{Name: "rag/compression", Description: "keep only the relevant sentences of the retrieved chunks", Model: true, Env: arkEmbedding},
+		{Name: "rag/graphrag", Description: "answer from a knowledge graph extracted from the documents", Model: true, Env: arkEmbedding},
+		{Name: "rag/hybrid", Description: "merge keyword and vector retrieval", Model: true, Env: arkEmbedding},
+		{Name: "rag/ingest", Description: "keep a vector store in sync with a directory of documents", Env: arkEmbedding},
+		{Name: "rag/kb", Description: "a knowledge base with ingest and query commands", Model: true, Env: arkEmbedding},
+		{Name: "rag/multimodal", Description: "retrieve passages and images for a vision model", Model: true, Env: arkEmbedding},
+		{Name: "rag/multiquery", Description: "retrieve with paraphrases of the question", Model: true, Env: arkEmbedding},
+		{Name: "rag/parentdoc", Description: "retrieve small chunks and answer from their parent documents", Model: true, Env: arkEmbedding},
+		{Name: "rag/reorder", Description: "measure how the order of the chunks changes the answers", Model: true, Env: arkEmbedding},
+		{Name: "rag/rewrite", Description: "rewrite follow-up questions before retrieving", Model: true, Env: arkEmbedding},
+	} {
+		Register(e)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// Service is a server an example connects to.
+type Service struct {
+	// Name is the name of the server, e.g. Elasticsearch.
+	Name string
+	// Env is the key of its address, Addr the address when the key is not set, host:port or a URL.
+	Env  string
+	Addr string
+	// Start tells how to start it locally.
+	Start string
+}
+
+// ollama is the server of the chat model with MODEL_PROVIDER=ollama.
+var ollama = Service{Name: "Ollama", Env: "OLLAMA_BASE_URL", Addr: "http://localhost:11434", Start: "ollama serve, then ollama pull <model>"}
+
+// Problem is a requirement of an example which is not met.
+type Problem = validate.Problem
+
+// Preflight checks the requirements of e before it runs: the keys, read from the environment and the .env file by
+// internal/config, and the services, by connecting to them. It returns all the requirements not met, at once.
+func (e *Example) Preflight(ctx context.Context) []Problem {
+	rules := []validate.Rule{validate.Env(e.Env...)}
+	services := e.Services
+	if e.Model {
+		rules = append([]validate.Rule{validate.Model()}, rules...)
+		if strings.EqualFold(config.String("MODEL_PROVIDER", models.ProviderOpenAI), models.ProviderOllama) {
+			services = append([]Service{ollama}, services...)
+		}
+	}
+
+	var problems []Problem
+	for _, r := range rules {
+		problems = append(problems, r()...)
+	}
+	for _, s := range services {
+		if p := checkService(ctx, s); p != nil {
+			problems = append(problems, *p)
+		}
+	}
+	return problems
+}
+
+func checkService(ctx context.Context, s Service) *Problem {
+	addr := s.Addr
+	if s.Env != "" {
+		addr = config.String(s.Env, addr)
+	}
+	hostPort, err := hostPortOf(addr)
+	if err != nil {
+		return &Problem{Key: s.Env, What: fmt.Sprintf("invalid address of %s %q", s.Name, addr),
+			Hint: "set " + s.Env + " to host:port or a URL"}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", hostPort)
+	if err != nil {
+		hint := "start it with " + s.Start
+		if s.Env != "" {
+			hint += ", or set " + s.Env + " to its address"
+		}
+		return &Problem{Key: s.Env, What: fmt.Sprintf("%s is not reachable at %s", s.Name, hostPort), Hint: hint}
+	}
+	_ = conn.Close()
+	return nil
+}
+
+// hostPortOf returns the host:port of addr, a URL or host:port, with the default port of the scheme of a URL.
+func hostPortOf(addr string) (string, error) {
+	if !strings.Contains(addr, "://") {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return "", err
+		}
+		return addr, nil
+	}
+	u, err := url.Parse(addr)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid URL %q", addr)
+	}
+	if u.Port() != "" {
+		return u.Host, nil
+	}
+	port := "80"
+	if u.Scheme == "https" {
+		port = "443"
+	}
+	return net.JoinHostPort(u.Hostname(), port), nil
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package registry lists the examples of the repository, for the launcher in cmd/examples to list and run them by
+// name. An example is named after its directory from the root of the repository, e.g. quickstart/chat; a new example
+// is registered in examples.go next to the others of its directory, with the keys and the servers it needs, which
+// the launcher checks before running it, see Example.Preflight.
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Example is a runnable example, a main package.
+type Example struct {
+	// Name is the directory of the example from the root of the repository, e.g. flow/agent/react.
+	Name string
+	// Description is one line telling what the example shows.
+	Description string
+	// Model is set for the examples creating their chat model with internal/models, which needs the keys of the
+	// provider picked by MODEL_PROVIDER, see models.RequiredEnv.
+	Model bool
+	// Env are the configuration keys the example needs besides those of its model.
+	Env []string
+	// Services are the servers the example connects to.
+	Services []Service
+}
+
+// Group is the top directory of the example, e.g. rag or flow.
+func (e *Example) Group() string {
+	group, _, _ := strings.Cut(e.Name, "/")
+	return group
+}
+
+var (
+	mu       sync.RWMutex
+	examples = make(map[string]*Example)
+)
+
+// Register adds e to the registry. It panics if the name is empty or already registered, as database/sql.Register
+// does, both being mistakes in the code.
+func Register(e *Example) {
+	mu.Lock()
+	defer mu.Unlock()
+	if e == nil || e.Name == "" {
+		panic("registry: register an example without name")
+	}
+	if _, dup := examples[e.Name]; dup {
+		panic("registry: register example " + e.Name + " twice")
+	}
+	examples[e.Name] = e
+}
+
+// All returns the examples sorted by name.
+func All() []*Example {
+	mu.RLock()
+	defer mu.RUnlock()
+	res := make([]*Example, 0, len(examples))
+	for _, e := range examples {
+		res = append(res, e)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// Find returns the example named name, or else the only one whose name ends with /name, e.g. todoagent for
+// quickstart/todoagent. A leading ./ and a trailing / are ignored, so that a directory completed by the shell works.
+func Find(name string) (*Example, error) {
+	name = strings.Trim(strings.TrimPrefix(name, "./"), "/")
+
+	mu.RLock()
+	defer mu.RUnlock()
+	if e, ok := examples[name]; ok {
+		return e, nil
+	}
+
+	var matches []string
+	for n := range examples {
+		if strings.HasSuffix(n, "/"+name) {
+			matches = append(matches, n)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no example named %q, see the list command", name)
+	case 1:
+		return examples[matches[0]], nil
+	default:
+		sort.Strings(matches)
+		return nil, fmt.Errorf("%q is ambiguous, it may be %s", name, strings.Join(matches, ", "))
+	}
+}
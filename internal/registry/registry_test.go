@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"context"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEveryExampleIsRegistered keeps the registry in sync with the main packages of the repository.
+func TestEveryExampleIsRegistered(t *testing.T) {
+	root := filepath.Join("..", "..")
+	mains := map[string]bool{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// eino_assistant is a module of its own, cmd holds the launcher itself
+			switch d.Name() {
+			case ".git", "testdata", "eino_assistant", "cmd":
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(string(data), "\npackage main\n") {
+			dir, _ := filepath.Rel(root, filepath.Dir(path))
+			mains[filepath.ToSlash(dir)] = true
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+
+	registered := map[string]bool{}
+	for _, e := range All() {
+		registered[e.Name] = true
+		assert.True(t, mains[e.Name], "%s is registered but is not a main package", e.Name)
+		assert.NotEmpty(t, e.Description, e.Name)
+	}
+	for dir := range mains {
+		assert.True(t, registered[dir], "%s is a main package, register it in examples.go", dir)
+	}
+}
+
+func TestFind(t *testing.T) {
+	e, err := Find("quickstart/todoagent")
+	assert.NoError(t, err)
+	assert.Equal(t, "quickstart", e.Group())
+
+	e, err = Find("./flow/agent/react_full/")
+	assert.NoError(t, err)
+	assert.Equal(t, "flow/agent/react_full", e.Name)
+
+	e, err = Find("todoagent")
+	assert.NoError(t, err)
+	assert.Equal(t, "quickstart/todoagent", e.Name)
+
+	_, err = Find("multiquery")
+	assert.ErrorContains(t, err, "components/retriever/multiquery, rag/multiquery")
+
+	_, err = Find("nope")
+	assert.ErrorContains(t, err, "no example named")
+}
+
+func TestPreflight(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("MODEL_PROVIDER", "openai")
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("OPENAI_MODEL_NAME", "gpt-4o-mini")
+	t.Setenv("ARK_API_KEY", "")
+	t.Setenv("ARK_API_KEY_FILE", "/run/secrets/ark")
+	t.Setenv("ARK_EMBEDDING_MODEL", "")
+
+	e := &Example{Name: "test", Model: true, Env: arkEmbedding}
+	var what []string
+	for _, p := range e.Preflight(ctx) {
+		what = append(what, p.What)
+		assert.NotEmpty(t, p.Hint)
+	}
+	assert.Equal(t, []string{"OPENAI_API_KEY is not set for MODEL_PROVIDER=openai", "ARK_EMBEDDING_MODEL is not set"}, what)
+
+	t.Setenv("MODEL_PROVIDER", "mock")
+	t.Setenv("ARK_EMBEDDING_MODEL", "doubao-embedding")
+	assert.Empty(t, e.Preflight(ctx))
+}
+
+func TestPreflightServices(t *testing.T) {
+	ctx := context.Background()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	addr := ln.Addr().String()
+
+	t.Setenv("TEST_ADDR", "http://"+addr)
+	e := &Example{Name: "test", Services: []Service{{Name: "Test", Env: "TEST_ADDR", Start: "test serve"}}}
+	assert.Empty(t, e.Preflight(ctx))
+
+	_ = ln.Close()
+	problems := e.Preflight(ctx)
+	if assert.Len(t, problems, 1) {
+		assert.Equal(t, "Test is not reachable at "+addr, problems[0].What)
+		assert.Equal(t, "start it with test serve, or set TEST_ADDR to its address", problems[0].Hint)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package secrets reads the API keys of the examples from where they are kept rather than from a .env file. The value
+// of a key in the configuration, see internal/config, is either the secret itself or a reference to it:
+//
+//	OPENAI_API_KEY=env://CORP_OPENAI_KEY                  another environment variable
+//	OPENAI_API_KEY=file:///run/secrets/openai             the content of a file, trimmed
+//	OPENAI_API_KEY=vault://secret/data/eino/openai#key    the field key of a secret of HashiCorp Vault
+//
+// and OPENAI_API_KEY_FILE=/run/secrets/openai, as Docker and Kubernetes mount secrets, is read when OPENAI_API_KEY is
+// not set. Vault is reached with VAULT_ADDR and VAULT_TOKEN, or the token of vault login in ~/.vault-token, see
+// VaultConfig. Other backends are added with Register.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// ErrNotFound is returned when a key is set nowhere, or a reference points to nothing.
+var ErrNotFound = errors.New("secret not found")
+
+// Resolver returns the secret a reference points to, the part of the reference after scheme://.
+type Resolver interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// ResolverFunc is a function as a Resolver.
+type ResolverFunc func(ctx context.Context, ref string) (string, error)
+
+func (f ResolverFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+var (
+	mu        sync.RWMutex
+	resolvers = map[string]Resolver{
+		"env":   ResolverFunc(resolveEnv),
+		"file":  ResolverFunc(resolveFile),
+		"vault": newLazyVault(),
+	}
+	// cache holds the resolved references, so that a secret is fetched once per run
+	cache sync.Map
+)
+
+// Register makes the references scheme://... resolved by r, replacing the resolver of scheme if any.
+func Register(scheme string, r Resolver) {
+	mu.Lock()
+	defer mu.Unlock()
+	resolvers[scheme] = r
+}
+
+// Resolve returns the secret of value: value itself, unless it is a reference of a registered scheme.
+func Resolve(ctx context.Context, value string) (string, error) {
+	scheme, ref, ok := strings.Cut(value, "://")
+	if !ok {
+		return value, nil
+	}
+	mu.RLock()
+	r, ok := resolvers[scheme]
+	mu.RUnlock()
+	if !ok {
+		// e.g. a URL set as a key by mistake, used as is rather than failing on a secret that is not one
+		return value, nil
+	}
+
+	if v, ok := cache.Load(value); ok {
+		return v.(string), nil
+	}
+	secret, err := r.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("resolve %s secret %s failed: %w", scheme, ref, err)
+	}
+	cache.Store(value, secret)
+	return secret, nil
+}
+
+// Get returns the secret of the configuration key: of its value, or else of the file named by key_FILE. It returns
+// an empty string if neither is set.
+func Get(ctx context.Context, key string) (string, error) {
+	if v := config.String(key, ""); v != "" {
+		return Resolve(ctx, v)
+	}
+	if path := config.String(key+"_FILE", ""); path != "" {
+		return resolveFile(ctx, path)
+	}
+	return "", nil
+}
+
+// MustGet is Get exiting on error, or if the secret is empty.
+func MustGet(ctx context.Context, key string) string {
+	v, err := Get(ctx, key)
+	if err != nil {
+		logs.Fatalf("read secret %s failed, err=%v", key, err)
+	}
+	if v == "" {
+		logs.Fatalf("%s is required, set it, a reference to it, or %s_FILE", key, key)
+	}
+	return v
+}
+
+func resolveEnv(_ context.Context, name string) (string, error) {
+	v, ok := os.LookupEnv(name)
+	if !ok || v == "" {
+		return "", fmt.Errorf("%w: %s is not set", ErrNotFound, name)
+	}
+	return v, nil
+}
+
+func resolveFile(_ context.Context, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s does not exist", ErrNotFound, path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("EINO_TEST_SECRET", "sk-env")
+	file := filepath.Join(t.TempDir(), "key")
+	assert.NoError(t, os.WriteFile(file, []byte("sk-file\n"), 0600))
+
+	for value, want := range map[string]string{
+		"sk-literal":                 "sk-literal",
+		"env://EINO_TEST_SECRET":     "sk-env",
+		"file://" + file:             "sk-file",
+		"https://api.openai.com/v1/": "https://api.openai.com/v1/",
+	} {
+		got, err := Resolve(ctx, value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	_, err := Resolve(ctx, "env://EINO_TEST_MISSING")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = Resolve(ctx, "file:///does/not/exist")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGet(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "key")
+	assert.NoError(t, os.WriteFile(file, []byte("sk-mounted"), 0600))
+
+	t.Setenv("EINO_TEST_API_KEY_FILE", file)
+	got, err := Get(ctx, "EINO_TEST_API_KEY")
+	assert.NoError(t, err)
+	assert.Equal(t, "sk-mounted", got)
+
+	t.Setenv("EINO_TEST_API_KEY", "sk-set")
+	got, err = Get(ctx, "EINO_TEST_API_KEY")
+	assert.NoError(t, err)
+	assert.Equal(t, "sk-set", got)
+
+	got, err = Get(ctx, "EINO_TEST_UNSET_KEY")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestVault(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/eino/openai":
+			fmt.Fprint(w, `{"data":{"data":{"api_key":"sk-v2","org":"eino"},"metadata":{"version":3}}}`)
+		case "/v1/kv/ark":
+			fmt.Fprint(w, `{"data":{"key":"ark-v1"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	v, err := NewVault(&VaultConfig{Address: srv.URL, Token: "s.token"})
+	assert.NoError(t, err)
+
+	got, err := v.Resolve(ctx, "secret/data/eino/openai#api_key")
+	assert.NoError(t, err)
+	assert.Equal(t, "sk-v2", got)
+	got, err = v.Resolve(ctx, "kv/ark")
+	assert.NoError(t, err)
+	assert.Equal(t, "ark-v1", got)
+
+	_, err = v.Resolve(ctx, "secret/data/eino/openai")
+	assert.ErrorContains(t, err, "has 2 fields")
+	_, err = v.Resolve(ctx, "secret/data/eino/openai#missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = v.Resolve(ctx, "secret/data/eino/other#key")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	Register("vault", v)
+	got, err = Resolve(ctx, "vault://kv/ark")
+	assert.NoError(t, err)
+	assert.Equal(t, "ark-v1", got)
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+)
+
+// VaultConfig configures a Vault resolver.
+type VaultConfig struct {
+	// Address is the URL of the Vault server, default VAULT_ADDR.
+	Address string
+	// Token authenticates the requests, default VAULT_TOKEN, or else the content of ~/.vault-token.
+	Token string
+	// Namespace is the namespace of Vault Enterprise, default VAULT_NAMESPACE.
+	Namespace string
+	// Client sends the requests, default a client with a timeout of 10s.
+	Client *http.Client
+}
+
+// Vault resolves the references path#field to the field of the secret at path, of a KV engine of version 1 or 2,
+// e.g. secret/data/eino/openai#api_key. Without #field, the secret must have a single field.
+type Vault struct {
+	address   string
+	token     string
+	namespace string
+	client    *http.Client
+}
+
+// NewVault returns a Vault resolver configured by config, nil for the defaults.
+func NewVault(config *VaultConfig) (*Vault, error) {
+	c := VaultConfig{}
+	if config != nil {
+		c = *config
+	}
+	if c.Address == "" {
+		c.Address = configString("VAULT_ADDR")
+	}
+	if c.Token == "" {
+		c.Token = configString("VAULT_TOKEN")
+	}
+	if c.Token == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			if data, err := os.ReadFile(filepath.Join(home, ".vault-token")); err == nil {
+				c.Token = strings.TrimSpace(string(data))
+			}
+		}
+	}
+	if c.Namespace == "" {
+		c.Namespace = configString("VAULT_NAMESPACE")
+	}
+	if c.Client == nil {
+		c.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	if c.Address == "" {
+		return nil, errors.New("VAULT_ADDR is not set")
+	}
+	if c.Token == "" {
+		return nil, errors.New("VAULT_TOKEN is not set, and there is no ~/.vault-token")
+	}
+	if _, err := url.Parse(c.Address); err != nil {
+		return nil, fmt.Errorf("invalid VAULT_ADDR: %w", err)
+	}
+	return &Vault{
+		address:   strings.TrimSuffix(c.Address, "/"),
+		token:     c.Token,
+		namespace: c.Namespace,
+		client:    c.Client,
+	}, nil
+}
+
+func configString(key string) string {
+	return config.String(key, "")
+}
+
+func (v *Vault) Resolve(ctx context.Context, ref string) (string, error) {
+	path, field, _ := strings.Cut(ref, "#")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.address+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: no secret at %s", ErrNotFound, path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+	if err = json.Unmarshal(body, &secret); err != nil {
+		return "", fmt.Errorf("decode vault response failed: %w", err)
+	}
+	fields := secret.Data
+	// KV version 2 nests the fields with the metadata of the version
+	if inner, ok := fields["data"].(map[string]any); ok {
+		if _, ok := fields["metadata"]; ok {
+			fields = inner
+		}
+	}
+
+	if field == "" {
+		if len(fields) != 1 {
+			return "", fmt.Errorf("the secret at %s has %d fields, name one with %s#<field>", path, len(fields), path)
+		}
+		for _, value := range fields {
+			return fmt.Sprint(value), nil
+		}
+	}
+	value, ok := fields[field]
+	if !ok {
+		return "", fmt.Errorf("%w: no field %s in the secret at %s", ErrNotFound, field, path)
+	}
+	return fmt.Sprint(value), nil
+}
+
+// lazyVault creates the Vault resolver from the configuration on the first vault:// reference, so that VAULT_ADDR is
+// only required when Vault is used.
+type lazyVault struct {
+	once  sync.Once
+	vault *Vault
+	err   error
+}
+
+func newLazyVault() *lazyVault {
+	return &lazyVault{}
+}
+
+func (l *lazyVault) Resolve(ctx context.Context, ref string) (string, error) {
+	l.once.Do(func() {
+		l.vault, l.err = NewVault(nil)
+	})
+	if l.err != nil {
+		return "", l.err
+	}
+	return l.vault.Resolve(ctx, ref)
+}
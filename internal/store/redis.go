@@ -0,0 +1,128 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisConfig configures RedisStore.
+type RedisConfig struct {
+	Client *redis.Client
+	// KeyPrefix is put before every key in Redis, and removed from the keys listed, default "eino:".
+	KeyPrefix string
+}
+
+// RedisStore keeps the values in Redis, which expires them.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+func NewRedisStore(config *RedisConfig) (*RedisStore, error) {
+	if config == nil || config.Client == nil {
+		return nil, fmt.Errorf("redis store needs a client")
+	}
+	prefix := config.KeyPrefix
+	if prefix == "" {
+		prefix = "eino:"
+	}
+	return &RedisStore{client: config.Client, prefix: prefix}, nil
+}
+
+func (r *RedisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := r.client.Get(ctx, r.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s failed: %w", key, err)
+	}
+	return data, nil
+}
+
+func (r *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := r.client.Set(ctx, r.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("set %s failed: %w", key, err)
+	}
+	return nil
+}
+
+func (r *RedisStore) Delete(ctx context.Context, key string) error {
+	if err := r.client.Del(ctx, r.prefix+key).Err(); err != nil {
+		return fmt.Errorf("delete %s failed: %w", key, err)
+	}
+	return nil
+}
+
+// List scans the keys, which takes a few round trips on a large database.
+func (r *RedisStore) List(ctx context.Context, prefix string) ([]string, error) {
+	match := escapeGlob(r.prefix+prefix) + "*"
+	var (
+		keys   []string
+		cursor uint64
+	)
+	for {
+		batch, next, err := r.client.Scan(ctx, cursor, match, 100).Result()
+		if err != nil {
+			return nil, fmt.Errorf("list %s failed: %w", prefix, err)
+		}
+		for _, k := range batch {
+			keys = append(keys, strings.TrimPrefix(k, r.prefix))
+		}
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+	// a key changed during the scan can be returned twice
+	sort.Strings(keys)
+	return dedupSorted(keys), nil
+}
+
+func (r *RedisStore) Close() error {
+	return r.client.Close()
+}
+
+// escapeGlob escapes the special characters of the patterns of SCAN.
+func escapeGlob(s string) string {
+	var sb strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
+
+func dedupSorted(keys []string) []string {
+	res := keys[:0]
+	for i, k := range keys {
+		if i == 0 || k != keys[i-1] {
+			res = append(res, k)
+		}
+	}
+	return res
+}
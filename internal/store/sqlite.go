@@ -0,0 +1,154 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// SQLiteConfig configures SQLiteStore.
+type SQLiteConfig struct {
+	// Path is the database file, created with its directory if needed.
+	Path string
+	// Table is the table of the values, default "kv". Several stores can share a file with their own tables.
+	Table string
+}
+
+// SQLiteStore keeps the values in a table of a local SQLite file, for the state of the examples kept between runs
+// on one machine. It needs cgo. The expired keys are deleted when the store is opened, and ignored until then.
+type SQLiteStore struct {
+	db    *sql.DB
+	table string
+	now   func() time.Time
+}
+
+var tableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func NewSQLiteStore(config *SQLiteConfig) (*SQLiteStore, error) {
+	if config == nil || config.Path == "" {
+		return nil, fmt.Errorf("sqlite store needs a path")
+	}
+	table := config.Table
+	if table == "" {
+		table = "kv"
+	}
+	if !tableName.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name %q", table)
+	}
+	if err := os.MkdirAll(filepath.Dir(config.Path), 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite3", config.Path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s failed: %w", config.Path, err)
+	}
+	s := &SQLiteStore{db: db, table: table, now: time.Now}
+
+	// expires_at is in unix nanoseconds, NULL for the keys which never expire
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS ` + table + ` (
+	key        TEXT PRIMARY KEY,
+	value      BLOB NOT NULL,
+	expires_at INTEGER
+)`)
+	if err == nil {
+		_, err = db.Exec(`DELETE FROM `+table+` WHERE expires_at <= ?`, s.now().UnixNano())
+	}
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("init %s failed: %w", config.Path, err)
+	}
+	return s, nil
+}
+
+func (s *SQLiteStore) Get(ctx context.Context, key string) ([]byte, error) {
+	var value []byte
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM `+s.table+` WHERE key = ? AND (expires_at IS NULL OR expires_at > ?)`,
+		key, s.now().UnixNano()).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s failed: %w", key, err)
+	}
+	return value, nil
+}
+
+func (s *SQLiteStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var expires any
+	if ttl > 0 {
+		expires = s.now().Add(ttl).UnixNano()
+	}
+	if value == nil {
+		value = []byte{}
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT INTO `+s.table+` (key, value, expires_at) VALUES (?, ?, ?)
+ON CONFLICT(key) DO UPDATE SET value = excluded.value, expires_at = excluded.expires_at`, key, value, expires)
+	if err != nil {
+		return fmt.Errorf("set %s failed: %w", key, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Delete(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM `+s.table+` WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("delete %s failed: %w", key, err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) List(ctx context.Context, prefix string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT key FROM `+s.table+
+		` WHERE key LIKE ? ESCAPE '\' AND (expires_at IS NULL OR expires_at > ?) ORDER BY key`,
+		escapeLike(prefix)+"%", s.now().UnixNano())
+	if err != nil {
+		return nil, fmt.Errorf("list %s failed: %w", prefix, err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var k string
+		if err = rows.Scan(&k); err != nil {
+			return nil, err
+		}
+		// LIKE ignores the case of ASCII letters
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	return keys, rows.Err()
+}
+
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
+
+// escapeLike escapes the wildcards of LIKE, with \ as the escape character.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
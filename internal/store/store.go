@@ -0,0 +1,96 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package store is a small key-value store with expiry, for the state the examples keep between runs or share
+// between processes: sessions, memories, checkpoints. It has three backends:
+//
+//	memory://                  in the process, lost when it exits
+//	sqlite://.cache/kv.db      a local file, needs cgo
+//	redis://127.0.0.1:6379/0   shared by every process of a service
+//
+// Open picks the backend from such a URL, so that an example takes its store as a flag.
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrNotFound is returned by Get for a key which was never set, deleted or expired.
+var ErrNotFound = errors.New("key not found")
+
+// Store is implemented by the backends, which are safe for concurrent use.
+type Store interface {
+	// Get returns ErrNotFound if key is not set.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set sets key to value, expiring after ttl, or never if ttl is 0.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes key, deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// List returns the keys starting with prefix, sorted, all of them if prefix is empty.
+	List(ctx context.Context, prefix string) ([]string, error)
+	Close() error
+}
+
+// Open returns the store of url, see the package documentation. The path of a sqlite URL is relative unless it
+// starts with a slash after the scheme, as in sqlite:///var/lib/kv.db.
+func Open(url string) (Store, error) {
+	scheme, rest, ok := strings.Cut(url, "://")
+	if !ok {
+		return nil, fmt.Errorf("invalid store url %q, use memory://, sqlite://<path> or redis://<addr>", url)
+	}
+	switch scheme {
+	case "memory":
+		return NewMemoryStore(), nil
+	case "sqlite":
+		return NewSQLiteStore(&SQLiteConfig{Path: rest})
+	case "redis", "rediss":
+		opts, err := redis.ParseURL(url)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redis url: %w", err)
+		}
+		return NewRedisStore(&RedisConfig{Client: redis.NewClient(opts)})
+	}
+	return nil, fmt.Errorf("unknown store %q, use memory://, sqlite://<path> or redis://<addr>", scheme)
+}
+
+// GetJSON gets key and unmarshals its value into a T.
+func GetJSON[T any](ctx context.Context, s Store, key string) (T, error) {
+	var v T
+	data, err := s.Get(ctx, key)
+	if err != nil {
+		return v, err
+	}
+	if err = json.Unmarshal(data, &v); err != nil {
+		return v, fmt.Errorf("decode %s failed: %w", key, err)
+	}
+	return v, nil
+}
+
+// SetJSON sets key to v marshaled, expiring after ttl.
+func SetJSON(ctx context.Context, s Store, key string, v any, ttl time.Duration) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode %s failed: %w", key, err)
+	}
+	return s.Set(ctx, key, data, ttl)
+}
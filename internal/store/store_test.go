@@ -0,0 +1,147 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// clock is a settable time for the expiry of the memory and SQLite stores.
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+// testStore runs the behavior every backend shares. advance moves the time of the store forward.
+func testStore(t *testing.T, s Store, advance func(time.Duration)) {
+	ctx := context.Background()
+
+	_, err := s.Get(ctx, "session:alice")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, s.Set(ctx, "session:alice", []byte("hello"), 0))
+	assert.NoError(t, s.Set(ctx, "session:bob", []byte("hi"), time.Minute))
+	assert.NoError(t, s.Set(ctx, "sessionx", []byte("other"), 0))
+	assert.NoError(t, s.Set(ctx, "memory:50%_off", []byte("wildcards"), 0))
+
+	v, err := s.Get(ctx, "session:alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(v))
+
+	keys, err := s.List(ctx, "session:")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"session:alice", "session:bob"}, keys)
+	keys, err = s.List(ctx, "memory:50%_")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"memory:50%_off"}, keys)
+
+	assert.NoError(t, s.Set(ctx, "session:alice", []byte("hello again"), 0))
+	v, err = s.Get(ctx, "session:alice")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello again", string(v))
+
+	if advance != nil {
+		advance(2 * time.Minute)
+		_, err = s.Get(ctx, "session:bob")
+		assert.ErrorIs(t, err, ErrNotFound)
+		keys, err = s.List(ctx, "session:")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"session:alice"}, keys)
+	}
+
+	assert.NoError(t, s.Delete(ctx, "session:alice"))
+	assert.NoError(t, s.Delete(ctx, "session:alice"))
+	_, err = s.Get(ctx, "session:alice")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	type session struct {
+		User  string   `json:"user"`
+		Turns []string `json:"turns"`
+	}
+	assert.NoError(t, SetJSON(ctx, s, "session:carol", &session{User: "carol", Turns: []string{"hi"}}, 0))
+	got, err := GetJSON[*session](ctx, s, "session:carol")
+	assert.NoError(t, err)
+	assert.Equal(t, &session{User: "carol", Turns: []string{"hi"}}, got)
+}
+
+func TestMemoryStore(t *testing.T) {
+	c := &clock{t: time.Now()}
+	s := NewMemoryStore()
+	s.now = c.now
+	testStore(t, s, func(d time.Duration) { c.t = c.t.Add(d) })
+}
+
+func TestSQLiteStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kv.db")
+	s, err := NewSQLiteStore(&SQLiteConfig{Path: path})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer s.Close()
+	c := &clock{t: time.Now()}
+	s.now = c.now
+	testStore(t, s, func(d time.Duration) { c.t = c.t.Add(d) })
+
+	// the values outlive the store
+	ctx := context.Background()
+	assert.NoError(t, s.Set(ctx, "kept", []byte("yes"), 0))
+	assert.NoError(t, s.Close())
+	s, err = NewSQLiteStore(&SQLiteConfig{Path: path})
+	if assert.NoError(t, err) {
+		v, err := s.Get(ctx, "kept")
+		assert.NoError(t, err)
+		assert.Equal(t, "yes", string(v))
+	}
+}
+
+// TestRedisStore needs a server, e.g. REDIS_URL=redis://127.0.0.1:6379/15, whose keys under eino:test: are
+// overwritten. Redis expires the keys itself, the expiry is not tested.
+func TestRedisStore(t *testing.T) {
+	url := os.Getenv("REDIS_URL")
+	if url == "" {
+		t.Skip("REDIS_URL not set")
+	}
+	s, err := Open(url)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer s.Close()
+	s.(*RedisStore).prefix = "eino:test:"
+	testStore(t, s, nil)
+}
+
+func TestOpen(t *testing.T) {
+	s, err := Open("memory://")
+	assert.NoError(t, err)
+	assert.IsType(t, &MemoryStore{}, s)
+
+	s, err = Open("sqlite://" + filepath.Join(t.TempDir(), "kv.db"))
+	if assert.NoError(t, err) {
+		assert.IsType(t, &SQLiteStore{}, s)
+		assert.NoError(t, s.Close())
+	}
+
+	_, err = Open("etcd://127.0.0.1:2379")
+	assert.ErrorContains(t, err, "unknown store")
+	_, err = Open(".cache/kv.db")
+	assert.ErrorContains(t, err, "invalid store url")
+}
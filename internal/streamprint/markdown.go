@@ -0,0 +1,206 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package streamprint
+
+import (
+	"io"
+	"strings"
+)
+
+// lineMarkers are the markdown a line can start with, after its indentation.
+var lineMarkers = []string{"```", "#", "- ", "* ", "> "}
+
+// renderer formats markdown as it streams: the start of a line is held until it tells what the line is, a heading, a
+// list item, a quote or a code fence, the rest is written at once, with `code` and **bold** colored. Without rich,
+// the content is written as is.
+type renderer struct {
+	out  io.Writer
+	rich bool
+
+	atLineStart bool
+	// pending is the start of the line held
+	pending string
+	// lineStyle is the color of the whole line, e.g. of a heading
+	lineStyle string
+	// fenceLine is set while the line is a fence, written once complete
+	fenceLine bool
+	inFence   bool
+	inCode    bool
+	inBold    bool
+	// star is a * held to tell a ** from a single one
+	star bool
+}
+
+func (r *renderer) write(s string) {
+	if !r.rich {
+		if s != "" {
+			r.atLineStart = strings.HasSuffix(s, "\n")
+			_, _ = io.WriteString(r.out, s)
+		}
+		return
+	}
+
+	sb := strings.Builder{}
+	for _, c := range s {
+		if c == '\n' {
+			r.endLine(&sb)
+			continue
+		}
+		if r.atLineStart || r.fenceLine {
+			r.pending += string(c)
+			r.resolveLineStart(&sb, false)
+			continue
+		}
+		r.inline(&sb, c)
+	}
+	_, _ = io.WriteString(r.out, sb.String())
+}
+
+// flush writes what is held, at the end of the stream.
+func (r *renderer) flush() {
+	if !r.rich {
+		return
+	}
+	sb := strings.Builder{}
+	if r.pending != "" {
+		r.resolveLineStart(&sb, true)
+	}
+	r.flushStar(&sb)
+	if r.lineStyle != "" || r.inCode || r.inBold {
+		sb.WriteString(colorReset)
+	}
+	r.lineStyle, r.inCode, r.inBold = "", false, false
+	_, _ = io.WriteString(r.out, sb.String())
+}
+
+// newline ends the line written if any.
+func (r *renderer) newline() {
+	if !r.atLineStart || r.pending != "" {
+		_, _ = io.WriteString(r.out, "\n")
+	}
+	r.atLineStart, r.pending = true, ""
+}
+
+func (r *renderer) endLine(sb *strings.Builder) {
+	if r.fenceLine {
+		r.inFence = !r.inFence
+		sb.WriteString(colorGray + r.pending + colorReset)
+	} else if r.pending != "" {
+		r.resolveLineStart(sb, true)
+	}
+	r.flushStar(sb)
+	if r.lineStyle != "" || r.inCode || r.inBold {
+		sb.WriteString(colorReset)
+	}
+	sb.WriteString("\n")
+	r.atLineStart, r.pending, r.fenceLine = true, "", false
+	r.lineStyle, r.inCode, r.inBold = "", false, false
+}
+
+// resolveLineStart writes pending once it tells what the line is, or anyway when final.
+func (r *renderer) resolveLineStart(sb *strings.Builder, final bool) {
+	if r.fenceLine {
+		if final {
+			sb.WriteString(colorGray + r.pending + colorReset)
+			r.pending = ""
+		}
+		return
+	}
+
+	text := strings.TrimLeft(r.pending, " \t")
+	indent := r.pending[:len(r.pending)-len(text)]
+	if !final {
+		if text == "" {
+			return
+		}
+		for _, m := range lineMarkers {
+			if len(text) < len(m) && strings.HasPrefix(m, text) {
+				return
+			}
+		}
+	}
+	r.atLineStart = false
+
+	switch {
+	case strings.HasPrefix(text, "```"):
+		// written whole at its end, with its language
+		r.fenceLine = true
+		return
+	case r.inFence:
+		sb.WriteString(colorGray + r.pending)
+		r.lineStyle = colorGray
+		r.pending = ""
+		return
+	case strings.HasPrefix(text, "#"):
+		r.lineStyle = colorHeading
+		sb.WriteString(indent + colorHeading)
+	case strings.HasPrefix(text, "- "), strings.HasPrefix(text, "* "):
+		sb.WriteString(indent + colorBullet + "•" + colorReset)
+		text = text[1:]
+	case strings.HasPrefix(text, "> "):
+		r.lineStyle = colorGray
+		sb.WriteString(indent + colorGray)
+	default:
+		sb.WriteString(indent)
+	}
+	r.pending = ""
+	for _, c := range text {
+		r.inline(sb, c)
+	}
+}
+
+func (r *renderer) inline(sb *strings.Builder, c rune) {
+	if r.inFence {
+		sb.WriteRune(c)
+		return
+	}
+	if c == '*' && !r.inCode {
+		if r.star {
+			r.star = false
+			r.inBold = !r.inBold
+			r.style(sb)
+		} else {
+			r.star = true
+		}
+		return
+	}
+	r.flushStar(sb)
+	if c == '`' {
+		r.inCode = !r.inCode
+		r.style(sb)
+		return
+	}
+	sb.WriteRune(c)
+}
+
+func (r *renderer) flushStar(sb *strings.Builder) {
+	if r.star {
+		r.star = false
+		sb.WriteByte('*')
+	}
+}
+
+// style switches to the colors of the current state.
+func (r *renderer) style(sb *strings.Builder) {
+	sb.WriteString(colorReset + r.lineStyle)
+	if r.inBold {
+		sb.WriteString(colorBold)
+	}
+	if r.inCode {
+		sb.WriteString(colorCode)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package streamprint renders the message streams of the examples in the terminal: a spinner until the first chunk,
+// the content as it arrives with light markdown formatting, and the tool calls of the message once complete.
+//
+//	msg, err := streamprint.Print(sr)
+//
+// prints the stream and returns its chunks concatenated, for the history of a chat. Off a terminal, with NO_COLOR
+// set, or with EINO_LOG_FORMAT=json, the content is printed as is.
+package streamprint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// Mode selects how a Printer formats the content.
+type Mode int
+
+const (
+	// ModeAuto is ModeRich on a terminal, unless NO_COLOR is set, and ModePlain otherwise.
+	ModeAuto Mode = iota
+	// ModePlain prints the content as it is, without colors nor spinner.
+	ModePlain
+	// ModeRich colors the markdown of the content and the tool calls.
+	ModeRich
+)
+
+const (
+	colorReset   = "\033[0m"
+	colorBold    = "\033[1m"
+	colorHeading = "\033[1;36m"
+	colorBullet  = "\033[36m"
+	colorCode    = "\033[33m"
+	colorGray    = "\033[90m"
+	colorTool    = "\033[35m"
+	clearLine    = "\r\033[K"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Config configures a Printer.
+type Config struct {
+	// Output is written the stream, default stdout, or the token lines of internal/logs with EINO_LOG_FORMAT=json.
+	Output io.Writer
+	// Mode is how the content is formatted, default ModeAuto.
+	Mode Mode
+	// Label is shown next to the spinner, default "thinking".
+	Label string
+}
+
+// Printer prints message streams.
+type Printer struct {
+	out   io.Writer
+	rich  bool
+	spin  bool
+	label string
+}
+
+// New returns a Printer configured by config, nil for the defaults.
+func New(config *Config) *Printer {
+	if config == nil {
+		config = &Config{}
+	}
+	p := &Printer{out: config.Output, label: config.Label}
+	if p.label == "" {
+		p.label = "thinking"
+	}
+
+	tty := false
+	if p.out == nil {
+		if strings.EqualFold(os.Getenv("EINO_LOG_FORMAT"), "json") {
+			// every chunk a JSON line, as the other outputs of the example
+			p.out = writerFunc(func(b []byte) (int, error) {
+				logs.Tokenf("%s", b)
+				return len(b), nil
+			})
+		} else {
+			p.out = os.Stdout
+			tty = isTerminal(os.Stdout)
+		}
+	} else if f, ok := p.out.(*os.File); ok {
+		tty = isTerminal(f)
+	}
+
+	switch config.Mode {
+	case ModeRich:
+		p.rich = true
+	case ModeAuto:
+		p.rich = tty && os.Getenv("NO_COLOR") == ""
+	}
+	// the spinner rewrites its line, which only a terminal does
+	p.spin = p.rich && tty
+	return p
+}
+
+// Print prints sr until its end and returns its chunks concatenated. sr is closed. On an error of the stream, what
+// was printed stays and the error is returned.
+func (p *Printer) Print(sr *schema.StreamReader[*schema.Message]) (*schema.Message, error) {
+	defer sr.Close()
+
+	stop := p.Spin(p.label)
+	r := &renderer{out: p.out, rich: p.rich, atLineStart: true}
+	var chunks []*schema.Message
+	for {
+		chunk, err := sr.Recv()
+		stop()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			r.flush()
+			r.newline()
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+		r.write(chunk.Content)
+	}
+	r.flush()
+	r.newline()
+
+	if len(chunks) == 0 {
+		return nil, errors.New("empty stream")
+	}
+	msg, err := schema.ConcatMessages(chunks)
+	if err != nil {
+		return nil, err
+	}
+	for _, tc := range msg.ToolCalls {
+		p.printf(colorTool, "→ %s(%s)\n", tc.Function.Name, tc.Function.Arguments)
+	}
+	return msg, nil
+}
+
+// Spin shows a spinner with label until stop is called, e.g. while waiting for a model to answer. It shows nothing
+// off a terminal. stop can be called several times.
+func (p *Printer) Spin(label string) (stop func()) {
+	if !p.spin {
+		return func() {}
+	}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		for i := 0; ; i++ {
+			fmt.Fprintf(p.out, "%s%s%s %s%s", clearLine, colorGray, spinnerFrames[i%len(spinnerFrames)], label, colorReset)
+			select {
+			case <-done:
+				fmt.Fprint(p.out, clearLine)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+func (p *Printer) printf(color, format string, args ...any) {
+	if p.rich {
+		fmt.Fprintf(p.out, color+format+colorReset, args...)
+		return
+	}
+	fmt.Fprintf(p.out, format, args...)
+}
+
+var (
+	defaultOnce    sync.Once
+	defaultPrinter *Printer
+)
+
+// Default returns the Printer of the defaults, behind Print and Spin.
+func Default() *Printer {
+	defaultOnce.Do(func() {
+		defaultPrinter = New(nil)
+	})
+	return defaultPrinter
+}
+
+// Print prints sr with the default Printer and returns its chunks concatenated.
+func Print(sr *schema.StreamReader[*schema.Message]) (*schema.Message, error) {
+	return Default().Print(sr)
+}
+
+// Spin shows a spinner with the default Printer until stop is called.
+func Spin(label string) (stop func()) {
+	return Default().Spin(label)
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(b []byte) (int, error) {
+	return f(b)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
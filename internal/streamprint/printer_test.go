@@ -0,0 +1,86 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package streamprint
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/testutil"
+)
+
+const answer = "# Eino\n\nEino is a **Go** framework:\n\n- `compose` builds graphs\n* agents use *tools*\n\n> quoted\n\n```go\n# not a heading\nfmt.Println(\"**\")\n```\ndone"
+
+func streamOf(chunks ...*schema.Message) *schema.StreamReader[*schema.Message] {
+	return schema.StreamReaderFromArray(chunks)
+}
+
+// split cuts text into chunks of n bytes, to cut the markdown anywhere.
+func split(text string, n int) []*schema.Message {
+	var chunks []*schema.Message
+	for len(text) > n {
+		chunks = append(chunks, schema.AssistantMessage(text[:n], nil))
+		text = text[n:]
+	}
+	return append(chunks, schema.AssistantMessage(text, nil))
+}
+
+func TestRichWhateverTheChunks(t *testing.T) {
+	render := func(n int) string {
+		out := &bytes.Buffer{}
+		msg, err := New(&Config{Output: out, Mode: ModeRich}).Print(streamOf(split(answer, n)...))
+		assert.NoError(t, err)
+		assert.Equal(t, answer, msg.Content)
+		return out.String()
+	}
+
+	want := render(len(answer))
+	testutil.AssertGolden(t, "rich", strings.ReplaceAll(want, "\033", "ESC"))
+	for _, n := range []int{1, 2, 3, 5} {
+		assert.Equal(t, want, render(n), "chunks of %d bytes", n)
+	}
+}
+
+func TestPlain(t *testing.T) {
+	out := &bytes.Buffer{}
+	chunks := split(answer, 4)
+	chunks = append(chunks, schema.AssistantMessage("", []schema.ToolCall{{
+		Function: schema.FunctionCall{Name: "search", Arguments: `{"query":"eino"}`},
+	}}))
+
+	msg, err := New(&Config{Output: out}).Print(streamOf(chunks...))
+	assert.NoError(t, err)
+	assert.Len(t, msg.ToolCalls, 1)
+	assert.Equal(t, answer+"\n→ search({\"query\":\"eino\"})\n", out.String())
+}
+
+func TestStreamError(t *testing.T) {
+	out := &bytes.Buffer{}
+	sr, sw := schema.Pipe[*schema.Message](2)
+	sw.Send(schema.AssistantMessage("partial", nil), nil)
+	sw.Send(nil, errors.New("connection reset"))
+	sw.Close()
+
+	_, err := New(&Config{Output: out, Mode: ModePlain}).Print(sr)
+	assert.ErrorContains(t, err, "connection reset")
+	assert.Equal(t, "partial\n", out.String())
+}
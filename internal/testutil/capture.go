@@ -0,0 +1,60 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testutil
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// CaptureOutput runs f and returns what it wrote to os.Stdout, with the lines of internal/logs in between, so that
+// the output of an example compares as it shows in a terminal. The logs are written to os.Stdout again after f.
+//
+// os.Stdout is global: the tests capturing output must not run in parallel.
+func CaptureOutput(t testing.TB, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("create pipe failed: %v", err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	logs.SetOutput(w)
+
+	done := make(chan []byte)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		done <- buf.Bytes()
+	}()
+
+	defer func() {
+		os.Stdout = stdout
+		logs.SetOutput(stdout)
+	}()
+	f()
+
+	_ = w.Close()
+	out := <-done
+	_ = r.Close()
+	return string(out)
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package testutil compares the outputs of the examples with golden files, testdata/<name>.golden next to the test,
+// once what changes from run to run is normalized: times, durations, IDs and colors. After an intended change of
+// output, the golden files are rewritten with
+//
+//	UPDATE_GOLDEN=1 go test ./...
+//
+// or go test -update for the packages importing testutil only.
+package testutil
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files with the outputs of the tests")
+
+// Normalizer replaces what varies between runs in an output with a placeholder.
+type Normalizer func(string) string
+
+// Replace returns a Normalizer replacing the matches of the regular expression pattern with repl, which can refer to
+// the groups as in regexp.Regexp.ReplaceAllString.
+func Replace(pattern, repl string) Normalizer {
+	re := regexp.MustCompile(pattern)
+	return func(s string) string {
+		return re.ReplaceAllString(s, repl)
+	}
+}
+
+var (
+	// ANSI removes the color codes, e.g. of the text format of internal/logs.
+	ANSI = Replace(`\x1b\[[0-9;]*m`, "")
+	// Timestamps replaces RFC 3339 times and the times of internal/logs, such as 2025-03-01 12:00:00.
+	Timestamps = Replace(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})?`, "<TIME>")
+	// Durations replaces Go durations, such as 1.5s or 12ms.
+	Durations = Replace(`\b\d+(\.\d+)?(ns|µs|us|ms|s|m|h)\b`, "<DURATION>")
+	// UUIDs replaces UUIDs, e.g. the IDs of the runs of internal/callbacks.
+	UUIDs = Replace(`\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`, "<UUID>")
+	// IDs replaces the IDs of model providers, such as call_3b9f... or chatcmpl-9x....
+	IDs = Replace(`\b(call|chatcmpl|msg|run|req|resp)[-_][A-Za-z0-9]{6,}\b`, "<ID>")
+)
+
+// Default is applied to every output before the normalizers of the assertion.
+var Default = []Normalizer{ANSI, Timestamps, UUIDs, IDs}
+
+// JSONFields returns a Normalizer replacing the values of the JSON fields named keys, strings or numbers, with
+// "<key>", e.g. a latency recorded in milliseconds.
+func JSONFields(keys ...string) Normalizer {
+	quoted := make([]string, len(keys))
+	for i, k := range keys {
+		quoted[i] = regexp.QuoteMeta(k)
+	}
+	re := regexp.MustCompile(`"(` + strings.Join(quoted, "|") + `)":(\s*)("(\\.|[^"\\])*"|-?\d+(\.\d+)?([eE][-+]?\d+)?)`)
+	return func(s string) string {
+		return re.ReplaceAllString(s, `"$1":$2"<$1>"`)
+	}
+}
+
+// Normalize applies Default then normalizers to s.
+func Normalize(s string, normalizers ...Normalizer) string {
+	for _, n := range Default {
+		s = n(s)
+	}
+	for _, n := range normalizers {
+		s = n(s)
+	}
+	return s
+}
+
+// AssertGolden compares got, normalized, with testdata/<name>.golden, or rewrites the file when updating.
+func AssertGolden(t testing.TB, name, got string, normalizers ...Normalizer) bool {
+	t.Helper()
+	got = Normalize(got, normalizers...)
+	path := filepath.Join("testdata", name+".golden")
+
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("create %s failed: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatalf("write %s failed: %v", path, err)
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Errorf("%s does not exist, create it with UPDATE_GOLDEN=1 go test", path)
+		return false
+	}
+	if err != nil {
+		t.Fatalf("read %s failed: %v", path, err)
+	}
+	return assert.Equal(t, string(want), got, "output differs from %s, rewrite it with UPDATE_GOLDEN=1 go test if intended", path)
+}
+
+// AssertGoldenJSON is AssertGolden of v as indented JSON.
+func AssertGoldenJSON(t testing.TB, name string, v any, normalizers ...Normalizer) bool {
+	t.Helper()
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("marshal %T failed: %v", v, err)
+	}
+	return AssertGolden(t, name, string(data)+"\n", normalizers...)
+}
+
+func updating() bool {
+	return *update || os.Getenv("UPDATE_GOLDEN") != ""
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testutil
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+func TestNormalize(t *testing.T) {
+	in := "\x1b[32m[INFO]\x1b[0m 2025-03-01 12:00:00 run 1b4e28ba-2fa1-11d2-883f-0016d3cca427 started\n" +
+		`{"id":"chatcmpl-9xQ3kLm","tool_call_id":"call_3b9fA2c","at":"2025-03-01T12:00:00.123Z"}` + "\n" +
+		"done in 1.52s, first token after 340ms\n"
+	want := "[INFO] <TIME> run <UUID> started\n" +
+		`{"id":"<ID>","tool_call_id":"<ID>","at":"<TIME>"}` + "\n" +
+		"done in <DURATION>, first token after <DURATION>\n"
+	assert.Equal(t, want, Normalize(in, Durations))
+}
+
+func TestJSONFields(t *testing.T) {
+	in := `{"node":"draft","latency_ms": 1234,"start":"yesterday \"noon\"","tokens":-1.5e3}`
+	want := `{"node":"draft","latency_ms": "<latency_ms>","start":"<start>","tokens":"<tokens>"}`
+	assert.Equal(t, want, JSONFields("latency_ms", "start", "tokens")(in))
+}
+
+func TestAssertGolden(t *testing.T) {
+	out := CaptureOutput(t, func() {
+		logs.Infof("answered at %s", "2025-03-01 12:00:00")
+		fmt.Println("Eino is a framework for LLM applications.")
+	})
+	AssertGolden(t, "capture", out)
+
+	AssertGoldenJSON(t, "json", map[string]any{
+		"node":       "draft_model",
+		"latency_ms": 42,
+	}, JSONFields("latency_ms"))
+}
@@ -0,0 +1,181 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tokens counts the tokens of texts and messages with the tiktoken encodings of the OpenAI models, to keep a
+// prompt within a budget. For the models of the other providers, the counts are a close estimation.
+package tokens
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/pkoukk/tiktoken-go"
+	tiktoken_loader "github.com/pkoukk/tiktoken-go-loader"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// DefaultEncoding is used for the models tiktoken does not know, a good enough estimation for most models.
+const DefaultEncoding = "cl100k_base"
+
+// the overhead of the chat format of OpenAI: every message is wrapped in 3 tokens, a name costs 1 more, and the
+// reply is primed with 3
+const (
+	tokensPerMessage = 3
+	tokensPerName    = 1
+	tokensForReply   = 3
+)
+
+func init() {
+	// the BPE files are embedded, rather than downloaded at run time
+	tiktoken.SetBpeLoader(tiktoken_loader.NewOfflineLoader())
+}
+
+// Counter counts tokens with the encoding of a model.
+type Counter struct {
+	tke *tiktoken.Tiktoken
+}
+
+var (
+	countersMu sync.Mutex
+	counters   = make(map[string]*Counter)
+)
+
+// For returns the counter of modelName, with DefaultEncoding if the model is unknown or empty. The counters are
+// cached, For is cheap to call on every request.
+func For(modelName string) *Counter {
+	countersMu.Lock()
+	defer countersMu.Unlock()
+	if c, ok := counters[modelName]; ok {
+		return c
+	}
+
+	tke, err := tiktoken.EncodingForModel(modelName)
+	if err != nil {
+		if tke, err = tiktoken.GetEncoding(DefaultEncoding); err != nil {
+			logs.Warnf("load encoding %s failed, counting 4 bytes per token, err=%v", DefaultEncoding, err)
+		}
+	}
+	c := &Counter{tke: tke}
+	counters[modelName] = c
+	return c
+}
+
+// Count returns the number of tokens of text.
+func (c *Counter) Count(text string) int {
+	if c.tke == nil {
+		return (len(text) + 3) / 4
+	}
+	return len(c.tke.Encode(text, nil, nil))
+}
+
+// CountMessages returns the number of prompt tokens of messages, following the chat format of OpenAI. The tools
+// bound to the model are not counted.
+func (c *Counter) CountMessages(messages []*schema.Message) int {
+	total := tokensForReply
+	for _, msg := range messages {
+		total += c.countMessage(msg)
+	}
+	return total
+}
+
+func (c *Counter) countMessage(msg *schema.Message) int {
+	n := tokensPerMessage + c.Count(string(msg.Role)) + c.Count(msg.Content)
+	if msg.Name != "" {
+		n += tokensPerName + c.Count(msg.Name)
+	}
+	for _, tc := range msg.ToolCalls {
+		n += c.Count(tc.Function.Name) + c.Count(tc.Function.Arguments)
+	}
+	return n
+}
+
+// TrimToBudget drops the oldest messages of a conversation until it fits in budget tokens. The leading system
+// messages are always kept, and the history is cut before a user message only, so a tool result is never kept
+// without the assistant message calling the tool. If even the last turn does not fit, the system messages and the
+// last turn are returned, over budget.
+func (c *Counter) TrimToBudget(messages []*schema.Message, budget int) []*schema.Message {
+	head := 0
+	for head < len(messages) && messages[head].Role == schema.System {
+		head++
+	}
+
+	// sizes[i] is the size of messages[i:], the head excluded
+	sizes := make([]int, len(messages)+1)
+	for i := len(messages) - 1; i >= head; i-- {
+		sizes[i] = sizes[i+1] + c.countMessage(messages[i])
+	}
+	fixed := c.CountMessages(messages[:head])
+	if fixed+sizes[head] <= budget {
+		return messages
+	}
+
+	last := -1
+	for i := head + 1; i < len(messages); i++ {
+		if messages[i].Role != schema.User {
+			continue
+		}
+		last = i
+		if fixed+sizes[i] <= budget {
+			break
+		}
+	}
+	if last < 0 {
+		// a single turn, nothing to drop
+		return messages
+	}
+
+	res := make([]*schema.Message, 0, head+len(messages)-last)
+	res = append(res, messages[:head]...)
+	return append(res, messages[last:]...)
+}
+
+// TrimText truncates text to its first budget tokens.
+func (c *Counter) TrimText(text string, budget int) string {
+	if c.tke == nil {
+		if len(text) <= budget*4 {
+			return text
+		}
+		return strings.ToValidUTF8(text[:budget*4], "")
+	}
+	ids := c.tke.Encode(text, nil, nil)
+	if len(ids) <= budget {
+		return text
+	}
+	// a token can hold a part of a multi-byte character, dropped with the end of the text
+	return strings.ToValidUTF8(c.tke.Decode(ids[:budget]), "")
+}
+
+// Count returns the number of tokens of text with DefaultEncoding.
+func Count(text string) int {
+	return For("").Count(text)
+}
+
+// CountMessages returns the number of prompt tokens of messages with DefaultEncoding, see Counter.CountMessages.
+func CountMessages(messages []*schema.Message) int {
+	return For("").CountMessages(messages)
+}
+
+// TrimToBudget is Counter.TrimToBudget with DefaultEncoding.
+func TrimToBudget(messages []*schema.Message, budget int) []*schema.Message {
+	return For("").TrimToBudget(messages, budget)
+}
+
+// TrimText is Counter.TrimText with DefaultEncoding.
+func TrimText(text string, budget int) string {
+	return For("").TrimText(text, budget)
+}
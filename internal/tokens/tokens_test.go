@@ -0,0 +1,70 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tokens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountMessages(t *testing.T) {
+	c := For("gpt-4o-mini")
+	assert.Same(t, c, For("gpt-4o-mini"))
+
+	msgs := []*schema.Message{schema.SystemMessage("You are helpful."), schema.UserMessage("Hello there")}
+	want := tokensForReply
+	for _, m := range msgs {
+		want += tokensPerMessage + c.Count(string(m.Role)) + c.Count(m.Content)
+	}
+	assert.Equal(t, want, c.CountMessages(msgs))
+	assert.Equal(t, tokensForReply, c.CountMessages(nil))
+}
+
+func TestTrimToBudget(t *testing.T) {
+	c := For("")
+	system := schema.SystemMessage("You are helpful.")
+	turn1 := []*schema.Message{
+		schema.UserMessage("What is the weather in Paris?"),
+		schema.AssistantMessage("", []schema.ToolCall{{ID: "call_1", Function: schema.FunctionCall{Name: "weather", Arguments: `{"city":"Paris"}`}}}),
+		schema.ToolMessage("sunny, 24 degrees", "call_1"),
+		schema.AssistantMessage("It is sunny and 24 degrees in Paris.", nil),
+	}
+	turn2 := []*schema.Message{
+		schema.UserMessage("And in Berlin?"),
+		schema.AssistantMessage("Cloudy, 18 degrees.", nil),
+	}
+	history := append(append([]*schema.Message{system}, turn1...), turn2...)
+	withoutTurn1 := append([]*schema.Message{system}, turn2...)
+
+	assert.Equal(t, history, c.TrimToBudget(history, c.CountMessages(history)))
+	// one token short, the whole first turn goes, not only its user message
+	assert.Equal(t, withoutTurn1, c.TrimToBudget(history, c.CountMessages(history)-1))
+	assert.Equal(t, withoutTurn1, c.TrimToBudget(history, c.CountMessages(withoutTurn1)))
+	// the last turn is kept even over budget
+	assert.Equal(t, withoutTurn1, c.TrimToBudget(history, 1))
+}
+
+func TestTrimText(t *testing.T) {
+	text := strings.Repeat("Eino is a framework for LLM applications. ", 50)
+	trimmed := TrimText(text, 20)
+	assert.True(t, strings.HasPrefix(text, trimmed))
+	assert.LessOrEqual(t, Count(trimmed), 20)
+	assert.Equal(t, "short", TrimText("short", 20))
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/schema"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	eerrors "github.com/cloudwego/eino-examples/internal/errors"
+)
+
+// maxAttrLen truncates the long attributes, such as the arguments of a tool.
+const maxAttrLen = 1024
+
+// Handler turns the runs of the components into spans named after the component and its name, e.g.
+// "ChatModel OpenAI" or "Graph BlogPost", with the attributes:
+//
+//	eino.component, eino.type, eino.name   the RunInfo of the run
+//	gen_ai.request.model                   the model of a chat model, when it reports it
+//	gen_ai.usage.input_tokens              the prompt tokens of a chat model
+//	gen_ai.usage.output_tokens             the completion tokens of a chat model
+//	eino.tool.arguments                    the arguments of a tool, truncated
+//	eino.error.kind                        the kind of the error of a failed run, see internal/errors
+type Handler struct {
+	tracer trace.Tracer
+	wg     sync.WaitGroup
+}
+
+// NewHandler returns a handler starting its spans with tracer.
+func NewHandler(tracer trace.Tracer) *Handler {
+	return &Handler{tracer: tracer}
+}
+
+// Wait waits for the pending streams to be drained and their spans ended.
+func (h *Handler) Wait() {
+	h.wg.Wait()
+}
+
+// Handler returns the callback handler to be passed to compose.WithCallbacks or callbacks.InitCallbacks.
+func (h *Handler) Handler() callbacks.Handler {
+	return callbacks.NewHandlerBuilder().
+		OnStartFn(func(ctx context.Context, info *callbacks.RunInfo, input callbacks.CallbackInput) context.Context {
+			ctx, span := h.start(ctx, info)
+			span.SetAttributes(inputAttributes(info, input)...)
+			return ctx
+		}).
+		OnStartWithStreamInputFn(func(ctx context.Context, info *callbacks.RunInfo,
+			input *schema.StreamReader[callbacks.CallbackInput]) context.Context {
+
+			input.Close()
+			ctx, _ = h.start(ctx, info)
+			return ctx
+		}).
+		OnEndFn(func(ctx context.Context, info *callbacks.RunInfo, output callbacks.CallbackOutput) context.Context {
+			if span, ok := spanOf(ctx); ok {
+				span.SetAttributes(outputAttributes(info, output)...)
+				span.End()
+			}
+			return ctx
+		}).
+		OnEndWithStreamOutputFn(func(ctx context.Context, info *callbacks.RunInfo,
+			output *schema.StreamReader[callbacks.CallbackOutput]) context.Context {
+
+			span, ok := spanOf(ctx)
+			if !ok {
+				output.Close()
+				return ctx
+			}
+			h.wg.Add(1)
+			go func() {
+				defer h.wg.Done()
+				defer output.Close()
+				defer span.End()
+				for {
+					frame, err := output.Recv()
+					if errors.Is(err, io.EOF) {
+						return
+					}
+					if err != nil {
+						recordError(span, err)
+						return
+					}
+					span.SetAttributes(outputAttributes(info, frame)...)
+				}
+			}()
+			return ctx
+		}).
+		OnErrorFn(func(ctx context.Context, info *callbacks.RunInfo, err error) context.Context {
+			if span, ok := spanOf(ctx); ok {
+				recordError(span, err)
+				span.End()
+			}
+			return ctx
+		}).
+		Build()
+}
+
+type spanKey struct{}
+
+// start starts the span of a run, child of the span in ctx. The span is also kept under a key of its own, so that a
+// run whose start was not seen does not end the span of its parent.
+func (h *Handler) start(ctx context.Context, info *callbacks.RunInfo) (context.Context, trace.Span) {
+	ctx, span := h.tracer.Start(ctx, spanName(info), trace.WithAttributes(
+		attribute.String("eino.component", string(info.Component)),
+		attribute.String("eino.type", info.Type),
+		attribute.String("eino.name", info.Name),
+	))
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func spanOf(ctx context.Context) (trace.Span, bool) {
+	span, ok := ctx.Value(spanKey{}).(trace.Span)
+	return span, ok
+}
+
+func spanName(info *callbacks.RunInfo) string {
+	if info == nil {
+		return "unknown"
+	}
+	name := info.Name
+	if name == "" {
+		name = info.Type
+	}
+	if name == "" || name == string(info.Component) {
+		return string(info.Component)
+	}
+	return string(info.Component) + " " + name
+}
+
+func inputAttributes(info *callbacks.RunInfo, input callbacks.CallbackInput) []attribute.KeyValue {
+	switch info.Component {
+	case components.ComponentOfChatModel:
+		if in := model.ConvCallbackInput(input); in != nil && in.Config != nil && in.Config.Model != "" {
+			return []attribute.KeyValue{attribute.String("gen_ai.request.model", in.Config.Model)}
+		}
+	case components.ComponentOfTool:
+		if in := tool.ConvCallbackInput(input); in != nil {
+			return []attribute.KeyValue{attribute.String("eino.tool.arguments", truncate(in.ArgumentsInJSON))}
+		}
+	}
+	return nil
+}
+
+func outputAttributes(info *callbacks.RunInfo, output callbacks.CallbackOutput) []attribute.KeyValue {
+	if info.Component != components.ComponentOfChatModel {
+		return nil
+	}
+	out := model.ConvCallbackOutput(output)
+	if out == nil {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	if out.Config != nil && out.Config.Model != "" {
+		attrs = append(attrs, attribute.String("gen_ai.request.model", out.Config.Model))
+	}
+	usage := out.TokenUsage
+	if usage == nil && out.Message != nil && out.Message.ResponseMeta != nil && out.Message.ResponseMeta.Usage != nil {
+		u := out.Message.ResponseMeta.Usage
+		usage = &model.TokenUsage{PromptTokens: u.PromptTokens, CompletionTokens: u.CompletionTokens}
+	}
+	if usage != nil {
+		attrs = append(attrs,
+			attribute.Int("gen_ai.usage.input_tokens", usage.PromptTokens),
+			attribute.Int("gen_ai.usage.output_tokens", usage.CompletionTokens))
+	}
+	return attrs
+}
+
+func recordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	span.SetAttributes(attribute.String("eino.error.kind", string(eerrors.KindOf(err))))
+}
+
+func truncate(s string) string {
+	if runes := []rune(s); len(runes) > maxAttrLen {
+		return string(runes[:maxAttrLen]) + "…"
+	}
+	return s
+}
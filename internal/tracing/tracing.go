@@ -0,0 +1,153 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tracing exports the runs of the graphs, chains and components of an example as OpenTelemetry spans, to
+// Jaeger, Tempo or any collector receiving OTLP. Two lines opt an example in:
+//
+//	shutdown := tracing.MustSetup(ctx, nil)
+//	defer shutdown(ctx)
+//
+// Every run is a span, child of the span of the graph or agent running it, with the model and the token usage of
+// the chat models, the arguments of the tools and the kind of the error of a failed run as attributes, see Handler.
+//
+// The spans are sent to OTEL_EXPORTER_OTLP_ENDPOINT over gRPC, e.g. http://localhost:4317 for a local Jaeger. Without
+// endpoint Setup does nothing, so that the examples run as before.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/eino/callbacks"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// instrumentationName is the name of the tracer of the spans.
+const instrumentationName = "github.com/cloudwego/eino-examples/internal/tracing"
+
+// Config configures Setup, the zero values reading the standard OpenTelemetry variables.
+type Config struct {
+	// ServiceName names the example in the traces, default OTEL_SERVICE_NAME or the name of the program.
+	ServiceName string
+	// Endpoint is the collector, host:port or a URL, default OTEL_EXPORTER_OTLP_ENDPOINT. The connection is
+	// insecure unless the URL is https.
+	Endpoint string
+	// SampleRatio is the ratio of the traces exported, default 1. A trace started by a caller, e.g. of an HTTP
+	// request, keeps the decision of the caller.
+	SampleRatio float64
+	// Exporter replaces the OTLP exporter, e.g. with a stdout exporter, or with an in memory one in tests.
+	Exporter sdktrace.SpanExporter
+}
+
+// Shutdown flushes the spans not exported yet and stops the exporter.
+type Shutdown func(ctx context.Context) error
+
+// Setup installs an OpenTelemetry tracer provider exporting to the collector of config, nil for the defaults, and a
+// global callback handler turning every run into a span. It replaces the handlers set before with
+// callbacks.InitCallbackHandlers. Without endpoint nor exporter it does nothing.
+func Setup(ctx context.Context, config *Config) (Shutdown, error) {
+	c := withDefaults(config)
+	if c.Exporter == nil && c.Endpoint == "" {
+		logs.Debugf("tracing disabled, set OTEL_EXPORTER_OTLP_ENDPOINT to export spans")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter := c.Exporter
+	if exporter == nil {
+		var err error
+		if exporter, err = otlptracegrpc.New(ctx, grpcOptions(c.Endpoint)...); err != nil {
+			return nil, fmt.Errorf("create otlp exporter failed: %w", err)
+		}
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", c.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("create resource failed: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	h := NewHandler(tp.Tracer(instrumentationName))
+	callbacks.InitCallbackHandlers([]callbacks.Handler{h.Handler()})
+	if c.Endpoint != "" {
+		logs.Infof("tracing %s to %s", c.ServiceName, c.Endpoint)
+	}
+
+	return func(ctx context.Context) error {
+		// the spans of the streams end once drained, wait for them before the last flush
+		h.Wait()
+		return tp.Shutdown(ctx)
+	}, nil
+}
+
+// MustSetup is Setup exiting on error. The returned Shutdown logs its error rather than returning it, to be deferred
+// as is.
+func MustSetup(ctx context.Context, config *Config) Shutdown {
+	shutdown, err := Setup(ctx, config)
+	if err != nil {
+		logs.Fatalf("setup tracing failed, err=%v", err)
+	}
+	return func(ctx context.Context) error {
+		if err := shutdown(ctx); err != nil {
+			logs.Warnf("shutdown tracing failed, err=%v", err)
+		}
+		return nil
+	}
+}
+
+func withDefaults(c *Config) *Config {
+	res := &Config{}
+	if c != nil {
+		*res = *c
+	}
+	if res.ServiceName == "" {
+		res.ServiceName = config.String("OTEL_SERVICE_NAME", strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe"))
+	}
+	if res.Endpoint == "" {
+		res.Endpoint = config.String("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", config.String("OTEL_EXPORTER_OTLP_ENDPOINT", ""))
+	}
+	if res.SampleRatio <= 0 {
+		res.SampleRatio = 1
+	}
+	return res
+}
+
+// grpcOptions connects to endpoint, insecure unless it is an https URL.
+func grpcOptions(endpoint string) []otlptracegrpc.Option {
+	if strings.HasPrefix(endpoint, "https://") {
+		return []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(strings.TrimPrefix(endpoint, "https://"))}
+	}
+	return []otlptracegrpc.Option{
+		otlptracegrpc.WithEndpoint(strings.TrimPrefix(endpoint, "http://")),
+		otlptracegrpc.WithInsecure(),
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/cloudwego/eino-examples/internal/mock"
+)
+
+// keptExporter keeps the spans on shutdown, which the in memory exporter forgets.
+type keptExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+func (keptExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func setup(t *testing.T) (*tracetest.InMemoryExporter, Shutdown) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	shutdown, err := Setup(context.Background(), &Config{ServiceName: "test", Exporter: keptExporter{exporter}})
+	assert.NoError(t, err)
+	t.Cleanup(func() { callbacks.InitCallbackHandlers(nil) })
+	return exporter, shutdown
+}
+
+func TestSetupWithoutEndpointDoesNothing(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	shutdown, err := Setup(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestSpansOfAChain(t *testing.T) {
+	ctx := context.Background()
+	exporter, shutdown := setup(t)
+
+	reply := schema.AssistantMessage("hi", nil)
+	reply.ResponseMeta = &schema.ResponseMeta{Usage: &schema.TokenUsage{PromptTokens: 12, CompletionTokens: 3}}
+	cm := mock.NewChatModel(&mock.Response{Message: reply}, &mock.Response{Message: reply})
+	runner, err := compose.NewChain[[]*schema.Message, *schema.Message]().
+		AppendChatModel(cm).
+		Compile(ctx, compose.WithGraphName("Greeter"))
+	assert.NoError(t, err)
+
+	_, err = runner.Invoke(ctx, []*schema.Message{schema.UserMessage("hello")})
+	assert.NoError(t, err)
+	sr, err := runner.Stream(ctx, []*schema.Message{schema.UserMessage("hello")})
+	assert.NoError(t, err)
+	sr.Close()
+	assert.NoError(t, shutdown(ctx))
+
+	spans := exporter.GetSpans()
+	byName := map[string][]tracetest.SpanStub{}
+	for _, s := range spans {
+		byName[s.Name] = append(byName[s.Name], s)
+	}
+	if assert.Len(t, byName["Chain Greeter"], 2) && assert.Len(t, byName["ChatModel Mock"], 2) {
+		graph, model := byName["Chain Greeter"][0], byName["ChatModel Mock"][0]
+		assert.Equal(t, graph.SpanContext.TraceID(), model.SpanContext.TraceID())
+		assert.Equal(t, graph.SpanContext.SpanID(), model.Parent.SpanID())
+
+		attrs := map[string]any{}
+		for _, kv := range model.Attributes {
+			attrs[string(kv.Key)] = kv.Value.AsInterface()
+		}
+		assert.Equal(t, "ChatModel", attrs["eino.component"])
+		assert.Equal(t, int64(12), attrs["gen_ai.usage.input_tokens"])
+		assert.Equal(t, int64(3), attrs["gen_ai.usage.output_tokens"])
+	}
+}
+
+func TestFailedRunIsAnErrorSpan(t *testing.T) {
+	ctx := context.Background()
+	exporter, shutdown := setup(t)
+
+	cm := mock.NewChatModel(mock.Fail(errors.New("429 Too Many Requests")))
+	runner, err := compose.NewChain[[]*schema.Message, *schema.Message]().AppendChatModel(cm).Compile(ctx)
+	assert.NoError(t, err)
+	_, err = runner.Invoke(ctx, []*schema.Message{schema.UserMessage("hello")})
+	assert.Error(t, err)
+	assert.NoError(t, shutdown(ctx))
+
+	var found bool
+	for _, s := range exporter.GetSpans() {
+		if s.Name != "ChatModel Mock" {
+			continue
+		}
+		found = true
+		assert.Equal(t, codes.Error, s.Status.Code)
+		assert.Contains(t, s.Attributes, attribute.String("eino.error.kind", "rate_limit"))
+	}
+	assert.True(t, found)
+}
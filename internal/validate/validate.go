@@ -0,0 +1,213 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package validate checks the configuration of an example on startup, and reports everything missing or invalid at
+// once with how to fix it, rather than the first missing key only, or a cryptic failure of the first request:
+//
+//	validate.Must(
+//		validate.Model(),
+//		validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"),
+//		validate.URL("OPENAI_BASE_URL"),
+//	)
+//
+// The keys are read with internal/config, so from the environment, and from .env and the YAML file once config.Init
+// is called; validate after it.
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+)
+
+// Problem is a key missing or invalid, or any requirement not met.
+type Problem struct {
+	// Key is the configuration key at fault, if any.
+	Key string
+	// What is wrong, e.g. "OPENAI_API_KEY is not set".
+	What string
+	// Hint tells how to fix it.
+	Hint string
+}
+
+func (p Problem) String() string {
+	if p.Hint == "" {
+		return p.What
+	}
+	return p.What + ": " + p.Hint
+}
+
+// Error is the problems found by Check.
+type Error struct {
+	Problems []Problem
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+	sb.WriteString("invalid configuration:")
+	for _, p := range e.Problems {
+		sb.WriteString("\n  ✗ " + p.What)
+		if p.Hint != "" {
+			sb.WriteString("\n    " + p.Hint)
+		}
+	}
+	return sb.String()
+}
+
+// Rule checks one or several keys.
+type Rule func() []Problem
+
+// Check runs all the rules and returns an *Error listing all their problems, nil if none.
+func Check(rules ...Rule) error {
+	var problems []Problem
+	for _, r := range rules {
+		problems = append(problems, r()...)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return &Error{Problems: problems}
+}
+
+// Must is Check exiting with the list of the problems, the one line at the top of the main of an example.
+func Must(rules ...Rule) {
+	if err := Check(rules...); err != nil {
+		logs.Fatalf("%v", err)
+	}
+}
+
+// Env requires keys to be set, with the hints of Hint.
+func Env(keys ...string) Rule {
+	return func() []Problem {
+		var problems []Problem
+		for _, k := range keys {
+			if !IsSet(k) {
+				problems = append(problems, Problem{Key: k, What: k + " is not set", Hint: Hint(k)})
+			}
+		}
+		return problems
+	}
+}
+
+// Required requires key to be set, with hint rather than the one of Hint.
+func Required(key, hint string) Rule {
+	return func() []Problem {
+		if IsSet(key) {
+			return nil
+		}
+		return []Problem{{Key: key, What: key + " is not set", Hint: hint}}
+	}
+}
+
+// Model requires the keys of the chat model of MODEL_PROVIDER, for the examples creating it with internal/models,
+// see models.RequiredEnv.
+func Model() Rule {
+	return ModelOf("")
+}
+
+// ModelOf is Model for provider, e.g. given by a flag, MODEL_PROVIDER if empty.
+func ModelOf(provider string) Rule {
+	return func() []Problem {
+		p, source := provider, "provider"
+		if p == "" {
+			p, source = config.String("MODEL_PROVIDER", models.ProviderOpenAI), "MODEL_PROVIDER"
+		}
+		p = strings.ToLower(p)
+		if !slices.Contains(models.Providers, p) {
+			return []Problem{{Key: "MODEL_PROVIDER", What: fmt.Sprintf("%s=%q is not supported", source, p),
+				Hint: "use one of " + strings.Join(models.Providers, ", ")}}
+		}
+
+		var problems []Problem
+		for _, k := range models.RequiredEnv(p) {
+			if !IsSet(k) {
+				problems = append(problems, Problem{
+					Key:  k,
+					What: fmt.Sprintf("%s is not set for %s=%s", k, source, p),
+					Hint: Hint(k) + "; or pick another provider, mock runs without any",
+				})
+			}
+		}
+		return problems
+	}
+}
+
+// URL requires key, if set, to be an http or https URL.
+func URL(key string) Rule {
+	return func() []Problem {
+		v := config.String(key, "")
+		if v == "" {
+			return nil
+		}
+		u, err := url.Parse(v)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return []Problem{{Key: key, What: fmt.Sprintf("%s=%q is not a URL", key, v),
+				Hint: "expect something like https://api.example.com/v1"}}
+		}
+		return nil
+	}
+}
+
+// OneOf requires key, if set, to be one of values, whatever the case.
+func OneOf(key string, values ...string) Rule {
+	return func() []Problem {
+		v := config.String(key, "")
+		if v == "" || slices.ContainsFunc(values, func(s string) bool { return strings.EqualFold(s, v) }) {
+			return nil
+		}
+		return []Problem{{Key: key, What: fmt.Sprintf("%s=%q is not supported", key, v),
+			Hint: "use one of " + strings.Join(values, ", ")}}
+	}
+}
+
+// IsSet reports whether key, or key_FILE holding it as internal/secrets reads it, is set.
+func IsSet(key string) bool {
+	return config.String(key, "") != "" || config.String(key+"_FILE", "") != ""
+}
+
+// hints tell where to find the values of the keys.
+var hints = map[string]string{
+	"OPENAI_API_KEY":              "create one at https://platform.openai.com/api-keys",
+	"OPENAI_MODEL_NAME":           "the model to call, e.g. gpt-4o-mini",
+	"OPENAI_REASONING_MODEL_NAME": "a reasoning model, e.g. o3-mini",
+	"ANTHROPIC_API_KEY":           "create one at https://console.anthropic.com/settings/keys",
+	"CLAUDE_MODEL_NAME":           "the model to call, e.g. claude-3-5-sonnet-latest",
+	"AZURE_OPENAI_API_KEY":        "the key of the resource, under Keys and Endpoint in the Azure portal",
+	"AZURE_OPENAI_ENDPOINT":       "the endpoint of the resource, e.g. https://my-resource.openai.azure.com",
+	"AZURE_OPENAI_DEPLOYMENT":     "the name of the deployment of the model in the resource",
+	"ARK_API_KEY":                 "create one in the Ark console, https://console.volcengine.com/ark",
+	"ARK_MODEL_ID":                "the ID of a model or of an endpoint in the Ark console",
+	"ARK_EMBEDDING_MODEL":         "the ID of an embedding model or of its endpoint in the Ark console, e.g. doubao-embedding",
+	"DEEPSEEK_API_KEY":            "create one at https://platform.deepseek.com/api_keys",
+	"DEEPSEEK_MODEL_ID":           "the model to call, e.g. deepseek-chat",
+	"VIKING_DB_HOST":              "the host of the VikingDB instance, with VIKING_DB_REGION, VIKING_DB_AK and VIKING_DB_SK",
+	"CUSTOM_API_KEY":              "the key of the OpenAI compatible service of quickstart/chat, sent in the api-key header",
+	"CUSTOM_API_URL":              "the base URL of the OpenAI compatible service, e.g. https://my-resource.openai.azure.com/v1",
+	"CUSTOM_MODEL_NAME":           "the model to call on the service, e.g. gpt-4o-mini",
+}
+
+// Hint tells where to find the value of key, or else how to set it.
+func Hint(key string) string {
+	if h, ok := hints[key]; ok {
+		return h
+	}
+	return "set it in the environment, or in the .env file at the root of the repository"
+}
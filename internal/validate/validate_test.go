@@ -0,0 +1,66 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckReportsAllProblems(t *testing.T) {
+	t.Setenv("MODEL_PROVIDER", "ark")
+	t.Setenv("ARK_API_KEY", "")
+	t.Setenv("ARK_API_KEY_FILE", "")
+	t.Setenv("ARK_MODEL_ID", "ep-1")
+	t.Setenv("ARK_EMBEDDING_MODEL", "")
+	t.Setenv("CUSTOM_API_URL", "api.example.com/v1")
+
+	err := Check(Model(), Env("ARK_EMBEDDING_MODEL"), URL("CUSTOM_API_URL"), Required("SMTP_ADDR", "host:port of the server"))
+	var verr *Error
+	if !assert.True(t, errors.As(err, &verr)) {
+		return
+	}
+	var keys []string
+	for _, p := range verr.Problems {
+		keys = append(keys, p.Key)
+		assert.NotEmpty(t, p.Hint)
+	}
+	assert.Equal(t, []string{"ARK_API_KEY", "ARK_EMBEDDING_MODEL", "CUSTOM_API_URL", "SMTP_ADDR"}, keys)
+	assert.Contains(t, err.Error(), "✗ ARK_API_KEY is not set for MODEL_PROVIDER=ark\n    create one in the Ark console")
+	assert.Contains(t, err.Error(), `✗ CUSTOM_API_URL="api.example.com/v1" is not a URL`)
+}
+
+func TestCheckPasses(t *testing.T) {
+	t.Setenv("MODEL_PROVIDER", "mock")
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("OPENAI_API_KEY_FILE", "/run/secrets/openai")
+	t.Setenv("OPENAI_BASE_URL", "https://api.openai.com/v1")
+
+	assert.NoError(t, Check(Model(), Env("OPENAI_API_KEY"), URL("OPENAI_BASE_URL"), URL("UNSET_URL")))
+}
+
+func TestUnknownProvider(t *testing.T) {
+	t.Setenv("MODEL_PROVIDER", "gemini")
+
+	problems := Model()()
+	if assert.Len(t, problems, 1) {
+		assert.Equal(t, `MODEL_PROVIDER="gemini" is not supported`, problems[0].What)
+		assert.Equal(t, "use one of openai, azure, ark, ollama, claude, mock", problems[0].Hint)
+	}
+}
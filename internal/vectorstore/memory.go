@@ -0,0 +1,339 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vectorstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+const defaultTopK = 3
+
+// MemoryConfig configures a MemoryStore.
+type MemoryConfig struct {
+	// Embedding embeds documents on Store and the query on Retrieve, required.
+	// It can be overridden per call with indexer.WithEmbedding / retriever.WithEmbedding.
+	Embedding embedding.Embedder
+	// TopK is the default max number of documents returned by Retrieve, default 3.
+	TopK int
+	// ScoreThreshold drops documents whose cosine similarity is below it, nil means no threshold.
+	ScoreThreshold *float64
+}
+
+// MemoryStore keeps documents and their vectors in memory and retrieves them by cosine similarity.
+// It implements both indexer.Indexer and retriever.Retriever, so one store can be passed to both sides of a RAG pipeline.
+// Every Retrieve is a linear scan, which is fine for demos and a few thousand chunks, use a real vector store beyond that.
+type MemoryStore struct {
+	emb            embedding.Embedder
+	topK           int
+	scoreThreshold *float64
+
+	mu      sync.RWMutex
+	entries []memoryEntry
+	// pos maps a document ID to its index in entries
+	pos map[string]int
+}
+
+type memoryEntry struct {
+	doc    *schema.Document
+	vector []float64
+}
+
+var (
+	_ indexer.Indexer     = (*MemoryStore)(nil)
+	_ retriever.Retriever = (*MemoryStore)(nil)
+)
+
+func NewMemoryStore(config *MemoryConfig) (*MemoryStore, error) {
+	if config == nil || config.Embedding == nil {
+		return nil, errors.New("embedding is required")
+	}
+
+	topK := config.TopK
+	if topK <= 0 {
+		topK = defaultTopK
+	}
+
+	return &MemoryStore{
+		emb:            config.Embedding,
+		topK:           topK,
+		scoreThreshold: config.ScoreThreshold,
+		pos:            map[string]int{},
+	}, nil
+}
+
+// Store embeds and saves docs. A document without ID gets the hash of its content as ID,
+// and storing a document with an existing ID replaces it, so indexing the same input twice does not duplicate it.
+func (m *MemoryStore) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	options := indexer.GetCommonOptions(&indexer.Options{Embedding: m.emb}, opts...)
+	if options.Embedding == nil {
+		return nil, errors.New("embedding is required")
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.Content
+	}
+	vectors, err := options.Embedding.EmbedStrings(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("embed documents failed: %w", err)
+	}
+	if len(vectors) != len(docs) {
+		return nil, fmt.Errorf("embedding returned %d vectors for %d documents", len(vectors), len(docs))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		if doc.ID == "" {
+			sum := sha256.Sum256([]byte(doc.Content))
+			doc.ID = hex.EncodeToString(sum[:8])
+		}
+		ids[i] = doc.ID
+
+		e := memoryEntry{doc: copyDocument(doc), vector: vectors[i]}
+		if p, ok := m.pos[doc.ID]; ok {
+			m.entries[p] = e
+			continue
+		}
+		m.pos[doc.ID] = len(m.entries)
+		m.entries = append(m.entries, e)
+	}
+
+	return ids, nil
+}
+
+// Retrieve returns the documents most similar to query, best first, with the cosine similarity as score.
+// TopK, ScoreThreshold and Embedding can be overridden per call with the common retriever options.
+func (m *MemoryStore) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	topK := m.topK
+	options := retriever.GetCommonOptions(&retriever.Options{
+		TopK:           &topK,
+		ScoreThreshold: m.scoreThreshold,
+		Embedding:      m.emb,
+	}, opts...)
+	if options.Embedding == nil {
+		return nil, errors.New("embedding is required")
+	}
+
+	vectors, err := options.Embedding.EmbedStrings(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("embed query failed: %w", err)
+	}
+	if len(vectors) != 1 {
+		return nil, fmt.Errorf("embedding returned %d vectors for 1 query", len(vectors))
+	}
+
+	type hit struct {
+		entry memoryEntry
+		score float64
+	}
+
+	m.mu.RLock()
+	hits := make([]hit, 0, len(m.entries))
+	for _, e := range m.entries {
+		score := CosineSimilarity(vectors[0], e.vector)
+		if options.ScoreThreshold != nil && score < *options.ScoreThreshold {
+			continue
+		}
+		hits = append(hits, hit{entry: e, score: score})
+	}
+	m.mu.RUnlock()
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].score > hits[j].score
+	})
+	if options.TopK != nil && *options.TopK > 0 && len(hits) > *options.TopK {
+		hits = hits[:*options.TopK]
+	}
+
+	docs := make([]*schema.Document, len(hits))
+	for i, h := range hits {
+		// return copies, so that callers changing the result do not change what is stored
+		docs[i] = copyDocument(h.entry.doc).WithScore(h.score)
+	}
+	return docs, nil
+}
+
+// Delete removes the documents with the given IDs and returns how many were found.
+func (m *MemoryStore) Delete(ids ...string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	n := 0
+	for _, id := range ids {
+		p, ok := m.pos[id]
+		if !ok {
+			continue
+		}
+
+		// move the last entry into the hole, order does not matter since Retrieve sorts by score
+		last := len(m.entries) - 1
+		if p != last {
+			m.entries[p] = m.entries[last]
+			m.pos[m.entries[p].doc.ID] = p
+		}
+		m.entries = m.entries[:last]
+		delete(m.pos, id)
+		n++
+	}
+	return n
+}
+
+// Len returns the number of stored documents.
+func (m *MemoryStore) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.entries)
+}
+
+// Documents returns copies of all stored documents, without their vectors, in no particular order.
+func (m *MemoryStore) Documents() []*schema.Document {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	docs := make([]*schema.Document, len(m.entries))
+	for i, e := range m.entries {
+		docs[i] = copyDocument(e.doc)
+	}
+	return docs
+}
+
+// persistedEntry is the file format of Save and Load.
+type persistedEntry struct {
+	ID       string         `json:"id"`
+	Content  string         `json:"content"`
+	MetaData map[string]any `json:"metadata,omitempty"`
+	Vector   []float64      `json:"vector"`
+}
+
+// Save writes all documents and their vectors to path as JSON, so that a later run can Load them
+// instead of embedding everything again. The file is replaced atomically.
+func (m *MemoryStore) Save(path string) error {
+	m.mu.RLock()
+	entries := make([]persistedEntry, len(m.entries))
+	for i, e := range m.entries {
+		entries[i] = persistedEntry{ID: e.doc.ID, Content: e.doc.Content, MetaData: e.doc.MetaData, Vector: e.vector}
+	}
+	m.mu.RUnlock()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// a temp file of its own, so that two stores saving to path never write to the same temp file
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// Load replaces the content of the store with the documents saved at path.
+// The returned error wraps os.ErrNotExist if the file does not exist, e.g. on the first run.
+func (m *MemoryStore) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var entries []persistedEntry
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("decode %s failed: %w", path, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make([]memoryEntry, len(entries))
+	m.pos = make(map[string]int, len(entries))
+	for i, e := range entries {
+		m.entries[i] = memoryEntry{
+			doc:    &schema.Document{ID: e.ID, Content: e.Content, MetaData: e.MetaData},
+			vector: e.Vector,
+		}
+		m.pos[e.ID] = i
+	}
+	return nil
+}
+
+func (m *MemoryStore) GetType() string {
+	return "MemoryStore"
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b, 0 if either is a zero vector or their lengths differ.
+func CosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+func copyDocument(doc *schema.Document) *schema.Document {
+	metadata := make(map[string]any, len(doc.MetaData))
+	for k, v := range doc.MetaData {
+		metadata[k] = v
+	}
+	return &schema.Document{ID: doc.ID, Content: doc.Content, MetaData: metadata}
+}
@@ -0,0 +1,267 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package vectorstore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// vectors are the embeddings of the test texts, the query being closest to cat, then dog, then car.
+var vectors = map[string][]float64{
+	"query": {1, 0, 0},
+	"cat":   {0.9, 0.1, 0},
+	"dog":   {0.7, 0.7, 0},
+	"car":   {0, 0.2, 1},
+	"kitty": {1, 0, 0.05},
+}
+
+type fakeEmbedder struct {
+	calls int
+	err   error
+}
+
+func (e *fakeEmbedder) EmbedStrings(_ context.Context, texts []string, _ ...embedding.Option) ([][]float64, error) {
+	e.calls++
+	if e.err != nil {
+		return nil, e.err
+	}
+	res := make([][]float64, len(texts))
+	for i, t := range texts {
+		res[i] = vectors[t]
+	}
+	return res, nil
+}
+
+func newStore(t *testing.T, config *MemoryConfig) *MemoryStore {
+	if config.Embedding == nil {
+		config.Embedding = &fakeEmbedder{}
+	}
+	m, err := NewMemoryStore(config)
+	assert.NoError(t, err)
+	return m
+}
+
+func docs(contents ...string) []*schema.Document {
+	res := make([]*schema.Document, len(contents))
+	for i, c := range contents {
+		res[i] = &schema.Document{ID: c, Content: c, MetaData: map[string]any{"source": c + ".txt"}}
+	}
+	return res
+}
+
+func ids(docs []*schema.Document) []string {
+	res := make([]string, len(docs))
+	for i, d := range docs {
+		res[i] = d.ID
+	}
+	return res
+}
+
+func TestNewMemoryStore(t *testing.T) {
+	_, err := NewMemoryStore(nil)
+	assert.EqualError(t, err, "embedding is required")
+	_, err = NewMemoryStore(&MemoryConfig{})
+	assert.EqualError(t, err, "embedding is required")
+
+	m := newStore(t, &MemoryConfig{})
+	assert.Equal(t, defaultTopK, m.topK)
+	assert.Equal(t, "MemoryStore", m.GetType())
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	m := newStore(t, &MemoryConfig{})
+
+	got, err := m.Store(ctx, docs("cat", "dog"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cat", "dog"}, got)
+
+	// a document without ID gets the hash of its content, the same on every run
+	noID := []*schema.Document{{Content: "car"}}
+	got, err = m.Store(ctx, noID)
+	assert.NoError(t, err)
+	assert.Len(t, got[0], 16)
+	assert.Equal(t, got[0], noID[0].ID)
+	again, err := m.Store(ctx, []*schema.Document{{Content: "car"}})
+	assert.NoError(t, err)
+	assert.Equal(t, got, again)
+	assert.Equal(t, 3, m.Len())
+
+	// a document with an existing ID replaces it
+	_, err = m.Store(ctx, []*schema.Document{{ID: "cat", Content: "kitty"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, m.Len())
+	res, err := m.Retrieve(ctx, "query", retriever.WithTopK(1))
+	assert.NoError(t, err)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, "kitty", res[0].Content)
+	}
+
+	// the store keeps copies
+	stored := docs("dog")
+	_, err = m.Store(ctx, stored)
+	assert.NoError(t, err)
+	stored[0].MetaData["source"] = "changed"
+	for _, d := range m.Documents() {
+		assert.NotEqual(t, "changed", d.MetaData["source"])
+	}
+
+	got, err = m.Store(ctx, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestStoreErrors(t *testing.T) {
+	ctx := context.Background()
+	emb := &fakeEmbedder{err: errors.New("quota exceeded")}
+	m := newStore(t, &MemoryConfig{Embedding: emb})
+
+	_, err := m.Store(ctx, docs("cat"))
+	assert.EqualError(t, err, "embed documents failed: quota exceeded")
+	_, err = m.Retrieve(ctx, "query")
+	assert.EqualError(t, err, "embed query failed: quota exceeded")
+	assert.Equal(t, 0, m.Len())
+
+	// the embedding of a call replaces the one of the store
+	ok := &fakeEmbedder{}
+	_, err = m.Store(ctx, docs("cat"), indexer.WithEmbedding(ok))
+	assert.NoError(t, err)
+	_, err = m.Retrieve(ctx, "query", retriever.WithEmbedding(ok))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, ok.calls)
+}
+
+func TestRetrieve(t *testing.T) {
+	ctx := context.Background()
+	threshold := 0.5
+	m := newStore(t, &MemoryConfig{TopK: 2, ScoreThreshold: &threshold})
+	_, err := m.Store(ctx, docs("car", "dog", "cat"))
+	assert.NoError(t, err)
+
+	res, err := m.Retrieve(ctx, "query")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cat", "dog"}, ids(res))
+	assert.InDelta(t, CosineSimilarity(vectors["query"], vectors["cat"]), res[0].Score(), 1e-9)
+	assert.Greater(t, res[0].Score(), res[1].Score())
+
+	// car is below the threshold of the store
+	res, err = m.Retrieve(ctx, "query", retriever.WithTopK(10))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cat", "dog"}, ids(res))
+
+	res, err = m.Retrieve(ctx, "query", retriever.WithTopK(10), retriever.WithScoreThreshold(0))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cat", "dog", "car"}, ids(res))
+
+	res, err = m.Retrieve(ctx, "query", retriever.WithScoreThreshold(0.9))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cat"}, ids(res))
+
+	// the results are copies
+	res[0].MetaData["source"] = "changed"
+	res, err = m.Retrieve(ctx, "query", retriever.WithTopK(1))
+	assert.NoError(t, err)
+	assert.Equal(t, "cat.txt", res[0].MetaData["source"])
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	m := newStore(t, &MemoryConfig{TopK: 10})
+	_, err := m.Store(ctx, docs("cat", "dog", "car"))
+	assert.NoError(t, err)
+
+	// the last entry moves into the hole of the first
+	assert.Equal(t, 1, m.Delete("cat", "missing"))
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, map[string]int{"car": 0, "dog": 1}, m.pos)
+	assert.Equal(t, "car", m.entries[0].doc.ID)
+
+	// deleting the last entry moves nothing
+	assert.Equal(t, 1, m.Delete("dog"))
+	assert.Equal(t, map[string]int{"car": 0}, m.pos)
+
+	// a deleted ID is stored again at the end
+	_, err = m.Store(ctx, docs("cat"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"car": 0, "cat": 1}, m.pos)
+	res, err := m.Retrieve(ctx, "query")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cat", "car"}, ids(res))
+
+	assert.Equal(t, 2, m.Delete("car", "cat", "cat"))
+	assert.Equal(t, 0, m.Len())
+	assert.Empty(t, m.pos)
+}
+
+func TestSaveLoad(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "index", "store.json")
+	m := newStore(t, &MemoryConfig{TopK: 10})
+	_, err := m.Store(ctx, docs("cat", "dog", "car"))
+	assert.NoError(t, err)
+	m.Delete("cat")
+	assert.NoError(t, m.Save(path))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "the temp file is renamed")
+
+	emb := &fakeEmbedder{}
+	loaded := newStore(t, &MemoryConfig{Embedding: emb, TopK: 10})
+	_, err = loaded.Store(ctx, docs("kitty"))
+	assert.NoError(t, err)
+	assert.NoError(t, loaded.Load(path))
+	assert.Equal(t, 2, loaded.Len())
+	assert.Equal(t, m.pos, loaded.pos)
+
+	// the vectors are loaded, only the query is embedded
+	emb.calls = 0
+	want, err := m.Retrieve(ctx, "query")
+	assert.NoError(t, err)
+	got, err := loaded.Retrieve(ctx, "query")
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, 1, emb.calls)
+
+	err = loaded.Load(filepath.Join(dir, "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	corrupt := filepath.Join(dir, "corrupt.json")
+	assert.NoError(t, os.WriteFile(corrupt, []byte("["), 0o644))
+	assert.ErrorContains(t, loaded.Load(corrupt), "decode "+corrupt+" failed")
+	assert.Equal(t, 2, loaded.Len())
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	assert.InDelta(t, 1, CosineSimilarity([]float64{1, 2}, []float64{2, 4}), 1e-9)
+	assert.InDelta(t, 0, CosineSimilarity([]float64{1, 0}, []float64{0, 1}), 1e-9)
+	assert.InDelta(t, -1, CosineSimilarity([]float64{1, 0}, []float64{-1, 0}), 1e-9)
+	assert.Equal(t, 0.0, CosineSimilarity([]float64{0, 0}, []float64{1, 0}))
+	assert.Equal(t, 0.0, CosineSimilarity([]float64{1}, []float64{1, 0}))
+	assert.Equal(t, 0.0, CosineSimilarity(nil, nil))
+}
@@ -14,23 +14,12 @@
  * limitations under the License.
  */
 
-package main
+// Package prompts holds the prompt files of the examples, loaded with internal/prompt.
+package prompts
 
-import (
-	"context"
-	"log"
+import "embed"
 
-	"github.com/cloudwego/eino-ext/components/model/ollama"
-	"github.com/cloudwego/eino/components/model"
-)
-
-func createOllamaChatModel(ctx context.Context) model.ChatModel {
-	chatModel, err := ollama.NewChatModel(ctx, &ollama.ChatModelConfig{
-		BaseURL: "http://localhost:11434", // Ollama 服务地址
-		Model:   "llama2",                 // 模型名称
-	})
-	if err != nil {
-		log.Fatalf("create ollama chat model failed: %v", err)
-	}
-	return chatModel
-}
+// FS holds the *.prompt files of this directory.
+//
+//go:embed *.prompt
+var FS embed.FS
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"flag"
+	"log"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+
+	"github.com/cloudwego/eino-examples/internal/gptr"
+	"github.com/cloudwego/eino-examples/quickstart/chat/personas"
+)
+
+var (
+	provider    = flag.String("provider", "openai", "model provider: openai, azure, ollama, ark or claude")
+	personaName = flag.String("persona", personas.DefaultName, "system prompt persona, one of: "+strings.Join(personas.Names(), ", "))
+	question    = flag.String("question", "", "the question to ask, defaults to the sample question of the persona")
+	noCache     = flag.Bool("no-cache", false, "always call the model instead of serving repeated prompts from the disk cache")
+	cacheDir    = flag.String("cache-dir", ".cache/chat", "directory of the response cache")
+	rpm         = flag.Int("rpm", 0, "client side limit of requests per minute, 0 means unlimited")
+	tpm         = flag.Int("tpm", 0, "client side limit of tokens per minute, 0 means unlimited")
+	serveAddr   = flag.String("serve", "", "serve the chat over SSE on this address, e.g. 127.0.0.1:8080, empty means run once in the terminal")
+	historyDir  = flag.String("history-dir", "", "keep the conversation of each session of the SSE server in this directory, empty means every question starts a new conversation")
+	jsonlLog    = flag.String("jsonl-log", "", "append every model input/output to this JSONL file, empty means disabled")
+)
+
+const (
+	// 在创建模型时传入，对该模型的每次调用都生效
+	optionModeConfig = "config"
+	// 在每次调用时通过 model.Option 传入，只对本次调用生效，会覆盖创建时的配置
+	optionModeCall = "call"
+)
+
+// generationFlags 命令行传入的生成参数，未设置的参数保持为 nil，使用模型服务端的默认值
+type generationFlags struct {
+	Mode        string
+	Temperature *float32
+	TopP        *float32
+	MaxTokens   *int
+	Stop        []string
+}
+
+func parseGenerationFlags() *generationFlags {
+	var (
+		mode        = flag.String("option-mode", optionModeConfig, "how to pass generation options: config (at model creation) or call (per call via model.Option)")
+		temperature = flag.Float64("temperature", -1, "sampling temperature, e.g. 0.7, negative means use the default")
+		topP        = flag.Float64("top-p", -1, "nucleus sampling probability, e.g. 0.9, negative means use the default")
+		maxTokens   = flag.Int("max-tokens", 0, "max tokens to generate, 0 means use the default")
+		stop        = flag.String("stop", "", "comma separated stop sequences")
+	)
+	flag.Parse()
+
+	if *mode != optionModeConfig && *mode != optionModeCall {
+		log.Fatalf("invalid -option-mode %q, must be %q or %q", *mode, optionModeConfig, optionModeCall)
+	}
+
+	f := &generationFlags{Mode: *mode}
+	if *temperature >= 0 {
+		f.Temperature = gptr.Of(float32(*temperature))
+	}
+	if *topP >= 0 {
+		f.TopP = gptr.Of(float32(*topP))
+	}
+	if *maxTokens > 0 {
+		f.MaxTokens = maxTokens
+	}
+	if *stop != "" {
+		f.Stop = strings.Split(*stop, ",")
+	}
+	return f
+}
+
+// configTime 返回创建模型时使用的参数，call 模式下返回空参数
+func (f *generationFlags) configTime() *generationFlags {
+	if f.Mode != optionModeConfig {
+		return &generationFlags{}
+	}
+	return f
+}
+
+// callOptions 把命令行参数转换为单次调用的 model.Option，config 模式下返回 nil
+func (f *generationFlags) callOptions() []model.Option {
+	if f.Mode != optionModeCall {
+		return nil
+	}
+
+	var opts []model.Option
+	if f.Temperature != nil {
+		opts = append(opts, model.WithTemperature(*f.Temperature))
+	}
+	if f.TopP != nil {
+		opts = append(opts, model.WithTopP(*f.TopP))
+	}
+	if f.MaxTokens != nil {
+		opts = append(opts, model.WithMaxTokens(*f.MaxTokens))
+	}
+	if len(f.Stop) > 0 {
+		opts = append(opts, model.WithStop(f.Stop))
+	}
+	return opts
+}
@@ -24,16 +24,16 @@ import (
 	"github.com/cloudwego/eino/schema"
 )
 
-func generate(ctx context.Context, llm model.ChatModel, in []*schema.Message) *schema.Message {
-	result, err := llm.Generate(ctx, in)
+func generate(ctx context.Context, llm model.ChatModel, in []*schema.Message, opts ...model.Option) *schema.Message {
+	result, err := llm.Generate(ctx, in, opts...)
 	if err != nil {
 		log.Fatalf("llm generate failed: %v", err)
 	}
 	return result
 }
 
-func stream(ctx context.Context, llm model.ChatModel, in []*schema.Message) *schema.StreamReader[*schema.Message] {
-	result, err := llm.Stream(ctx, in)
+func stream(ctx context.Context, llm model.ChatModel, in []*schema.Message, opts ...model.Option) *schema.StreamReader[*schema.Message] {
+	result, err := llm.Stream(ctx, in, opts...)
 	if err != nil {
 		log.Fatalf("llm generate failed: %v", err)
 	}
@@ -18,39 +18,103 @@ package main
 
 import (
 	"context"
-	"github.com/joho/godotenv"
 	"log"
+
+	"github.com/cloudwego/eino/callbacks"
+	"github.com/cloudwego/eino/components"
+
+	cbutils "github.com/cloudwego/eino-examples/internal/callbacks"
+	"github.com/cloudwego/eino-examples/internal/chatmodel"
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/ratelimit"
+	"github.com/cloudwego/eino-examples/internal/streamprint"
+	"github.com/cloudwego/eino-examples/quickstart/chat/personas"
 )
 
 func main() {
-	// 加载 .env 文件
-	if err := godotenv.Load(); err != nil {
-		log.Printf("Warning: 无法加载 .env 文件: %v\n", err)
-	}
-
 	ctx := context.Background()
 
+	// 解析命令行参数，例如 -persona translator -temperature 0.2 -max-tokens 256 -option-mode call
+	gen := parseGenerationFlags()
+
+	// 合并 .env、EINO_CONFIG 指定的 YAML 文件、环境变量和命令行参数，
+	// .env 中的值同时写入环境变量，供直接读取环境变量的代理配置使用
+	if err := config.Init(&config.Options{ExportEnv: true}); err != nil {
+		log.Fatalf("load config failed: %v", err)
+	}
+	// 在调用模型之前一次性检查所有缺失的配置，而不是在第一次请求时才失败
+	validateConfig(*provider)
+
 	// 使用模版创建messages
 	log.Printf("===create messages===\n")
-	messages := createMessagesFromTemplate()
+	persona, err := personas.Get(*personaName)
+	if err != nil {
+		log.Fatalf("get persona failed: %v", err)
+	}
+	log.Printf("persona: %s (%s)\n", persona.Name, persona.Description)
+	messages := createMessagesFromTemplate(persona, *question)
 	log.Printf("messages: %+v\n\n", messages)
 
+	// 发送之前统计 prompt 的 token 数，超出预算直接拒绝
+	promptTokens, err := checkTokenBudget(messages, modelName(*provider), tokenBudget())
+	if err != nil {
+		log.Fatalf("check token budget failed: %v", err)
+	}
+	log.Printf("prompt tokens: %d/%d\n\n", promptTokens, tokenBudget())
+
 	// 创建llm
 	log.Printf("===create llm===\n")
-	cm := createOpenAIChatModel(ctx)
-	// cm := createOllamaChatModel(ctx)
+	cm := createChatModel(ctx, *provider, gen.configTime())
+	// 客户端限流，循环调用时避免触发服务端的 RPM/TPM 限制；放在重试里面，重试的请求同样受限
+	if *rpm > 0 || *tpm > 0 {
+		cm = ratelimit.WrapChatModel(cm, ratelimit.New(*provider, &ratelimit.Config{
+			RequestsPerMinute: *rpm,
+			TokensPerMinute:   *tpm,
+		}))
+	}
+	// 遇到 429/5xx 等临时性错误时，按指数退避自动重试
+	cm = chatmodel.NewRetryChatModel(cm, &chatmodel.RetryConfig{MaxRetries: 3})
+	// 相同的 messages 和参数直接从磁盘缓存返回，开发调试时节省时间和费用，-no-cache 可以关闭
+	if !*noCache {
+		cm, err = chatmodel.NewCachedChatModel(cm, &chatmodel.CacheConfig{
+			Dir:       *cacheDir,
+			Provider:  *provider,
+			ModelName: modelName(*provider),
+		})
+		if err != nil {
+			log.Fatalf("create cached chat model failed: %v", err)
+		}
+	}
 	log.Printf("create llm success\n\n")
 
+	// 把每次调用的输入输出追加到 JSONL 文件，便于之后分析或整理成微调数据集
+	// 这里直接调用 ChatModel 而不是通过 compose 编排，所以需要用 InitCallbacks 手动注入 handler
+	if *jsonlLog != "" {
+		logger, err := cbutils.NewJSONLLogger(*jsonlLog)
+		if err != nil {
+			log.Fatalf("create jsonl logger failed: %v", err)
+		}
+		defer logger.Close()
+		ctx = callbacks.InitCallbacks(ctx, &callbacks.RunInfo{
+			Name:      "quickstart/chat",
+			Type:      *provider,
+			Component: components.ComponentOfChatModel,
+		}, logger.Handler())
+	}
+
+	// 以 HTTP 服务的方式运行，浏览器打开 http://127.0.0.1:8080 即可看到流式输出
+	if *serveAddr != "" {
+		serveSSE(ctx, *serveAddr, cm, persona, modelName(*provider), *historyDir, gen.callOptions()...)
+		return
+	}
+
 	log.Printf("===llm generate===\n")
-	result := generate(ctx, cm, messages)
+	result := generate(ctx, cm, messages, gen.callOptions()...)
 	log.Printf("result: %+v\n\n", result)
 
 	log.Printf("===llm stream generate===\n")
-	streamResult := stream(ctx, cm, messages)
-	//reportStream(streamResult)
-	r, err := reportStream2(streamResult)
-	if err != nil {
-		return
+	streamResult := stream(ctx, cm, messages, gen.callOptions()...)
+	if _, err = streamprint.Print(streamResult); err != nil {
+		log.Fatalf("recv stream failed: %v", err)
 	}
-	log.Printf("r: %+v\n\n", r)
 }
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/cloudwego/eino/components/model"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// validateConfig 检查 provider 需要的配置，缺少任何一项时列出全部问题后退出。
+// openai 检查本示例自己的 CUSTOM_* 配置，其余 provider 检查 internal/models 需要的环境变量
+func validateConfig(provider string) {
+	if provider == models.ProviderOpenAI {
+		validate.Must(validate.Env("CUSTOM_API_KEY", "CUSTOM_API_URL", "CUSTOM_MODEL_NAME"), validate.URL("CUSTOM_API_URL"))
+		return
+	}
+	validate.Must(validate.ModelOf(provider))
+}
+
+// createChatModel 通过 internal/models 创建 provider 对应的 ChatModel，生成参数在这里传入时对该模型的每次调用都生效。
+// openai 使用本示例自己的 CUSTOM_API_KEY / CUSTOM_API_URL / CUSTOM_MODEL_NAME，并通过 api-key 请求头鉴权，
+// 其余 provider 的配置项见 internal/models
+func createChatModel(ctx context.Context, provider string, gen *generationFlags) model.ChatModel {
+	opts := []models.Option{models.WithProvider(provider)}
+	if provider == models.ProviderOpenAI {
+		apiKey := secrets.MustGet(ctx, "CUSTOM_API_KEY")
+		opts = append(opts,
+			models.WithAPIKey(apiKey),
+			models.WithBaseURL(config.String("CUSTOM_API_URL", "")),
+			models.WithModel(config.String("CUSTOM_MODEL_NAME", "")),
+			models.WithHeaders(map[string]string{
+				"api-key":      apiKey,
+				"Content-Type": "application/json",
+			}),
+			// 支持 HTTPS_PROXY / ALL_PROXY 以及仅对本示例生效的 CUSTOM_API_PROXY
+			models.WithProxyKey("CUSTOM_API_PROXY"),
+		)
+	}
+
+	if gen.Temperature != nil {
+		opts = append(opts, models.WithTemperature(*gen.Temperature))
+	}
+	if gen.TopP != nil {
+		opts = append(opts, models.WithTopP(*gen.TopP))
+	}
+	if gen.MaxTokens != nil {
+		opts = append(opts, models.WithMaxTokens(*gen.MaxTokens))
+	}
+	if len(gen.Stop) > 0 {
+		opts = append(opts, models.WithStop(gen.Stop))
+	}
+
+	chatModel, err := models.NewChatModel(ctx, opts...)
+	if err != nil {
+		log.Fatalf("create %s chat model failed: %v", provider, err)
+	}
+	return chatModel
+}
+
+// modelName 返回 provider 使用的模型名，用于选择统计 token 的编码，也作为缓存 key 的一部分，切换模型后不会命中之前模型的回答
+func modelName(provider string) string {
+	if provider == models.ProviderOpenAI {
+		return config.String("CUSTOM_MODEL_NAME", "")
+	}
+	return models.ModelName(provider)
+}
@@ -0,0 +1,219 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/history"
+	"github.com/cloudwego/eino-examples/internal/tokens"
+	"github.com/cloudwego/eino-examples/quickstart/chat/personas"
+)
+
+// sessionIDPattern 限制会话 ID 的字符，会话 ID 直接用作文件名
+var sessionIDPattern = regexp.MustCompile(`^[0-9A-Za-z_-]{1,64}$`)
+
+// sseServer 通过 Server-Sent Events 把模型输出的 token 逐个推送给浏览器
+type sseServer struct {
+	cm      model.ChatModel
+	persona *personas.Persona
+	opts    []model.Option
+	// modelName 用于统计 token，选择对应的编码
+	modelName string
+	// historyDir 保存每个会话的对话历史，为空时每个问题都是新的对话
+	historyDir string
+}
+
+// serveSSE 启动 HTTP 服务：
+//   - GET /            一个最简单的页面，使用 EventSource 展示流式输出
+//   - GET /chat?q=...  SSE 接口，每个 chunk 一个 message 事件，结束时发送 done 事件，出错时发送 error 事件
+//
+// 设置了 historyDir 时，带 session 参数的请求会接着该会话之前的对话回答，对话历史通过 internal/history 保存在
+// historyDir/<session>.json，服务重启后仍然可以继续。超出 token 预算时丢弃最早的几轮对话，避免会话越来越长后超出上下文
+func serveSSE(ctx context.Context, addr string, cm model.ChatModel, persona *personas.Persona, modelName, historyDir string, opts ...model.Option) {
+	s := &sseServer{cm: cm, persona: persona, opts: opts, modelName: modelName, historyDir: historyDir}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleIndex)
+	mux.HandleFunc("/chat", s.handleChat)
+
+	srv := &http.Server{
+		Addr:        addr,
+		Handler:     mux,
+		BaseContext: func(_ net.Listener) context.Context { return ctx },
+	}
+
+	log.Printf("sse server listening on http://%s\n", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("sse server failed: %v", err)
+	}
+}
+
+func (s *sseServer) handleChat(w http.ResponseWriter, r *http.Request) {
+	// 不支持 Flush 的 ResponseWriter 无法做到逐块推送
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	// r.Context() 会在客户端断开连接时被取消，传给 Stream 后底层的 HTTP 请求也会随之取消
+	ctx := r.Context()
+	session := r.URL.Query().Get("session")
+	if session != "" && !sessionIDPattern.MatchString(session) {
+		http.Error(w, "invalid session, use up to 64 letters, digits, - or _", http.StatusBadRequest)
+		return
+	}
+	prior, err := s.loadHistory(session)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("load history failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+	messages := createMessagesFromTemplate(s.persona, r.URL.Query().Get("q"))
+	if len(prior) > 0 {
+		messages = createMessagesWithHistory(s.persona, prior, r.URL.Query().Get("q"))
+	}
+	// 会话历史每次都完整发送，超出预算时丢弃最早的几轮；只剩当前问题仍然超出时直接拒绝
+	if trimmed := tokens.For(s.modelName).TrimToBudget(messages, tokenBudget()); len(trimmed) < len(messages) {
+		log.Printf("session %s exceeds the token budget, drop its %d oldest messages\n", session, len(messages)-len(trimmed))
+		messages = trimmed
+	}
+	if _, err = checkTokenBudget(messages, s.modelName, tokenBudget()); err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	sr, err := s.cm.Stream(ctx, messages, s.opts...)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("llm stream failed: %v", err), http.StatusBadGateway)
+		return
+	}
+	// 无论正常结束还是客户端提前断开，都要关闭 StreamReader 释放资源
+	defer sr.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// 关闭 nginx 等反向代理的缓冲，否则客户端会一次性收到全部内容
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	var answer strings.Builder
+	for {
+		chunk, err := sr.Recv()
+		if errors.Is(err, io.EOF) {
+			// 只保存完整的回答，中途失败或断开的问题不计入对话历史
+			if err = s.saveHistory(session, messages, answer.String()); err != nil {
+				log.Printf("save history of session %s failed: %v\n", session, err)
+			}
+			writeEvent(w, "done", "[DONE]")
+			flusher.Flush()
+			return
+		}
+		if ctx.Err() != nil {
+			log.Printf("client disconnected: %v\n", ctx.Err())
+			return
+		}
+		if err != nil {
+			writeEvent(w, "error", err.Error())
+			flusher.Flush()
+			return
+		}
+		if chunk.Content == "" {
+			continue
+		}
+		answer.WriteString(chunk.Content)
+
+		// 用 JSON 编码内容，避免 token 中的换行符破坏 SSE 的帧格式
+		data, _ := json.Marshal(map[string]string{"content": chunk.Content})
+		if err = writeEvent(w, "message", string(data)); err != nil {
+			log.Printf("write sse event failed, client may be gone: %v\n", err)
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// loadHistory 返回会话之前的对话，不含系统消息；新的会话返回空
+func (s *sseServer) loadHistory(session string) ([]*schema.Message, error) {
+	if s.historyDir == "" || session == "" {
+		return nil, nil
+	}
+	return history.Load(filepath.Join(s.historyDir, session+".json"))
+}
+
+// saveHistory 保存本次的问题和回答，系统消息由模板生成，不保存
+func (s *sseServer) saveHistory(session string, messages []*schema.Message, answer string) error {
+	if s.historyDir == "" || session == "" {
+		return nil
+	}
+	conv := append(messages[1:len(messages):len(messages)], schema.AssistantMessage(answer, nil))
+	return history.Save(filepath.Join(s.historyDir, session+".json"), conv)
+}
+
+func writeEvent(w io.Writer, event, data string) error {
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
+
+func (s *sseServer) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = io.WriteString(w, indexHTML)
+}
+
+const indexHTML = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>eino chat</title></head>
+<body>
+<form id="f"><input id="q" size="60" placeholder="问题"><button>发送</button></form>
+<pre id="out" style="white-space: pre-wrap"></pre>
+<script>
+let es;
+// 每次打开页面是一个新的会话，服务端设置了 -history-dir 时会记住之前的问题
+const session = crypto.randomUUID();
+document.getElementById("f").onsubmit = (e) => {
+  e.preventDefault();
+  if (es) es.close();
+  const out = document.getElementById("out");
+  out.textContent = "";
+  es = new EventSource("/chat?session=" + session + "&q=" + encodeURIComponent(document.getElementById("q").value));
+  es.addEventListener("message", (ev) => { out.textContent += JSON.parse(ev.data).content; });
+  es.addEventListener("done", () => es.close());
+  es.addEventListener("error", (ev) => { if (ev.data) out.textContent += "\n[error] " + ev.data; es.close(); });
+};
+</script>
+</body>
+</html>
+`
@@ -1,57 +0,0 @@
-/*
- * Copyright 2025 CloudWeGo Authors
- *
- * Licensed under the Apache License, Version 2.0 (the "License");
- * you may not use this file except in compliance with the License.
- * You may obtain a copy of the License at
- *
- *     http://www.apache.org/licenses/LICENSE-2.0
- *
- * Unless required by applicable law or agreed to in writing, software
- * distributed under the License is distributed on an "AS IS" BASIS,
- * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
- * See the License for the specific language governing permissions and
- * limitations under the License.
- */
-
-package main
-
-import (
-	"io"
-	"log"
-
-	"github.com/cloudwego/eino/schema"
-)
-
-func reportStream(sr *schema.StreamReader[*schema.Message]) {
-	defer sr.Close()
-
-	i := 0
-	for {
-		message, err := sr.Recv()
-		if err == io.EOF {
-			return
-		}
-		if err != nil {
-			log.Fatalf("recv failed: %v", err)
-		}
-		log.Printf("message[%d]: %+v\n", i, message)
-		i++
-	}
-}
-
-func reportStream2(sr *schema.StreamReader[*schema.Message]) (string, error) {
-	defer sr.Close()
-	var result string
-	for {
-		message, err := sr.Recv()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return "", err
-		}
-		result += message.Content
-	}
-	return result, nil
-}
@@ -22,13 +22,15 @@ import (
 
 	"github.com/cloudwego/eino/components/prompt"
 	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/quickstart/chat/personas"
 )
 
-func createTemplate() prompt.ChatTemplate {
+func createTemplate(persona *personas.Persona) prompt.ChatTemplate {
 	// 创建模板，使用 FString 格式
 	return prompt.FromMessages(schema.FString,
-		// 系统消息模板
-		schema.SystemMessage("你是一个{role}。你需要用{style}的语气回答问题。你的目标是帮助程序员保持积极乐观的心态，提供技术建议的同时也要关注他们的心理健康。"),
+		// 系统消息模板，内容由所选的人设决定
+		schema.SystemMessage(persona.SystemPrompt),
 
 		// 插入需要的对话历史（新对话的话这里不填）
 		schema.MessagesPlaceholder("chat_history", true),
@@ -38,21 +40,22 @@ func createTemplate() prompt.ChatTemplate {
 	)
 }
 
-func createMessagesFromTemplate() []*schema.Message {
-	template := createTemplate()
+func createMessagesFromTemplate(persona *personas.Persona, question string) []*schema.Message {
+	// 对话历史（部分人设带有模拟的对话历史）
+	return createMessagesWithHistory(persona, persona.History, question)
+}
+
+// createMessagesWithHistory 使用给定的对话历史生成消息，例如 SSE 服务保存的会话
+func createMessagesWithHistory(persona *personas.Persona, history []*schema.Message, question string) []*schema.Message {
+	template := createTemplate(persona)
+	if question == "" {
+		question = persona.SampleQuestion
+	}
 
 	// 使用模板生成消息
 	messages, err := template.Format(context.Background(), map[string]any{
-		"role":     "程序员鼓励师",
-		"style":    "积极、温暖且专业",
-		"question": "我的代码一直报错，感觉好沮丧，该怎么办？",
-		// 对话历史（这个例子里模拟两轮对话历史）
-		"chat_history": []*schema.Message{
-			schema.UserMessage("你好"),
-			schema.AssistantMessage("嘿！我是你的程序员鼓励师！记住，每个优秀的程序员都是从 Debug 中成长起来的。有什么我可以帮你的吗？", nil),
-			schema.UserMessage("我觉得自己写的代码太烂了"),
-			schema.AssistantMessage("每个程序员都经历过这个阶段！重要的是你在不断学习和进步。让我们一起看看代码，我相信通过重构和优化，它会变得更好。记住，Rome wasn't built in a day，代码质量是通过持续改进来提升的。", nil),
-		},
+		"question":     question,
+		"chat_history": history,
 	})
 	if err != nil {
 		log.Fatalf("format template failed: %v\n", err)
@@ -62,8 +65,9 @@ func createMessagesFromTemplate() []*schema.Message {
 
 // 输出结果
 //func main() {
-//	messages := createMessagesFromTemplate()
+//	persona, _ := personas.Get(personas.DefaultName)
+//	messages := createMessagesFromTemplate(persona, "")
 //	fmt.Printf("formatted message: %v", messages)
 //}
 
-// formatted message: [system: 你是一个程序员鼓励师。你需要用积极、温暖且专业的语气回答问题。你的目标是帮助程序员保持积极乐观的心态，提供技术建议的同时也要关注他们的心理健康。 user: 你好 assistant: 嘿！我是你的程序员鼓励师！记住，每个优秀的程序员都是从 Debug 中成长起来的。有什么我可以帮你的吗？ user: 我觉得自己写的代码太烂了 assistant: 每个程序员都经历过这个阶段！重要的是你在不断学习和进步。让我们一起看看代码，我相信通过重构和优化，它会变得更好。记住，Rome wasn't built in a day，代码质量是通过持续改进来提升的。 user: 问题: 我的代码一直报错，感觉好沮丧，该怎么办？]
+// formatted message: [system: # 角色\n你是一个程序员鼓励师。\n\n# 目标\n帮助程序员保持积极乐观的心态，提供技术建议的同时也要关注他们的心理健康。\n\n# 约束\n- 使用积极、温暖且专业的语气回答问题 user: 你好 assistant: 嘿！我是你的程序员鼓励师！记住，每个优秀的程序员都是从 Debug 中成长起来的。有什么我可以帮你的吗？ user: 我觉得自己写的代码太烂了 assistant: 每个程序员都经历过这个阶段！重要的是你在不断学习和进步。让我们一起看看代码，我相信通过重构和优化，它会变得更好。记住，Rome wasn't built in a day，代码质量是通过持续改进来提升的。 user: 问题: 我的代码一直报错，感觉好沮丧，该怎么办？]
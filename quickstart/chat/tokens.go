@@ -0,0 +1,49 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/tokens"
+)
+
+// 默认的 prompt token 预算
+const defaultTokenBudget = 4096
+
+// tokenBudget 从配置项 MAX_PROMPT_TOKENS 读取 prompt 的 token 预算
+func tokenBudget() int {
+	budget := config.Int("MAX_PROMPT_TOKENS", defaultTokenBudget)
+	if budget <= 0 {
+		return defaultTokenBudget
+	}
+	return budget
+}
+
+// checkTokenBudget 在发送请求之前统计 prompt 的 token 数，超过预算时返回错误，
+// 避免上下文超长后被服务端静默截断或直接报错
+func checkTokenBudget(messages []*schema.Message, modelName string, budget int) (int, error) {
+	// 无法根据模型名识别编码时按 cl100k_base 统计，对大多数模型是一个足够好的近似
+	n := tokens.For(modelName).CountMessages(messages)
+	if n > budget {
+		return n, fmt.Errorf("prompt has %d tokens, exceeds the budget of %d tokens", n, budget)
+	}
+	return n, nil
+}
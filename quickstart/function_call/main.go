@@ -0,0 +1,138 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
+)
+
+// This example shows the "raw" function calling flow without ToolsNode, Chain or Graph:
+//  1. bind the tool info to the chat model
+//  2. call Generate and inspect resp.ToolCalls manually
+//  3. run the matched tool with the arguments produced by the model
+//  4. send the tool result back as a tool message and call Generate again
+//
+// This is exactly what compose.ToolsNode and the react agent do for you.
+func main() {
+	validate.Must(validate.Model())
+
+	ctx := context.Background()
+
+	chatModel := models.MustChatModel(ctx)
+
+	weatherTool, err := utils.InferTool("get_weather", "query the weather of a city", getWeather)
+	if err != nil {
+		logs.Fatalf("InferTool failed, err=%v", err)
+	}
+
+	// the tools the model is allowed to call, indexed by name so that we can dispatch tool calls
+	tools := map[string]tool.InvokableTool{}
+	toolInfos := make([]*schema.ToolInfo, 0, 1)
+	for _, t := range []tool.InvokableTool{weatherTool} {
+		info, err := t.Info(ctx)
+		if err != nil {
+			logs.Fatalf("get ToolInfo failed, err=%v", err)
+		}
+		tools[info.Name] = t
+		toolInfos = append(toolInfos, info)
+	}
+
+	// step 1: bind tool infos, so that the model knows which functions it can call
+	if err = chatModel.BindTools(toolInfos); err != nil {
+		logs.Fatalf("BindTools failed, err=%v", err)
+	}
+
+	messages := []*schema.Message{
+		schema.SystemMessage("You are a helpful assistant. Use the tools when you need real-time information."),
+		schema.UserMessage("What's the weather like in Beijing and Shanghai today?"),
+	}
+
+	// step 2: the model decides whether to call tools
+	resp, err := chatModel.Generate(ctx, messages)
+	if err != nil {
+		logs.Fatalf("Generate failed, err=%v", err)
+	}
+
+	if len(resp.ToolCalls) == 0 {
+		logs.Infof("model answered directly: %s", resp.Content)
+		return
+	}
+
+	// the assistant message carrying the tool calls must stay in the history,
+	// otherwise the model can not match the following tool messages with its calls
+	messages = append(messages, resp)
+
+	// step 3: execute every tool call and collect the results as tool messages
+	for _, tc := range resp.ToolCalls {
+		logs.Infof("model wants to call %s with %s", tc.Function.Name, tc.Function.Arguments)
+
+		t, ok := tools[tc.Function.Name]
+		if !ok {
+			logs.Fatalf("model called an unknown tool: %s", tc.Function.Name)
+		}
+
+		result, err := t.InvokableRun(ctx, tc.Function.Arguments)
+		if err != nil {
+			logs.Fatalf("InvokableRun failed, tool=%s, err=%v", tc.Function.Name, err)
+		}
+
+		logs.Infof("tool %s returned: %s", tc.Function.Name, result)
+
+		// step 4: the tool message must reference the id of the tool call it answers
+		messages = append(messages, schema.ToolMessage(result, tc.ID))
+	}
+
+	// step 5: let the model generate the final answer based on the tool results
+	final, err := chatModel.Generate(ctx, messages)
+	if err != nil {
+		logs.Fatalf("Generate failed, err=%v", err)
+	}
+
+	logs.Infof("final answer: %s", final.Content)
+}
+
+type weatherRequest struct {
+	City string `json:"city" jsonschema:"description=the city to query, e.g. Beijing"`
+}
+
+type weatherResponse struct {
+	City        string `json:"city"`
+	Weather     string `json:"weather"`
+	Temperature int    `json:"temperature"`
+}
+
+func getWeather(_ context.Context, req *weatherRequest) (*weatherResponse, error) {
+	// fake data, replace with a real weather service if needed
+	weathers := map[string]*weatherResponse{
+		"Beijing":  {City: "Beijing", Weather: "sunny", Temperature: 26},
+		"Shanghai": {City: "Shanghai", Weather: "rainy", Temperature: 22},
+	}
+
+	if w, ok := weathers[req.City]; ok {
+		return w, nil
+	}
+
+	return &weatherResponse{City: req.City, Weather: "cloudy", Temperature: 20}, nil
+}
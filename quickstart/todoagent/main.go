@@ -18,21 +18,20 @@ package main
 
 import (
 	"context"
-	"os"
 
-	"github.com/cloudwego/eino-ext/components/model/openai"
 	"github.com/cloudwego/eino-ext/components/tool/duckduckgo"
 	"github.com/cloudwego/eino/components/tool"
 	"github.com/cloudwego/eino/components/tool/utils"
 	"github.com/cloudwego/eino/compose"
 	"github.com/cloudwego/eino/schema"
 
-	"github.com/cloudwego/eino-examples/internal/gptr"
 	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/validate"
 )
 
 func main() {
-	openAIAPIKey := os.Getenv("OPENAI_API_KEY")
+	validate.Must(validate.Model())
 
 	ctx := context.Background()
 
@@ -58,15 +57,7 @@ func main() {
 	}
 
 	// 创建并配置 ChatModel
-	chatModel, err := openai.NewChatModel(context.Background(), &openai.ChatModelConfig{
-		Model:       "gpt-4o",
-		APIKey:      openAIAPIKey,
-		Temperature: gptr.Of(float32(0.7)),
-	})
-	if err != nil {
-		logs.Errorf("NewChatModel failed, err=%v", err)
-		return
-	}
+	chatModel := models.MustChatModel(ctx, models.WithTemperature(0.7))
 
 	// 获取工具信息, 用于绑定到 ChatModel
 	toolInfos := make([]*schema.ToolInfo, 0, len(todoTools))
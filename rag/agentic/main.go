@@ -0,0 +1,228 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/components/tool"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/flow/agent/react"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+type searchInput struct {
+	Query string `json:"query" jsonschema:"description=what to look for, in a few words"`
+}
+
+// Requirements:
+//   - ARK_API_KEY / ARK_EMBEDDING_MODEL for embedding
+//   - MODEL_PROVIDER and the keys of the provider for the chat model, see internal/models
+//
+// This example exposes retrieval as a tool of a ReAct agent, which decides whether to search, with which query
+// and how many times, and compares it with a fixed pipeline retrieving once with the question, as in rag/:
+//
+//	-mode agentic:  ReAct agent <-> search_docs(query)
+//	-mode pipeline: question -> Retriever -> ChatTemplate -> ChatModel
+//
+// The pipeline costs one retrieval and one model call, always, even for "hello"; and a question about two topics
+// gets the chunks closest to the mix of both. The agent searches each topic with its own query, and skips the
+// search when none is needed, for at least one more model call per question.
+func main() {
+	mode := flag.String("mode", "agentic", "agentic or pipeline")
+	source := flag.String("source", "rag/testdata/eino.md", "path of the document to index")
+	question := flag.String("question", "", "question to ask, default a few questions showing the difference")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	cm := models.MustChatModel(ctx)
+
+	store, err := indexDocument(ctx, emb, *source)
+	if err != nil {
+		logs.Fatalf("indexDocument failed, err=%v", err)
+	}
+	// counts the searches of both modes, the pipeline searching once per question
+	searches := &atomic.Int32{}
+	ret := &countingRetriever{Retriever: store, count: searches}
+
+	var answer func(ctx context.Context, q string) (*schema.Message, error)
+	switch *mode {
+	case "agentic":
+		answer, err = newAgent(ctx, cm, ret)
+	case "pipeline":
+		answer, err = newPipeline(ctx, cm, ret)
+	default:
+		logs.Fatalf("unknown mode %q, use agentic or pipeline", *mode)
+	}
+	if err != nil {
+		logs.Fatalf("create %s failed, err=%v", *mode, err)
+	}
+
+	questions := []string{
+		"Hi! What can you help me with?",
+		"How does a handler receive callbacks, and how do nodes share state during a run?",
+		"What orchestration APIs does Eino provide?",
+	}
+	if *question != "" {
+		questions = []string{*question}
+	}
+	for _, q := range questions {
+		searches.Store(0)
+		logs.Infof("question: %s", q)
+		msg, err := answer(ctx, q)
+		if err != nil {
+			logs.Fatalf("answer failed, err=%v", err)
+		}
+		logs.Infof("answer (%d searches): %s", searches.Load(), msg.Content)
+	}
+}
+
+func indexDocument(ctx context.Context, emb *ark.Embedder, source string) (*vectorstore.MemoryStore, error) {
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+	splitter, err := recursive.NewSplitter(ctx, &recursive.Config{ChunkSize: 500, OverlapSize: 50})
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := splitter.Transform(ctx, []*schema.Document{{ID: source, Content: string(content)}})
+	if err != nil {
+		return nil, err
+	}
+	for i, c := range chunks {
+		c.ID = fmt.Sprintf("%s#%d", source, i)
+	}
+
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 3})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = store.Store(ctx, chunks); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// newAgent gives the retriever to a ReAct agent as the search_docs tool.
+func newAgent(ctx context.Context, cm model.ChatModel, ret retriever.Retriever) (func(context.Context, string) (*schema.Message, error), error) {
+	search, err := utils.InferTool("search_docs", "search the Eino documentation, returns the most relevant passages",
+		func(ctx context.Context, in *searchInput) (string, error) {
+			docs, err := ret.Retrieve(ctx, in.Query)
+			if err != nil {
+				return "", err
+			}
+			logs.Infof("search_docs(%q): %d passages", in.Query, len(docs))
+			if len(docs) == 0 {
+				return "no result", nil
+			}
+			return formatDocuments(docs), nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	persona, err := prompt.MustGet("rag_agentic_agent").Format(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ra, err := react.NewAgent(ctx, &react.AgentConfig{
+		Model:           cm,
+		ToolsConfig:     compose.ToolsNodeConfig{Tools: []tool.BaseTool{search}},
+		MessageModifier: react.NewPersonaModifier(persona[0].Content),
+		// at most 4 searches and the answer
+		MaxStep: 9,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, q string) (*schema.Message, error) {
+		return ra.Generate(ctx, []*schema.Message{schema.UserMessage(q)})
+	}, nil
+}
+
+// newPipeline retrieves once with the question, whatever the question.
+func newPipeline(ctx context.Context, cm model.ChatModel, ret retriever.Retriever) (func(context.Context, string) (*schema.Message, error), error) {
+	chain := compose.NewChain[string, *schema.Message]()
+	chain.
+		AppendLambda(compose.InvokableLambda(func(ctx context.Context, q string) (map[string]any, error) {
+			docs, err := ret.Retrieve(ctx, q)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{"documents": formatDocuments(docs), "question": q}, nil
+		})).
+		AppendChatTemplate(prompt.MustGet("rag_agentic_pipeline")).
+		AppendChatModel(cm)
+
+	r, err := chain.Compile(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, q string) (*schema.Message, error) {
+		return r.Invoke(ctx, q)
+	}, nil
+}
+
+// countingRetriever counts the retrievals.
+type countingRetriever struct {
+	retriever.Retriever
+	count *atomic.Int32
+}
+
+func (c *countingRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	c.count.Add(1)
+	return c.Retriever.Retrieve(ctx, query, opts...)
+}
+
+func formatDocuments(docs []*schema.Document) string {
+	parts := make([]string, 0, len(docs))
+	for i, d := range docs {
+		parts = append(parts, fmt.Sprintf("[%d] %s", i+1, d.Content))
+	}
+	return strings.Join(parts, "\n\n")
+}
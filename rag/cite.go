@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// metaKeySource is set by the file loader to the uri of the file.
+const metaKeySource = "_source"
+
+// Answer is the output of the RAG graph: the answer of the chat model and the documents it cites.
+type Answer struct {
+	Content string
+	// Sources are the documents cited in Content, ordered by their citation number.
+	Sources []*Source
+}
+
+// Source maps a citation number such as [2] back to the retrieved document.
+type Source struct {
+	Index    int
+	DocID    string
+	Location string
+	Score    float64
+}
+
+// citationRegex matches [1] as well as [1, 3] and [1][3], which models also produce.
+var citationRegex = regexp.MustCompile(`\[(\d+(?:\s*,\s*\d+)*)\]`)
+
+// citeSources finds the citation numbers in content and resolves them against docs, numbered from 1 in the prompt.
+// Numbers the model made up, which point to no document, are dropped.
+func citeSources(content string, docs []*schema.Document) *Answer {
+	cited := map[int]bool{}
+	for _, m := range citationRegex.FindAllStringSubmatch(content, -1) {
+		for _, n := range strings.Split(m[1], ",") {
+			i, err := strconv.Atoi(strings.TrimSpace(n))
+			if err == nil && i >= 1 && i <= len(docs) {
+				cited[i] = true
+			}
+		}
+	}
+
+	answer := &Answer{Content: content}
+	for i := range cited {
+		doc := docs[i-1]
+		answer.Sources = append(answer.Sources, &Source{
+			Index:    i,
+			DocID:    doc.ID,
+			Location: location(doc),
+			Score:    doc.Score(),
+		})
+	}
+	sort.Slice(answer.Sources, func(i, j int) bool {
+		return answer.Sources[i].Index < answer.Sources[j].Index
+	})
+
+	return answer
+}
+
+// location describes where a chunk comes from, e.g. rag/testdata/eino.md § Eino > Orchestration,
+// from the source set by the loader and the headers set by the markdown splitter.
+func location(doc *schema.Document) string {
+	src, _ := doc.MetaData[metaKeySource].(string)
+	if src == "" {
+		src = doc.ID
+	}
+
+	var headers []string
+	for _, key := range []string{"h1", "h2"} {
+		if h, ok := doc.MetaData[key].(string); ok && h != "" {
+			headers = append(headers, h)
+		}
+	}
+	if len(headers) == 0 {
+		return src
+	}
+	return fmt.Sprintf("%s § %s", src, strings.Join(headers, " > "))
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/file"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/markdown"
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	redisidx "github.com/cloudwego/eino-ext/components/indexer/redis"
+	redisret "github.com/cloudwego/eino-ext/components/retriever/redis"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+)
+
+func newLoader(ctx context.Context) (document.Loader, error) {
+	return file.NewFileLoader(ctx, &file.FileLoaderConfig{})
+}
+
+func newSplitter(ctx context.Context) (document.Transformer, error) {
+	return markdown.NewHeaderSplitter(ctx, &markdown.HeaderConfig{
+		Headers: map[string]string{
+			"#":  "h1",
+			"##": "h2",
+		},
+	})
+}
+
+func newEmbedder(ctx context.Context) (embedding.Embedder, error) {
+	apiKey, err := secrets.Get(ctx, "ARK_API_KEY")
+	if err != nil {
+		return nil, err
+	}
+	return ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  apiKey,
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+}
+
+func newChatModel(ctx context.Context) (model.ChatModel, error) {
+	return models.NewChatModel(ctx)
+}
+
+func newIndexer(ctx context.Context, client *redis.Client, emb embedding.Embedder) (indexer.Indexer, error) {
+	return redisidx.NewIndexer(ctx, &redisidx.IndexerConfig{
+		Client:    client,
+		KeyPrefix: redisPrefix,
+		BatchSize: 10,
+		DocumentToHashes: func(ctx context.Context, doc *schema.Document) (*redisidx.Hashes, error) {
+			// use the hash of the content as the key, so that indexing the same document twice does not duplicate chunks
+			sum := sha256.Sum256([]byte(doc.Content))
+			doc.ID = hex.EncodeToString(sum[:8])
+
+			metadata, err := json.Marshal(doc.MetaData)
+			if err != nil {
+				return nil, fmt.Errorf("marshal metadata failed: %w", err)
+			}
+
+			return &redisidx.Hashes{
+				Key: doc.ID,
+				Field2Value: map[string]redisidx.FieldValue{
+					contentField:  {Value: doc.Content, EmbedKey: vectorField},
+					metadataField: {Value: metadata},
+				},
+			}, nil
+		},
+		Embedding: emb,
+	})
+}
+
+func newRetriever(ctx context.Context, client *redis.Client, emb embedding.Embedder) (retriever.Retriever, error) {
+	return redisret.NewRetriever(ctx, &redisret.RetrieverConfig{
+		Client:       client,
+		Index:        redisIndexName,
+		Dialect:      2,
+		ReturnFields: []string{contentField, metadataField, distanceField},
+		TopK:         3,
+		VectorField:  vectorField,
+		DocumentConverter: func(ctx context.Context, doc redis.Document) (*schema.Document, error) {
+			resp := &schema.Document{
+				ID:       doc.ID,
+				MetaData: map[string]any{},
+			}
+			for field, val := range doc.Fields {
+				switch field {
+				case contentField:
+					resp.Content = val
+				case metadataField:
+					_ = json.Unmarshal([]byte(val), &resp.MetaData)
+				case distanceField:
+					distance, err := strconv.ParseFloat(val, 64)
+					if err != nil {
+						continue
+					}
+					resp.WithScore(1 - distance)
+				}
+			}
+			return resp, nil
+		},
+		Embedding: emb,
+	})
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+// compressor shortens the retrieved documents to what is relevant to the question. A document left empty is dropped.
+type compressor interface {
+	Compress(ctx context.Context, question string, docs []*schema.Document) ([]*schema.Document, error)
+}
+
+// sentenceCompressor keeps the sentences most similar to the question. It costs one embedding call for all the
+// sentences and no chat model call, but it judges every sentence on its own: a sentence that only makes sense with
+// the previous one can be lost.
+type sentenceCompressor struct {
+	emb embedding.Embedder
+	// minScore is the cosine similarity below which a sentence is dropped.
+	minScore float64
+	// keep is the number of sentences kept per document at least, even below minScore, so that a retrieved
+	// document is never emptied completely.
+	keep int
+}
+
+func (c *sentenceCompressor) Compress(ctx context.Context, question string, docs []*schema.Document) ([]*schema.Document, error) {
+	texts := []string{question}
+	sentences := make([][]string, len(docs))
+	for i, d := range docs {
+		sentences[i] = splitSentences(d.Content)
+		texts = append(texts, sentences[i]...)
+	}
+
+	vectors, err := c.emb.EmbedStrings(ctx, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("got %d vectors for %d texts", len(vectors), len(texts))
+	}
+	q, vectors := vectors[0], vectors[1:]
+
+	out := make([]*schema.Document, 0, len(docs))
+	for i, d := range docs {
+		scores := make([]float64, len(sentences[i]))
+		for j := range sentences[i] {
+			scores[j] = cosine(q, vectors[j])
+		}
+		vectors = vectors[len(sentences[i]):]
+
+		// the c.keep best scores, sentences scoring as well as the last of them are kept too
+		threshold := c.minScore
+		if kth := kthLargest(scores, c.keep); kth < threshold {
+			threshold = kth
+		}
+		var kept []string
+		for j, s := range sentences[i] {
+			if scores[j] >= threshold {
+				kept = append(kept, s)
+			}
+		}
+		if len(kept) > 0 {
+			out = append(out, withContent(d, strings.Join(kept, "\n")))
+		}
+	}
+	return out, nil
+}
+
+// llmCompressor asks the chat model to extract the relevant sentences of each document. It understands the context,
+// and it is the most precise, but it costs a model call per document, usually with a cheaper model than the one
+// answering.
+type llmCompressor struct {
+	cm model.ChatModel
+}
+
+func (c *llmCompressor) Compress(ctx context.Context, question string, docs []*schema.Document) ([]*schema.Document, error) {
+	out := make([]*schema.Document, 0, len(docs))
+	for _, d := range docs {
+		in, err := prompt.MustGet("rag_compression_extract").Format(ctx, map[string]any{
+			"question": question,
+			"document": d.Content,
+		})
+		if err != nil {
+			return nil, err
+		}
+		msg, err := c.cm.Generate(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		content := strings.TrimSpace(msg.Content)
+		if content == "NO_OUTPUT" {
+			continue
+		}
+		out = append(out, withContent(d, content))
+	}
+	return out, nil
+}
+
+// withContent copies d with another content, the retrieved document is left as it is.
+func withContent(d *schema.Document, content string) *schema.Document {
+	return &schema.Document{ID: d.ID, Content: content, MetaData: d.MetaData}
+}
+
+// splitSentences splits on the end of sentences and on line breaks, Markdown lists and headings being one line each.
+func splitSentences(text string) []string {
+	var (
+		res []string
+		sb  strings.Builder
+	)
+	flush := func() {
+		if s := strings.TrimSpace(sb.String()); s != "" {
+			res = append(res, s)
+		}
+		sb.Reset()
+	}
+	runes := []rune(text)
+	for i, r := range runes {
+		if r == '\n' {
+			flush()
+			continue
+		}
+		sb.WriteRune(r)
+		end := r == '.' || r == '!' || r == '?'
+		// not the dot of a number or a name such as schema.Document
+		if r == '。' || end && (i+1 == len(runes) || runes[i+1] == ' ' || runes[i+1] == '\n') {
+			flush()
+		}
+	}
+	flush()
+	return res
+}
+
+func cosine(a, b []float64) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// kthLargest returns the k-th largest score, or the smallest one when there are fewer than k.
+func kthLargest(scores []float64, k int) float64 {
+	if len(scores) == 0 || k <= 0 {
+		return math.Inf(1)
+	}
+	sorted := append([]float64(nil), scores...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+	return sorted[min(k, len(sorted))-1]
+}
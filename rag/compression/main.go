@@ -0,0 +1,201 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/tokens"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+const (
+	nodeKeyPrepare   = "Prepare"
+	nodeKeyRetrieve  = "Retrieve"
+	nodeKeyCompress  = "Compress"
+	nodeKeyTemplate  = "ChatTemplate"
+	nodeKeyChatModel = "ChatModel"
+)
+
+type compressionState struct {
+	Question string
+}
+
+// Requirements:
+//   - ARK_API_KEY / ARK_EMBEDDING_MODEL for embedding
+//   - MODEL_PROVIDER and the keys of the provider for the chat model, see internal/models
+//
+// This example compresses the retrieved context before generation:
+//
+//	Prepare -> Retrieve -> Compress -> ChatTemplate -> ChatModel
+//
+// Retrieval returns whole chunks, of which often a sentence or two answer the question; the rest is paid for in
+// prompt tokens on every question, and can distract the model. The Compress node keeps only the relevant parts,
+// with -method=sentence (embedding similarity of every sentence) or -method=llm (a model extracts the sentences),
+// and prints the tokens of the context before and after. -method=none skips it, for comparison.
+func main() {
+	source := flag.String("source", "rag/testdata/eino.md", "path of the document to index")
+	question := flag.String("question", "How can a handler receive the callbacks of only one run?", "question to ask")
+	method := flag.String("method", "sentence", "sentence, llm or none")
+	minScore := flag.Float64("min-score", 0.5, "for -method=sentence, similarity under which a sentence is dropped, depends on the embedding model")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	cm := models.MustChatModel(ctx)
+
+	var comp compressor
+	switch *method {
+	case "sentence":
+		comp = &sentenceCompressor{emb: emb, minScore: *minScore, keep: 1}
+	case "llm":
+		comp = &llmCompressor{cm: cm}
+	case "none":
+	default:
+		logs.Fatalf("unknown method %q, use sentence, llm or none", *method)
+	}
+
+	content, err := os.ReadFile(*source)
+	if err != nil {
+		logs.Fatalf("read %s failed, err=%v", *source, err)
+	}
+	splitter, err := recursive.NewSplitter(ctx, &recursive.Config{ChunkSize: 500, OverlapSize: 50})
+	if err != nil {
+		logs.Fatalf("recursive.NewSplitter failed, err=%v", err)
+	}
+	chunks, err := splitter.Transform(ctx, []*schema.Document{{ID: *source, Content: string(content)}})
+	if err != nil {
+		logs.Fatalf("split failed, err=%v", err)
+	}
+	for i, c := range chunks {
+		c.ID = fmt.Sprintf("%s#%d", *source, i)
+	}
+
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 3})
+	if err != nil {
+		logs.Fatalf("create memory store failed, err=%v", err)
+	}
+	if _, err = store.Store(ctx, chunks); err != nil {
+		logs.Fatalf("index failed, err=%v", err)
+	}
+
+	counter := tokens.For(config.String("OPENAI_MODEL_NAME", ""))
+
+	runner, err := buildGraph(ctx, store, comp, cm, counter)
+	if err != nil {
+		logs.Fatalf("buildGraph failed, err=%v", err)
+	}
+	answer, err := runner.Invoke(ctx, *question)
+	if err != nil {
+		logs.Fatalf("Invoke failed, err=%v", err)
+	}
+
+	logs.Infof("question: %s", *question)
+	logs.Infof("answer: %s", answer.Content)
+}
+
+func buildGraph(ctx context.Context, ret retriever.Retriever, comp compressor, cm model.ChatModel,
+	counter *tokens.Counter) (compose.Runnable[string, *schema.Message], error) {
+
+	g := compose.NewGraph[string, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *compressionState {
+		return &compressionState{}
+	}))
+
+	_ = g.AddLambdaNode(nodeKeyPrepare, compose.InvokableLambda(func(ctx context.Context, question string) (string, error) {
+		err := compose.ProcessState[*compressionState](ctx, func(_ context.Context, s *compressionState) error {
+			s.Question = question
+			return nil
+		})
+		return question, err
+	}))
+	_ = g.AddRetrieverNode(nodeKeyRetrieve, ret)
+	_ = g.AddLambdaNode(nodeKeyCompress, compose.InvokableLambda(func(ctx context.Context, docs []*schema.Document) (map[string]any, error) {
+		var question string
+		err := compose.ProcessState[*compressionState](ctx, func(_ context.Context, s *compressionState) error {
+			question = s.Question
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		before := formatDocuments(docs)
+		after := before
+		if comp != nil {
+			compressed, err := comp.Compress(ctx, question, docs)
+			if err != nil {
+				return nil, err
+			}
+			after = formatDocuments(compressed)
+		}
+
+		tokensBefore, tokensAfter := counter.Count(before), counter.Count(after)
+		ratio := 0.0
+		if tokensBefore > 0 {
+			ratio = 100 * (1 - float64(tokensAfter)/float64(tokensBefore))
+		}
+		logs.Infof("context: %d tokens before, %d tokens after compression, %.0f%% saved", tokensBefore, tokensAfter, ratio)
+		logs.Infof("compressed context:\n%s", after)
+
+		return map[string]any{"documents": after, "question": question}, nil
+	}))
+	_ = g.AddChatTemplateNode(nodeKeyTemplate, prompt.MustGet("rag_answer"))
+	_ = g.AddChatModelNode(nodeKeyChatModel, cm)
+
+	_ = g.AddEdge(compose.START, nodeKeyPrepare)
+	_ = g.AddEdge(nodeKeyPrepare, nodeKeyRetrieve)
+	_ = g.AddEdge(nodeKeyRetrieve, nodeKeyCompress)
+	_ = g.AddEdge(nodeKeyCompress, nodeKeyTemplate)
+	_ = g.AddEdge(nodeKeyTemplate, nodeKeyChatModel)
+	_ = g.AddEdge(nodeKeyChatModel, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("CompressionRAG"))
+}
+
+func formatDocuments(docs []*schema.Document) string {
+	parts := make([]string, 0, len(docs))
+	for i, d := range docs {
+		parts = append(parts, fmt.Sprintf("[%d] %s", i+1, d.Content))
+	}
+	return strings.Join(parts, "\n\n")
+}
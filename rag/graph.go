@@ -0,0 +1,179 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodeKeyPrepare   = "Prepare"
+	nodeKeyLoader    = "Loader"
+	nodeKeySplitter  = "Splitter"
+	nodeKeyIndexer   = "Indexer"
+	nodeKeyToQuery   = "ToQuery"
+	nodeKeyRetriever = "Retriever"
+	nodeKeyRerank    = "Rerank"
+	nodeKeyToVars    = "ToVariables"
+	nodeKeyTemplate  = "ChatTemplate"
+	nodeKeyChatModel = "ChatModel"
+	nodeKeyCite      = "Cite"
+)
+
+// Input is the input of the RAG graph: the document to be indexed and the question to be answered.
+type Input struct {
+	Source   document.Source
+	Question string
+}
+
+type ragState struct {
+	Question string
+	// Documents are the ones sent to the chat model, kept to resolve the citations in the answer
+	Documents []*schema.Document
+}
+
+type ragComponents struct {
+	Loader    document.Loader
+	Splitter  document.Transformer
+	Embedder  embedding.Embedder
+	Indexer   indexer.Indexer
+	Retriever retriever.Retriever
+	ChatModel model.ChatModel
+	// Reranker is optional, when set the retrieved documents are reranked before being sent to the chat model
+	Reranker *apiReranker
+}
+
+// buildRAGGraph orchestrates the whole pipeline in a single graph:
+//
+//	Prepare -> Loader -> Splitter -> Indexer -> ToQuery -> Retriever -> ToVariables -> ChatTemplate -> ChatModel -> Cite
+//
+// The question is kept in the graph state while the document is being indexed,
+// and taken out again once the indexer finishes.
+// The documents sent to the chat model are kept in the state as well, so that Cite can map
+// the citations in the answer back to them. With a reranker, a Rerank node is inserted between Retriever and ToVariables.
+func buildRAGGraph(ctx context.Context, c *ragComponents) (compose.Runnable[*Input, *Answer], error) {
+	g := compose.NewGraph[*Input, *Answer](compose.WithGenLocalState(func(ctx context.Context) *ragState {
+		return &ragState{}
+	}))
+
+	_ = g.AddLambdaNode(nodeKeyPrepare, compose.InvokableLambda(func(ctx context.Context, in *Input) (document.Source, error) {
+		err := compose.ProcessState[*ragState](ctx, func(_ context.Context, s *ragState) error {
+			s.Question = in.Question
+			return nil
+		})
+		return in.Source, err
+	}))
+	_ = g.AddLoaderNode(nodeKeyLoader, c.Loader)
+	_ = g.AddDocumentTransformerNode(nodeKeySplitter, c.Splitter)
+	_ = g.AddIndexerNode(nodeKeyIndexer, c.Indexer)
+	_ = g.AddLambdaNode(nodeKeyToQuery, compose.InvokableLambda(func(ctx context.Context, ids []string) (string, error) {
+		logs.Infof("indexed %d chunks", len(ids))
+
+		var question string
+		err := compose.ProcessState[*ragState](ctx, func(_ context.Context, s *ragState) error {
+			question = s.Question
+			return nil
+		})
+		return question, err
+	}))
+	_ = g.AddRetrieverNode(nodeKeyRetriever, c.Retriever)
+	if c.Reranker != nil {
+		_ = g.AddLambdaNode(nodeKeyRerank, compose.InvokableLambda(func(ctx context.Context, docs []*schema.Document) ([]*schema.Document, error) {
+			var question string
+			err := compose.ProcessState[*ragState](ctx, func(_ context.Context, s *ragState) error {
+				question = s.Question
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			logs.Infof("reranking %d retrieved chunks", len(docs))
+			return c.Reranker.Rerank(ctx, question, docs)
+		}))
+	}
+	_ = g.AddLambdaNode(nodeKeyToVars, compose.InvokableLambda(func(ctx context.Context, docs []*schema.Document) (map[string]any, error) {
+		var question string
+		err := compose.ProcessState[*ragState](ctx, func(_ context.Context, s *ragState) error {
+			question = s.Question
+			s.Documents = docs
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]any{
+			"documents": formatDocuments(docs),
+			"question":  question,
+		}, nil
+	}))
+	_ = g.AddChatTemplateNode(nodeKeyTemplate, prompt.MustGet("rag_answer_cited"))
+	_ = g.AddChatModelNode(nodeKeyChatModel, c.ChatModel)
+	_ = g.AddLambdaNode(nodeKeyCite, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*Answer, error) {
+		var docs []*schema.Document
+		err := compose.ProcessState[*ragState](ctx, func(_ context.Context, s *ragState) error {
+			docs = s.Documents
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return citeSources(msg.Content, docs), nil
+	}))
+
+	_ = g.AddEdge(compose.START, nodeKeyPrepare)
+	_ = g.AddEdge(nodeKeyPrepare, nodeKeyLoader)
+	_ = g.AddEdge(nodeKeyLoader, nodeKeySplitter)
+	_ = g.AddEdge(nodeKeySplitter, nodeKeyIndexer)
+	_ = g.AddEdge(nodeKeyIndexer, nodeKeyToQuery)
+	_ = g.AddEdge(nodeKeyToQuery, nodeKeyRetriever)
+	if c.Reranker != nil {
+		_ = g.AddEdge(nodeKeyRetriever, nodeKeyRerank)
+		_ = g.AddEdge(nodeKeyRerank, nodeKeyToVars)
+	} else {
+		_ = g.AddEdge(nodeKeyRetriever, nodeKeyToVars)
+	}
+	_ = g.AddEdge(nodeKeyToVars, nodeKeyTemplate)
+	_ = g.AddEdge(nodeKeyTemplate, nodeKeyChatModel)
+	_ = g.AddEdge(nodeKeyChatModel, nodeKeyCite)
+	_ = g.AddEdge(nodeKeyCite, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("RAG"))
+}
+
+func formatDocuments(docs []*schema.Document) string {
+	sb := strings.Builder{}
+	for i, doc := range docs {
+		logs.Infof("retrieved [%d] id=%s, score=%.3f, metadata=%v", i+1, doc.ID, doc.Score(), doc.MetaData)
+		sb.WriteString(fmt.Sprintf("[%d] %s\n\n", i+1, doc.Content))
+	}
+	return sb.String()
+}
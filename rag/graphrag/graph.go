@@ -0,0 +1,134 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodeKeyPrepare        = "Prepare"
+	nodeKeyEntityRetrieve = "EntityRetriever"
+	nodeKeyTraverse       = "Traverse"
+	nodeKeyChunkRetrieve  = "ChunkRetriever"
+	nodeKeyToVars         = "ToVariables"
+	nodeKeyTemplate       = "ChatTemplate"
+	nodeKeyChatModel      = "ChatModel"
+
+	outputKeyFacts  = "facts"
+	outputKeyChunks = "chunks"
+)
+
+type graphRAGState struct {
+	Question string
+}
+
+// buildGraphRAG retrieves along two paths and merges them into the prompt:
+//
+//	            -> EntityRetriever -> Traverse -
+//	Prepare -<                                   >- ToVariables -> ChatTemplate -> ChatModel
+//	            -> ChunkRetriever --------------
+//
+// The entities closest to the question are the seeds of a walk in the knowledge graph, which collects the facts
+// up to hops relations away. A question like "who manages the team that built the vision of Orbit?" needs two
+// facts from two paragraphs, which vector retrieval of passages alone finds only by luck; the passages still bring
+// the details the extracted facts lose.
+func buildGraphRAG(ctx context.Context, kg *knowledgeGraph, entities, chunks retriever.Retriever, cm model.ChatModel,
+	hops, maxFacts int) (compose.Runnable[string, *schema.Message], error) {
+
+	g := compose.NewGraph[string, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *graphRAGState {
+		return &graphRAGState{}
+	}))
+
+	_ = g.AddLambdaNode(nodeKeyPrepare, compose.InvokableLambda(func(ctx context.Context, question string) (string, error) {
+		err := compose.ProcessState[*graphRAGState](ctx, func(_ context.Context, s *graphRAGState) error {
+			s.Question = question
+			return nil
+		})
+		return question, err
+	}))
+	_ = g.AddRetrieverNode(nodeKeyEntityRetrieve, entities)
+	_ = g.AddLambdaNode(nodeKeyTraverse, compose.InvokableLambda(func(ctx context.Context, docs []*schema.Document) ([]string, error) {
+		seeds := make([]string, 0, len(docs))
+		for _, d := range docs {
+			seeds = append(seeds, d.ID)
+		}
+		logs.Infof("seed entities: %s", strings.Join(seeds, ", "))
+		facts := kg.neighborhood(seeds, hops, maxFacts)
+		logs.Infof("%d facts within %d hops", len(facts), hops)
+		return facts, nil
+	}), compose.WithOutputKey(outputKeyFacts))
+	_ = g.AddRetrieverNode(nodeKeyChunkRetrieve, chunks, compose.WithOutputKey(outputKeyChunks))
+	_ = g.AddLambdaNode(nodeKeyToVars, compose.InvokableLambda(func(ctx context.Context, in map[string]any) (map[string]any, error) {
+		facts, _ := in[outputKeyFacts].([]string)
+		passages, _ := in[outputKeyChunks].([]*schema.Document)
+
+		var question string
+		err := compose.ProcessState[*graphRAGState](ctx, func(_ context.Context, s *graphRAGState) error {
+			question = s.Question
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]any{
+			"facts":    formatFacts(facts),
+			"passages": formatPassages(passages),
+			"question": question,
+		}, nil
+	}))
+	_ = g.AddChatTemplateNode(nodeKeyTemplate, prompt.MustGet("rag_graphrag_answer"))
+	_ = g.AddChatModelNode(nodeKeyChatModel, cm)
+
+	_ = g.AddEdge(compose.START, nodeKeyPrepare)
+	_ = g.AddEdge(nodeKeyPrepare, nodeKeyEntityRetrieve)
+	_ = g.AddEdge(nodeKeyEntityRetrieve, nodeKeyTraverse)
+	_ = g.AddEdge(nodeKeyPrepare, nodeKeyChunkRetrieve)
+	_ = g.AddEdge(nodeKeyTraverse, nodeKeyToVars)
+	_ = g.AddEdge(nodeKeyChunkRetrieve, nodeKeyToVars)
+	_ = g.AddEdge(nodeKeyToVars, nodeKeyTemplate)
+	_ = g.AddEdge(nodeKeyTemplate, nodeKeyChatModel)
+	_ = g.AddEdge(nodeKeyChatModel, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("GraphRAG"))
+}
+
+func formatFacts(facts []string) string {
+	if len(facts) == 0 {
+		return "(none)"
+	}
+	return "- " + strings.Join(facts, "\n- ")
+}
+
+func formatPassages(docs []*schema.Document) string {
+	parts := make([]string, 0, len(docs))
+	for i, d := range docs {
+		parts = append(parts, fmt.Sprintf("[%d] %s", i+1, d.Content))
+	}
+	return strings.Join(parts, "\n\n")
+}
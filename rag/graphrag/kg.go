@@ -0,0 +1,202 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// extraction is what the model extracts from one chunk, as the arguments of a forced tool call.
+type extraction struct {
+	Entities  []*extractedEntity   `json:"entities"`
+	Relations []*extractedRelation `json:"relations"`
+}
+
+type extractedEntity struct {
+	Name        string `json:"name" jsonschema:"description=full name as written in the text"`
+	Type        string `json:"type" jsonschema:"description=person or organization or team or product or place or event"`
+	Description string `json:"description" jsonschema:"description=one sentence about the entity from the text"`
+}
+
+type extractedRelation struct {
+	Source   string `json:"source" jsonschema:"description=name of an entity of the list"`
+	Relation string `json:"relation" jsonschema:"description=short verb phrase such as manages or built or supplies"`
+	Target   string `json:"target" jsonschema:"description=name of an entity of the list"`
+}
+
+type entity struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Descriptions []string `json:"descriptions"`
+	Chunks       []string `json:"chunks"`
+}
+
+type relation struct {
+	Source   string `json:"source"`
+	Relation string `json:"relation"`
+	Target   string `json:"target"`
+	Chunk    string `json:"chunk"`
+}
+
+// knowledgeGraph is a small in-memory graph of entities and relations, kept in a JSON file. The same entity
+// named in several chunks is merged by its name, case and spaces ignored; it is no entity resolution, "P. Raman"
+// and "Priya Raman" stay two entities.
+type knowledgeGraph struct {
+	Entities  map[string]*entity `json:"entities"`
+	Relations []*relation        `json:"relations"`
+
+	// adjacency maps an entity key to the indexes of its relations, both directions
+	adjacency map[string][]int
+}
+
+func newKnowledgeGraph() *knowledgeGraph {
+	return &knowledgeGraph{Entities: map[string]*entity{}, adjacency: map[string][]int{}}
+}
+
+func entityKey(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// add merges the extraction of a chunk. Relations between entities not in the extraction are dropped, the model
+// sometimes relates a name it did not list.
+func (kg *knowledgeGraph) add(chunkID string, ext *extraction) {
+	for _, e := range ext.Entities {
+		key := entityKey(e.Name)
+		if key == "" {
+			continue
+		}
+		ent, ok := kg.Entities[key]
+		if !ok {
+			ent = &entity{Name: e.Name, Type: strings.ToLower(e.Type)}
+			kg.Entities[key] = ent
+		}
+		if e.Description != "" {
+			ent.Descriptions = append(ent.Descriptions, e.Description)
+		}
+		ent.Chunks = appendUnique(ent.Chunks, chunkID)
+	}
+	for _, r := range ext.Relations {
+		source, target := entityKey(r.Source), entityKey(r.Target)
+		if kg.Entities[source] == nil || kg.Entities[target] == nil || source == target {
+			continue
+		}
+		kg.Relations = append(kg.Relations, &relation{Source: source, Relation: r.Relation, Target: target, Chunk: chunkID})
+		i := len(kg.Relations) - 1
+		kg.adjacency[source] = append(kg.adjacency[source], i)
+		kg.adjacency[target] = append(kg.adjacency[target], i)
+	}
+}
+
+// neighborhood walks the relations from the seed entities, breadth first, up to hops relations away, and returns
+// the facts found, at most limit of them, the closest first.
+func (kg *knowledgeGraph) neighborhood(seeds []string, hops, limit int) []string {
+	visited := map[string]bool{}
+	used := map[int]bool{}
+	frontier := make([]string, 0, len(seeds))
+	for _, s := range seeds {
+		if kg.Entities[s] != nil && !visited[s] {
+			visited[s] = true
+			frontier = append(frontier, s)
+		}
+	}
+
+	var facts []string
+	for hop := 0; hop < hops && len(frontier) > 0; hop++ {
+		var next []string
+		for _, key := range frontier {
+			for _, i := range kg.adjacency[key] {
+				if used[i] {
+					continue
+				}
+				used[i] = true
+				r := kg.Relations[i]
+				facts = append(facts, kg.formatRelation(r))
+				if len(facts) == limit {
+					return facts
+				}
+				for _, k := range []string{r.Source, r.Target} {
+					if !visited[k] {
+						visited[k] = true
+						next = append(next, k)
+					}
+				}
+			}
+		}
+		frontier = next
+	}
+	return facts
+}
+
+func (kg *knowledgeGraph) formatRelation(r *relation) string {
+	return fmt.Sprintf("%s %s %s", kg.Entities[r.Source].Name, r.Relation, kg.Entities[r.Target].Name)
+}
+
+// describe is the text of an entity which is embedded to find it from a question.
+func (e *entity) describe() string {
+	return fmt.Sprintf("%s (%s): %s", e.Name, e.Type, strings.Join(e.Descriptions, " "))
+}
+
+func (kg *knowledgeGraph) save(path string) error {
+	data, err := json.MarshalIndent(kg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func loadKnowledgeGraph(path string) (*knowledgeGraph, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	kg := newKnowledgeGraph()
+	if err = json.Unmarshal(data, kg); err != nil {
+		return nil, err
+	}
+	for i, r := range kg.Relations {
+		kg.adjacency[r.Source] = append(kg.adjacency[r.Source], i)
+		kg.adjacency[r.Target] = append(kg.adjacency[r.Target], i)
+	}
+	return kg, nil
+}
+
+// keys returns the keys of the entities, sorted, for a stable order of indexing.
+func (kg *knowledgeGraph) keys() []string {
+	keys := make([]string, 0, len(kg.Entities))
+	for k := range kg.Entities {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func appendUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
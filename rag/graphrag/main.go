@@ -0,0 +1,189 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/tool/utils"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+// Requirements:
+//   - ARK_API_KEY / ARK_EMBEDDING_MODEL for embedding
+//   - MODEL_PROVIDER and the keys of the provider for the chat model, see internal/models
+//
+// This example builds a knowledge graph from a document, then answers questions with it. Indexing:
+//
+//	chunks -> model extracts entities and relations -> knowledge graph, saved to -graph
+//	       -> entity descriptions and chunks embedded in two vectorstore.MemoryStore
+//
+// The extraction is the expensive part, one model call per chunk; the graph is saved and reused by the next runs,
+// -rebuild extracts it again.
+func main() {
+	source := flag.String("source", "rag/graphrag/testdata/northwind.md", "path of the document to index")
+	graphPath := flag.String("graph", ".cache/graphrag/northwind.json", "where the knowledge graph is saved")
+	rebuild := flag.Bool("rebuild", false, "extract the knowledge graph again even if it was saved")
+	question := flag.String("question", "", "question to ask, default a few questions about the sample document")
+	hops := flag.Int("hops", 2, "max number of relations walked from the entities of the question")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	cm := models.MustChatModel(ctx)
+
+	content, err := os.ReadFile(*source)
+	if err != nil {
+		logs.Fatalf("read %s failed, err=%v", *source, err)
+	}
+	splitter, err := recursive.NewSplitter(ctx, &recursive.Config{ChunkSize: 800, OverlapSize: 80})
+	if err != nil {
+		logs.Fatalf("recursive.NewSplitter failed, err=%v", err)
+	}
+	chunks, err := splitter.Transform(ctx, []*schema.Document{{ID: *source, Content: string(content)}})
+	if err != nil {
+		logs.Fatalf("split failed, err=%v", err)
+	}
+	for i, c := range chunks {
+		c.ID = fmt.Sprintf("%s#%d", *source, i)
+	}
+
+	kg, err := loadKnowledgeGraph(*graphPath)
+	if *rebuild || errors.Is(err, os.ErrNotExist) {
+		kg, err = buildKnowledgeGraph(ctx, chunks)
+		if err == nil {
+			err = kg.save(*graphPath)
+		}
+	}
+	if err != nil {
+		logs.Fatalf("knowledge graph failed, err=%v", err)
+	}
+	logs.Infof("knowledge graph: %d entities, %d relations", len(kg.Entities), len(kg.Relations))
+
+	entityStore, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 3})
+	if err != nil {
+		logs.Fatalf("create memory store failed, err=%v", err)
+	}
+	entityDocs := make([]*schema.Document, 0, len(kg.Entities))
+	for _, key := range kg.keys() {
+		entityDocs = append(entityDocs, &schema.Document{ID: key, Content: kg.Entities[key].describe()})
+	}
+	if _, err = entityStore.Store(ctx, entityDocs); err != nil {
+		logs.Fatalf("index entities failed, err=%v", err)
+	}
+	chunkStore, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 2})
+	if err != nil {
+		logs.Fatalf("create memory store failed, err=%v", err)
+	}
+	if _, err = chunkStore.Store(ctx, chunks); err != nil {
+		logs.Fatalf("index chunks failed, err=%v", err)
+	}
+
+	runner, err := buildGraphRAG(ctx, kg, entityStore, chunkStore, cm, *hops, 30)
+	if err != nil {
+		logs.Fatalf("buildGraphRAG failed, err=%v", err)
+	}
+
+	questions := []string{
+		"Who manages the team that built the vision system of Orbit?",
+		"Which supplier's parts were involved in the incident at Freshway, and who fixed it?",
+		"How is the company founded by Tomas Berg connected to Northwind Robotics today?",
+	}
+	if *question != "" {
+		questions = []string{*question}
+	}
+	for _, q := range questions {
+		logs.Infof("question: %s", q)
+		answer, err := runner.Invoke(ctx, q)
+		if err != nil {
+			logs.Fatalf("Invoke failed, err=%v", err)
+		}
+		logs.Infof("answer: %s", answer.Content)
+	}
+}
+
+// buildKnowledgeGraph extracts the entities and relations of every chunk with a model forced to call a tool whose
+// parameters are the schema of extraction.
+func buildKnowledgeGraph(ctx context.Context, chunks []*schema.Document) (*knowledgeGraph, error) {
+	info, err := utils.GoStruct2ToolInfo[extraction]("save_extraction", "save the entities and relations extracted from the text")
+	if err != nil {
+		return nil, err
+	}
+	// forced tool choice is a method of the OpenAI client, so the provider is openai, azure or claude
+	extractor, err := models.NewOpenAIChatModel(ctx, models.WithTemperature(0))
+	if err != nil {
+		return nil, err
+	}
+	if err = extractor.BindForcedTools([]*schema.ToolInfo{info}); err != nil {
+		return nil, err
+	}
+
+	kg := newKnowledgeGraph()
+	for _, c := range chunks {
+		ext, err := extract(ctx, extractor, c.Content)
+		if err != nil {
+			return nil, fmt.Errorf("extract %s failed: %w", c.ID, err)
+		}
+		logs.Infof("%s: %d entities, %d relations", c.ID, len(ext.Entities), len(ext.Relations))
+		kg.add(c.ID, ext)
+	}
+	return kg, nil
+}
+
+func extract(ctx context.Context, cm model.ChatModel, text string) (*extraction, error) {
+	in, err := prompt.MustGet("rag_graphrag_extract").Format(ctx, map[string]any{"text": text})
+	if err != nil {
+		return nil, err
+	}
+	msg, err := cm.Generate(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if len(msg.ToolCalls) == 0 {
+		return nil, fmt.Errorf("the model did not call the extraction tool")
+	}
+	ext := &extraction{}
+	if err = json.Unmarshal([]byte(msg.ToolCalls[0].Function.Arguments), ext); err != nil {
+		return nil, fmt.Errorf("decode extraction failed: %w", err)
+	}
+	return ext, nil
+}
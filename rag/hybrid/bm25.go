@@ -0,0 +1,125 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+)
+
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// bm25Retriever is a small in-memory keyword retriever scoring documents with Okapi BM25.
+// It is built once from a fixed corpus, which is all the example needs.
+type bm25Retriever struct {
+	docs   []*schema.Document
+	terms  []map[string]int // term frequencies of every document
+	lens   []int
+	avgLen float64
+	df     map[string]int // number of documents containing a term
+	topK   int
+}
+
+func newBM25Retriever(docs []*schema.Document, topK int) *bm25Retriever {
+	r := &bm25Retriever{
+		docs:  docs,
+		terms: make([]map[string]int, len(docs)),
+		lens:  make([]int, len(docs)),
+		df:    map[string]int{},
+		topK:  topK,
+	}
+
+	total := 0
+	for i, doc := range docs {
+		tokens := tokenize(doc.Content)
+		tf := map[string]int{}
+		for _, t := range tokens {
+			tf[t]++
+		}
+		for t := range tf {
+			r.df[t]++
+		}
+		r.terms[i] = tf
+		r.lens[i] = len(tokens)
+		total += len(tokens)
+	}
+	if len(docs) > 0 {
+		r.avgLen = float64(total) / float64(len(docs))
+	}
+
+	return r
+}
+
+func (r *bm25Retriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	topK := r.topK
+	options := retriever.GetCommonOptions(&retriever.Options{TopK: &topK}, opts...)
+
+	n := float64(len(r.docs))
+	qTerms := tokenize(query)
+
+	type hit struct {
+		idx   int
+		score float64
+	}
+	var hits []hit
+	for i, tf := range r.terms {
+		score := 0.0
+		for _, t := range qTerms {
+			f := float64(tf[t])
+			if f == 0 {
+				continue
+			}
+			idf := math.Log(1 + (n-float64(r.df[t])+0.5)/(float64(r.df[t])+0.5))
+			score += idf * f * (bm25K1 + 1) / (f + bm25K1*(1-bm25B+bm25B*float64(r.lens[i])/r.avgLen))
+		}
+		// documents sharing no term with the query are not a keyword match at all
+		if score > 0 {
+			hits = append(hits, hit{idx: i, score: score})
+		}
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].score > hits[j].score
+	})
+	if len(hits) > *options.TopK {
+		hits = hits[:*options.TopK]
+	}
+
+	docs := make([]*schema.Document, len(hits))
+	for i, h := range hits {
+		d := r.docs[h.idx]
+		docs[i] = (&schema.Document{ID: d.ID, Content: d.Content, MetaData: map[string]any{}}).WithScore(h.score)
+	}
+	return docs, nil
+}
+
+// tokenize lower-cases s and splits it on everything but letters, digits and underscores,
+// so that identifiers such as ERR_QUOTA_4012 stay one token.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+}
@@ -0,0 +1,160 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodeKeyPrepare   = "Prepare"
+	nodeKeyBM25      = "BM25Retriever"
+	nodeKeyVector    = "VectorRetriever"
+	nodeKeyFuse      = "RRFFuse"
+	nodeKeyTemplate  = "ChatTemplate"
+	nodeKeyChatModel = "ChatModel"
+
+	// rrfK dampens the advantage of the very first ranks, 60 is the value from the original RRF paper
+	rrfK = 60
+	// fusedTopK is the number of documents passed to the chat model after fusion
+	fusedTopK = 3
+)
+
+type hybridState struct {
+	Question string
+}
+
+// buildHybridGraph runs keyword and vector retrieval in parallel and fuses the two rankings:
+//
+//	            -> BM25Retriever   -
+//	Prepare -<                      >- RRFFuse -> ChatTemplate -> ChatModel
+//	            -> VectorRetriever -
+//
+// Both retrievers write their result under their own output key,
+// so the fuse node receives one map with both lists once the two branches have finished.
+func buildHybridGraph(ctx context.Context, bm25, vector retriever.Retriever, cm model.ChatModel) (compose.Runnable[string, *schema.Message], error) {
+	g := compose.NewGraph[string, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *hybridState {
+		return &hybridState{}
+	}))
+
+	_ = g.AddLambdaNode(nodeKeyPrepare, compose.InvokableLambda(func(ctx context.Context, question string) (string, error) {
+		err := compose.ProcessState[*hybridState](ctx, func(_ context.Context, s *hybridState) error {
+			s.Question = question
+			return nil
+		})
+		return question, err
+	}))
+	_ = g.AddRetrieverNode(nodeKeyBM25, bm25, compose.WithOutputKey(nodeKeyBM25))
+	_ = g.AddRetrieverNode(nodeKeyVector, vector, compose.WithOutputKey(nodeKeyVector))
+	_ = g.AddLambdaNode(nodeKeyFuse, compose.InvokableLambda(func(ctx context.Context, in map[string]any) (map[string]any, error) {
+		keyword, _ := in[nodeKeyBM25].([]*schema.Document)
+		semantic, _ := in[nodeKeyVector].([]*schema.Document)
+
+		logRanking("bm25", keyword)
+		logRanking("vector", semantic)
+
+		fused := fuseRRF(rrfK, keyword, semantic)
+		if len(fused) > fusedTopK {
+			fused = fused[:fusedTopK]
+		}
+		logRanking("rrf", fused)
+
+		var question string
+		err := compose.ProcessState[*hybridState](ctx, func(_ context.Context, s *hybridState) error {
+			question = s.Question
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]any{
+			"documents": formatDocuments(fused),
+			"question":  question,
+		}, nil
+	}))
+	_ = g.AddChatTemplateNode(nodeKeyTemplate, prompt.MustGet("rag_answer"))
+	_ = g.AddChatModelNode(nodeKeyChatModel, cm)
+
+	_ = g.AddEdge(compose.START, nodeKeyPrepare)
+	_ = g.AddEdge(nodeKeyPrepare, nodeKeyBM25)
+	_ = g.AddEdge(nodeKeyPrepare, nodeKeyVector)
+	_ = g.AddEdge(nodeKeyBM25, nodeKeyFuse)
+	_ = g.AddEdge(nodeKeyVector, nodeKeyFuse)
+	_ = g.AddEdge(nodeKeyFuse, nodeKeyTemplate)
+	_ = g.AddEdge(nodeKeyTemplate, nodeKeyChatModel)
+	_ = g.AddEdge(nodeKeyChatModel, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("HybridRAG"))
+}
+
+// fuseRRF merges several rankings with reciprocal rank fusion: every document scores sum(1 / (k + rank))
+// over the lists it appears in. Only ranks are used, so BM25 scores and cosine similarities,
+// which live on different scales, never have to be normalized against each other.
+func fuseRRF(k int, lists ...[]*schema.Document) []*schema.Document {
+	scores := map[string]float64{}
+	docs := map[string]*schema.Document{}
+	var order []string
+
+	for _, list := range lists {
+		for rank, doc := range list {
+			if _, ok := docs[doc.ID]; !ok {
+				docs[doc.ID] = doc
+				order = append(order, doc.ID)
+			}
+			scores[doc.ID] += 1 / float64(k+rank+1)
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	fused := make([]*schema.Document, len(order))
+	for i, id := range order {
+		d := docs[id]
+		fused[i] = (&schema.Document{ID: d.ID, Content: d.Content, MetaData: map[string]any{}}).WithScore(scores[id])
+	}
+	return fused
+}
+
+func logRanking(name string, docs []*schema.Document) {
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = fmt.Sprintf("%s(%.3f)", doc.ID, doc.Score())
+	}
+	logs.Infof("%-6s: %s", name, strings.Join(ids, " "))
+}
+
+func formatDocuments(docs []*schema.Document) string {
+	sb := strings.Builder{}
+	for i, doc := range docs {
+		sb.WriteString(fmt.Sprintf("[%d] %s\n\n", i+1, doc.Content))
+	}
+	return sb.String()
+}
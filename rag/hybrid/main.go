@@ -0,0 +1,88 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+// corpus mixes prose with identifiers: vector search handles the paraphrased questions,
+// BM25 handles the exact error codes, and RRF keeps what either of them is good at.
+var corpus = []*schema.Document{
+	{ID: "quota", Content: "Error ERR_QUOTA_4012 is returned when the monthly token quota of the account is used up. Upgrade the plan or wait for the next billing cycle."},
+	{ID: "ratelimit", Content: "Requests beyond the per minute limit are rejected with HTTP 429. Slow down and retry with exponential backoff."},
+	{ID: "auth", Content: "Error ERR_AUTH_1001 means the API key is missing or invalid. Create a new key in the console."},
+	{ID: "timeout", Content: "Error ERR_TIMEOUT_5003 is returned when generation takes longer than the request timeout. Use streaming for long answers."},
+	{ID: "streaming", Content: "Set stream to true to receive the answer incrementally as server sent events."},
+	{ID: "models", Content: "The models endpoint lists every model the account can call, with its context window."},
+}
+
+// Requirements:
+//   - ARK_API_KEY / ARK_EMBEDDING_MODEL for embedding
+//   - MODEL_PROVIDER and the keys of the provider for the chat model, see internal/models
+func main() {
+	question := flag.String("question", "What does ERR_QUOTA_4012 mean and how do I get unblocked?", "question to ask")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+
+	cm := models.MustChatModel(ctx)
+
+	// each retriever returns more candidates than the chat model gets, fusion picks the final ones
+	vector, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 5})
+	if err != nil {
+		logs.Fatalf("create memory store failed, err=%v", err)
+	}
+	if _, err = vector.Store(ctx, corpus); err != nil {
+		logs.Fatalf("index corpus failed, err=%v", err)
+	}
+	bm25 := newBM25Retriever(corpus, 5)
+
+	runner, err := buildHybridGraph(ctx, bm25, vector, cm)
+	if err != nil {
+		logs.Fatalf("build hybrid graph failed, err=%v", err)
+	}
+
+	answer, err := runner.Invoke(ctx, *question)
+	if err != nil {
+		logs.Fatalf("invoke hybrid graph failed, err=%v", err)
+	}
+
+	logs.Infof("question: %s", *question)
+	logs.Infof("answer: %s", answer.Content)
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/markdown"
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/embedcache"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/store"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+const (
+	metaKeySource   = "_source"
+	metaKeyFileHash = "file_hash"
+)
+
+// This example keeps a vector store in sync with a directory of documents. Run it, edit, add or delete
+// a file under -dir, and run it again: only the files whose content changed are embedded again,
+// and the chunks of changed and deleted files are removed from the store.
+//
+// The vectors are also cached by text, in -data/embeddings.db by default: the chunks of a changed file which did not
+// change themselves, or the whole corpus indexed again once the store is gone, are not embedded again.
+//
+//	ARK_API_KEY=xxx ARK_EMBEDDING_MODEL=xxx go run ./rag/ingest -dir rag/testdata
+func main() {
+	dir := flag.String("dir", "rag/testdata", "directory of the documents to index")
+	dataDir := flag.String("data", ".cache/ingest", "directory of the vector store and the manifest")
+	dryRun := flag.Bool("dry-run", false, "only print what would be done")
+	cacheURL := flag.String("embed-cache", "", "url of the store caching the vectors, default sqlite://<data>/embeddings.db, off to disable")
+	flag.Parse()
+	validate.Must(validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	storePath := filepath.Join(*dataDir, "store.json")
+	manifestPath := filepath.Join(*dataDir, "manifest.json")
+
+	arkEmb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	var emb embedding.Embedder = arkEmb
+	var cache *embedcache.Embedder
+	if *cacheURL != "off" {
+		if *cacheURL == "" {
+			*cacheURL = "sqlite://" + filepath.Join(*dataDir, "embeddings.db")
+		}
+		c, closeCache, err := newCache(arkEmb, *cacheURL, config.String("ARK_EMBEDDING_MODEL", ""))
+		if err != nil {
+			logs.Fatalf("open embedding cache failed, err=%v", err)
+		}
+		defer closeCache()
+		cache, emb = c, c
+	}
+
+	splitter, err := markdown.NewHeaderSplitter(ctx, &markdown.HeaderConfig{
+		Headers: map[string]string{"#": "h1", "##": "h2"},
+	})
+	if err != nil {
+		logs.Fatalf("markdown.NewHeaderSplitter failed, err=%v", err)
+	}
+
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb})
+	if err != nil {
+		logs.Fatalf("create memory store failed, err=%v", err)
+	}
+	if err = store.Load(storePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logs.Fatalf("load store failed, err=%v", err)
+	}
+
+	m, err := loadManifest(manifestPath)
+	if err != nil {
+		logs.Fatalf("load manifest failed, err=%v", err)
+	}
+	// the manifest is only valid together with the store it describes, start over if the store is gone
+	if store.Len() == 0 && len(m.Files) > 0 {
+		logs.Infof("store is empty but the manifest lists %d files, reindexing everything", len(m.Files))
+		m.Files = map[string]*fileEntry{}
+	}
+
+	files, err := scanDir(*dir, []string{".md", ".markdown", ".txt"})
+	if err != nil {
+		logs.Fatalf("scan %s failed, err=%v", *dir, err)
+	}
+
+	var (
+		toIndex                                 []*scannedFile
+		stale                                   []string
+		added, changed, unchanged, deletedFiles int
+		seen                                    = map[string]bool{}
+	)
+	for _, f := range files {
+		seen[f.Path] = true
+
+		entry, ok := m.Files[f.Path]
+		switch {
+		case !ok:
+			logs.Infof("+ %s", f.Path)
+			added++
+		case entry.Hash != f.Hash:
+			logs.Infof("~ %s", f.Path)
+			changed++
+			stale = append(stale, entry.ChunkIDs...)
+		default:
+			unchanged++
+			continue
+		}
+		toIndex = append(toIndex, f)
+	}
+	for path, entry := range m.Files {
+		if !seen[path] {
+			logs.Infof("- %s", path)
+			deletedFiles++
+			stale = append(stale, entry.ChunkIDs...)
+		}
+	}
+
+	logs.Infof("%d added, %d changed, %d unchanged, %d deleted, %d stale chunks",
+		added, changed, unchanged, deletedFiles, len(stale))
+	if *dryRun || (len(toIndex) == 0 && len(stale) == 0) {
+		return
+	}
+
+	deleted := store.Delete(stale...)
+
+	var chunks []*schema.Document
+	for _, f := range toIndex {
+		fileChunks, err := splitFile(ctx, splitter, f)
+		if err != nil {
+			logs.Fatalf("split %s failed, err=%v", f.Path, err)
+		}
+		chunks = append(chunks, fileChunks...)
+	}
+
+	start := time.Now()
+	if _, err = store.Store(ctx, chunks); err != nil {
+		logs.Fatalf("index failed, err=%v", err)
+	}
+	logs.Infof("embedded %d chunks in %v, deleted %d stale chunks, the store has %d chunks",
+		len(chunks), time.Since(start).Round(time.Millisecond), deleted, store.Len())
+	if cache != nil {
+		hits, misses := cache.Stats()
+		logs.Infof("embedding cache: %d chunks unchanged, %d embedded", hits, misses)
+	}
+
+	// update the manifest only after the chunks are stored, a failed run is retried in full next time
+	for path := range m.Files {
+		if !seen[path] {
+			delete(m.Files, path)
+		}
+	}
+	now := time.Now()
+	for _, f := range toIndex {
+		m.Files[f.Path] = &fileEntry{Hash: f.Hash, IndexedAt: now}
+	}
+	for _, c := range chunks {
+		src := c.MetaData[metaKeySource].(string)
+		m.Files[src].ChunkIDs = append(m.Files[src].ChunkIDs, c.ID)
+	}
+
+	// the store is saved before the manifest: if the manifest save fails, the next run only redoes some work
+	if err = store.Save(storePath); err != nil {
+		logs.Fatalf("save store failed, err=%v", err)
+	}
+	if err = m.save(manifestPath); err != nil {
+		logs.Fatalf("save manifest failed, err=%v", err)
+	}
+}
+
+// newCache wraps emb with a cache of its vectors in the store at url, and returns the function closing the store.
+func newCache(emb embedding.Embedder, url, model string) (*embedcache.Embedder, func() error, error) {
+	kv, err := store.Open(url)
+	if err != nil {
+		return nil, nil, err
+	}
+	cache, err := embedcache.New(&embedcache.Config{Embedder: emb, Store: kv, Model: model})
+	if err != nil {
+		_ = kv.Close()
+		return nil, nil, err
+	}
+	return cache, kv.Close, nil
+}
+
+// splitFile splits a file by markdown headers. Chunk IDs are derived from the path and the position,
+// they only need to be unique, the manifest is what ties them to the file.
+func splitFile(ctx context.Context, splitter document.Transformer, f *scannedFile) ([]*schema.Document, error) {
+	parts, err := splitter.Transform(ctx, []*schema.Document{{
+		Content:  string(f.Content),
+		MetaData: map[string]any{metaKeySource: f.Path, metaKeyFileHash: f.Hash},
+	}})
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make([]*schema.Document, 0, len(parts))
+	for i, p := range parts {
+		if p.MetaData == nil {
+			p.MetaData = map[string]any{}
+		}
+		p.MetaData[metaKeySource] = f.Path
+		p.MetaData[metaKeyFileHash] = f.Hash
+		p.ID = fmt.Sprintf("%s#%d", f.Path, i)
+		chunks = append(chunks, p)
+	}
+	return chunks, nil
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// manifest remembers what has been indexed: for every file, the hash of its content when it was indexed
+// and the IDs of the chunks it produced. Comparing it with the directory tells which files to re-embed
+// and which chunks are stale.
+type manifest struct {
+	Files map[string]*fileEntry `json:"files"`
+}
+
+type fileEntry struct {
+	Hash      string    `json:"hash"`
+	ChunkIDs  []string  `json:"chunk_ids"`
+	IndexedAt time.Time `json:"indexed_at"`
+}
+
+func loadManifest(path string) (*manifest, error) {
+	m := &manifest{Files: map[string]*fileEntry{}}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	if m.Files == nil {
+		m.Files = map[string]*fileEntry{}
+	}
+	return m, nil
+}
+
+// save writes the manifest to a temp file and renames it, so that a crash never leaves a half written manifest.
+func (m *manifest) save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+type scannedFile struct {
+	Path    string
+	Hash    string
+	Content []byte
+}
+
+// scanDir reads every file under dir with one of exts and hashes its content, sorted by path.
+// Hashing the content rather than trusting the modification time catches edits that keep the mtime,
+// and ignores touches that change nothing.
+func scanDir(dir string, exts []string) ([]*scannedFile, error) {
+	var files []*scannedFile
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !hasExt(path, exts) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		files = append(files, &scannedFile{
+			Path:    filepath.ToSlash(path),
+			Hash:    hex.EncodeToString(sum[:]),
+			Content: content,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path < files[j].Path
+	})
+	return files, nil
+}
+
+func hasExt(path string, exts []string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, e := range exts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
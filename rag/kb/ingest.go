@@ -0,0 +1,178 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/markdown"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+const metaKeySource = "_source"
+
+var supportedExts = map[string]bool{".md": true, ".markdown": true, ".txt": true}
+
+// runIngest makes the knowledge base mirror the files under the given paths.
+// Chunk IDs are the hash of their content, so a chunk that has not changed keeps its ID and is not embedded again,
+// and chunks whose ID no longer comes out of any file are the stale ones to delete.
+func runIngest(ctx context.Context, args []string) error {
+	fs, dataDir := newFlagSet("ingest")
+	_ = fs.Parse(args)
+	if fs.NArg() == 0 {
+		return errors.New("ingest needs at least one path, e.g. kb ingest ./docs")
+	}
+
+	store, err := openStore(ctx, *dataDir, 0)
+	if err != nil {
+		return err
+	}
+	splitter, err := markdown.NewHeaderSplitter(ctx, &markdown.HeaderConfig{
+		Headers: map[string]string{"#": "h1", "##": "h2", "###": "h3"},
+	})
+	if err != nil {
+		return err
+	}
+
+	var chunks []*schema.Document
+	files := 0
+	for _, root := range fs.Args() {
+		err = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !supportedExts[strings.ToLower(filepath.Ext(path))] {
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			parts, err := splitter.Transform(ctx, []*schema.Document{{
+				Content:  string(content),
+				MetaData: map[string]any{metaKeySource: filepath.ToSlash(path)},
+			}})
+			if err != nil {
+				return err
+			}
+
+			files++
+			chunks = append(chunks, parts...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	existing := map[string]bool{}
+	for _, doc := range store.Documents() {
+		existing[doc.ID] = true
+	}
+
+	var (
+		current = map[string]bool{}
+		toEmbed []*schema.Document
+	)
+	for _, c := range chunks {
+		if c.MetaData == nil {
+			c.MetaData = map[string]any{}
+		}
+		// the source is part of the hash, the same paragraph in two files is two chunks
+		sum := sha256.Sum256([]byte(c.MetaData[metaKeySource].(string) + "\x00" + c.Content))
+		c.ID = hex.EncodeToString(sum[:8])
+		if current[c.ID] {
+			continue
+		}
+		current[c.ID] = true
+		if !existing[c.ID] {
+			toEmbed = append(toEmbed, c)
+		}
+	}
+
+	var stale []string
+	for id := range existing {
+		if !current[id] {
+			stale = append(stale, id)
+		}
+	}
+
+	logs.Infof("%d files, %d chunks: %d new, %d unchanged, %d stale",
+		files, len(current), len(toEmbed), len(current)-len(toEmbed), len(stale))
+
+	deleted := store.Delete(stale...)
+	if len(toEmbed) > 0 {
+		start := time.Now()
+		if _, err = store.Store(ctx, toEmbed); err != nil {
+			return err
+		}
+		logs.Infof("embedded %d chunks in %v", len(toEmbed), time.Since(start).Round(time.Millisecond))
+	}
+	if len(toEmbed) == 0 && deleted == 0 {
+		logs.Infof("knowledge base is up to date")
+		return nil
+	}
+
+	if err = store.Save(storePath(*dataDir)); err != nil {
+		return err
+	}
+	logs.Infof("knowledge base saved to %s, %d chunks", storePath(*dataDir), store.Len())
+	return nil
+}
+
+// runStats prints the number of chunks per source file.
+func runStats(ctx context.Context, args []string) error {
+	fs, dataDir := newFlagSet("stats")
+	_ = fs.Parse(args)
+
+	store, err := openStore(ctx, *dataDir, 0)
+	if err != nil {
+		return err
+	}
+	if store.Len() == 0 {
+		logs.Infof("knowledge base at %s is empty, run kb ingest first", *dataDir)
+		return nil
+	}
+
+	perSource := map[string]int{}
+	for _, doc := range store.Documents() {
+		src, _ := doc.MetaData[metaKeySource].(string)
+		perSource[src]++
+	}
+	sources := make([]string, 0, len(perSource))
+	for src := range perSource {
+		sources = append(sources, src)
+	}
+	sort.Strings(sources)
+
+	logs.Infof("%d chunks from %d files in %s", store.Len(), len(sources), *dataDir)
+	for _, src := range sources {
+		logs.Infof("  %4d  %s", perSource[src], src)
+	}
+	return nil
+}
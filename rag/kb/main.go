@@ -0,0 +1,123 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/ratelimit"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+const usage = `kb is a small knowledge base on top of the eino indexing and retrieval components.
+
+Usage:
+  kb ingest [-data DIR] PATH...     index the markdown and text files under PATH, only changed chunks are embedded
+  kb query  [-data DIR] [-k N] [-answer] QUESTION
+                                    print the chunks most relevant to QUESTION, or answer it with -answer
+  kb stats  [-data DIR]             print what is in the knowledge base
+
+Environment:
+  ARK_API_KEY / ARK_EMBEDDING_MODEL                       embedding model, for ingest and query
+  MODEL_PROVIDER and the keys of the provider             chat model, for query -answer, see internal/models
+
+Build it with: go build -o kb ./rag/kb
+`
+
+const defaultDataDir = ".cache/kb"
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "ingest":
+		err = runIngest(ctx, args)
+	case "query":
+		err = runQuery(ctx, args)
+	case "stats":
+		err = runStats(ctx, args)
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		logs.Errorf("%v", err)
+		os.Exit(1)
+	}
+}
+
+// newFlagSet returns a flag set with the flags shared by all commands.
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet("kb "+name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+	}
+	dataDir := fs.String("data", defaultDataDir, "directory where the knowledge base is stored")
+	return fs, dataDir
+}
+
+func storePath(dataDir string) string {
+	return filepath.Join(dataDir, "store.json")
+}
+
+// openStore creates the store and loads what a previous ingest saved in dataDir.
+func openStore(ctx context.Context, dataDir string, topK int) (*vectorstore.MemoryStore, error) {
+	apiKey, err := secrets.Get(ctx, "ARK_API_KEY")
+	if err != nil {
+		return nil, err
+	}
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  apiKey,
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create embedder failed: %w", err)
+	}
+
+	// ingest embeds many chunks in a row, the limiter keeps it within the embedding quota of ark
+	limited := ratelimit.WrapEmbedder(emb, ratelimit.Shared("ark/embedding"))
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: limited, TopK: topK})
+	if err != nil {
+		return nil, err
+	}
+	if err = store.Load(storePath(dataDir)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("load knowledge base failed: %w", err)
+	}
+	return store, nil
+}
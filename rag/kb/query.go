@@ -0,0 +1,98 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/streamprint"
+)
+
+// runQuery retrieves the chunks most relevant to the question, and with -answer,
+// lets the chat model answer from them, streaming the answer to stdout.
+func runQuery(ctx context.Context, args []string) error {
+	fs, dataDir := newFlagSet("query")
+	k := fs.Int("k", 4, "number of chunks to retrieve")
+	answer := fs.Bool("answer", false, "answer the question with the chat model instead of printing the chunks")
+	_ = fs.Parse(args)
+
+	question := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if question == "" {
+		return errors.New(`query needs a question, e.g. kb query "what is a graph?"`)
+	}
+
+	store, err := openStore(ctx, *dataDir, *k)
+	if err != nil {
+		return err
+	}
+	if store.Len() == 0 {
+		return fmt.Errorf("knowledge base at %s is empty, run kb ingest first", *dataDir)
+	}
+
+	docs, err := store.Retrieve(ctx, question)
+	if err != nil {
+		return err
+	}
+
+	if !*answer {
+		for i, doc := range docs {
+			fmt.Printf("[%d] %s (score %.3f)\n%s\n\n", i+1, doc.MetaData[metaKeySource], doc.Score(), doc.Content)
+		}
+		return nil
+	}
+
+	cm, err := models.NewChatModel(ctx)
+	if err != nil {
+		return fmt.Errorf("create chat model failed: %w", err)
+	}
+
+	chain, err := compose.NewChain[map[string]any, *schema.Message]().
+		AppendChatTemplate(prompt.MustGet("rag_answer_cited")).
+		AppendChatModel(cm).
+		Compile(ctx)
+	if err != nil {
+		return err
+	}
+
+	sb := strings.Builder{}
+	for i, doc := range docs {
+		sb.WriteString(fmt.Sprintf("[%d] %s\n\n", i+1, doc.Content))
+	}
+
+	sr, err := chain.Stream(ctx, map[string]any{"documents": sb.String(), "question": question})
+	if err != nil {
+		return err
+	}
+	if _, err = streamprint.Print(sr); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("Sources:")
+	for i, doc := range docs {
+		fmt.Printf("  [%d] %s\n", i+1, doc.MetaData[metaKeySource])
+	}
+	return nil
+}
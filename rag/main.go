@@ -0,0 +1,184 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+// This example runs a complete RAG pipeline in one compose.Graph:
+// load a markdown file, split it by headers, embed and index the chunks,
+// then retrieve the chunks relevant to the question and let the chat model answer with them.
+//
+// By default the chunks are kept in an in-memory vector store, so nothing but the model APIs is needed.
+// Run with -store sqlite to persist them to a local file with sqlite-vec (needs cgo),
+// or with -store redis to index into redis instead.
+//
+// Requirements:
+//   - ARK_API_KEY / ARK_EMBEDDING_MODEL for embedding
+//   - MODEL_PROVIDER and the keys of the provider for the chat model, see internal/models
+//   - with -rerank, RERANK_BASE_URL / RERANK_API_KEY / RERANK_MODEL of a Jina / Cohere compatible rerank API,
+//     e.g. https://api.jina.ai/v1 with jina-reranker-v2-base-multilingual, run with and without -rerank to compare the answers
+//   - with -store redis, a redis server with the RediSearch module, e.g. docker run -p 6379:6379 redis/redis-stack-server,
+//     and ARK_EMBEDDING_DIMENSION if the embedding dimension is not 4096
+func main() {
+	source := flag.String("source", "rag/testdata/eino.md", "path of the document to index")
+	question := flag.String("question", "What orchestration APIs does Eino provide?", "question to ask")
+	store := flag.String("store", "memory", "vector store to use, memory, sqlite or redis")
+	sqlitePath := flag.String("sqlite-path", ".cache/rag/vectors.db", "file of the sqlite store")
+	rerank := flag.Bool("rerank", false, "rerank the retrieved chunks with the rerank API before answering")
+	candidates := flag.Int("candidates", 50, "number of chunks to retrieve when reranking")
+	rerankTop := flag.Int("rerank-top", 5, "number of chunks to keep after reranking")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	embedder, err := newEmbedder(ctx)
+	if err != nil {
+		logs.Fatalf("create embedder failed, err=%v", err)
+	}
+
+	var (
+		idx indexer.Indexer
+		ret retriever.Retriever
+	)
+	switch *store {
+	case "memory":
+		ms, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: embedder, TopK: 3})
+		if err != nil {
+			logs.Fatalf("create memory store failed, err=%v", err)
+		}
+		idx, ret = ms, ms
+	case "sqlite":
+		ss, err := newSQLiteStore(ctx, *sqlitePath, embedder)
+		if err != nil {
+			logs.Fatalf("create sqlite store failed, err=%v", err)
+		}
+		defer ss.Close()
+		idx, ret = ss, ss
+	case "redis":
+		client := newRedisClient(ctx)
+		defer client.Close()
+
+		if idx, err = newIndexer(ctx, client, embedder); err != nil {
+			logs.Fatalf("create redis indexer failed, err=%v", err)
+		}
+		if ret, err = newRetriever(ctx, client, embedder); err != nil {
+			logs.Fatalf("create redis retriever failed, err=%v", err)
+		}
+	default:
+		logs.Fatalf("unknown store %q, use memory, sqlite or redis", *store)
+	}
+
+	c, err := newComponents(ctx, embedder, idx, ret)
+	if err != nil {
+		logs.Fatalf("create components failed, err=%v", err)
+	}
+
+	var invokeOpts []compose.Option
+	if *rerank {
+		apiKey, err := secrets.Get(ctx, "RERANK_API_KEY")
+		if err != nil {
+			logs.Fatalf("read RERANK_API_KEY failed, err=%v", err)
+		}
+		c.Reranker = newAPIReranker(config.String("RERANK_BASE_URL", ""), apiKey, config.String("RERANK_MODEL", ""), *rerankTop)
+		// retrieve a wide set of candidates and let the reranker pick the best few
+		invokeOpts = append(invokeOpts, compose.WithRetrieverOption(retriever.WithTopK(*candidates)))
+	}
+
+	runner, err := buildRAGGraph(ctx, c)
+	if err != nil {
+		logs.Fatalf("build rag graph failed, err=%v", err)
+	}
+
+	answer, err := runner.Invoke(ctx, &Input{
+		Source:   document.Source{URI: *source},
+		Question: *question,
+	}, invokeOpts...)
+	if err != nil {
+		logs.Fatalf("invoke rag graph failed, err=%v", err)
+	}
+
+	logs.Infof("question: %s", *question)
+	logs.Infof("answer: %s", answer.Content)
+	if len(answer.Sources) == 0 {
+		logs.Infof("sources: none cited")
+		return
+	}
+	logs.Infof("sources:")
+	for _, src := range answer.Sources {
+		logs.Infof("  [%d] %s (id=%s, score=%.3f)", src.Index, src.Location, src.DocID, src.Score)
+	}
+}
+
+// newRedisClient connects to REDIS_ADDR and makes sure the vector index exists.
+func newRedisClient(ctx context.Context) *redis.Client {
+	redisAddr := config.String("REDIS_ADDR", "localhost:6379")
+	dimension := 4096
+	if d := config.Int("ARK_EMBEDDING_DIMENSION", 0); d > 0 {
+		dimension = d
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     redisAddr,
+		Protocol: 2,
+	})
+
+	if err := initRedisIndex(ctx, client, dimension); err != nil {
+		logs.Fatalf("init redis index failed, err=%v", err)
+	}
+	return client
+}
+
+func newComponents(ctx context.Context, embedder embedding.Embedder, idx indexer.Indexer, ret retriever.Retriever) (*ragComponents, error) {
+	loader, err := newLoader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	splitter, err := newSplitter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cm, err := newChatModel(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ragComponents{
+		Loader:    loader,
+		Splitter:  splitter,
+		Embedder:  embedder,
+		Indexer:   idx,
+		Retriever: ret,
+		ChatModel: cm,
+	}, nil
+}
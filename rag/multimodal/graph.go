@@ -0,0 +1,155 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodeKeyPrepare        = "Prepare"
+	nodeKeyTextRetriever  = "TextRetriever"
+	nodeKeyImageRetriever = "ImageRetriever"
+	nodeKeyBuildMessages  = "BuildMessages"
+	nodeKeyChatModel      = "ChatModel"
+
+	outputKeyTexts  = "texts"
+	outputKeyImages = "images"
+
+	// metaKeyPath is the metadata of an image document holding the path of the image, its content is the caption
+	metaKeyPath = "image_path"
+)
+
+type multimodalState struct {
+	Question string
+}
+
+// buildMultimodalRAG retrieves passages and images in parallel and sends both to a vision model:
+//
+//	            -> TextRetriever --
+//	Prepare -<                     >- BuildMessages -> ChatModel
+//	            -> ImageRetriever -
+//
+// The images are found by their captions, written by the vision model at indexing time, but the model answering
+// gets the images themselves: a caption says a chart shows sales per region, it may not have every number right.
+// Two retrievers rather than one store of both: with one, the passages would usually outrank the captions, and the
+// images would never be retrieved.
+func buildMultimodalRAG(ctx context.Context, texts, images retriever.Retriever, cm model.ChatModel) (compose.Runnable[string, *schema.Message], error) {
+	g := compose.NewGraph[string, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *multimodalState {
+		return &multimodalState{}
+	}))
+
+	_ = g.AddLambdaNode(nodeKeyPrepare, compose.InvokableLambda(func(ctx context.Context, question string) (string, error) {
+		err := compose.ProcessState[*multimodalState](ctx, func(_ context.Context, s *multimodalState) error {
+			s.Question = question
+			return nil
+		})
+		return question, err
+	}))
+	_ = g.AddRetrieverNode(nodeKeyTextRetriever, texts, compose.WithOutputKey(outputKeyTexts))
+	_ = g.AddRetrieverNode(nodeKeyImageRetriever, images, compose.WithOutputKey(outputKeyImages))
+	_ = g.AddLambdaNode(nodeKeyBuildMessages, compose.InvokableLambda(func(ctx context.Context, in map[string]any) ([]*schema.Message, error) {
+		passages, _ := in[outputKeyTexts].([]*schema.Document)
+		pictures, _ := in[outputKeyImages].([]*schema.Document)
+
+		var question string
+		err := compose.ProcessState[*multimodalState](ctx, func(_ context.Context, s *multimodalState) error {
+			question = s.Question
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return buildMessages(ctx, question, passages, pictures)
+	}))
+	_ = g.AddChatModelNode(nodeKeyChatModel, cm)
+
+	_ = g.AddEdge(compose.START, nodeKeyPrepare)
+	_ = g.AddEdge(nodeKeyPrepare, nodeKeyTextRetriever)
+	_ = g.AddEdge(nodeKeyPrepare, nodeKeyImageRetriever)
+	_ = g.AddEdge(nodeKeyTextRetriever, nodeKeyBuildMessages)
+	_ = g.AddEdge(nodeKeyImageRetriever, nodeKeyBuildMessages)
+	_ = g.AddEdge(nodeKeyBuildMessages, nodeKeyChatModel)
+	_ = g.AddEdge(nodeKeyChatModel, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("MultimodalRAG"))
+}
+
+// buildMessages puts the question, the passages and the images in one user message, every image preceded by its
+// file name so that the answer can refer to it, after the system message of the prompt rag_multimodal_answer.
+func buildMessages(ctx context.Context, question string, passages, pictures []*schema.Document) ([]*schema.Message, error) {
+	texts := make([]string, 0, len(passages))
+	for i, p := range passages {
+		texts = append(texts, fmt.Sprintf("[%d] %s", i+1, p.Content))
+	}
+	parts := []schema.ChatMessagePart{{
+		Type: schema.ChatMessagePartTypeText,
+		Text: fmt.Sprintf("Passages:\n%s\n\nQuestion: %s", strings.Join(texts, "\n\n"), question),
+	}}
+
+	for _, p := range pictures {
+		path, _ := p.MetaData[metaKeyPath].(string)
+		part, err := imagePart(path)
+		if err != nil {
+			return nil, err
+		}
+		logs.Infof("image %s (score %.3f)", filepath.Base(path), p.Score())
+		parts = append(parts,
+			schema.ChatMessagePart{Type: schema.ChatMessagePartTypeText, Text: "Image " + filepath.Base(path) + ":"},
+			part,
+		)
+	}
+
+	return prompt.MustGet("rag_multimodal_answer").Format(ctx, map[string]any{
+		"question": []*schema.Message{{Role: schema.User, MultiContent: parts}},
+	})
+}
+
+// imagePart embeds the image file in the message as a data URL, which works for local files and every
+// OpenAI-compatible vision API.
+func imagePart(path string) (schema.ChatMessagePart, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return schema.ChatMessagePart{}, err
+	}
+	mimeType := mime.TypeByExtension(filepath.Ext(path))
+	if mimeType == "" {
+		mimeType = "image/png"
+	}
+	return schema.ChatMessagePart{
+		Type: schema.ChatMessagePartTypeImageURL,
+		ImageURL: &schema.ChatMessageImageURL{
+			URL:      "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data),
+			MIMEType: mimeType,
+			Detail:   schema.ImageURLDetailHigh,
+		},
+	}, nil
+}
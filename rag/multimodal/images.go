@@ -0,0 +1,203 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// The sample images are drawn by the example rather than shipped, with a 5x7 bitmap font for their labels:
+// what matters is that their content, the numbers of a chart, the rooms of a floor plan, is in no text document.
+
+var glyphs = map[rune][7]string{
+	'A': {" ### ", "#   #", "#   #", "#####", "#   #", "#   #", "#   #"},
+	'B': {"#### ", "#   #", "#   #", "#### ", "#   #", "#   #", "#### "},
+	'C': {" ### ", "#   #", "#    ", "#    ", "#    ", "#   #", " ### "},
+	'D': {"#### ", "#   #", "#   #", "#   #", "#   #", "#   #", "#### "},
+	'E': {"#####", "#    ", "#    ", "#### ", "#    ", "#    ", "#####"},
+	'F': {"#####", "#    ", "#    ", "#### ", "#    ", "#    ", "#    "},
+	'G': {" ### ", "#   #", "#    ", "# ###", "#   #", "#   #", " ####"},
+	'H': {"#   #", "#   #", "#   #", "#####", "#   #", "#   #", "#   #"},
+	'I': {" ### ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+	'J': {"  ###", "   # ", "   # ", "   # ", "   # ", "#  # ", " ##  "},
+	'K': {"#   #", "#  # ", "# #  ", "##   ", "# #  ", "#  # ", "#   #"},
+	'L': {"#    ", "#    ", "#    ", "#    ", "#    ", "#    ", "#####"},
+	'M': {"#   #", "## ##", "# # #", "# # #", "#   #", "#   #", "#   #"},
+	'N': {"#   #", "#   #", "##  #", "# # #", "#  ##", "#   #", "#   #"},
+	'O': {" ### ", "#   #", "#   #", "#   #", "#   #", "#   #", " ### "},
+	'P': {"#### ", "#   #", "#   #", "#### ", "#    ", "#    ", "#    "},
+	'Q': {" ### ", "#   #", "#   #", "#   #", "# # #", "#  # ", " ## #"},
+	'R': {"#### ", "#   #", "#   #", "#### ", "# #  ", "#  # ", "#   #"},
+	'S': {" ####", "#    ", "#    ", " ### ", "    #", "    #", "#### "},
+	'T': {"#####", "  #  ", "  #  ", "  #  ", "  #  ", "  #  ", "  #  "},
+	'U': {"#   #", "#   #", "#   #", "#   #", "#   #", "#   #", " ### "},
+	'V': {"#   #", "#   #", "#   #", "#   #", "#   #", " # # ", "  #  "},
+	'W': {"#   #", "#   #", "#   #", "# # #", "# # #", "# # #", " # # "},
+	'X': {"#   #", "#   #", " # # ", "  #  ", " # # ", "#   #", "#   #"},
+	'Y': {"#   #", "#   #", " # # ", "  #  ", "  #  ", "  #  ", "  #  "},
+	'Z': {"#####", "    #", "   # ", "  #  ", " #   ", "#    ", "#####"},
+	'0': {" ### ", "#   #", "#  ##", "# # #", "##  #", "#   #", " ### "},
+	'1': {"  #  ", " ##  ", "  #  ", "  #  ", "  #  ", "  #  ", " ### "},
+	'2': {" ### ", "#   #", "    #", "   # ", "  #  ", " #   ", "#####"},
+	'3': {"#####", "   # ", "  #  ", "   # ", "    #", "#   #", " ### "},
+	'4': {"   # ", "  ## ", " # # ", "#  # ", "#####", "   # ", "   # "},
+	'5': {"#####", "#    ", "#### ", "    #", "    #", "#   #", " ### "},
+	'6': {"  ## ", " #   ", "#    ", "#### ", "#   #", "#   #", " ### "},
+	'7': {"#####", "    #", "   # ", "  #  ", " #   ", " #   ", " #   "},
+	'8': {" ### ", "#   #", "#   #", " ### ", "#   #", "#   #", " ### "},
+	'9': {" ### ", "#   #", "#   #", " ####", "    #", "   # ", " ##  "},
+	':': {"     ", "  #  ", "  #  ", "     ", "  #  ", "  #  ", "     "},
+	'-': {"     ", "     ", "     ", "#####", "     ", "     ", "     "},
+	'(': {"   # ", "  #  ", " #   ", " #   ", " #   ", "  #  ", "   # "},
+	')': {" #   ", "  #  ", "   # ", "   # ", "   # ", "  #  ", " #   "},
+}
+
+var (
+	white = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	ink   = color.RGBA{R: 30, G: 30, B: 30, A: 255}
+	blue  = color.RGBA{R: 60, G: 110, B: 200, A: 255}
+	gray  = color.RGBA{R: 225, G: 225, B: 225, A: 255}
+)
+
+// drawText draws text with its top left corner at x, y, every pixel of the font a square of scale pixels.
+// Runes without a glyph are drawn as spaces.
+func drawText(img draw.Image, x, y, scale int, text string, c color.Color) {
+	for _, r := range text {
+		for row, line := range glyphs[r] {
+			for col, px := range line {
+				if px == '#' {
+					fill(img, x+col*scale, y+row*scale, scale, scale, c)
+				}
+			}
+		}
+		x += 6 * scale
+	}
+}
+
+func textWidth(text string, scale int) int {
+	return len([]rune(text))*6*scale - scale
+}
+
+func fill(img draw.Image, x, y, w, h int, c color.Color) {
+	draw.Draw(img, image.Rect(x, y, x+w, y+h), &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+func outline(img draw.Image, x, y, w, h, width int, c color.Color) {
+	fill(img, x, y, w, width, c)
+	fill(img, x, y+h-width, w, width, c)
+	fill(img, x, y, width, h, c)
+	fill(img, x+w-width, y, width, h, c)
+}
+
+func newCanvas(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	fill(img, 0, 0, w, h, white)
+	return img
+}
+
+// salesChart is a bar chart of the units sold per region, the numbers are only in the image.
+func salesChart() image.Image {
+	img := newCanvas(720, 440)
+	drawText(img, 40, 24, 3, "Q3 SALES BY REGION (THOUSAND UNITS)", ink)
+
+	regions := []struct {
+		name  string
+		units int
+	}{{"NORTH", 42}, {"SOUTH", 27}, {"EAST", 35}, {"WEST", 18}}
+	const baseline, unit = 380, 6
+	fill(img, 40, baseline, 640, 3, ink)
+	for i, r := range regions {
+		x := 80 + i*160
+		h := r.units * unit
+		fill(img, x, baseline-h, 80, h, blue)
+		label := strconv.Itoa(r.units)
+		drawText(img, x+40-textWidth(label, 3)/2, baseline-h-30, 3, label, ink)
+		drawText(img, x+40-textWidth(r.name, 2)/2, baseline+14, 2, r.name, ink)
+	}
+	return img
+}
+
+// floorPlan is the plan of the third floor, the position of the rooms is only in the image.
+func floorPlan() image.Image {
+	img := newCanvas(720, 440)
+	drawText(img, 40, 20, 3, "FLOOR 3", ink)
+
+	rooms := []struct {
+		name       string
+		x, y, w, h int
+	}{
+		{"KITCHEN", 40, 70, 200, 130},
+		{"LAB 2", 240, 70, 200, 130},
+		{"MEETING A", 440, 70, 240, 130},
+		{"RECEPTION", 40, 290, 300, 120},
+		{"MEETING B", 340, 290, 340, 120},
+	}
+	for _, r := range rooms {
+		outline(img, r.x, r.y, r.w, r.h, 4, ink)
+		drawText(img, r.x+r.w/2-textWidth(r.name, 3)/2, r.y+r.h/2-10, 3, r.name, ink)
+	}
+	fill(img, 40, 200, 640, 90, gray)
+	drawText(img, 260, 236, 2, "CORRIDOR", ink)
+	drawText(img, 600, 236, 2, "EXIT", color.RGBA{R: 200, G: 40, B: 40, A: 255})
+	return img
+}
+
+// wifiSign is the sign at the reception, the credentials are only in the image.
+func wifiSign() image.Image {
+	img := newCanvas(720, 260)
+	outline(img, 10, 10, 700, 240, 6, blue)
+	drawText(img, 60, 40, 5, "GUEST WIFI", blue)
+	drawText(img, 60, 130, 3, "NETWORK: NW-GUEST", ink)
+	drawText(img, 60, 180, 3, "PASSWORD: ORBIT-7731", ink)
+	return img
+}
+
+// writeSampleImages draws the sample images into dir, unless they are there already.
+func writeSampleImages(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	images := map[string]func() image.Image{
+		"q3_sales.png":  salesChart,
+		"floor3.png":    floorPlan,
+		"wifi_sign.png": wifiSign,
+	}
+	for name, render := range images {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		err = png.Encode(f, render())
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
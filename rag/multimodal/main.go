@@ -0,0 +1,176 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+// Requirements:
+//   - ARK_API_KEY / ARK_EMBEDDING_MODEL for embedding
+//   - MODEL_PROVIDER and the keys of the provider for the chat model, see internal/models, which must accept images
+//
+// This example answers questions over text and images. Indexing:
+//
+//	text     -> chunks                                  -> text store
+//	images   -> vision model writes a caption per image -> image store, the path of the image in the metadata
+//
+// then every question retrieves from both stores and the vision model gets the passages and the images themselves,
+// see buildMultimodalRAG. The sample images, a chart, a floor plan and a sign, are drawn into -images on first run;
+// the answers to the sample questions need both a passage and an image.
+func main() {
+	textPath := flag.String("text", "rag/multimodal/testdata/handbook.md", "text document to index")
+	imageDir := flag.String("images", ".cache/multimodal/images", "directory of the .png and .jpg images to index, the samples are drawn there if missing")
+	question := flag.String("question", "", "question to ask, default a few questions about the samples")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	cm := models.MustChatModel(ctx, models.WithTemperature(0))
+
+	if err = writeSampleImages(*imageDir); err != nil {
+		logs.Fatalf("writeSampleImages failed, err=%v", err)
+	}
+
+	textStore, err := indexText(ctx, emb, *textPath)
+	if err != nil {
+		logs.Fatalf("indexText failed, err=%v", err)
+	}
+	imageStore, err := indexImages(ctx, emb, cm, *imageDir)
+	if err != nil {
+		logs.Fatalf("indexImages failed, err=%v", err)
+	}
+
+	runner, err := buildMultimodalRAG(ctx, textStore, imageStore, cm)
+	if err != nil {
+		logs.Fatalf("buildMultimodalRAG failed, err=%v", err)
+	}
+
+	questions := []string{
+		"Which regions missed their sales target in Q3?",
+		"I'm in the kitchen on floor 3. Where is Lab 2, and what do I need to get in?",
+		"A visitor asks for the Wi-Fi. What do I tell them, and can I use the same network on my work laptop?",
+	}
+	if *question != "" {
+		questions = []string{*question}
+	}
+	for _, q := range questions {
+		logs.Infof("question: %s", q)
+		answer, err := runner.Invoke(ctx, q)
+		if err != nil {
+			logs.Fatalf("Invoke failed, err=%v", err)
+		}
+		logs.Infof("answer: %s", answer.Content)
+	}
+}
+
+func indexText(ctx context.Context, emb *ark.Embedder, path string) (*vectorstore.MemoryStore, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	splitter, err := recursive.NewSplitter(ctx, &recursive.Config{ChunkSize: 500, Separators: []string{"\n## ", "\n\n"}})
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := splitter.Transform(ctx, []*schema.Document{{ID: path, Content: string(content)}})
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 2})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = store.Store(ctx, chunks); err != nil {
+		return nil, err
+	}
+	logs.Infof("%s: %d chunks indexed", path, len(chunks))
+	return store, nil
+}
+
+// indexImages captions every image of dir with the vision model and indexes the captions.
+func indexImages(ctx context.Context, emb *ark.Embedder, cm model.ChatModel, dir string) (*vectorstore.MemoryStore, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, e := range entries {
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".png", ".jpg", ".jpeg":
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(paths)
+
+	docs := make([]*schema.Document, 0, len(paths))
+	for _, path := range paths {
+		part, err := imagePart(path)
+		if err != nil {
+			return nil, err
+		}
+		in, err := prompt.MustGet("rag_multimodal_caption").Format(ctx, map[string]any{
+			"image": []*schema.Message{{Role: schema.User, MultiContent: []schema.ChatMessagePart{part}}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		caption, err := cm.Generate(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		logs.Infof("%s: %s", filepath.Base(path), caption.Content)
+		docs = append(docs, &schema.Document{ID: path, Content: caption.Content, MetaData: map[string]any{metaKeyPath: path}})
+	}
+
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 1})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = store.Store(ctx, docs); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
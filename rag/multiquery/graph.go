@@ -0,0 +1,192 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodeKeyPrepare       = "Prepare"
+	nodeKeyQueryTemplate = "QueryTemplate"
+	nodeKeyQueryModel    = "QueryModel"
+	nodeKeyParseQueries  = "ParseQueries"
+	nodeKeyMultiRetrieve = "MultiRetrieve"
+	nodeKeyToVars        = "ToVariables"
+	nodeKeyTemplate      = "ChatTemplate"
+	nodeKeyChatModel     = "ChatModel"
+
+	numParaphrases = 3
+)
+
+type multiQueryState struct {
+	Question string
+}
+
+// buildMultiQueryGraph retrieves with several paraphrases of the question instead of the question alone:
+//
+//	Prepare -> QueryTemplate -> QueryModel -> ParseQueries -> MultiRetrieve -> ToVariables -> ChatTemplate -> ChatModel
+//
+// A single query embeds to a single point, and relevant chunks phrased differently may sit far from it.
+// Each paraphrase lands somewhere else, so the union of their results recalls more of the relevant chunks.
+func buildMultiQueryGraph(ctx context.Context, ret retriever.Retriever, cm model.ChatModel) (compose.Runnable[string, *schema.Message], error) {
+	g := compose.NewGraph[string, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *multiQueryState {
+		return &multiQueryState{}
+	}))
+
+	_ = g.AddLambdaNode(nodeKeyPrepare, compose.InvokableLambda(func(ctx context.Context, question string) (map[string]any, error) {
+		err := compose.ProcessState[*multiQueryState](ctx, func(_ context.Context, s *multiQueryState) error {
+			s.Question = question
+			return nil
+		})
+		return map[string]any{"question": question, "n": numParaphrases}, err
+	}))
+	_ = g.AddChatTemplateNode(nodeKeyQueryTemplate, prompt.MustGet("rag_paraphrase"))
+	_ = g.AddChatModelNode(nodeKeyQueryModel, cm)
+	_ = g.AddLambdaNode(nodeKeyParseQueries, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) ([]string, error) {
+		var question string
+		err := compose.ProcessState[*multiQueryState](ctx, func(_ context.Context, s *multiQueryState) error {
+			question = s.Question
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// the original question is always kept, the paraphrases can only add to it
+		queries := []string{question}
+		for _, line := range strings.Split(msg.Content, "\n") {
+			line = strings.TrimSpace(strings.TrimLeft(line, "-*0123456789.) "))
+			if line != "" && len(queries) <= numParaphrases {
+				queries = append(queries, line)
+			}
+		}
+		for i, q := range queries {
+			logs.Infof("query[%d]: %s", i, q)
+		}
+		return queries, nil
+	}))
+	_ = g.AddLambdaNode(nodeKeyMultiRetrieve, compose.InvokableLambda(func(ctx context.Context, queries []string) ([]*schema.Document, error) {
+		return retrieveAll(ctx, ret, queries)
+	}))
+	_ = g.AddLambdaNode(nodeKeyToVars, compose.InvokableLambda(func(ctx context.Context, docs []*schema.Document) (map[string]any, error) {
+		var question string
+		err := compose.ProcessState[*multiQueryState](ctx, func(_ context.Context, s *multiQueryState) error {
+			question = s.Question
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]any{
+			"documents": formatDocuments(docs),
+			"question":  question,
+		}, nil
+	}))
+	_ = g.AddChatTemplateNode(nodeKeyTemplate, prompt.MustGet("rag_answer"))
+	_ = g.AddChatModelNode(nodeKeyChatModel, cm)
+
+	_ = g.AddEdge(compose.START, nodeKeyPrepare)
+	_ = g.AddEdge(nodeKeyPrepare, nodeKeyQueryTemplate)
+	_ = g.AddEdge(nodeKeyQueryTemplate, nodeKeyQueryModel)
+	_ = g.AddEdge(nodeKeyQueryModel, nodeKeyParseQueries)
+	_ = g.AddEdge(nodeKeyParseQueries, nodeKeyMultiRetrieve)
+	_ = g.AddEdge(nodeKeyMultiRetrieve, nodeKeyToVars)
+	_ = g.AddEdge(nodeKeyToVars, nodeKeyTemplate)
+	_ = g.AddEdge(nodeKeyTemplate, nodeKeyChatModel)
+	_ = g.AddEdge(nodeKeyChatModel, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("MultiQueryRAG"))
+}
+
+// retrieveAll runs one retrieval per query concurrently and merges the results.
+// A chunk found by several queries is kept once, with its best score, and ranked by
+// how many queries found it first, then by that score.
+func retrieveAll(ctx context.Context, ret retriever.Retriever, queries []string) ([]*schema.Document, error) {
+	results := make([][]*schema.Document, len(queries))
+	errs := make([]error, len(queries))
+
+	var wg sync.WaitGroup
+	for i, q := range queries {
+		wg.Add(1)
+		go func(i int, q string) {
+			defer wg.Done()
+			results[i], errs[i] = ret.Retrieve(ctx, q)
+		}(i, q)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("retrieve for query %q failed: %w", queries[i], err)
+		}
+	}
+
+	var (
+		merged []*schema.Document
+		byID   = map[string]*schema.Document{}
+		hits   = map[string]int{}
+	)
+	for i, docs := range results {
+		logs.Infof("query[%d] retrieved %d chunks", i, len(docs))
+		for _, doc := range docs {
+			hits[doc.ID]++
+			if seen, ok := byID[doc.ID]; ok {
+				if doc.Score() > seen.Score() {
+					seen.WithScore(doc.Score())
+				}
+				continue
+			}
+			byID[doc.ID] = doc
+			merged = append(merged, doc)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		if hits[merged[i].ID] != hits[merged[j].ID] {
+			return hits[merged[i].ID] > hits[merged[j].ID]
+		}
+		return merged[i].Score() > merged[j].Score()
+	})
+
+	logs.Infof("%d unique chunks from %d queries", len(merged), len(queries))
+	for i, doc := range merged {
+		logs.Infof("  [%d] id=%s, found by %d queries, best score=%.3f", i+1, doc.ID, hits[doc.ID], doc.Score())
+	}
+	return merged, nil
+}
+
+func formatDocuments(docs []*schema.Document) string {
+	sb := strings.Builder{}
+	for i, doc := range docs {
+		sb.WriteString(fmt.Sprintf("[%d] %s\n\n", i+1, doc.Content))
+	}
+	return sb.String()
+}
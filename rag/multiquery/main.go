@@ -0,0 +1,100 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/file"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/markdown"
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/document"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+// Requirements:
+//   - ARK_API_KEY / ARK_EMBEDDING_MODEL for embedding
+//   - MODEL_PROVIDER and the keys of the provider for the chat model, see internal/models
+func main() {
+	source := flag.String("source", "rag/testdata/eino.md", "path of the document to index")
+	question := flag.String("question", "How do I build an agent that can call tools with Eino?", "question to ask")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+
+	cm := models.MustChatModel(ctx)
+
+	loader, err := file.NewFileLoader(ctx, &file.FileLoaderConfig{})
+	if err != nil {
+		logs.Fatalf("file.NewFileLoader failed, err=%v", err)
+	}
+	splitter, err := markdown.NewHeaderSplitter(ctx, &markdown.HeaderConfig{
+		Headers: map[string]string{"#": "h1", "##": "h2"},
+	})
+	if err != nil {
+		logs.Fatalf("markdown.NewHeaderSplitter failed, err=%v", err)
+	}
+
+	docs, err := loader.Load(ctx, document.Source{URI: *source})
+	if err != nil {
+		logs.Fatalf("load %s failed, err=%v", *source, err)
+	}
+	chunks, err := splitter.Transform(ctx, docs)
+	if err != nil {
+		logs.Fatalf("split failed, err=%v", err)
+	}
+
+	// a small top k per query makes the recall gained from the paraphrases easy to see
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 2})
+	if err != nil {
+		logs.Fatalf("create memory store failed, err=%v", err)
+	}
+	if _, err = store.Store(ctx, chunks); err != nil {
+		logs.Fatalf("index failed, err=%v", err)
+	}
+	logs.Infof("indexed %d chunks of %s", store.Len(), *source)
+
+	runner, err := buildMultiQueryGraph(ctx, store, cm)
+	if err != nil {
+		logs.Fatalf("build multi query graph failed, err=%v", err)
+	}
+
+	answer, err := runner.Invoke(ctx, *question)
+	if err != nil {
+		logs.Fatalf("invoke multi query graph failed, err=%v", err)
+	}
+
+	logs.Infof("question: %s", *question)
+	logs.Infof("answer: %s", answer.Content)
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino-ext/components/document/loader/file"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/markdown"
+	"github.com/cloudwego/eino-ext/components/document/transformer/splitter/recursive"
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+// Requirements:
+//   - ARK_API_KEY / ARK_EMBEDDING_MODEL for embedding
+//   - MODEL_PROVIDER and the keys of the provider for the chat model, see internal/models
+func main() {
+	source := flag.String("source", "rag/testdata/eino.md", "path of the document to index")
+	question := flag.String("question", "How can nodes share data without a lock?", "question to ask")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+
+	cm := models.MustChatModel(ctx)
+
+	loader, err := file.NewFileLoader(ctx, &file.FileLoaderConfig{})
+	if err != nil {
+		logs.Fatalf("file.NewFileLoader failed, err=%v", err)
+	}
+	// parents are whole sections, split by markdown headers
+	sectionSplitter, err := markdown.NewHeaderSplitter(ctx, &markdown.HeaderConfig{
+		Headers: map[string]string{"#": "h1", "##": "h2"},
+	})
+	if err != nil {
+		logs.Fatalf("markdown.NewHeaderSplitter failed, err=%v", err)
+	}
+	// children are a sentence or two, small enough that each embeds a single idea
+	chunkSplitter, err := recursive.NewSplitter(ctx, &recursive.Config{
+		ChunkSize:   160,
+		OverlapSize: 20,
+		Separators:  []string{"\n\n", "\n", ". ", " "},
+	})
+	if err != nil {
+		logs.Fatalf("recursive.NewSplitter failed, err=%v", err)
+	}
+
+	docs, err := loader.Load(ctx, document.Source{URI: *source})
+	if err != nil {
+		logs.Fatalf("load %s failed, err=%v", *source, err)
+	}
+	sections, err := sectionSplitter.Transform(ctx, docs)
+	if err != nil {
+		logs.Fatalf("split sections failed, err=%v", err)
+	}
+
+	children, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb})
+	if err != nil {
+		logs.Fatalf("create memory store failed, err=%v", err)
+	}
+	parents := newParentStore()
+
+	n, err := indexParents(ctx, sections, chunkSplitter, children, parents)
+	if err != nil {
+		logs.Fatalf("index failed, err=%v", err)
+	}
+	logs.Infof("indexed %d chunks from %d sections", n, len(sections))
+
+	ret := &parentRetriever{children: children, store: parents, childTopK: 6, topK: 2}
+
+	// the retriever and a passthrough of the question run in parallel, their outputs are merged into one map
+	chain := compose.NewChain[string, *schema.Message]()
+	chain.
+		AppendParallel(compose.NewParallel().
+			AddRetriever("documents", ret).
+			AddLambda("question", compose.InvokableLambda(func(ctx context.Context, q string) (string, error) {
+				return q, nil
+			}))).
+		AppendLambda(compose.InvokableLambda(func(ctx context.Context, in map[string]any) (map[string]any, error) {
+			docs, _ := in["documents"].([]*schema.Document)
+			sb := strings.Builder{}
+			for i, doc := range docs {
+				logs.Infof("parent %s, score=%.3f, len=%d", doc.ID, doc.Score(), len(doc.Content))
+				sb.WriteString(fmt.Sprintf("[%d] %s\n\n", i+1, doc.Content))
+			}
+			return map[string]any{"documents": sb.String(), "question": in["question"]}, nil
+		})).
+		AppendChatTemplate(prompt.MustGet("rag_answer")).
+		AppendChatModel(cm)
+
+	runner, err := chain.Compile(ctx)
+	if err != nil {
+		logs.Fatalf("compile chain failed, err=%v", err)
+	}
+
+	answer, err := runner.Invoke(ctx, *question)
+	if err != nil {
+		logs.Fatalf("invoke chain failed, err=%v", err)
+	}
+
+	logs.Infof("question: %s", *question)
+	logs.Infof("answer: %s", answer.Content)
+}
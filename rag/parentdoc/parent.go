@@ -0,0 +1,140 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cloudwego/eino/components/document"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// metaKeyParentID links a child chunk to the section it was cut from.
+const metaKeyParentID = "parent_id"
+
+// parentStore is the bookkeeping side of the pattern: the vector store only holds the small chunks,
+// the full parent sections are kept here by ID. In production this is any key value store, e.g. redis or a SQL table.
+type parentStore struct {
+	mu      sync.RWMutex
+	parents map[string]*schema.Document
+}
+
+func newParentStore() *parentStore {
+	return &parentStore{parents: map[string]*schema.Document{}}
+}
+
+func (p *parentStore) Put(doc *schema.Document) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.parents[doc.ID] = doc
+}
+
+func (p *parentStore) Get(id string) (*schema.Document, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	doc, ok := p.parents[id]
+	return doc, ok
+}
+
+// indexParents splits every parent into small children, indexes the children with idx,
+// and records the parents in store. The children carry the ID of their parent in metaKeyParentID.
+func indexParents(ctx context.Context, parents []*schema.Document, splitter document.Transformer,
+	idx indexer.Indexer, store *parentStore) (int, error) {
+
+	var children []*schema.Document
+	for i, parent := range parents {
+		// splitters may copy the ID of the source document into every part, so give each parent its own
+		parent.ID = fmt.Sprintf("section-%d", i)
+		store.Put(parent)
+
+		parts, err := splitter.Transform(ctx, []*schema.Document{{Content: parent.Content}})
+		if err != nil {
+			return 0, fmt.Errorf("split %s failed: %w", parent.ID, err)
+		}
+		for j, part := range parts {
+			children = append(children, &schema.Document{
+				ID:       fmt.Sprintf("%s#%d", parent.ID, j),
+				Content:  part.Content,
+				MetaData: map[string]any{metaKeyParentID: parent.ID},
+			})
+		}
+	}
+
+	if _, err := idx.Store(ctx, children); err != nil {
+		return 0, err
+	}
+	return len(children), nil
+}
+
+// parentRetriever searches the small chunks, which embed one precise idea each and match queries well,
+// then returns the parent sections of the hits, which give the chat model enough context to answer.
+type parentRetriever struct {
+	children retriever.Retriever
+	store    *parentStore
+	// childTopK is the number of chunks to search, several of them usually share a parent
+	childTopK int
+	// topK is the max number of parents to return
+	topK int
+}
+
+func (r *parentRetriever) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	topK := r.topK
+	options := retriever.GetCommonOptions(&retriever.Options{TopK: &topK}, opts...)
+
+	hits, err := r.children.Retrieve(ctx, query, retriever.WithTopK(r.childTopK))
+	if err != nil {
+		return nil, err
+	}
+
+	var parents []*schema.Document
+	seen := map[string]bool{}
+	for _, hit := range hits {
+		parentID, _ := hit.MetaData[metaKeyParentID].(string)
+		logs.Infof("chunk %s, score=%.3f, len=%d: %q", hit.ID, hit.Score(), len(hit.Content), hit.Content)
+		if seen[parentID] {
+			continue
+		}
+		seen[parentID] = true
+
+		parent, ok := r.store.Get(parentID)
+		if !ok {
+			return nil, fmt.Errorf("parent %q of chunk %s not found", parentID, hit.ID)
+		}
+		// copy the metadata, WithScore writes into it and the stored parent must not change
+		metadata := make(map[string]any, len(parent.MetaData))
+		for k, v := range parent.MetaData {
+			metadata[k] = v
+		}
+		// hits are sorted by score, so the first child of a parent carries its best score
+		parents = append(parents, (&schema.Document{
+			ID:       parent.ID,
+			Content:  parent.Content,
+			MetaData: metadata,
+		}).WithScore(hit.Score()))
+		if len(parents) == *options.TopK {
+			break
+		}
+	}
+
+	return parents, nil
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisPrefix    = "eino:rag:"
+	redisIndexName = redisPrefix + "vector_index"
+
+	contentField  = "content"
+	metadataField = "metadata"
+	vectorField   = "content_vector"
+	distanceField = "distance"
+)
+
+// initRedisIndex creates the RediSearch vector index if it does not exist yet.
+// dimension must match the output dimension of the embedding model.
+func initRedisIndex(ctx context.Context, client *redis.Client, dimension int) error {
+	if err := client.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("connect to redis failed: %w", err)
+	}
+
+	if _, err := client.Do(ctx, "FT.INFO", redisIndexName).Result(); err == nil {
+		return nil
+	} else if !strings.Contains(strings.ToLower(err.Error()), "unknown index name") {
+		return fmt.Errorf("check index failed: %w", err)
+	}
+
+	err := client.Do(ctx,
+		"FT.CREATE", redisIndexName,
+		"ON", "HASH",
+		"PREFIX", "1", redisPrefix,
+		"SCHEMA",
+		contentField, "TEXT",
+		metadataField, "TEXT",
+		vectorField, "VECTOR", "FLAT", "6",
+		"TYPE", "FLOAT32",
+		"DIM", dimension,
+		"DISTANCE_METRIC", "COSINE",
+	).Err()
+	if err != nil {
+		return fmt.Errorf("create index failed: %w", err)
+	}
+
+	return nil
+}
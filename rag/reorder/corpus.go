@@ -0,0 +1,75 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// question is a question of the evaluation, with the chunk holding its answer and a string the answer must contain.
+type question struct {
+	Text   string
+	Gold   string
+	Expect string
+}
+
+var (
+	sites    = []string{"Frankfurt", "Dublin", "Singapore", "Virginia"}
+	services = []string{"PostgreSQL 14", "PostgreSQL 16", "Redis 7", "Kafka 3.6", "Elasticsearch 8", "MinIO", "Nginx", "ClickHouse"}
+	teams    = []string{"checkout", "search", "payments", "identity", "analytics", "platform", "notifications"}
+	notes    = []string{
+		"Its disks were replaced during the spring maintenance window without downtime.",
+		"The on-call runbook for it was rewritten after the last incident review.",
+		"It is scheduled for an operating system upgrade next quarter.",
+		"Its monitoring dashboards moved to the shared observability stack last year.",
+		"Backups are taken nightly and kept for thirty days.",
+		"It sits behind the internal load balancer and is not reachable from the internet.",
+	}
+)
+
+// inventory generates the notes of n hosts, alike enough that a question retrieves many of them: the host
+// holding the answer is then one chunk among many in a long context. The generation is seeded, the corpus and the
+// questions are the same on every run.
+func inventory(n int) ([]*schema.Document, []*question) {
+	r := rand.New(rand.NewSource(42))
+	docs := make([]*schema.Document, 0, n)
+	var questions []*question
+	for i := 1; i <= n; i++ {
+		host := fmt.Sprintf("host-%02d", i)
+		rack := fmt.Sprintf("%c%d", 'A'+r.Intn(6), 1+r.Intn(9))
+		patched := fmt.Sprintf("2025-%02d-%02d", 1+r.Intn(9), 1+r.Intn(28))
+		docs = append(docs, &schema.Document{
+			ID: host,
+			Content: fmt.Sprintf("%s is in rack %s of the %s data center. It runs %s for the %s team and was last patched on %s. %s %s",
+				host, rack, sites[r.Intn(len(sites))], services[r.Intn(len(services))], teams[r.Intn(len(teams))], patched,
+				notes[r.Intn(len(notes))], notes[r.Intn(len(notes))]),
+		})
+
+		if i%5 != 0 {
+			continue
+		}
+		if i%10 == 0 {
+			questions = append(questions, &question{Text: "In which rack is " + host + "?", Gold: host, Expect: rack})
+		} else {
+			questions = append(questions, &question{Text: "When was " + host + " last patched?", Gold: host, Expect: patched})
+		}
+	}
+	return docs, questions
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodeKeyPrepare   = "Prepare"
+	nodeKeyRetriever = "Retriever"
+	nodeKeyReorder   = "Reorder"
+	nodeKeyToVars    = "ToVariables"
+	nodeKeyTemplate  = "ChatTemplate"
+	nodeKeyChatModel = "ChatModel"
+	nodeKeyAnswer    = "Answer"
+)
+
+// strategy orders the retrieved chunks, best first, before they are put in the prompt.
+type strategy func(question string, docs []*schema.Document) []*schema.Document
+
+var strategies = map[string]strategy{
+	// ranked keeps the order of the retriever, the most relevant first and the least relevant last
+	"ranked": func(_ string, docs []*schema.Document) []*schema.Document {
+		return docs
+	},
+	// shuffled is what a context assembled without regard to relevance looks like, e.g. chunks in document order
+	"shuffled": func(question string, docs []*schema.Document) []*schema.Document {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(question))
+		out := append([]*schema.Document(nil), docs...)
+		rand.New(rand.NewSource(int64(h.Sum64()))).Shuffle(len(out), func(i, j int) { out[i], out[j] = out[j], out[i] })
+		return out
+	},
+	"edges": func(_ string, docs []*schema.Document) []*schema.Document {
+		return reorderToEdges(docs)
+	},
+}
+
+// reorderToEdges puts the most relevant chunks at both ends of the context and the least relevant in the middle:
+// with docs ranked 1, 2, 3, 4, 5, the context is 1, 3, 5, 4, 2. Models use the start and the end of a long context
+// better than its middle ("Lost in the Middle", Liu et al., 2023), so the middle is where a chunk matters the least.
+func reorderToEdges(docs []*schema.Document) []*schema.Document {
+	out := make([]*schema.Document, len(docs))
+	front, back := 0, len(docs)-1
+	for i, d := range docs {
+		if i%2 == 0 {
+			out[front] = d
+			front++
+		} else {
+			out[back] = d
+			back--
+		}
+	}
+	return out
+}
+
+type reorderState struct {
+	Question string
+	Context  []*schema.Document
+}
+
+// answer is the output of the graph: the reply and the IDs of the chunks in the order the model read them.
+type answer struct {
+	Content string
+	Order   []string
+}
+
+// buildReorderRAG is a RAG pipeline with a Reorder node between retrieval and the prompt:
+//
+//	Prepare -> Retriever -> Reorder -> ToVariables -> ChatTemplate -> ChatModel -> Answer
+func buildReorderRAG(ctx context.Context, ret retriever.Retriever, cm model.ChatModel, order strategy) (compose.Runnable[string, *answer], error) {
+	g := compose.NewGraph[string, *answer](compose.WithGenLocalState(func(ctx context.Context) *reorderState {
+		return &reorderState{}
+	}))
+
+	_ = g.AddLambdaNode(nodeKeyPrepare, compose.InvokableLambda(func(ctx context.Context, question string) (string, error) {
+		err := compose.ProcessState[*reorderState](ctx, func(_ context.Context, s *reorderState) error {
+			s.Question = question
+			return nil
+		})
+		return question, err
+	}))
+	_ = g.AddRetrieverNode(nodeKeyRetriever, ret)
+	_ = g.AddLambdaNode(nodeKeyReorder, compose.InvokableLambda(func(ctx context.Context, docs []*schema.Document) ([]*schema.Document, error) {
+		var reordered []*schema.Document
+		err := compose.ProcessState[*reorderState](ctx, func(_ context.Context, s *reorderState) error {
+			reordered = order(s.Question, docs)
+			s.Context = reordered
+			return nil
+		})
+		return reordered, err
+	}))
+	_ = g.AddLambdaNode(nodeKeyToVars, compose.InvokableLambda(func(ctx context.Context, docs []*schema.Document) (map[string]any, error) {
+		var question string
+		err := compose.ProcessState[*reorderState](ctx, func(_ context.Context, s *reorderState) error {
+			question = s.Question
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"documents": formatDocuments(docs), "question": question}, nil
+	}))
+	_ = g.AddChatTemplateNode(nodeKeyTemplate, prompt.MustGet("rag_reorder_answer"))
+	_ = g.AddChatModelNode(nodeKeyChatModel, cm)
+	_ = g.AddLambdaNode(nodeKeyAnswer, compose.InvokableLambda(func(ctx context.Context, msg *schema.Message) (*answer, error) {
+		a := &answer{Content: msg.Content}
+		err := compose.ProcessState[*reorderState](ctx, func(_ context.Context, s *reorderState) error {
+			for _, d := range s.Context {
+				a.Order = append(a.Order, d.ID)
+			}
+			return nil
+		})
+		return a, err
+	}))
+
+	_ = g.AddEdge(compose.START, nodeKeyPrepare)
+	_ = g.AddEdge(nodeKeyPrepare, nodeKeyRetriever)
+	_ = g.AddEdge(nodeKeyRetriever, nodeKeyReorder)
+	_ = g.AddEdge(nodeKeyReorder, nodeKeyToVars)
+	_ = g.AddEdge(nodeKeyToVars, nodeKeyTemplate)
+	_ = g.AddEdge(nodeKeyTemplate, nodeKeyChatModel)
+	_ = g.AddEdge(nodeKeyChatModel, nodeKeyAnswer)
+	_ = g.AddEdge(nodeKeyAnswer, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("ReorderRAG"))
+}
+
+func formatDocuments(docs []*schema.Document) string {
+	parts := make([]string, 0, len(docs))
+	for i, d := range docs {
+		parts = append(parts, fmt.Sprintf("[%d] %s", i+1, d.Content))
+	}
+	return strings.Join(parts, "\n\n")
+}
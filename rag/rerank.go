@@ -0,0 +1,133 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// metaKeyRetrievalRank keeps the rank a document had before reranking, to show how much reranking moved it.
+const metaKeyRetrievalRank = "retrieval_rank"
+
+// apiReranker scores (query, document) pairs with a cross-encoder served behind a rerank API.
+// The request format {model, query, documents, top_n} -> {results: [{index, relevance_score}]}
+// is shared by Jina, Cohere, SiliconFlow, Xinference, vLLM and the volcengine knowledge base rerank.
+//
+// A bi-encoder embeds the query and the documents separately, a cross-encoder reads them together,
+// so it is much more accurate but too slow to run on the whole corpus. That is why the retriever
+// casts a wide net first (e.g. top 50) and the reranker keeps the best few (e.g. top 5).
+type apiReranker struct {
+	baseURL string
+	apiKey  string
+	model   string
+	topN    int
+	client  *http.Client
+}
+
+func newAPIReranker(baseURL, apiKey, model string, topN int) *apiReranker {
+	return &apiReranker{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+		topN:    topN,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type rerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+	TopN      int      `json:"top_n"`
+}
+
+type rerankResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+// Rerank returns the topN documents most relevant to query, best first, with the relevance score as score.
+func (r *apiReranker) Rerank(ctx context.Context, query string, docs []*schema.Document) ([]*schema.Document, error) {
+	if len(docs) == 0 {
+		return docs, nil
+	}
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		texts[i] = doc.Content
+	}
+	body, err := json.Marshal(&rerankRequest{Model: r.model, Query: query, Documents: texts, TopN: r.topN})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.baseURL+"/rerank", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+r.apiKey)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rerank request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("rerank request failed: status %s, body %s", resp.Status, msg)
+	}
+
+	var out rerankResponse
+	if err = json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode rerank response failed: %w", err)
+	}
+
+	// results are sorted by relevance already, the index points back into docs
+	reranked := make([]*schema.Document, 0, len(out.Results))
+	for _, res := range out.Results {
+		if res.Index < 0 || res.Index >= len(docs) {
+			return nil, fmt.Errorf("rerank returned index %d out of %d documents", res.Index, len(docs))
+		}
+		d := docs[res.Index]
+		metadata := make(map[string]any, len(d.MetaData)+1)
+		for k, v := range d.MetaData {
+			metadata[k] = v
+		}
+		metadata[metaKeyRetrievalRank] = res.Index + 1
+
+		reranked = append(reranked, (&schema.Document{ID: d.ID, Content: d.Content, MetaData: metadata}).WithScore(res.RelevanceScore))
+		logs.Infof("reranked [%d] id=%s, score=%.3f, was [%d] with score %.3f",
+			len(reranked), d.ID, res.RelevanceScore, res.Index+1, d.Score())
+	}
+
+	return reranked, nil
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/components/model"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/compose"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/prompt"
+)
+
+const (
+	nodeKeyPrepare   = "Prepare"
+	nodeKeyRewrite   = "Rewrite"
+	nodeKeyKeepQuery = "KeepQuery"
+	nodeKeyRetrieve  = "Retrieve"
+	nodeKeyToVars    = "ToVariables"
+	nodeKeyTemplate  = "ChatTemplate"
+	nodeKeyChatModel = "ChatModel"
+)
+
+// Turn is the input of the graph: the conversation so far and the new question.
+type Turn struct {
+	History  []*schema.Message
+	Question string
+}
+
+type rewriteState struct {
+	Turn *Turn
+}
+
+// buildRewriteGraph retrieves with a standalone rewrite of the question when there is a conversation before it:
+//
+//	Prepare --(history)--> Rewrite -------> Retrieve -> ToVariables -> ChatTemplate -> ChatModel
+//	        --(first question)--> KeepQuery --^
+//
+// A follow-up such as "what about its pricing?" embeds to a vector about pricing in general; the document about the
+// product being discussed is not found. Rewritten as "what is the pricing of Orbit CRM?", it is. The answer is still
+// generated from the original conversation, the rewrite only serves the retrieval.
+func buildRewriteGraph(ctx context.Context, ret retriever.Retriever, cm model.ChatModel, rewrite bool) (compose.Runnable[*Turn, *schema.Message], error) {
+	g := compose.NewGraph[*Turn, *schema.Message](compose.WithGenLocalState(func(ctx context.Context) *rewriteState {
+		return &rewriteState{}
+	}))
+
+	_ = g.AddLambdaNode(nodeKeyPrepare, compose.InvokableLambda(func(ctx context.Context, t *Turn) (*Turn, error) {
+		err := compose.ProcessState[*rewriteState](ctx, func(_ context.Context, s *rewriteState) error {
+			s.Turn = t
+			return nil
+		})
+		return t, err
+	}))
+	_ = g.AddLambdaNode(nodeKeyRewrite, compose.InvokableLambda(func(ctx context.Context, t *Turn) (string, error) {
+		in, err := prompt.MustGet("rag_rewrite_query").Format(ctx, map[string]any{
+			"history":  formatHistory(t.History),
+			"question": t.Question,
+		})
+		if err != nil {
+			return "", err
+		}
+		msg, err := cm.Generate(ctx, in)
+		if err != nil {
+			return "", err
+		}
+		query := strings.TrimSpace(msg.Content)
+		if query == "" {
+			query = t.Question
+		}
+		logs.Infof("rewritten query: %s", query)
+		return query, nil
+	}))
+	_ = g.AddLambdaNode(nodeKeyKeepQuery, compose.InvokableLambda(func(ctx context.Context, t *Turn) (string, error) {
+		return t.Question, nil
+	}))
+	_ = g.AddRetrieverNode(nodeKeyRetrieve, ret)
+	_ = g.AddLambdaNode(nodeKeyToVars, compose.InvokableLambda(func(ctx context.Context, docs []*schema.Document) (map[string]any, error) {
+		var t *Turn
+		err := compose.ProcessState[*rewriteState](ctx, func(_ context.Context, s *rewriteState) error {
+			t = s.Turn
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		ids := make([]string, 0, len(docs))
+		for _, d := range docs {
+			ids = append(ids, d.ID)
+		}
+		logs.Infof("retrieved: %s", strings.Join(ids, ", "))
+
+		return map[string]any{
+			"documents": formatDocuments(docs),
+			"history":   t.History,
+			"question":  t.Question,
+		}, nil
+	}))
+	_ = g.AddChatTemplateNode(nodeKeyTemplate, prompt.MustGet("rag_rewrite_answer"))
+	_ = g.AddChatModelNode(nodeKeyChatModel, cm)
+
+	_ = g.AddEdge(compose.START, nodeKeyPrepare)
+	_ = g.AddBranch(nodeKeyPrepare, compose.NewGraphBranch(func(ctx context.Context, t *Turn) (string, error) {
+		if rewrite && len(t.History) > 0 {
+			return nodeKeyRewrite, nil
+		}
+		return nodeKeyKeepQuery, nil
+	}, map[string]bool{nodeKeyRewrite: true, nodeKeyKeepQuery: true}))
+	_ = g.AddEdge(nodeKeyRewrite, nodeKeyRetrieve)
+	_ = g.AddEdge(nodeKeyKeepQuery, nodeKeyRetrieve)
+	_ = g.AddEdge(nodeKeyRetrieve, nodeKeyToVars)
+	_ = g.AddEdge(nodeKeyToVars, nodeKeyTemplate)
+	_ = g.AddEdge(nodeKeyTemplate, nodeKeyChatModel)
+	_ = g.AddEdge(nodeKeyChatModel, compose.END)
+
+	return g.Compile(ctx, compose.WithGraphName("RewriteRAG"))
+}
+
+func formatHistory(history []*schema.Message) string {
+	sb := strings.Builder{}
+	for _, m := range history {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", m.Role, m.Content))
+	}
+	return sb.String()
+}
+
+func formatDocuments(docs []*schema.Document) string {
+	parts := make([]string, 0, len(docs))
+	for _, d := range docs {
+		parts = append(parts, fmt.Sprintf("[%s] %s", d.ID, d.Content))
+	}
+	return strings.Join(parts, "\n\n")
+}
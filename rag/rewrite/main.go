@@ -0,0 +1,94 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/cloudwego/eino-ext/components/embedding/ark"
+	"github.com/cloudwego/eino/schema"
+
+	"github.com/cloudwego/eino-examples/internal/config"
+	"github.com/cloudwego/eino-examples/internal/logs"
+	"github.com/cloudwego/eino-examples/internal/models"
+	"github.com/cloudwego/eino-examples/internal/secrets"
+	"github.com/cloudwego/eino-examples/internal/validate"
+	"github.com/cloudwego/eino-examples/internal/vectorstore"
+)
+
+// catalog is the documentation of two made-up products, with the same kinds of pages for both, so that a question
+// without the product name matches the pages of either of them.
+var catalog = []*schema.Document{
+	{ID: "inkwell-overview", Content: "Inkwell is a note taking app for teams, with shared notebooks, offline mode and Markdown export."},
+	{ID: "inkwell-pricing", Content: "Inkwell pricing: Free for up to 3 notebooks, Pro at 8 USD per user per month, Team at 12 USD per user per month."},
+	{ID: "inkwell-integrations", Content: "Inkwell integrates with Slack, Google Drive and GitHub."},
+	{ID: "orbit-overview", Content: "Orbit CRM tracks leads, deals and customer conversations, with a sales pipeline and email sequences."},
+	{ID: "orbit-pricing", Content: "Orbit CRM pricing: Starter at 15 USD per user per month, Growth at 39 USD per user per month, Enterprise on quote."},
+	{ID: "orbit-integrations", Content: "Orbit CRM integrates with Gmail, Outlook, Stripe and HubSpot imports."},
+}
+
+// Requirements:
+//   - ARK_API_KEY / ARK_EMBEDDING_MODEL for embedding
+//   - MODEL_PROVIDER and the keys of the provider for the chat model, see internal/models
+//
+// Run it with -rewrite=false to see the follow-up questions retrieve the pages of the wrong product.
+func main() {
+	rewrite := flag.Bool("rewrite", true, "rewrite follow-up questions into standalone queries before retrieval")
+	flag.Parse()
+	validate.Must(validate.Model(), validate.Env("ARK_API_KEY", "ARK_EMBEDDING_MODEL"))
+
+	ctx := context.Background()
+
+	emb, err := ark.NewEmbedder(ctx, &ark.EmbeddingConfig{
+		BaseURL: "https://ark.cn-beijing.volces.com/api/v3",
+		APIKey:  secrets.MustGet(ctx, "ARK_API_KEY"),
+		Model:   config.String("ARK_EMBEDDING_MODEL", ""),
+	})
+	if err != nil {
+		logs.Fatalf("ark.NewEmbedder failed, err=%v", err)
+	}
+	cm := models.MustChatModel(ctx)
+
+	store, err := vectorstore.NewMemoryStore(&vectorstore.MemoryConfig{Embedding: emb, TopK: 2})
+	if err != nil {
+		logs.Fatalf("create memory store failed, err=%v", err)
+	}
+	if _, err = store.Store(ctx, catalog); err != nil {
+		logs.Fatalf("index failed, err=%v", err)
+	}
+
+	runner, err := buildRewriteGraph(ctx, store, cm, *rewrite)
+	if err != nil {
+		logs.Fatalf("buildRewriteGraph failed, err=%v", err)
+	}
+
+	var history []*schema.Message
+	for _, q := range []string{
+		"What is Orbit CRM?",
+		"What about its pricing?",
+		"And does it work with Stripe?",
+	} {
+		logs.Infof("user: %s", q)
+		answer, err := runner.Invoke(ctx, &Turn{History: history, Question: q})
+		if err != nil {
+			logs.Fatalf("Invoke failed, err=%v", err)
+		}
+		logs.Infof("assistant: %s", answer.Content)
+		history = append(history, schema.UserMessage(q), answer)
+	}
+}
@@ -0,0 +1,264 @@
+/*
+ * Copyright 2025 CloudWeGo Authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	sqlitevec "github.com/asg017/sqlite-vec-go-bindings/cgo"
+	"github.com/cloudwego/eino/components/embedding"
+	"github.com/cloudwego/eino/components/indexer"
+	"github.com/cloudwego/eino/components/retriever"
+	"github.com/cloudwego/eino/schema"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/cloudwego/eino-examples/internal/logs"
+)
+
+// sqliteStore persists chunks and their vectors to a single local file with the sqlite-vec extension.
+// Chunks live in a plain table, vectors in a vec0 virtual table sharing the same rowid.
+// The vec0 table is created on the first Store, when the dimension of the embedding model is known.
+//
+// It needs cgo, since both sqlite and sqlite-vec are compiled from C.
+type sqliteStore struct {
+	db   *sql.DB
+	emb  embedding.Embedder
+	topK int
+}
+
+func newSQLiteStore(ctx context.Context, path string, emb embedding.Embedder) (*sqliteStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	// register sqlite-vec into every connection opened by the sqlite3 driver from now on
+	sqlitevec.Auto()
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("open %s failed: %w", path, err)
+	}
+
+	var version string
+	if err = db.QueryRowContext(ctx, "SELECT vec_version()").Scan(&version); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("sqlite-vec is not loaded: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS meta (key TEXT PRIMARY KEY, value TEXT NOT NULL);
+CREATE TABLE IF NOT EXISTS chunks (
+	rowid    INTEGER PRIMARY KEY,
+	id       TEXT NOT NULL UNIQUE,
+	content  TEXT NOT NULL,
+	metadata TEXT NOT NULL
+);`)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("create tables failed: %w", err)
+	}
+
+	logs.Infof("sqlite store opened at %s, sqlite-vec %s", path, version)
+	return &sqliteStore{db: db, emb: emb, topK: 3}, nil
+}
+
+func (s *sqliteStore) Close() error {
+	return s.db.Close()
+}
+
+// Store saves docs, using the hash of the content as ID.
+// Chunks already in the file are skipped without being embedded again, so re-running the example on the same input is cheap.
+func (s *sqliteStore) Store(ctx context.Context, docs []*schema.Document, opts ...indexer.Option) ([]string, error) {
+	options := indexer.GetCommonOptions(&indexer.Options{Embedding: s.emb}, opts...)
+
+	ids := make([]string, len(docs))
+	var (
+		todo  []*schema.Document
+		texts []string
+	)
+	for i, doc := range docs {
+		sum := sha256.Sum256([]byte(doc.Content))
+		doc.ID = hex.EncodeToString(sum[:8])
+		ids[i] = doc.ID
+
+		var exists int
+		err := s.db.QueryRowContext(ctx, "SELECT COUNT(1) FROM chunks WHERE id = ?", doc.ID).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if exists == 0 {
+			todo = append(todo, doc)
+			texts = append(texts, doc.Content)
+		}
+	}
+
+	logs.Infof("sqlite store: %d chunks already stored, %d to embed", len(docs)-len(todo), len(todo))
+	if len(todo) == 0 {
+		return ids, nil
+	}
+
+	vectors, err := options.Embedding.EmbedStrings(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("embed documents failed: %w", err)
+	}
+	if len(vectors) != len(todo) {
+		return nil, fmt.Errorf("embedding returned %d vectors for %d documents", len(vectors), len(todo))
+	}
+	if err = s.ensureVectorTable(ctx, len(vectors[0])); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for i, doc := range todo {
+		metadata, err := json.Marshal(doc.MetaData)
+		if err != nil {
+			return nil, fmt.Errorf("marshal metadata failed: %w", err)
+		}
+		res, err := tx.ExecContext(ctx, "INSERT INTO chunks (id, content, metadata) VALUES (?, ?, ?)",
+			doc.ID, doc.Content, string(metadata))
+		if err != nil {
+			return nil, fmt.Errorf("insert chunk failed: %w", err)
+		}
+		rowid, err := res.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+
+		blob, err := sqlitevec.SerializeFloat32(toFloat32(vectors[i]))
+		if err != nil {
+			return nil, err
+		}
+		if _, err = tx.ExecContext(ctx, "INSERT INTO vec_chunks (rowid, embedding) VALUES (?, ?)", rowid, blob); err != nil {
+			return nil, fmt.Errorf("insert vector failed: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (s *sqliteStore) Retrieve(ctx context.Context, query string, opts ...retriever.Option) ([]*schema.Document, error) {
+	topK := s.topK
+	options := retriever.GetCommonOptions(&retriever.Options{TopK: &topK, Embedding: s.emb}, opts...)
+
+	if _, err := s.dimension(ctx); err != nil {
+		return nil, err
+	}
+
+	vectors, err := options.Embedding.EmbedStrings(ctx, []string{query})
+	if err != nil {
+		return nil, fmt.Errorf("embed query failed: %w", err)
+	}
+	blob, err := sqlitevec.SerializeFloat32(toFloat32(vectors[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	// the KNN query runs on the vec0 table, then joins back to the chunks by rowid
+	rows, err := s.db.QueryContext(ctx, `
+SELECT c.id, c.content, c.metadata, v.distance
+FROM (SELECT rowid, distance FROM vec_chunks WHERE embedding MATCH ? AND k = ?) v
+JOIN chunks c ON c.rowid = v.rowid
+ORDER BY v.distance`, blob, *options.TopK)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []*schema.Document
+	for rows.Next() {
+		var (
+			doc      = &schema.Document{MetaData: map[string]any{}}
+			metadata string
+			distance float64
+		)
+		if err = rows.Scan(&doc.ID, &doc.Content, &metadata, &distance); err != nil {
+			return nil, err
+		}
+		_ = json.Unmarshal([]byte(metadata), &doc.MetaData)
+
+		score := 1 - distance
+		if options.ScoreThreshold != nil && score < *options.ScoreThreshold {
+			continue
+		}
+		docs = append(docs, doc.WithScore(score))
+	}
+
+	return docs, rows.Err()
+}
+
+// ensureVectorTable creates the vec0 table for dim, or checks that the existing one has the same dimension.
+func (s *sqliteStore) ensureVectorTable(ctx context.Context, dim int) error {
+	existing, err := s.dimension(ctx)
+	if err == nil {
+		if existing != dim {
+			return fmt.Errorf("the store has dimension %d but the embedding has %d, delete the file to start over", existing, dim)
+		}
+		return nil
+	}
+	if !errors.Is(err, errEmptyStore) {
+		return err
+	}
+
+	stmt := fmt.Sprintf("CREATE VIRTUAL TABLE vec_chunks USING vec0(embedding float[%d] distance_metric=cosine)", dim)
+	if _, err = s.db.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("create vector table failed: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, "INSERT INTO meta (key, value) VALUES ('dimension', ?)", strconv.Itoa(dim))
+	return err
+}
+
+var errEmptyStore = errors.New("nothing has been stored yet")
+
+func (s *sqliteStore) dimension(ctx context.Context) (int, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx, "SELECT value FROM meta WHERE key = 'dimension'").Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, errEmptyStore
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(value))
+}
+
+func toFloat32(v []float64) []float32 {
+	f := make([]float32, len(v))
+	for i := range v {
+		f[i] = float32(v[i])
+	}
+	return f
+}